@@ -1,9 +1,12 @@
 package main
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"reviewsrv/pkg/reviewer/ctl"
@@ -54,13 +57,34 @@ func main() {
 	pf.StringVar(&cfg.MRTitle, "mr-title", os.Getenv("CI_MERGE_REQUEST_TITLE"), "MR title")
 	pf.StringVar(&cfg.ExternalID, "external-id", os.Getenv("CI_MERGE_REQUEST_IID"), "external ID")
 	pf.StringVar(&cfg.DiffBaseSHA, "diff-base-sha", os.Getenv("CI_MERGE_REQUEST_DIFF_BASE_SHA"), "diff base SHA")
-	pf.StringVar(&cfg.SessionID, "session", "", "Claude session ID for --resume (reuses prompt cache)")
-	pf.BoolVar(&cfg.ContinueSession, "continue", false, "continue last Claude session (auto-detect)")
+	pf.StringVar(&cfg.SessionID, "session", "", "session ID to resume: Claude --resume (reuses prompt cache), or a saved direct-runner session")
+	pf.BoolVar(&cfg.ContinueSession, "continue", false, "continue the last session (auto-detect)")
 	pf.BoolVar(&cfg.DebugUpload, "debug-upload", ctl.EnvBool("REVIEW_DEBUG_UPLOAD", false), "always upload artifacts to /v1/upload/debug/ (failures upload regardless)")
 	pf.BoolVar(&cfg.AllowDangerousPermissions, "allow-dangerous-permissions", ctl.EnvBool("REVIEW_ALLOW_DANGEROUS_PERMISSIONS", true), "pass --dangerously-skip-permissions to opencode (default true; required for unattended CI)")
 	pf.StringVar(&cfg.APIProvider, "api-provider", ctl.EnvDefault("REVIEW_API_PROVIDER", "deepseek"), "direct runner provider: deepseek | openai-compat | anthropic (key from ANTHROPIC_API_KEY/DEEPSEEK_API_KEY env)")
 	pf.StringVar(&cfg.APIBaseURL, "api-base-url", os.Getenv("REVIEW_API_BASE_URL"), "direct runner API base URL (defaults to provider's standard endpoint)")
 	pf.StringVar(&cfg.Effort, "effort", os.Getenv("REVIEW_EFFORT"), "direct runner reasoning effort for Anthropic: low|medium|high|xhigh|max")
+	pf.DurationVar(&cfg.StreamIdleTimeout, "stream-idle-timeout", ctl.EnvDuration("REVIEW_STREAM_IDLE_TIMEOUT", 0), "direct runner: abort a round whose response stream is silent this long (0 = default 5m)")
+	pf.IntVar(&cfg.ContextWindow, "context-window", ctl.EnvInt("REVIEW_CONTEXT_WINDOW", 0), "direct runner: model context window in tokens, sizes preload and compaction (0 = built-in table by model)")
+	pf.StringVar(&cfg.CacheBreakpoints, "cache-breakpoints", os.Getenv("REVIEW_CACHE_BREAKPOINTS"), `direct runner, Anthropic: prompt-cache breakpoints, comma-separated tools,system,preload,history (default all; "none" disables caching)`)
+	pf.StringVar(&cfg.CacheTTL, "cache-ttl", os.Getenv("REVIEW_CACHE_TTL"), "direct runner, Anthropic: prompt-cache TTL, 5m or 1h (1h writes cost more but survive until a re-run; default 5m)")
+	pf.StringVar(&cfg.Replay, "replay", os.Getenv("REVIEW_REPLAY"), "direct runner: replay provider responses from a recorded direct-cassette.jsonl instead of calling the API (no key needed)")
+	pf.BoolVar(&cfg.ReplayStrict, "replay-strict", ctl.EnvBool("REVIEW_REPLAY_STRICT", false), "with --replay: fail on a request the recording doesn't match exactly instead of replaying in recorded order")
+	pf.StringVar(&cfg.Linters, "linters", os.Getenv("REVIEW_LINTERS"), `direct runner: linters the model may run on changed code, "name=command args; ..." ({pkgs}, {files}, {files:.ts,.vue} expand to changed targets)`)
+	pf.BoolVar(&cfg.LintPreRun, "lint-prerun", ctl.EnvBool("REVIEW_LINT_PRERUN", false), "direct runner: run --linters before the review and add their findings to the kickoff")
+	pf.StringVar(&cfg.MCPConfig, "mcp-config", os.Getenv("REVIEW_MCP_CONFIG"), `direct runner: JSON file of MCP tool servers to mount ({"mcpServers": {"name": {"command"/"args" or "url", "allow", "timeout", "toolTimeouts"}}})`)
+	pf.StringVar(&cfg.ToolLimits, "tool-limits", os.Getenv("REVIEW_TOOL_LIMITS"), `direct runner: per-run caps on individual tools, "tool=calls:N,bytes:N; ..." (e.g. "read_file=bytes:2000000; grep=calls:40")`)
+	pf.StringVar(&cfg.SessionDir, "session-dir", os.Getenv("REVIEW_SESSION_DIR"), "direct runner: where resumable sessions are saved (default: <user cache dir>/reviewctl/direct-sessions)")
+	pf.BoolVar(&cfg.SubAgents, "sub-agents", ctl.EnvBool("REVIEW_SUB_AGENTS", false), "direct runner: a planner splits the review by group or package and parallel sub-agents review the slices")
+	pf.BoolVar(&cfg.SingleShot, "single-shot", ctl.EnvBool("REVIEW_SINGLE_SHOT", false), "direct runner: no tools; the model answers with the whole review as one structured-output JSON object")
+	pf.BoolVar(&cfg.Structured, "structured", ctl.EnvBool("REVIEW_STRUCTURED", false), "direct runner: no tools; one structured-output JSON answer per group, then the verdict (for models with weak function calling)")
+	pf.StringVar(&cfg.ResponseFormat, "response-format", os.Getenv("REVIEW_RESPONSE_FORMAT"), "with --single-shot/--structured on OpenAI-compatible backends: json_schema (default), json_object or none")
+	pf.BoolVar(&cfg.Batch, "batch", ctl.EnvBool("REVIEW_BATCH", false), "direct runner: run every round as a batch job at half price, for nightly reviews (anthropic; a re-run resumes pending jobs)")
+	pf.DurationVar(&cfg.BatchPoll, "batch-poll", ctl.EnvDuration("REVIEW_BATCH_POLL", 0), "with --batch: how often to check a pending batch job (0 = 30s)")
+	pf.StringVar(&cfg.ModelCatalog, "model-catalog", os.Getenv("REVIEW_MODEL_CATALOG"), "model catalogue override: a JSON file or http(s) URL with per-model prices, context limits and features, laid over the built-in table")
+	pf.BoolVar(&cfg.Verify, "verify", ctl.EnvBool("REVIEW_VERIFY", false), "re-check every issue with a verifier model before upload (any runner; uses the --api-provider settings and key)")
+	pf.StringVar(&cfg.VerifyModel, "verify-model", os.Getenv("REVIEW_VERIFY_MODEL"), "verifier model (defaults to --model with --runner direct; required otherwise)")
+	pf.BoolVar(&cfg.VerifyDrop, "verify-drop", ctl.EnvBool("REVIEW_VERIFY_DROP", false), "with --verify: drop rejected issues instead of flagging them in the title")
 
 	reviewCmd := &cobra.Command{
 		Use:   "review",
@@ -70,11 +94,23 @@ func main() {
 				return err
 			}
 			log := slog.Default()
-			rr, err := buildRunner(cfg, log)
+			cat, err := direct.LoadCatalog(cmd.Context(), cfg.ModelCatalog)
+			if err != nil {
+				return fmt.Errorf("--model-catalog: %w", err)
+			}
+			rr, err := buildRunner(cfg, cat, log)
 			if err != nil {
 				return err
 			}
+			v, err := buildVerifier(cfg, cat)
+			if err != nil {
+				return err
+			}
+			warnModelCatalog(cfg, cat, log)
 			c := ctl.NewController(cfg, rr, log)
+			if v != nil {
+				c.WithVerifier(v, cfg.VerifyDrop)
+			}
 			return c.Review(cmd.Context())
 		},
 	}
@@ -119,7 +155,7 @@ func main() {
 	}
 }
 
-func buildRunner(cfg *ctl.Config, log *slog.Logger) (runner.ReviewRunner, error) {
+func buildRunner(cfg *ctl.Config, cat *direct.Catalog, log *slog.Logger) (runner.ReviewRunner, error) {
 	cfg.ResolveDefaults()
 	switch cfg.Runner {
 	case "", runner.RunnerClaude:
@@ -131,39 +167,178 @@ func buildRunner(cfg *ctl.Config, log *slog.Logger) (runner.ReviewRunner, error)
 			SessionID:                 cfg.SessionID,
 			ContinueSession:           cfg.ContinueSession,
 			AllowDangerousPermissions: cfg.AllowDangerousPermissions,
+			Catalog:                   cat,
 			Log:                       log,
 		}, nil
 	case runner.RunnerCodex:
-		return &runner.ExecCodexRunner{Model: cfg.Model, Dir: cfg.Dir, SessionID: cfg.SessionID, ContinueSession: cfg.ContinueSession, Log: log}, nil
+		return &runner.ExecCodexRunner{Model: cfg.Model, Dir: cfg.Dir, SessionID: cfg.SessionID, ContinueSession: cfg.ContinueSession, Catalog: cat, Log: log}, nil
 	case runner.RunnerDirect:
-		return buildDirectRunner(cfg, log)
+		return buildDirectRunner(cfg, cat, log)
 	default:
 		return nil, fmt.Errorf("unknown --runner %q (supported: %s, %s, %s, %s)", cfg.Runner, runner.RunnerClaude, runner.RunnerOpenCode, runner.RunnerCodex, runner.RunnerDirect)
 	}
 }
 
-func buildDirectRunner(cfg *ctl.Config, log *slog.Logger) (runner.ReviewRunner, error) {
+func buildDirectRunner(cfg *ctl.Config, cat *direct.Catalog, log *slog.Logger) (runner.ReviewRunner, error) {
+	prov, err := directProvider(cfg, cat)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Batch {
+		if prov, err = batchProvider(cfg, prov, log); err != nil {
+			return nil, err
+		}
+	}
+	linters, err := direct.ParseLinters(cfg.Linters)
+	if err != nil {
+		return nil, fmt.Errorf("--linters: %w", err)
+	}
+	var mcpServers []direct.MCPServer
+	if cfg.MCPConfig != "" {
+		if mcpServers, err = direct.LoadMCPConfig(cfg.MCPConfig); err != nil {
+			return nil, fmt.Errorf("--mcp-config: %w", err)
+		}
+	}
+	toolLimits, err := direct.ParseToolLimits(cfg.ToolLimits)
+	if err != nil {
+		return nil, fmt.Errorf("--tool-limits: %w", err)
+	}
+	return &runner.DirectRunner{
+		Provider:          prov,
+		Dir:               cfg.Dir,
+		DiffBase:          cfg.TargetBranch,
+		DiffHead:          cfg.SourceBranch,
+		Effort:            cfg.Effort,
+		Catalog:           cat,
+		StreamIdleTimeout: cfg.StreamIdleTimeout,
+		Linters:           linters,
+		LintPreRun:        cfg.LintPreRun,
+		MCPServers:        mcpServers,
+		ToolLimits:        toolLimits,
+		SubAgents:         cfg.SubAgents,
+		SingleShot:        cfg.SingleShot,
+		Structured:        cfg.Structured,
+		SessionID:         cfg.SessionID,
+		ContinueSession:   cfg.ContinueSession,
+		SessionDir:        cfg.SessionDir,
+		Log:               log,
+	}, nil
+}
+
+// batchProvider runs the direct runner's rounds through the provider's batch
+// endpoint; a --replay cassette answers through the local stand-in. Pending
+// jobs are kept next to the sessions.
+func batchProvider(cfg *ctl.Config, prov direct.LLMProvider, log *slog.Logger) (direct.LLMProvider, error) {
+	api, ok := prov.(direct.BatchAPI)
+	if _, replay := prov.(*direct.CassettePlayer); replay {
+		api, ok = direct.LocalBatch(prov), true
+	}
+	if !ok {
+		return nil, fmt.Errorf("--batch: provider %q has no batch endpoint (use anthropic)", cfg.APIProvider)
+	}
+	dir := cfg.SessionDir
+	if dir == "" {
+		dir = direct.DefaultSessionDir()
+	}
+	return direct.NewBatchProvider(prov, api, direct.BatchOptions{
+		Store: direct.BatchStore{Dir: filepath.Join(dir, "batches")},
+		Poll:  cfg.BatchPoll,
+		OnStatus: func(h direct.BatchHandle, status string) {
+			log.Info("direct: batch "+status, "batchId", h.ID, "model", h.Model, "submittedAt", h.SubmittedAt)
+		},
+	}), nil
+}
+
+// directProvider builds the API provider, or a cassette player for --replay.
+// The cassette is loaded here, before the review wipes the previous run's
+// artifacts — so replaying <dir>/direct-cassette.jsonl itself works.
+func directProvider(cfg *ctl.Config, cat *direct.Catalog) (direct.LLMProvider, error) {
+	if cfg.Replay != "" {
+		player, err := direct.LoadCassette(cfg.Replay, cfg.ReplayStrict)
+		if err != nil {
+			return nil, fmt.Errorf("--replay: %w", err)
+		}
+		return player, nil
+	}
 	apiKey := directAPIKey(cfg.APIProvider)
 	if apiKey == "" {
 		return nil, fmt.Errorf("--runner direct: API key not found in environment (set %s)", strings.Join(directKeyEnvs(cfg.APIProvider), " or "))
 	}
+	cache, err := direct.ParseAnthropicCache(cfg.CacheBreakpoints, cfg.CacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("--cache-breakpoints/--cache-ttl: %w", err)
+	}
+	return direct.NewProvider(direct.ProviderConfig{
+		Provider:       cfg.APIProvider,
+		Model:          cfg.Model,
+		BaseURL:        cfg.APIBaseURL,
+		APIKey:         apiKey,
+		ContextWindow:  cfg.ContextWindow,
+		Cache:          &cache,
+		ResponseFormat: cfg.ResponseFormat,
+		Catalog:        cat,
+	})
+}
+
+// warnModelCatalog warns about models the catalogue cannot price — their cost
+// would read 0 — and about an --effort the direct runner's model ignores. The
+// claude runner is skipped: the CLI reports its own cost.
+func warnModelCatalog(cfg *ctl.Config, cat *direct.Catalog, log *slog.Logger) {
+	var models []string
+	switch cfg.Runner {
+	case runner.RunnerCodex, runner.RunnerOpenCode:
+		models = append(models, cfg.Model)
+	case runner.RunnerDirect:
+		if cfg.Replay == "" {
+			models = append(models, cfg.Model)
+		}
+		if spec, ok := cat.Lookup(cfg.Model); ok && cfg.Effort != "" && !spec.Effort {
+			log.Warn("model takes no effort level; --effort is ignored", "model", cfg.Model, "effort", cfg.Effort)
+		}
+	}
+	if cfg.Verify {
+		models = append(models, cmp.Or(cfg.VerifyModel, cfg.Model))
+	}
+	for _, m := range models {
+		if m == "" {
+			continue
+		}
+		if err := cat.CheckPricing(m); err != nil {
+			log.Warn("cost estimate unavailable; add the model to --model-catalog", "err", err)
+		}
+	}
+}
+
+// buildVerifier builds the --verify pass, or nil when it is off. It always
+// calls the API (never --replay) with the direct-runner provider settings;
+// the model defaults to --model only for the direct runner, since the CLI
+// runners' model names are aliases the API doesn't know.
+func buildVerifier(cfg *ctl.Config, cat *direct.Catalog) (*direct.Verifier, error) {
+	if !cfg.Verify {
+		return nil, nil
+	}
+	model := cfg.VerifyModel
+	if model == "" && cfg.Runner == runner.RunnerDirect {
+		model = cfg.Model
+	}
+	if model == "" {
+		return nil, errors.New("--verify: --verify-model is required")
+	}
+	apiKey := directAPIKey(cfg.APIProvider)
+	if apiKey == "" {
+		return nil, fmt.Errorf("--verify: API key not found in environment (set %s)", strings.Join(directKeyEnvs(cfg.APIProvider), " or "))
+	}
 	prov, err := direct.NewProvider(direct.ProviderConfig{
 		Provider: cfg.APIProvider,
-		Model:    cfg.Model,
+		Model:    model,
 		BaseURL:  cfg.APIBaseURL,
 		APIKey:   apiKey,
+		Catalog:  cat,
 	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("--verify: %w", err)
 	}
-	return &runner.DirectRunner{
-		Provider: prov,
-		Dir:      cfg.Dir,
-		DiffBase: cfg.TargetBranch,
-		DiffHead: cfg.SourceBranch,
-		Effort:   cfg.Effort,
-		Log:      log,
-	}, nil
+	return &direct.Verifier{Provider: prov, Root: cfg.Dir}, nil
 }
 
 // directKeyEnvs reports the env vars that may hold the API key for the given
@@ -1,8 +1,15 @@
 package main
 
 import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"reviewsrv/pkg/reviewer/ctl"
+	"reviewsrv/pkg/reviewer/direct"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,3 +42,36 @@ func TestDirectAPIKey(t *testing.T) {
 		require.Equal(t, "ds", directAPIKey("deepseek"))
 	})
 }
+
+func TestDirectProviderReplay(t *testing.T) {
+	t.Setenv("REVIEW_API_KEY", "")
+	t.Setenv("DEEPSEEK_API_KEY", "")
+
+	path := filepath.Join(t.TempDir(), direct.CassetteFile)
+	require.NoError(t, os.WriteFile(path, []byte(`{"kind":"header","model":"claude-opus-4-7"}`+"\n"), 0o644))
+
+	// --replay needs no API key and reports the recorded model.
+	prov, err := directProvider(&ctl.Config{Replay: path}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "claude-opus-4-7", prov.Model())
+
+	_, err = directProvider(&ctl.Config{Replay: filepath.Join(t.TempDir(), "missing.jsonl")}, nil)
+	require.Error(t, err)
+
+	_, err = directProvider(&ctl.Config{}, nil)
+	require.ErrorContains(t, err, "API key not found")
+}
+
+func TestWarnModelCatalog(t *testing.T) {
+	warnings := func(cfg *ctl.Config) string {
+		var buf bytes.Buffer
+		warnModelCatalog(cfg, nil, slog.New(slog.NewTextHandler(&buf, nil)))
+		return buf.String()
+	}
+
+	require.Empty(t, warnings(&ctl.Config{Runner: "direct", Model: "claude-opus-4-8", Effort: "xhigh"}))
+	require.Empty(t, warnings(&ctl.Config{Runner: "claude", Model: "opus"}), "the CLI reports its own cost")
+	require.Contains(t, warnings(&ctl.Config{Runner: "codex", Model: "gpt-9-codex"}), `model \"gpt-9-codex\": no pricing`)
+	require.Contains(t, warnings(&ctl.Config{Runner: "direct", Model: "deepseek-chat", Verify: true, VerifyModel: "qwen3"}), `model \"qwen3\": no pricing`)
+	require.Contains(t, warnings(&ctl.Config{Runner: "direct", Model: "deepseek-chat", Effort: "high"}), "--effort is ignored")
+}
@@ -0,0 +1,24 @@
+ALTER TABLE "reviews" ADD COLUMN "previousReviewId" integer;
+ALTER TABLE "reviews" ADD COLUMN "versionStats" jsonb;
+ALTER TABLE "reviews" ADD CONSTRAINT "Ref_reviews_to_reviews" FOREIGN KEY ("previousReviewId")
+	REFERENCES "reviews"("reviewId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+CREATE INDEX "IX_reviews_previousReviewId" ON "reviews" ("previousReviewId");
+
+ALTER TABLE "issues" ADD COLUMN "fingerprint" varchar(64);
+ALTER TABLE "issues" ADD COLUMN "previousIssueId" integer;
+ALTER TABLE "issues" ADD COLUMN "fixedInReviewId" integer;
+ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_issues" FOREIGN KEY ("previousIssueId")
+	REFERENCES "issues"("issueId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_fixedInReviews" FOREIGN KEY ("fixedInReviewId")
+	REFERENCES "reviews"("reviewId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+CREATE INDEX "IX_issues_previousIssueId" ON "issues" ("previousIssueId");
+CREATE INDEX "IX_issues_fixedInReviewId" ON "issues" ("fixedInReviewId");
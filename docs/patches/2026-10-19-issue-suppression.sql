@@ -0,0 +1,7 @@
+ALTER TABLE "issues" ADD COLUMN "suppressedByIssueId" integer;
+ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_suppressedByIssues" FOREIGN KEY ("suppressedByIssueId")
+	REFERENCES "issues"("issueId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+CREATE INDEX "IX_issues_suppressedByIssueId" ON "issues" ("suppressedByIssueId");
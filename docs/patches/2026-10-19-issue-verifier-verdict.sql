@@ -0,0 +1,3 @@
+-- Verifier pass verdict per issue (confirm / downgrade / reject), kept apart
+-- from the title so rejected issues keep their title and fingerprint.
+ALTER TABLE "issues" ADD COLUMN "verifierVerdict" varchar(16);
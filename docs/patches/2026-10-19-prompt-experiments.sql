@@ -0,0 +1,40 @@
+CREATE TABLE "promptExperiments" (
+	"promptExperimentId" integer NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"projectId" integer NOT NULL,
+	"title" varchar(255) NOT NULL,
+	"variantPromptId" integer NOT NULL,
+	"trafficPercent" integer NOT NULL,
+	"createdAt" timestamptz NOT NULL DEFAULT now(),
+	"statusId" integer NOT NULL,
+	CONSTRAINT "promptExperiments_pkey" PRIMARY KEY("promptExperimentId")
+);
+
+CREATE INDEX "IX_promptExperiments_projectId" ON "promptExperiments" ("projectId");
+CREATE INDEX "ix_promptExperiments_statusId" ON "promptExperiments" ("statusId");
+
+ALTER TABLE "promptExperiments" ADD CONSTRAINT "Ref_promptExperiments_to_projects" FOREIGN KEY ("projectId")
+	REFERENCES "projects"("projectId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "promptExperiments" ADD CONSTRAINT "Ref_promptExperiments_to_prompts" FOREIGN KEY ("variantPromptId")
+	REFERENCES "prompts"("promptId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "promptExperiments" ADD CONSTRAINT "Ref_promptExperiments_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "reviews" ADD COLUMN "promptExperimentId" integer;
+ALTER TABLE "reviews" ADD COLUMN "promptVariant" varchar(8);
+ALTER TABLE "reviews" ADD CONSTRAINT "Ref_reviews_to_promptExperiments" FOREIGN KEY ("promptExperimentId")
+	REFERENCES "promptExperiments"("promptExperimentId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+CREATE INDEX "IX_reviews_promptExperimentId" ON "reviews" ("promptExperimentId");
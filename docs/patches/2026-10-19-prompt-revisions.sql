@@ -0,0 +1,42 @@
+CREATE TABLE "promptRevisions" (
+	"promptRevisionId" integer NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"promptId" integer NOT NULL,
+	"common" text NOT NULL,
+	"architecture" text NOT NULL,
+	"code" text NOT NULL,
+	"security" text NOT NULL,
+	"tests" text NOT NULL,
+	"operability" text NOT NULL,
+	"userId" integer,
+	"createdAt" timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT "promptRevisions_pkey" PRIMARY KEY("promptRevisionId")
+);
+
+CREATE INDEX "IX_promptRevisions_promptId" ON "promptRevisions" ("promptId");
+
+ALTER TABLE "promptRevisions" ADD CONSTRAINT "Ref_promptRevisions_to_prompts" FOREIGN KEY ("promptId")
+	REFERENCES "prompts"("promptId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "promptRevisions" ADD CONSTRAINT "Ref_promptRevisions_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+-- current text of every prompt becomes its first revision
+INSERT INTO "promptRevisions" ("promptId", "common", "architecture", "code", "security", "tests", "operability", "createdAt")
+SELECT "promptId", "common", "architecture", "code", "security", "tests", "operability", "createdAt"
+FROM "prompts"
+ORDER BY "promptId";
+
+-- existing reviews keep NULL: the text they were produced with is unknown
+ALTER TABLE "reviews" ADD COLUMN "promptRevisionId" integer;
+ALTER TABLE "reviews" ADD CONSTRAINT "Ref_reviews_to_promptRevisions" FOREIGN KEY ("promptRevisionId")
+	REFERENCES "promptRevisions"("promptRevisionId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+CREATE INDEX "IX_reviews_promptRevisionId" ON "reviews" ("promptRevisionId");
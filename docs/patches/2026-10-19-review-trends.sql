@@ -0,0 +1,3 @@
+-- Range scans for project trends: per project and over all projects.
+CREATE INDEX "IX_reviews_projectId_createdAt" ON "reviews" ("projectId", "createdAt");
+CREATE INDEX "IX_reviews_createdAt" ON "reviews" ("createdAt");
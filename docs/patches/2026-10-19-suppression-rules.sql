@@ -0,0 +1,39 @@
+CREATE TABLE "suppressionRules" (
+	"suppressionRuleId" integer NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"projectId" integer NOT NULL,
+	"pathGlob" varchar(255),
+	"issueType" varchar(32),
+	"reviewType" varchar(32),
+	"titlePattern" varchar(255),
+	"maxSeverity" varchar(16),
+	"reason" varchar(1024) NOT NULL,
+	"owner" varchar(255) NOT NULL,
+	"expiresAt" timestamptz NOT NULL,
+	"createdAt" timestamptz NOT NULL DEFAULT now(),
+	"statusId" integer NOT NULL,
+	CONSTRAINT "suppressionRules_pkey" PRIMARY KEY("suppressionRuleId")
+);
+
+CREATE INDEX "IX_suppressionRules_projectId" ON "suppressionRules" ("projectId");
+CREATE INDEX "IX_suppressionRules_expiresAt" ON "suppressionRules" ("expiresAt");
+CREATE INDEX "ix_suppressionRules_statusId" ON "suppressionRules" ("statusId");
+
+ALTER TABLE "suppressionRules" ADD CONSTRAINT "Ref_suppressionRules_to_projects" FOREIGN KEY ("projectId")
+	REFERENCES "projects"("projectId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "suppressionRules" ADD CONSTRAINT "Ref_suppressionRules_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "issues" ADD COLUMN "suppressedByRuleId" integer;
+ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_suppressionRules" FOREIGN KEY ("suppressedByRuleId")
+	REFERENCES "suppressionRules"("suppressionRuleId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+CREATE INDEX "IX_issues_suppressedByRuleId" ON "issues" ("suppressedByRuleId");
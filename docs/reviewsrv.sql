@@ -12,6 +12,20 @@ CREATE TABLE "prompts" (
 	CONSTRAINT "prompts_pkey" PRIMARY KEY("promptId")
 );
 
+CREATE TABLE "promptRevisions" (
+	"promptRevisionId" integer NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"promptId" integer NOT NULL,
+	"common" text NOT NULL,
+	"architecture" text NOT NULL,
+	"code" text NOT NULL,
+	"security" text NOT NULL,
+	"tests" text NOT NULL,
+	"operability" text NOT NULL,
+	"userId" integer,
+	"createdAt" timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT "promptRevisions_pkey" PRIMARY KEY("promptRevisionId")
+);
+
 CREATE TABLE "statuses" (
 	"statusId" integer NOT NULL,
 	"title" varchar(255) NOT NULL,
@@ -55,6 +69,33 @@ CREATE TABLE "projects" (
 	CONSTRAINT "UNQ_projects_projectKey" UNIQUE("projectKey")
 );
 
+CREATE TABLE "suppressionRules" (
+	"suppressionRuleId" integer NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"projectId" integer NOT NULL,
+	"pathGlob" varchar(255),
+	"issueType" varchar(32),
+	"reviewType" varchar(32),
+	"titlePattern" varchar(255),
+	"maxSeverity" varchar(16),
+	"reason" varchar(1024) NOT NULL,
+	"owner" varchar(255) NOT NULL,
+	"expiresAt" timestamptz NOT NULL,
+	"createdAt" timestamptz NOT NULL DEFAULT now(),
+	"statusId" integer NOT NULL,
+	CONSTRAINT "suppressionRules_pkey" PRIMARY KEY("suppressionRuleId")
+);
+
+CREATE TABLE "promptExperiments" (
+	"promptExperimentId" integer NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"projectId" integer NOT NULL,
+	"title" varchar(255) NOT NULL,
+	"variantPromptId" integer NOT NULL,
+	"trafficPercent" integer NOT NULL,
+	"createdAt" timestamptz NOT NULL DEFAULT now(),
+	"statusId" integer NOT NULL,
+	CONSTRAINT "promptExperiments_pkey" PRIMARY KEY("promptExperimentId")
+);
+
 CREATE TABLE "reviews" (
 	"reviewId" integer NOT NULL GENERATED BY DEFAULT AS IDENTITY,
 	"projectId" integer NOT NULL,
@@ -73,6 +114,11 @@ CREATE TABLE "reviews" (
 	"promptId" integer NOT NULL,
 	"effortMinutes" integer,
 	"aiSlopScore" real,
+	"previousReviewId" integer,
+	"versionStats" jsonb,
+	"promptRevisionId" integer,
+	"promptExperimentId" integer,
+	"promptVariant" varchar(8),
 	CONSTRAINT "reviews_pkey" PRIMARY KEY("reviewId")
 );
 
@@ -109,6 +155,12 @@ CREATE TABLE "issues" (
 	"suggestedFix" text,
 	"statusId" integer NOT NULL,
 	"archivedAt" timestamptz,
+	"fingerprint" varchar(64),
+	"previousIssueId" integer,
+	"fixedInReviewId" integer,
+	"suppressedByIssueId" integer,
+	"suppressedByRuleId" integer,
+	"verifierVerdict" varchar(16),
 	CONSTRAINT "issues_pkey" PRIMARY KEY("issueId")
 );
 
@@ -155,6 +207,67 @@ CREATE INDEX "IX_issues_archivedAt" ON "issues" (
 	"archivedAt"
 );
 
+CREATE INDEX "IX_issues_previousIssueId" ON "issues" (
+	"previousIssueId"
+);
+
+CREATE INDEX "IX_issues_fixedInReviewId" ON "issues" (
+	"fixedInReviewId"
+);
+
+CREATE INDEX "IX_issues_suppressedByIssueId" ON "issues" (
+	"suppressedByIssueId"
+);
+
+CREATE INDEX "IX_issues_suppressedByRuleId" ON "issues" (
+	"suppressedByRuleId"
+);
+
+CREATE INDEX "IX_suppressionRules_projectId" ON "suppressionRules" (
+	"projectId"
+);
+
+CREATE INDEX "IX_suppressionRules_expiresAt" ON "suppressionRules" (
+	"expiresAt"
+);
+
+CREATE INDEX "ix_suppressionRules_statusId" ON "suppressionRules" (
+	"statusId"
+);
+
+CREATE INDEX "IX_reviews_previousReviewId" ON "reviews" (
+	"previousReviewId"
+);
+
+CREATE INDEX "IX_reviews_projectId_createdAt" ON "reviews" (
+	"projectId",
+	"createdAt"
+);
+
+CREATE INDEX "IX_reviews_createdAt" ON "reviews" (
+	"createdAt"
+);
+
+CREATE INDEX "IX_reviews_promptRevisionId" ON "reviews" (
+	"promptRevisionId"
+);
+
+CREATE INDEX "IX_promptRevisions_promptId" ON "promptRevisions" (
+	"promptId"
+);
+
+CREATE INDEX "IX_reviews_promptExperimentId" ON "reviews" (
+	"promptExperimentId"
+);
+
+CREATE INDEX "IX_promptExperiments_projectId" ON "promptExperiments" (
+	"projectId"
+);
+
+CREATE INDEX "ix_promptExperiments_statusId" ON "promptExperiments" (
+	"statusId"
+);
+
 CREATE INDEX "ix_projects_statusId" ON "projects" (
 	"statusId"
 );
@@ -241,6 +354,18 @@ ALTER TABLE "projects" ADD CONSTRAINT "Ref_projects_to_statuses" FOREIGN KEY ("s
 	ON UPDATE RESTRICT
 	NOT DEFERRABLE;
 
+ALTER TABLE "suppressionRules" ADD CONSTRAINT "Ref_suppressionRules_to_projects" FOREIGN KEY ("projectId")
+	REFERENCES "projects"("projectId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "suppressionRules" ADD CONSTRAINT "Ref_suppressionRules_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
 ALTER TABLE "reviews" ADD CONSTRAINT "Ref_reviews_to_projects" FOREIGN KEY ("projectId")
 	REFERENCES "projects"("projectId")
 	ON DELETE RESTRICT
@@ -259,6 +384,54 @@ ALTER TABLE "reviews" ADD CONSTRAINT "Ref_reviews_to_prompts" FOREIGN KEY ("prom
 	ON UPDATE RESTRICT
 	NOT DEFERRABLE;
 
+ALTER TABLE "reviews" ADD CONSTRAINT "Ref_reviews_to_promptRevisions" FOREIGN KEY ("promptRevisionId")
+	REFERENCES "promptRevisions"("promptRevisionId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "promptRevisions" ADD CONSTRAINT "Ref_promptRevisions_to_prompts" FOREIGN KEY ("promptId")
+	REFERENCES "prompts"("promptId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "promptRevisions" ADD CONSTRAINT "Ref_promptRevisions_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "reviews" ADD CONSTRAINT "Ref_reviews_to_promptExperiments" FOREIGN KEY ("promptExperimentId")
+	REFERENCES "promptExperiments"("promptExperimentId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "promptExperiments" ADD CONSTRAINT "Ref_promptExperiments_to_projects" FOREIGN KEY ("projectId")
+	REFERENCES "projects"("projectId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "promptExperiments" ADD CONSTRAINT "Ref_promptExperiments_to_prompts" FOREIGN KEY ("variantPromptId")
+	REFERENCES "prompts"("promptId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "promptExperiments" ADD CONSTRAINT "Ref_promptExperiments_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "reviews" ADD CONSTRAINT "Ref_reviews_to_reviews" FOREIGN KEY ("previousReviewId")
+	REFERENCES "reviews"("reviewId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
 ALTER TABLE "reviewFiles" ADD CONSTRAINT "Ref_reviewFiles_to_reviews" FOREIGN KEY ("reviewId")
 	REFERENCES "reviews"("reviewId")
 	ON DELETE RESTRICT
@@ -295,6 +468,30 @@ ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_reviews" FOREIGN KEY ("review
 	ON UPDATE RESTRICT
 	NOT DEFERRABLE;
 
+ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_issues" FOREIGN KEY ("previousIssueId")
+	REFERENCES "issues"("issueId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_fixedInReviews" FOREIGN KEY ("fixedInReviewId")
+	REFERENCES "reviews"("reviewId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_suppressedByIssues" FOREIGN KEY ("suppressedByIssueId")
+	REFERENCES "issues"("issueId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "issues" ADD CONSTRAINT "Ref_issues_to_suppressionRules" FOREIGN KEY ("suppressedByRuleId")
+	REFERENCES "suppressionRules"("suppressionRuleId")
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
 ALTER TABLE "users" ADD CONSTRAINT "Ref_users_to_statuses" FOREIGN KEY ("statusId")
 	REFERENCES "statuses"("statusId")
 	ON DELETE RESTRICT
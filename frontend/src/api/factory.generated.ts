@@ -1,6 +1,15 @@
 /* Code generated from jsonrpc schema by rpcgen v2.5.x with typescript v1.0.0; DO NOT EDIT. */
 /* eslint-disable */
 // @ts-nocheck
+export interface IFeedbackStats {
+  valid: number,
+  falsePositive: number,
+  ignored: number,
+  validRatio: number,
+  falsePositiveRatio: number,
+  ignoredRatio: number
+}
+
 export interface IIssue {
   issueId: number,
   reviewId: number,
@@ -16,7 +25,13 @@ export interface IIssue {
   commitHash: string,
   suggestedFix?: string,
   statusId: number,
-  comment?: string
+  comment?: string,
+  lifecycle: string,
+  previousIssueId?: number,
+  fixedInReviewId?: number,
+  suppressedByIssueId?: number
+  suppressedByRuleId?: number
+  verifierVerdict?: string
 }
 
 export interface IIssueFilters {
@@ -41,6 +56,29 @@ export interface ILastReview {
   trafficLight: string
 }
 
+export interface ILeaderboardEntry {
+  runner: string,
+  model: string,
+  promptId: number,
+  promptTitle: string,
+  reviewCount: number,
+  issueCount: number,
+  feedback: IFeedbackStats,
+  precision: number,
+  issuesPerReview: number,
+  costUsd: number,
+  costPerValidIssue?: number,
+  medianDurationMs: number
+}
+
+export interface ILeaderboardFilters {
+  projectId?: number,
+  language?: string,
+  from?: string,
+  to?: string,
+  rankBy?: string
+}
+
 export interface IModelInfo {
   model: string,
   inputTokens: number,
@@ -81,6 +119,18 @@ export interface IProject {
   lastReview?: ILastReview
 }
 
+export interface IProjectTrend {
+  projectId: number,
+  bucketStart: string,
+  reviewCount: number,
+  issues: IIssueStats,
+  issuesByReviewType: Record<string, number>,
+  feedback: IFeedbackStats,
+  medianDurationMs: number,
+  costUsd: number,
+  costPerReview: number
+}
+
 export interface IReview {
   reviewId: number,
   projectId: number,
@@ -98,7 +148,12 @@ export interface IReview {
   reviewFiles: Array<IReviewFile>,
   effortMinutes?: number,
   aiSlopScore?: number,
-  lastVersionReviewId?: number
+  lastVersionReviewId?: number,
+  previousReviewId?: number,
+  versionStats?: IVersionStats,
+  promptRevisionId?: number,
+  promptExperimentId?: number,
+  promptVariant?: string
 }
 
 export interface IReviewArchiveAcceptedRisksParams {
@@ -145,7 +200,8 @@ export interface IReviewFilters {
   title?: string,
   author?: string,
   trafficLight?: string,
-  externalId?: string
+  externalId?: string,
+  sourceBranch?: string
 }
 
 export interface IReviewGetByIDParams {
@@ -169,6 +225,10 @@ export interface IReviewIssuesParams {
   filters?: IIssueFilters
 }
 
+export interface IReviewLeaderboardParams {
+  filters?: ILeaderboardFilters
+}
+
 export interface IReviewProjectByIDParams {
   projectId: number
 }
@@ -190,7 +250,37 @@ export interface IReviewSummary {
   reviewFiles: Array<IReviewFileSummary>,
   effortMinutes?: number,
   aiSlopScore?: number,
-  lastVersionReviewId?: number
+  lastVersionReviewId?: number,
+  previousReviewId?: number,
+  versionStats?: IVersionStats
+}
+
+export interface IReviewTrendsParams {
+  filters?: ITrendFilters
+}
+
+export interface ITrendFilters {
+  projectIds: Array<number>,
+  from?: string,
+  to?: string,
+  bucket?: string
+}
+
+export interface IVersionStats {
+  new: number,
+  persisting: number,
+  fixed: number
+}
+
+export class FeedbackStats implements IFeedbackStats {
+  static entityName = "feedbackstats";
+
+  valid: number = 0;
+  falsePositive: number = 0;
+  ignored: number = 0;
+  validRatio: number = 0;
+  falsePositiveRatio: number = 0;
+  ignoredRatio: number = 0;
 }
 
 export class Issue implements IIssue {
@@ -211,6 +301,12 @@ export class Issue implements IIssue {
   suggestedFix?: string = null;
   statusId: number = 0;
   comment?: string = null;
+  lifecycle: string = null;
+  previousIssueId?: number = 0;
+  fixedInReviewId?: number = 0;
+  suppressedByIssueId?: number = 0;
+  suppressedByRuleId?: number = 0;
+  verifierVerdict?: string = null;
 }
 
 export class IssueFilters implements IIssueFilters {
@@ -241,6 +337,33 @@ export class LastReview implements ILastReview {
   trafficLight: string = null;
 }
 
+export class LeaderboardEntry implements ILeaderboardEntry {
+  static entityName = "leaderboardentry";
+
+  runner: string = null;
+  model: string = null;
+  promptId: number = 0;
+  promptTitle: string = null;
+  reviewCount: number = 0;
+  issueCount: number = 0;
+  feedback: IFeedbackStats = null;
+  precision: number = 0;
+  issuesPerReview: number = 0;
+  costUsd: number = 0;
+  costPerValidIssue?: number = null;
+  medianDurationMs: number = 0;
+}
+
+export class LeaderboardFilters implements ILeaderboardFilters {
+  static entityName = "leaderboardfilters";
+
+  projectId?: number = null;
+  language?: string = null;
+  from?: string = null;
+  to?: string = null;
+  rankBy?: string = null;
+}
+
 export class ModelInfo implements IModelInfo {
   static entityName = "modelinfo";
 
@@ -287,6 +410,20 @@ export class Project implements IProject {
   lastReview?: ILastReview = null;
 }
 
+export class ProjectTrend implements IProjectTrend {
+  static entityName = "projecttrend";
+
+  projectId: number = 0;
+  bucketStart: string = null;
+  reviewCount: number = 0;
+  issues: IIssueStats = null;
+  issuesByReviewType: Record<string, number> = null;
+  feedback: IFeedbackStats = null;
+  medianDurationMs: number = 0;
+  costUsd: number = 0;
+  costPerReview: number = 0;
+}
+
 export class Review implements IReview {
   static entityName = "review";
 
@@ -307,6 +444,11 @@ export class Review implements IReview {
   effortMinutes?: number = 0;
   aiSlopScore?: number = 0;
   lastVersionReviewId?: number = 0;
+  previousReviewId?: number = 0;
+  versionStats?: IVersionStats = null;
+  promptRevisionId?: number = 0;
+  promptExperimentId?: number = 0;
+  promptVariant?: string = null;
 }
 
 export class ReviewArchiveAcceptedRisksParams implements IReviewArchiveAcceptedRisksParams {
@@ -401,6 +543,12 @@ export class ReviewIssuesParams implements IReviewIssuesParams {
   filters?: IIssueFilters = null;
 }
 
+export class ReviewLeaderboardParams implements IReviewLeaderboardParams {
+  static entityName = "reviewleaderboardparams";
+
+  filters?: ILeaderboardFilters = null;
+}
+
 export class ReviewProjectByIDParams implements IReviewProjectByIDParams {
   static entityName = "reviewprojectbyidparams";
 
@@ -429,6 +577,31 @@ export class ReviewSummary implements IReviewSummary {
   effortMinutes?: number = 0;
   aiSlopScore?: number = 0;
   lastVersionReviewId?: number = 0;
+  previousReviewId?: number = 0;
+  versionStats?: IVersionStats = null;
+}
+
+export class ReviewTrendsParams implements IReviewTrendsParams {
+  static entityName = "reviewtrendsparams";
+
+  filters?: ITrendFilters = null;
+}
+
+export class TrendFilters implements ITrendFilters {
+  static entityName = "trendfilters";
+
+  projectIds: Array<number> = null;
+  from?: string = null;
+  to?: string = null;
+  bucket?: string = null;
+}
+
+export class VersionStats implements IVersionStats {
+  static entityName = "versionstats";
+
+  new: number = 0;
+  persisting: number = 0;
+  fixed: number = 0;
 }
 
 export const factory = (send: any) => ({
@@ -496,6 +669,13 @@ for a project by setting archivedAt = NOW(). Returns count of archived issues.
     issuesByProject(params: IReviewIssuesByProjectParams): Promise<Array<IIssue>> {
       return send('review.IssuesByProject', params)
     },
+    /**
+     * Leaderboard ranks (runner, model, prompt) combinations by human feedback:
+precision (valid / processed), issues per review, cost per valid issue and latency.
+     */
+    leaderboard(params: IReviewLeaderboardParams): Promise<Array<ILeaderboardEntry>> {
+      return send('review.Leaderboard', params)
+    },
     /**
      * ProjectByID returns a single project by ID.
      */
@@ -513,6 +693,14 @@ for a project by setting archivedAt = NOW(). Returns count of archived issues.
      */
     setComment(params: IReviewSetCommentParams): Promise<boolean> {
       return send('review.SetComment', params)
+    },
+    /**
+     * Trends returns review stats per project and time bucket for charts:
+review count, issues by severity and review type, feedback ratios, median duration and cost.
+Buckets without reviews are omitted.
+     */
+    trends(params: IReviewTrendsParams): Promise<Array<IProjectTrend>> {
+      return send('review.Trends', params)
     }
   }
 })
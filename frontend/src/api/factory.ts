@@ -6,6 +6,8 @@ export type { IIssueStats as IssueStats, ILastReview as LastReview, IModelInfo a
 export type { IProject as Project, IReview as Review, IReviewSummary as ReviewSummary } from './factory.generated'
 export type { IReviewFile as ReviewFile, IReviewFileSummary as ReviewFileSummary } from './factory.generated'
 export type { IIssue as Issue, IReviewFilters as ReviewFilters, IIssueFilters as IssueFilters } from './factory.generated'
+export type { IProjectTrend as ProjectTrend, IFeedbackStats as FeedbackStats, ITrendFilters as TrendFilters } from './factory.generated'
+export type { ILeaderboardEntry as LeaderboardEntry, ILeaderboardFilters as LeaderboardFilters } from './factory.generated'
 
 const client = new HttpRpcClient({ url: '/v1/rpc/', isClient: true })
 
@@ -16,6 +16,37 @@ export interface ICIFile {
   content: string
 }
 
+export interface IExperimentComparison {
+  metric: string,
+  a: number,
+  b: number,
+  pValue?: number,
+  significance: string
+}
+
+export interface IExperimentReport {
+  experiment?: IPromptExperimentSummary,
+  a: IExperimentVariant,
+  b: IExperimentVariant,
+  comparisons: Array<IExperimentComparison>
+}
+
+export interface IExperimentVariant {
+  variant: string,
+  reviewCount: number,
+  issueCount: number,
+  processed: number,
+  valid: number,
+  falsePositive: number,
+  ignored: number,
+  unfilled: number,
+  costUsd: number,
+  falsePositiveRate: number,
+  validIssuesPerReview: number,
+  costPerReview: number,
+  unfilledRate: number
+}
+
 export interface IFieldError {
   field: string,
   error: string,
@@ -27,6 +58,32 @@ export interface IFieldErrorConstraint {
   min: number // Min value for field.
 }
 
+export interface ILeaderboardEntry {
+  runner: string,
+  model: string,
+  promptId: number,
+  promptTitle: string,
+  reviewCount: number,
+  issueCount: number,
+  processed: number,
+  valid: number,
+  falsePositive: number,
+  ignored: number,
+  precision: number,
+  issuesPerReview: number,
+  costUsd: number,
+  costPerValidIssue?: number,
+  medianDurationMs: number
+}
+
+export interface ILeaderboardSearch {
+  projectId?: number,
+  language?: string,
+  from?: string,
+  to?: string,
+  rankBy?: string
+}
+
 export interface IProject {
   id: number,
   title: string,
@@ -126,6 +183,51 @@ export interface IPromptDeleteParams {
   id: number
 }
 
+export interface IPromptDiffLine {
+  op: string,
+  text: string
+}
+
+export interface IPromptDiffParams {
+  promptId: number,
+  fromId: number,
+  toId: number
+}
+
+export interface IPromptExperiment {
+  id: number,
+  projectId: number,
+  title: string,
+  variantPromptId: number,
+  trafficPercent: number,
+  createdAt: string,
+  statusId: number,
+  project?: IProjectSummary,
+  variantPrompt?: IPromptSummary,
+  status?: IStatus
+}
+
+export interface IPromptExperimentSearch {
+  id?: number,
+  projectId?: number,
+  title?: string,
+  variantPromptId?: number,
+  statusId?: number,
+  ids: Array<number>
+}
+
+export interface IPromptExperimentSummary {
+  id: number,
+  projectId: number,
+  title: string,
+  variantPromptId: number,
+  trafficPercent: number,
+  createdAt: string,
+  project?: IProjectSummary,
+  variantPrompt?: IPromptSummary,
+  status?: IStatus
+}
+
 export interface IPromptGetByIDParams {
   id: number
 }
@@ -135,6 +237,29 @@ export interface IPromptGetParams {
   viewOps?: IViewOps
 }
 
+export interface IPromptRevision {
+  id: number,
+  promptId: number,
+  common: string,
+  architecture: string,
+  code: string,
+  security: string,
+  tests: string,
+  operability: string,
+  userId?: number,
+  createdAt: string,
+  user?: IUserSummary
+}
+
+export interface IPromptRevisionsParams {
+  promptId: number
+}
+
+export interface IPromptRollbackParams {
+  promptId: number,
+  revisionId: number
+}
+
 export interface IPromptSearch {
   id?: number,
   title?: string,
@@ -148,6 +273,12 @@ export interface IPromptSearch {
   ids: Array<number>
 }
 
+export interface IPromptSectionDiff {
+  section: string,
+  changed: boolean,
+  lines: Array<IPromptDiffLine>
+}
+
 export interface IPromptSummary {
   id: number,
   title: string,
@@ -168,6 +299,43 @@ export interface IPromptValidateParams {
   prompt: IPrompt
 }
 
+export interface IPromptexperimentAddParams {
+  promptExperiment: IPromptExperiment
+}
+
+export interface IPromptexperimentCountParams {
+  search?: IPromptExperimentSearch
+}
+
+export interface IPromptexperimentDeleteParams {
+  id: number
+}
+
+export interface IPromptexperimentGetByIDParams {
+  id: number
+}
+
+export interface IPromptexperimentGetParams {
+  search?: IPromptExperimentSearch,
+  viewOps?: IViewOps
+}
+
+export interface IPromptexperimentUpdateParams {
+  promptExperiment: IPromptExperiment
+}
+
+export interface IPromptexperimentValidateParams {
+  promptExperiment: IPromptExperiment
+}
+
+export interface IReportExperimentParams {
+  promptExperimentId: number
+}
+
+export interface IReportLeaderboardParams {
+  search?: ILeaderboardSearch
+}
+
 export interface ISlackChannel {
   id: number,
   title: string,
@@ -229,6 +397,81 @@ export interface IStatus {
   title: string
 }
 
+export interface ISuppressionRule {
+  id: number,
+  projectId: number,
+  pathGlob?: string,
+  issueType?: string,
+  reviewType?: string,
+  titlePattern?: string,
+  maxSeverity?: string,
+  reason: string,
+  owner: string,
+  expiresAt: string,
+  createdAt: string,
+  statusId: number,
+  project?: IProjectSummary,
+  status?: IStatus
+}
+
+export interface ISuppressionRuleSearch {
+  id?: number,
+  projectId?: number,
+  pathGlob?: string,
+  issueType?: string,
+  reviewType?: string,
+  titlePattern?: string,
+  reason?: string,
+  owner?: string,
+  statusId?: number,
+  ids: Array<number>
+}
+
+export interface ISuppressionRuleSummary {
+  id: number,
+  projectId: number,
+  pathGlob?: string,
+  issueType?: string,
+  reviewType?: string,
+  titlePattern?: string,
+  maxSeverity?: string,
+  reason: string,
+  owner: string,
+  expiresAt: string,
+  createdAt: string,
+  project?: IProjectSummary,
+  status?: IStatus
+}
+
+export interface ISuppressionruleAddParams {
+  suppressionRule: ISuppressionRule
+}
+
+export interface ISuppressionruleCountParams {
+  search?: ISuppressionRuleSearch
+}
+
+export interface ISuppressionruleDeleteParams {
+  id: number
+}
+
+export interface ISuppressionruleGetByIDParams {
+  id: number
+}
+
+export interface ISuppressionruleGetParams {
+  search?: ISuppressionRuleSearch,
+  viewOps?: IViewOps
+}
+
+export interface ISuppressionruleUpdateParams {
+  suppressionRule: ISuppressionRule
+}
+
+export interface ISuppressionruleValidateParams {
+  suppressionRule: ISuppressionRule
+}
+
 export interface ITaskTracker {
   id: number,
   title: string,
@@ -380,6 +623,43 @@ export class CIFile implements ICIFile {
   content: string = null;
 }
 
+export class ExperimentComparison implements IExperimentComparison {
+  static entityName = "experimentcomparison";
+
+  metric: string = null;
+  a: number = 0;
+  b: number = 0;
+  pValue?: number = null;
+  significance: string = null;
+}
+
+export class ExperimentReport implements IExperimentReport {
+  static entityName = "experimentreport";
+
+  experiment?: IPromptExperimentSummary = null;
+  a: IExperimentVariant = null;
+  b: IExperimentVariant = null;
+  comparisons: Array<IExperimentComparison> = [];
+}
+
+export class ExperimentVariant implements IExperimentVariant {
+  static entityName = "experimentvariant";
+
+  variant: string = null;
+  reviewCount: number = 0;
+  issueCount: number = 0;
+  processed: number = 0;
+  valid: number = 0;
+  falsePositive: number = 0;
+  ignored: number = 0;
+  unfilled: number = 0;
+  costUsd: number = 0;
+  falsePositiveRate: number = 0;
+  validIssuesPerReview: number = 0;
+  costPerReview: number = 0;
+  unfilledRate: number = 0;
+}
+
 export class FieldError implements IFieldError {
   static entityName = "fielderror";
 
@@ -395,6 +675,36 @@ export class FieldErrorConstraint implements IFieldErrorConstraint {
   min: number = 0;
 }
 
+export class LeaderboardEntry implements ILeaderboardEntry {
+  static entityName = "leaderboardentry";
+
+  runner: string = null;
+  model: string = null;
+  promptId: number = 0;
+  promptTitle: string = null;
+  reviewCount: number = 0;
+  issueCount: number = 0;
+  processed: number = 0;
+  valid: number = 0;
+  falsePositive: number = 0;
+  ignored: number = 0;
+  precision: number = 0;
+  issuesPerReview: number = 0;
+  costUsd: number = 0;
+  costPerValidIssue?: number = null;
+  medianDurationMs: number = 0;
+}
+
+export class LeaderboardSearch implements ILeaderboardSearch {
+  static entityName = "leaderboardsearch";
+
+  projectId?: number = null;
+  language?: string = null;
+  from?: string = null;
+  to?: string = null;
+  rankBy?: string = null;
+}
+
 export class Project implements IProject {
   static entityName = "project";
 
@@ -522,6 +832,61 @@ export class PromptDeleteParams implements IPromptDeleteParams {
   id: number = 0;
 }
 
+export class PromptDiffLine implements IPromptDiffLine {
+  static entityName = "promptdiffline";
+
+  op: string = null;
+  text: string = null;
+}
+
+export class PromptDiffParams implements IPromptDiffParams {
+  static entityName = "promptdiffparams";
+
+  promptId: number = 0;
+  fromId: number = 0;
+  toId: number = 0;
+}
+
+export class PromptExperiment implements IPromptExperiment {
+  static entityName = "promptexperiment";
+
+  id: number = 0;
+  projectId: number = 0;
+  title: string = null;
+  variantPromptId: number = 0;
+  trafficPercent: number = 0;
+  createdAt: string = null;
+  statusId: number = 0;
+  project?: IProjectSummary = null;
+  variantPrompt?: IPromptSummary = null;
+  status?: IStatus = null;
+}
+
+export class PromptExperimentSearch implements IPromptExperimentSearch {
+  static entityName = "promptexperimentsearch";
+
+  id?: number = 0;
+  projectId?: number = 0;
+  title?: string = "";
+  variantPromptId?: number = 0;
+  statusId?: number = 0;
+  ids: Array<number> = [0];
+}
+
+export class PromptExperimentSummary implements IPromptExperimentSummary {
+  static entityName = "promptexperiment";
+
+  id: number = 0;
+  projectId: number = 0;
+  title: string = null;
+  variantPromptId: number = 0;
+  trafficPercent: number = 0;
+  createdAt: string = null;
+  project?: IProjectSummary = null;
+  variantPrompt?: IPromptSummary = null;
+  status?: IStatus = null;
+}
+
 export class PromptGetByIDParams implements IPromptGetByIDParams {
   static entityName = "promptgetbyidparams";
 
@@ -535,6 +900,35 @@ export class PromptGetParams implements IPromptGetParams {
   viewOps?: IViewOps = null;
 }
 
+export class PromptRevision implements IPromptRevision {
+  static entityName = "promptrevision";
+
+  id: number = 0;
+  promptId: number = 0;
+  common: string = null;
+  architecture: string = null;
+  code: string = null;
+  security: string = null;
+  tests: string = null;
+  operability: string = null;
+  userId?: number = 0;
+  createdAt: string = null;
+  user?: IUserSummary = null;
+}
+
+export class PromptRevisionsParams implements IPromptRevisionsParams {
+  static entityName = "promptrevisionsparams";
+
+  promptId: number = 0;
+}
+
+export class PromptRollbackParams implements IPromptRollbackParams {
+  static entityName = "promptrollbackparams";
+
+  promptId: number = 0;
+  revisionId: number = 0;
+}
+
 export class PromptSearch implements IPromptSearch {
   static entityName = "promptsearch";
 
@@ -550,6 +944,14 @@ export class PromptSearch implements IPromptSearch {
   ids: Array<number> = [0];
 }
 
+export class PromptSectionDiff implements IPromptSectionDiff {
+  static entityName = "promptsectiondiff";
+
+  section: string = null;
+  changed: boolean = false;
+  lines: Array<IPromptDiffLine> = null;
+}
+
 export class PromptSummary implements IPromptSummary {
   static entityName = "prompt";
 
@@ -576,6 +978,61 @@ export class PromptValidateParams implements IPromptValidateParams {
   prompt: IPrompt = null;
 }
 
+export class PromptexperimentAddParams implements IPromptexperimentAddParams {
+  static entityName = "promptexperimentaddparams";
+
+  promptExperiment: IPromptExperiment = null;
+}
+
+export class PromptexperimentCountParams implements IPromptexperimentCountParams {
+  static entityName = "promptexperimentcountparams";
+
+  search?: IPromptExperimentSearch = null;
+}
+
+export class PromptexperimentDeleteParams implements IPromptexperimentDeleteParams {
+  static entityName = "promptexperimentdeleteparams";
+
+  id: number = 0;
+}
+
+export class PromptexperimentGetByIDParams implements IPromptexperimentGetByIDParams {
+  static entityName = "promptexperimentgetbyidparams";
+
+  id: number = 0;
+}
+
+export class PromptexperimentGetParams implements IPromptexperimentGetParams {
+  static entityName = "promptexperimentgetparams";
+
+  search?: IPromptExperimentSearch = null;
+  viewOps?: IViewOps = null;
+}
+
+export class PromptexperimentUpdateParams implements IPromptexperimentUpdateParams {
+  static entityName = "promptexperimentupdateparams";
+
+  promptExperiment: IPromptExperiment = null;
+}
+
+export class PromptexperimentValidateParams implements IPromptexperimentValidateParams {
+  static entityName = "promptexperimentvalidateparams";
+
+  promptExperiment: IPromptExperiment = null;
+}
+
+export class ReportExperimentParams implements IReportExperimentParams {
+  static entityName = "reportexperimentparams";
+
+  promptExperimentId: number = 0;
+}
+
+export class ReportLeaderboardParams implements IReportLeaderboardParams {
+  static entityName = "reportleaderboardparams";
+
+  search?: ILeaderboardSearch = null;
+}
+
 export class SlackChannel implements ISlackChannel {
   static entityName = "slackchannel";
 
@@ -659,6 +1116,101 @@ export class Status implements IStatus {
   title: string = null;
 }
 
+export class SuppressionRule implements ISuppressionRule {
+  static entityName = "suppressionrule";
+
+  id: number = 0;
+  projectId: number = 0;
+  pathGlob?: string = null;
+  issueType?: string = null;
+  reviewType?: string = null;
+  titlePattern?: string = null;
+  maxSeverity?: string = null;
+  reason: string = null;
+  owner: string = null;
+  expiresAt: string = null;
+  createdAt: string = null;
+  statusId: number = 0;
+  project?: IProjectSummary = null;
+  status?: IStatus = null;
+}
+
+export class SuppressionRuleSearch implements ISuppressionRuleSearch {
+  static entityName = "suppressionrulesearch";
+
+  id?: number = 0;
+  projectId?: number = 0;
+  pathGlob?: string = "";
+  issueType?: string = "";
+  reviewType?: string = "";
+  titlePattern?: string = "";
+  reason?: string = "";
+  owner?: string = "";
+  statusId?: number = 0;
+  ids: Array<number> = [0];
+}
+
+export class SuppressionRuleSummary implements ISuppressionRuleSummary {
+  static entityName = "suppressionrule";
+
+  id: number = 0;
+  projectId: number = 0;
+  pathGlob?: string = null;
+  issueType?: string = null;
+  reviewType?: string = null;
+  titlePattern?: string = null;
+  maxSeverity?: string = null;
+  reason: string = null;
+  owner: string = null;
+  expiresAt: string = null;
+  createdAt: string = null;
+  project?: IProjectSummary = null;
+  status?: IStatus = null;
+}
+
+export class SuppressionruleAddParams implements ISuppressionruleAddParams {
+  static entityName = "suppressionruleaddparams";
+
+  suppressionRule: ISuppressionRule = null;
+}
+
+export class SuppressionruleCountParams implements ISuppressionruleCountParams {
+  static entityName = "suppressionrulecountparams";
+
+  search?: ISuppressionRuleSearch = null;
+}
+
+export class SuppressionruleDeleteParams implements ISuppressionruleDeleteParams {
+  static entityName = "suppressionruledeleteparams";
+
+  id: number = 0;
+}
+
+export class SuppressionruleGetByIDParams implements ISuppressionruleGetByIDParams {
+  static entityName = "suppressionrulegetbyidparams";
+
+  id: number = 0;
+}
+
+export class SuppressionruleGetParams implements ISuppressionruleGetParams {
+  static entityName = "suppressionrulegetparams";
+
+  search?: ISuppressionRuleSearch = null;
+  viewOps?: IViewOps = null;
+}
+
+export class SuppressionruleUpdateParams implements ISuppressionruleUpdateParams {
+  static entityName = "suppressionruleupdateparams";
+
+  suppressionRule: ISuppressionRule = null;
+}
+
+export class SuppressionruleValidateParams implements ISuppressionruleValidateParams {
+  static entityName = "suppressionrulevalidateparams";
+
+  suppressionRule: ISuppressionRule = null;
+}
+
 export class TaskTracker implements ITaskTracker {
   static entityName = "tasktracker";
 
@@ -935,6 +1487,12 @@ export const factory = (send: any) => ({
     delete(params: IPromptDeleteParams): Promise<boolean> {
       return send('prompt.Delete', params)
     },
+    /**
+     * Diff returns per-section line diffs between two revisions of the Prompt.
+     */
+    diff(params: IPromptDiffParams): Promise<Array<IPromptSectionDiff>> {
+      return send('prompt.Diff', params)
+    },
     /**
      * Get returns а list of Prompts according to conditions in search params.
      */
@@ -947,6 +1505,18 @@ export const factory = (send: any) => ({
     getByID(params: IPromptGetByIDParams): Promise<IPrompt> {
       return send('prompt.GetByID', params)
     },
+    /**
+     * Revisions returns all revisions of the Prompt, newest first.
+     */
+    revisions(params: IPromptRevisionsParams): Promise<Array<IPromptRevision>> {
+      return send('prompt.Revisions', params)
+    },
+    /**
+     * Rollback restores the Prompt texts from the revision and records them as a new revision.
+     */
+    rollback(params: IPromptRollbackParams): Promise<IPromptRevision> {
+      return send('prompt.Rollback', params)
+    },
     /**
      * Update updates the Prompt data identified by id from the query.
      */
@@ -960,6 +1530,67 @@ export const factory = (send: any) => ({
       return send('prompt.Validate', params)
     }
   },
+  promptexperiment: {
+    /**
+     * Add adds a PromptExperiment from the query.
+     */
+    add(params: IPromptexperimentAddParams): Promise<IPromptExperiment> {
+      return send('promptexperiment.Add', params)
+    },
+    /**
+     * Count returns count PromptExperiments according to conditions in search params.
+     */
+    count(params: IPromptexperimentCountParams): Promise<number> {
+      return send('promptexperiment.Count', params)
+    },
+    /**
+     * Delete deletes the PromptExperiment by its ID.
+     */
+    delete(params: IPromptexperimentDeleteParams): Promise<boolean> {
+      return send('promptexperiment.Delete', params)
+    },
+    /**
+     * Get returns а list of PromptExperiments according to conditions in search params.
+     */
+    get(params: IPromptexperimentGetParams): Promise<Array<IPromptExperimentSummary>> {
+      return send('promptexperiment.Get', params)
+    },
+    /**
+     * GetByID returns a PromptExperiment by its ID.
+     */
+    getByID(params: IPromptexperimentGetByIDParams): Promise<IPromptExperiment> {
+      return send('promptexperiment.GetByID', params)
+    },
+    /**
+     * Update updates the PromptExperiment data identified by id from the query.
+     */
+    update(params: IPromptexperimentUpdateParams): Promise<boolean> {
+      return send('promptexperiment.Update', params)
+    },
+    /**
+     * Validate verifies that PromptExperiment data is valid.
+     */
+    validate(params: IPromptexperimentValidateParams): Promise<Array<IFieldError>> {
+      return send('promptexperiment.Validate', params)
+    }
+  },
+  report: {
+    /**
+     * Experiment compares the prompt experiment variants: false positive rate, valid issues per review,
+cost per review and unfilled review.json rate. Each metric has a two-sided p-value and
+significance: significant (p < 0.05), notSignificant or insufficientData (under 10 samples per variant).
+     */
+    experiment(params: IReportExperimentParams): Promise<IExperimentReport> {
+      return send('report.Experiment', params)
+    },
+    /**
+     * Leaderboard ranks (runner, model, prompt) combinations by precision (valid / processed),
+issues per review, cost per valid issue or latency.
+     */
+    leaderboard(params: IReportLeaderboardParams): Promise<Array<ILeaderboardEntry>> {
+      return send('report.Leaderboard', params)
+    }
+  },
   slackchannel: {
     /**
      * Add adds a SlackChannel from the query.
@@ -1004,6 +1635,50 @@ export const factory = (send: any) => ({
       return send('slackchannel.Validate', params)
     }
   },
+  suppressionrule: {
+    /**
+     * Add adds a SuppressionRule from the query.
+     */
+    add(params: ISuppressionruleAddParams): Promise<ISuppressionRule> {
+      return send('suppressionrule.Add', params)
+    },
+    /**
+     * Count returns count SuppressionRules according to conditions in search params.
+     */
+    count(params: ISuppressionruleCountParams): Promise<number> {
+      return send('suppressionrule.Count', params)
+    },
+    /**
+     * Delete deletes the SuppressionRule by its ID.
+     */
+    delete(params: ISuppressionruleDeleteParams): Promise<boolean> {
+      return send('suppressionrule.Delete', params)
+    },
+    /**
+     * Get returns а list of SuppressionRules according to conditions in search params.
+     */
+    get(params: ISuppressionruleGetParams): Promise<Array<ISuppressionRuleSummary>> {
+      return send('suppressionrule.Get', params)
+    },
+    /**
+     * GetByID returns a SuppressionRule by its ID.
+     */
+    getByID(params: ISuppressionruleGetByIDParams): Promise<ISuppressionRule> {
+      return send('suppressionrule.GetByID', params)
+    },
+    /**
+     * Update updates the SuppressionRule data identified by id from the query.
+     */
+    update(params: ISuppressionruleUpdateParams): Promise<boolean> {
+      return send('suppressionrule.Update', params)
+    },
+    /**
+     * Validate verifies that SuppressionRule data is valid.
+     */
+    validate(params: ISuppressionruleValidateParams): Promise<Array<IFieldError>> {
+      return send('suppressionrule.Validate', params)
+    }
+  },
   tasktracker: {
     /**
      * Add adds a TaskTracker from the query.
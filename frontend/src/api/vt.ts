@@ -5,8 +5,12 @@ import { getAuthKey, setAuthKey } from './auth'
 // Re-export types from generated file
 export type { IFieldError as FieldError, IViewOps as ViewOps, IStatus as Status } from './vt.generated'
 export type { IProject as Project, IProjectSummary as ProjectSummary, IProjectSearch as ProjectSearch, ICIFile } from './vt.generated'
-export type { IPrompt as Prompt, IPromptSummary as PromptSummary, IPromptSearch as PromptSearch } from './vt.generated'
+export type { IExperimentComparison as ExperimentComparison, IExperimentReport as ExperimentReport, IExperimentVariant as ExperimentVariant } from './vt.generated'
+export type { ILeaderboardEntry as LeaderboardEntry, ILeaderboardSearch as LeaderboardSearch } from './vt.generated'
+export type { IPrompt as Prompt, IPromptSummary as PromptSummary, IPromptSearch as PromptSearch, IPromptRevision as PromptRevision, IPromptSectionDiff as PromptSectionDiff } from './vt.generated'
+export type { IPromptExperiment as PromptExperiment, IPromptExperimentSummary as PromptExperimentSummary, IPromptExperimentSearch as PromptExperimentSearch } from './vt.generated'
 export type { ISlackChannel as SlackChannel, ISlackChannelSummary as SlackChannelSummary, ISlackChannelSearch as SlackChannelSearch } from './vt.generated'
+export type { ISuppressionRule as SuppressionRule, ISuppressionRuleSummary as SuppressionRuleSummary, ISuppressionRuleSearch as SuppressionRuleSearch } from './vt.generated'
 export type { ITaskTracker as TaskTracker, ITaskTrackerSummary as TaskTrackerSummary, ITaskTrackerSearch as TaskTrackerSearch } from './vt.generated'
 export type { IUser as User, IUserSummary as UserSummary, IUserSearch as UserSearch, IUserProfile as UserProfile } from './vt.generated'
 
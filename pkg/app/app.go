@@ -49,6 +49,7 @@ type App struct {
 	echo         *echo.Echo
 	vtsrv        *zenrpc.Server
 	srv          *zenrpc.Server
+	mcpsrv       *rpc.MCPServer
 	debugStorage *debug.Storage
 }
 
@@ -67,6 +68,7 @@ func New(appName, version string, sl embedlog.Logger, cfg Config, db db.DB, dbc
 	// add services
 	a.vtsrv = vt.New(a.db, a.Logger, a.cfg.Server.IsDevel, a.cfg.Server.BaseURL)
 	a.srv = rpc.New(a.db, a.Logger, a.cfg.Server.IsDevel, a.version)
+	a.mcpsrv = rpc.NewMCPServer(a.db, a.Logger, a.version)
 
 	return a
 }
@@ -86,6 +88,8 @@ func (a *App) Run(ctx context.Context) error {
 	}
 	a.registerMetadata()
 
+	go a.runSuppressionRuleExpiry(ctx)
+
 	return a.runHTTPServer(ctx, a.cfg.Server.Host, a.cfg.Server.Port)
 }
 
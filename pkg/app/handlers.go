@@ -118,6 +118,9 @@ func (a *App) registerAPIHandlers() {
 	a.echo.Any("/v1/rpc/doc/", appkit.EchoHandlerFunc(zenrpc.SMDBoxHandler))
 	a.echo.Any("/v1/rpc/openrpc.json", appkit.EchoHandlerFunc(rpcgen.Handler(gen.OpenRPC("reviewsrv", "http://localhost:8075/v1/rpc"))))
 	a.echo.Any("/v1/rpc/api.ts", appkit.EchoHandlerFunc(rpcgen.Handler(gen.TSClient(nil))))
+
+	// MCP endpoint for IDE agents: the same review data and access as /v1/rpc/.
+	a.echo.Any("/v1/mcp/", appkit.EchoHandler(appkit.XRequestID(a.mcpsrv)))
 }
 
 // registerSPAHandlers serves an embedded SPA at the given prefix.
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reviewsrv/pkg/reviewer"
+	"reviewsrv/pkg/slack"
+)
+
+// suppressionRuleExpiryInterval is how often expired suppression rules are disabled.
+const suppressionRuleExpiryInterval = time.Hour
+
+// runSuppressionRuleExpiry disables expired suppression rules on start and then
+// every suppressionRuleExpiryInterval until ctx is done.
+func (a *App) runSuppressionRuleExpiry(ctx context.Context) {
+	pm := reviewer.NewProjectManager(a.db)
+	notifier := slack.NewNotifier(a.Logger)
+
+	ticker := time.NewTicker(suppressionRuleExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		a.expireSuppressionRules(ctx, pm, notifier)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expireSuppressionRules disables the expired rules and notifies the Slack
+// channel of each affected project, if it has one.
+func (a *App) expireSuppressionRules(ctx context.Context, pm *reviewer.ProjectManager, notifier *slack.Notifier) {
+	rules, err := pm.ExpireSuppressionRules(ctx, time.Now())
+	if err != nil {
+		a.Error(ctx, "expire suppression rules failed", "err", err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+	a.Print(ctx, "suppression rules expired", "count", len(rules), "ids", rules.IDs())
+
+	var order []int
+	byProject := make(map[int]*slack.RulesExpiredNotification)
+	for _, r := range rules {
+		pr := reviewer.NewProject(r.Project)
+		if pr == nil || !pr.HasSlackWebhook() {
+			continue
+		}
+		n, ok := byProject[pr.ID]
+		if !ok {
+			n = &slack.RulesExpiredNotification{
+				WebhookURL:   pr.SlackChannel.WebhookURL,
+				ProjectTitle: pr.Title,
+				RulesURL:     fmt.Sprintf("%s/vt/suppression-rules?projectId=%d", a.cfg.Server.BaseURL, pr.ID),
+			}
+			byProject[pr.ID] = n
+			order = append(order, pr.ID)
+		}
+		n.Rules = append(n.Rules, slack.ExpiredRule{ID: r.ID, Scope: r.Scope(), Owner: r.Owner})
+	}
+
+	for _, id := range order {
+		notifier.SendRulesExpired(ctx, *byProject[id])
+	}
+}
@@ -13,7 +13,7 @@ var Columns = struct {
 		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID string
 	}
 	Issue struct {
-		ID, ReviewFileID, IssueType, ReviewID, Title, Severity, Description, Content, File, Lines, Comment, ProcessedAt, CreatedAt, UserID, StatusID, LocalID, SuggestedFix, ArchivedAt string
+		ID, ReviewFileID, IssueType, ReviewID, Title, Severity, Description, Content, File, Lines, Comment, ProcessedAt, CreatedAt, UserID, StatusID, LocalID, SuggestedFix, ArchivedAt, Fingerprint, PreviousIssueID, FixedInReviewID, SuppressedByIssueID, SuppressedByRuleID, VerifierVerdict string
 
 		ReviewFile, Review, User string
 	}
@@ -23,7 +23,7 @@ var Columns = struct {
 		Review string
 	}
 	Review struct {
-		ID, ProjectID, Title, Description, ExternalID, TrafficLight, CommitHash, SourceBranch, TargetBranch, Author, CreatedAt, DurationMS, ModelInfo, StatusID, PromptID, EffortMinutes, AiSlopScore string
+		ID, ProjectID, Title, Description, ExternalID, TrafficLight, CommitHash, SourceBranch, TargetBranch, Author, CreatedAt, DurationMS, ModelInfo, StatusID, PromptID, EffortMinutes, AiSlopScore, PreviousReviewID, VersionStats, PromptRevisionID, PromptExperimentID, PromptVariant string
 
 		Project, Prompt string
 	}
@@ -35,9 +35,24 @@ var Columns = struct {
 	Prompt struct {
 		ID, Title, Common, Architecture, Code, Security, Tests, Operability, CreatedAt, StatusID string
 	}
+	PromptExperiment struct {
+		ID, ProjectID, Title, VariantPromptID, TrafficPercent, CreatedAt, StatusID string
+
+		Project, VariantPrompt string
+	}
+	PromptRevision struct {
+		ID, PromptID, Common, Architecture, Code, Security, Tests, Operability, UserID, CreatedAt string
+
+		Prompt, User string
+	}
 	SlackChannel struct {
 		ID, Title, Channel, WebhookURL, StatusID string
 	}
+	SuppressionRule struct {
+		ID, ProjectID, PathGlob, IssueType, ReviewType, TitlePattern, MaxSeverity, Reason, Owner, ExpiresAt, CreatedAt, StatusID string
+
+		Project string
+	}
 	TaskTracker struct {
 		ID, Title, AuthToken, FetchPrompt, CreatedAt, StatusID, URL string
 	}
@@ -54,28 +69,34 @@ var Columns = struct {
 		StatusID:       "statusId",
 	},
 	Issue: struct {
-		ID, ReviewFileID, IssueType, ReviewID, Title, Severity, Description, Content, File, Lines, Comment, ProcessedAt, CreatedAt, UserID, StatusID, LocalID, SuggestedFix, ArchivedAt string
+		ID, ReviewFileID, IssueType, ReviewID, Title, Severity, Description, Content, File, Lines, Comment, ProcessedAt, CreatedAt, UserID, StatusID, LocalID, SuggestedFix, ArchivedAt, Fingerprint, PreviousIssueID, FixedInReviewID, SuppressedByIssueID, SuppressedByRuleID, VerifierVerdict string
 
 		ReviewFile, Review, User string
 	}{
-		ID:           "issueId",
-		ReviewFileID: "reviewFileId",
-		IssueType:    "issueType",
-		ReviewID:     "reviewId",
-		Title:        "title",
-		Severity:     "severity",
-		Description:  "description",
-		Content:      "content",
-		File:         "file",
-		Lines:        "lines",
-		Comment:      "comment",
-		ProcessedAt:  "processedAt",
-		CreatedAt:    "createdAt",
-		UserID:       "userId",
-		StatusID:     "statusId",
-		LocalID:      "localId",
-		SuggestedFix: "suggestedFix",
-		ArchivedAt:   "archivedAt",
+		ID:                  "issueId",
+		ReviewFileID:        "reviewFileId",
+		IssueType:           "issueType",
+		ReviewID:            "reviewId",
+		Title:               "title",
+		Severity:            "severity",
+		Description:         "description",
+		Content:             "content",
+		File:                "file",
+		Lines:               "lines",
+		Comment:             "comment",
+		ProcessedAt:         "processedAt",
+		CreatedAt:           "createdAt",
+		UserID:              "userId",
+		StatusID:            "statusId",
+		LocalID:             "localId",
+		SuggestedFix:        "suggestedFix",
+		ArchivedAt:          "archivedAt",
+		Fingerprint:         "fingerprint",
+		PreviousIssueID:     "previousIssueId",
+		FixedInReviewID:     "fixedInReviewId",
+		SuppressedByIssueID: "suppressedByIssueId",
+		SuppressedByRuleID:  "suppressedByRuleId",
+		VerifierVerdict:     "verifierVerdict",
 
 		ReviewFile: "ReviewFile",
 		Review:     "Review",
@@ -100,27 +121,32 @@ var Columns = struct {
 		Review: "Review",
 	},
 	Review: struct {
-		ID, ProjectID, Title, Description, ExternalID, TrafficLight, CommitHash, SourceBranch, TargetBranch, Author, CreatedAt, DurationMS, ModelInfo, StatusID, PromptID, EffortMinutes, AiSlopScore string
+		ID, ProjectID, Title, Description, ExternalID, TrafficLight, CommitHash, SourceBranch, TargetBranch, Author, CreatedAt, DurationMS, ModelInfo, StatusID, PromptID, EffortMinutes, AiSlopScore, PreviousReviewID, VersionStats, PromptRevisionID, PromptExperimentID, PromptVariant string
 
 		Project, Prompt string
 	}{
-		ID:            "reviewId",
-		ProjectID:     "projectId",
-		Title:         "title",
-		Description:   "description",
-		ExternalID:    "externalId",
-		TrafficLight:  "trafficLight",
-		CommitHash:    "commitHash",
-		SourceBranch:  "sourceBranch",
-		TargetBranch:  "targetBranch",
-		Author:        "author",
-		CreatedAt:     "createdAt",
-		DurationMS:    "durationMS",
-		ModelInfo:     "modelInfo",
-		StatusID:      "statusId",
-		PromptID:      "promptId",
-		EffortMinutes: "effortMinutes",
-		AiSlopScore:   "aiSlopScore",
+		ID:                 "reviewId",
+		ProjectID:          "projectId",
+		Title:              "title",
+		Description:        "description",
+		ExternalID:         "externalId",
+		TrafficLight:       "trafficLight",
+		CommitHash:         "commitHash",
+		SourceBranch:       "sourceBranch",
+		TargetBranch:       "targetBranch",
+		Author:             "author",
+		CreatedAt:          "createdAt",
+		DurationMS:         "durationMS",
+		ModelInfo:          "modelInfo",
+		StatusID:           "statusId",
+		PromptID:           "promptId",
+		EffortMinutes:      "effortMinutes",
+		AiSlopScore:        "aiSlopScore",
+		PreviousReviewID:   "previousReviewId",
+		VersionStats:       "versionStats",
+		PromptRevisionID:   "promptRevisionId",
+		PromptExperimentID: "promptExperimentId",
+		PromptVariant:      "promptVariant",
 
 		Project: "Project",
 		Prompt:  "Prompt",
@@ -160,6 +186,41 @@ var Columns = struct {
 		CreatedAt:    "createdAt",
 		StatusID:     "statusId",
 	},
+	PromptExperiment: struct {
+		ID, ProjectID, Title, VariantPromptID, TrafficPercent, CreatedAt, StatusID string
+
+		Project, VariantPrompt string
+	}{
+		ID:              "promptExperimentId",
+		ProjectID:       "projectId",
+		Title:           "title",
+		VariantPromptID: "variantPromptId",
+		TrafficPercent:  "trafficPercent",
+		CreatedAt:       "createdAt",
+		StatusID:        "statusId",
+
+		Project:       "Project",
+		VariantPrompt: "VariantPrompt",
+	},
+	PromptRevision: struct {
+		ID, PromptID, Common, Architecture, Code, Security, Tests, Operability, UserID, CreatedAt string
+
+		Prompt, User string
+	}{
+		ID:           "promptRevisionId",
+		PromptID:     "promptId",
+		Common:       "common",
+		Architecture: "architecture",
+		Code:         "code",
+		Security:     "security",
+		Tests:        "tests",
+		Operability:  "operability",
+		UserID:       "userId",
+		CreatedAt:    "createdAt",
+
+		Prompt: "Prompt",
+		User:   "User",
+	},
 	SlackChannel: struct {
 		ID, Title, Channel, WebhookURL, StatusID string
 	}{
@@ -169,6 +230,26 @@ var Columns = struct {
 		WebhookURL: "webhookURL",
 		StatusID:   "statusId",
 	},
+	SuppressionRule: struct {
+		ID, ProjectID, PathGlob, IssueType, ReviewType, TitlePattern, MaxSeverity, Reason, Owner, ExpiresAt, CreatedAt, StatusID string
+
+		Project string
+	}{
+		ID:           "suppressionRuleId",
+		ProjectID:    "projectId",
+		PathGlob:     "pathGlob",
+		IssueType:    "issueType",
+		ReviewType:   "reviewType",
+		TitlePattern: "titlePattern",
+		MaxSeverity:  "maxSeverity",
+		Reason:       "reason",
+		Owner:        "owner",
+		ExpiresAt:    "expiresAt",
+		CreatedAt:    "createdAt",
+		StatusID:     "statusId",
+
+		Project: "Project",
+	},
 	TaskTracker: struct {
 		ID, Title, AuthToken, FetchPrompt, CreatedAt, StatusID, URL string
 	}{
@@ -201,9 +282,18 @@ var Tables = struct {
 	Prompt struct {
 		Name, Alias string
 	}
+	PromptExperiment struct {
+		Name, Alias string
+	}
+	PromptRevision struct {
+		Name, Alias string
+	}
 	SlackChannel struct {
 		Name, Alias string
 	}
+	SuppressionRule struct {
+		Name, Alias string
+	}
 	TaskTracker struct {
 		Name, Alias string
 	}
@@ -244,12 +334,30 @@ var Tables = struct {
 		Name:  "prompts",
 		Alias: "t",
 	},
+	PromptExperiment: struct {
+		Name, Alias string
+	}{
+		Name:  "promptExperiments",
+		Alias: "t",
+	},
+	PromptRevision: struct {
+		Name, Alias string
+	}{
+		Name:  "promptRevisions",
+		Alias: "t",
+	},
 	SlackChannel: struct {
 		Name, Alias string
 	}{
 		Name:  "slackChannels",
 		Alias: "t",
 	},
+	SuppressionRule: struct {
+		Name, Alias string
+	}{
+		Name:  "suppressionRules",
+		Alias: "t",
+	},
 	TaskTracker: struct {
 		Name, Alias string
 	}{
@@ -273,24 +381,30 @@ type User struct {
 type Issue struct {
 	tableName struct{} `pg:"issues,alias:t,discard_unknown_columns"`
 
-	ID           int        `pg:"issueId,pk"`
-	ReviewFileID int        `pg:"reviewFileId,use_zero"`
-	IssueType    string     `pg:"issueType,use_zero"`
-	ReviewID     int        `pg:"reviewId,use_zero"`
-	Title        string     `pg:"title,use_zero"`
-	Severity     string     `pg:"severity,use_zero"`
-	Description  string     `pg:"description,use_zero"`
-	Content      string     `pg:"content,use_zero"`
-	File         string     `pg:"file,use_zero"`
-	Lines        string     `pg:"lines,use_zero"`
-	Comment      *string    `pg:"comment"`
-	ProcessedAt  *time.Time `pg:"processedAt"`
-	CreatedAt    time.Time  `pg:"createdAt,use_zero"`
-	UserID       *int       `pg:"userId"`
-	StatusID     int        `pg:"statusId,use_zero"`
-	LocalID      *string    `pg:"localId"`
-	SuggestedFix *string    `pg:"suggestedFix"`
-	ArchivedAt   *time.Time `pg:"archivedAt"`
+	ID                  int        `pg:"issueId,pk"`
+	ReviewFileID        int        `pg:"reviewFileId,use_zero"`
+	IssueType           string     `pg:"issueType,use_zero"`
+	ReviewID            int        `pg:"reviewId,use_zero"`
+	Title               string     `pg:"title,use_zero"`
+	Severity            string     `pg:"severity,use_zero"`
+	Description         string     `pg:"description,use_zero"`
+	Content             string     `pg:"content,use_zero"`
+	File                string     `pg:"file,use_zero"`
+	Lines               string     `pg:"lines,use_zero"`
+	Comment             *string    `pg:"comment"`
+	ProcessedAt         *time.Time `pg:"processedAt"`
+	CreatedAt           time.Time  `pg:"createdAt,use_zero"`
+	UserID              *int       `pg:"userId"`
+	StatusID            int        `pg:"statusId,use_zero"`
+	LocalID             *string    `pg:"localId"`
+	SuggestedFix        *string    `pg:"suggestedFix"`
+	ArchivedAt          *time.Time `pg:"archivedAt"`
+	Fingerprint         *string    `pg:"fingerprint"`
+	PreviousIssueID     *int       `pg:"previousIssueId"`
+	FixedInReviewID     *int       `pg:"fixedInReviewId"`
+	SuppressedByIssueID *int       `pg:"suppressedByIssueId"`
+	SuppressedByRuleID  *int       `pg:"suppressedByRuleId"`
+	VerifierVerdict     *string    `pg:"verifierVerdict"`
 
 	ReviewFile *ReviewFile `pg:"fk:reviewFileId,rel:has-one"`
 	Review     *Review     `pg:"fk:reviewId,rel:has-one"`
@@ -317,23 +431,28 @@ type ReviewFile struct {
 type Review struct {
 	tableName struct{} `pg:"reviews,alias:t,discard_unknown_columns"`
 
-	ID            int             `pg:"reviewId,pk"`
-	ProjectID     int             `pg:"projectId,use_zero"`
-	Title         string          `pg:"title,use_zero"`
-	Description   string          `pg:"description,use_zero"`
-	ExternalID    string          `pg:"externalId,use_zero"`
-	TrafficLight  string          `pg:"trafficLight,use_zero"`
-	CommitHash    string          `pg:"commitHash,use_zero"`
-	SourceBranch  string          `pg:"sourceBranch,use_zero"`
-	TargetBranch  string          `pg:"targetBranch,use_zero"`
-	Author        string          `pg:"author,use_zero"`
-	CreatedAt     time.Time       `pg:"createdAt,use_zero"`
-	DurationMS    int             `pg:"durationMS,use_zero"`
-	ModelInfo     ReviewModelInfo `pg:"modelInfo,use_zero"`
-	StatusID      int             `pg:"statusId,use_zero"`
-	PromptID      int             `pg:"promptId,use_zero"`
-	EffortMinutes *int            `pg:"effortMinutes"`
-	AiSlopScore   *float32        `pg:"aiSlopScore"`
+	ID                 int                 `pg:"reviewId,pk"`
+	ProjectID          int                 `pg:"projectId,use_zero"`
+	Title              string              `pg:"title,use_zero"`
+	Description        string              `pg:"description,use_zero"`
+	ExternalID         string              `pg:"externalId,use_zero"`
+	TrafficLight       string              `pg:"trafficLight,use_zero"`
+	CommitHash         string              `pg:"commitHash,use_zero"`
+	SourceBranch       string              `pg:"sourceBranch,use_zero"`
+	TargetBranch       string              `pg:"targetBranch,use_zero"`
+	Author             string              `pg:"author,use_zero"`
+	CreatedAt          time.Time           `pg:"createdAt,use_zero"`
+	DurationMS         int                 `pg:"durationMS,use_zero"`
+	ModelInfo          ReviewModelInfo     `pg:"modelInfo,use_zero"`
+	StatusID           int                 `pg:"statusId,use_zero"`
+	PromptID           int                 `pg:"promptId,use_zero"`
+	EffortMinutes      *int                `pg:"effortMinutes"`
+	AiSlopScore        *float32            `pg:"aiSlopScore"`
+	PreviousReviewID   *int                `pg:"previousReviewId"`
+	VersionStats       *ReviewVersionStats `pg:"versionStats"`
+	PromptRevisionID   *int                `pg:"promptRevisionId"`
+	PromptExperimentID *int                `pg:"promptExperimentId"`
+	PromptVariant      *string             `pg:"promptVariant"`
 
 	Project *Project `pg:"fk:projectId,rel:has-one"`
 	Prompt  *Prompt  `pg:"fk:promptId,rel:has-one"`
@@ -374,6 +493,39 @@ type Prompt struct {
 	StatusID     int       `pg:"statusId,use_zero"`
 }
 
+type PromptExperiment struct {
+	tableName struct{} `pg:"promptExperiments,alias:t,discard_unknown_columns"`
+
+	ID              int       `pg:"promptExperimentId,pk"`
+	ProjectID       int       `pg:"projectId,use_zero"`
+	Title           string    `pg:"title,use_zero"`
+	VariantPromptID int       `pg:"variantPromptId,use_zero"`
+	TrafficPercent  int       `pg:"trafficPercent,use_zero"`
+	CreatedAt       time.Time `pg:"createdAt,use_zero"`
+	StatusID        int       `pg:"statusId,use_zero"`
+
+	Project       *Project `pg:"fk:projectId,rel:has-one"`
+	VariantPrompt *Prompt  `pg:"fk:variantPromptId,rel:has-one"`
+}
+
+type PromptRevision struct {
+	tableName struct{} `pg:"promptRevisions,alias:t,discard_unknown_columns"`
+
+	ID           int       `pg:"promptRevisionId,pk"`
+	PromptID     int       `pg:"promptId,use_zero"`
+	Common       string    `pg:"common,use_zero"`
+	Architecture string    `pg:"architecture,use_zero"`
+	Code         string    `pg:"code,use_zero"`
+	Security     string    `pg:"security,use_zero"`
+	Tests        string    `pg:"tests,use_zero"`
+	Operability  string    `pg:"operability,use_zero"`
+	UserID       *int      `pg:"userId"`
+	CreatedAt    time.Time `pg:"createdAt,use_zero"`
+
+	Prompt *Prompt `pg:"fk:promptId,rel:has-one"`
+	User   *User   `pg:"fk:userId,rel:has-one"`
+}
+
 type SlackChannel struct {
 	tableName struct{} `pg:"slackChannels,alias:t,discard_unknown_columns"`
 
@@ -384,6 +536,25 @@ type SlackChannel struct {
 	StatusID   int    `pg:"statusId,use_zero"`
 }
 
+type SuppressionRule struct {
+	tableName struct{} `pg:"suppressionRules,alias:t,discard_unknown_columns"`
+
+	ID           int       `pg:"suppressionRuleId,pk"`
+	ProjectID    int       `pg:"projectId,use_zero"`
+	PathGlob     *string   `pg:"pathGlob"`
+	IssueType    *string   `pg:"issueType"`
+	ReviewType   *string   `pg:"reviewType"`
+	TitlePattern *string   `pg:"titlePattern"`
+	MaxSeverity  *string   `pg:"maxSeverity"`
+	Reason       string    `pg:"reason,use_zero"`
+	Owner        string    `pg:"owner,use_zero"`
+	ExpiresAt    time.Time `pg:"expiresAt,use_zero"`
+	CreatedAt    time.Time `pg:"createdAt,use_zero"`
+	StatusID     int       `pg:"statusId,use_zero"`
+
+	Project *Project `pg:"fk:projectId,rel:has-one"`
+}
+
 type TaskTracker struct {
 	tableName struct{} `pg:"taskTrackers,alias:t,discard_unknown_columns"`
 
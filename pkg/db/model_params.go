@@ -7,6 +7,13 @@ type ReviewFileIssueStats struct {
 	Low      int `json:"low"`
 	Total    int `json:"total"`
 }
+
+// ReviewVersionStats — issue lifecycle against the previous version of the same MR.
+type ReviewVersionStats struct {
+	New        int `json:"new"`
+	Persisting int `json:"persisting"`
+	Fixed      int `json:"fixed"`
+}
 type ReviewModelInfo struct {
 	Model        string  `json:"model"`
 	Runner       string  `json:"runner,omitempty"` // "claude" | "opencode" — which CLI produced the result
@@ -37,6 +44,22 @@ type ReviewModelInfo struct {
 
 	// Per-model breakdown (e.g. opus + haiku for compaction).
 	Models map[string]ModelUseStats `json:"models,omitempty"`
+
+	// Post-review verifier pass (reviewctl --verify); its spend is included above.
+	Verifier *VerifierStats `json:"verifier,omitempty"`
+
+	// Per-tool use of the direct runner's agent loop, keyed by tool name.
+	Tools map[string]ToolUseStats `json:"tools,omitempty"`
+}
+
+// VerifierStats — verdict counts of the verifier pass that re-checked each issue.
+type VerifierStats struct {
+	Model      string `json:"model"`
+	Confirmed  int    `json:"confirmed"`
+	Downgraded int    `json:"downgraded"`
+	Rejected   int    `json:"rejected"`
+	Dropped    int    `json:"dropped,omitempty"` // rejected and removed from the review
+	Failed     int    `json:"failed,omitempty"`  // could not be verified, kept as is
 }
 
 // ModelUseStats — per-model tokens and cost within a single run.
@@ -48,7 +71,16 @@ type ModelUseStats struct {
 	CostUsd                  float64 `json:"costUsd"`
 }
 
-// Add accumulates numeric counters and Models map entries from o into m.
+// ToolUseStats — one tool's calls, output and latency within a single run.
+type ToolUseStats struct {
+	Calls      int `json:"calls"`
+	Errors     int `json:"errors,omitempty"`
+	Capped     int `json:"capped,omitempty"` // refused or cut by a per-tool limit
+	Bytes      int `json:"bytes"`            // result bytes returned to the model
+	DurationMs int `json:"durationMs"`
+}
+
+// Add accumulates numeric counters and Models/Tools map entries from o into m.
 // Used by the Step 2 retry path to merge first-pass + retry billable spend
 // into one record so dashboards reflect total cost. Identity-shaped fields
 // (Model, Runner, SessionID, StopReason, TerminalReason, IsError) are left
@@ -66,6 +98,7 @@ func (m *ReviewModelInfo) Add(o ReviewModelInfo) {
 	m.CacheCreate5mInputTokens += o.CacheCreate5mInputTokens
 	m.WebSearchRequests += o.WebSearchRequests
 	m.WebFetchRequests += o.WebFetchRequests
+	m.addTools(o.Tools)
 
 	if len(o.Models) == 0 {
 		return
@@ -83,3 +116,21 @@ func (m *ReviewModelInfo) Add(o ReviewModelInfo) {
 		m.Models[name] = cur
 	}
 }
+
+func (m *ReviewModelInfo) addTools(tools map[string]ToolUseStats) {
+	if len(tools) == 0 {
+		return
+	}
+	if m.Tools == nil {
+		m.Tools = make(map[string]ToolUseStats, len(tools))
+	}
+	for name, s := range tools {
+		cur := m.Tools[name]
+		cur.Calls += s.Calls
+		cur.Errors += s.Errors
+		cur.Capped += s.Capped
+		cur.Bytes += s.Bytes
+		cur.DurationMs += s.DurationMs
+		m.Tools[name] = cur
+	}
+}
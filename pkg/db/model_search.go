@@ -153,6 +153,11 @@ type IssueSearch struct {
 	LocalID              *string
 	SuggestedFix         *string
 	ArchivedAt           *time.Time
+	Fingerprint          *string
+	PreviousIssueID      *int
+	FixedInReviewID      *int
+	SuppressedByIssueID  *int
+	SuppressedByRuleID   *int
 	IDs                  []int
 	IssueTypeILike       *string
 	TitleILike           *string
@@ -226,6 +231,21 @@ func (is *IssueSearch) Apply(query *orm.Query) *orm.Query {
 	if is.ArchivedAt != nil {
 		is.where(query, Tables.Issue.Alias, Columns.Issue.ArchivedAt, is.ArchivedAt)
 	}
+	if is.Fingerprint != nil {
+		is.where(query, Tables.Issue.Alias, Columns.Issue.Fingerprint, is.Fingerprint)
+	}
+	if is.PreviousIssueID != nil {
+		is.where(query, Tables.Issue.Alias, Columns.Issue.PreviousIssueID, is.PreviousIssueID)
+	}
+	if is.FixedInReviewID != nil {
+		is.where(query, Tables.Issue.Alias, Columns.Issue.FixedInReviewID, is.FixedInReviewID)
+	}
+	if is.SuppressedByIssueID != nil {
+		is.where(query, Tables.Issue.Alias, Columns.Issue.SuppressedByIssueID, is.SuppressedByIssueID)
+	}
+	if is.SuppressedByRuleID != nil {
+		is.where(query, Tables.Issue.Alias, Columns.Issue.SuppressedByRuleID, is.SuppressedByRuleID)
+	}
 	if len(is.IDs) > 0 {
 		Filter{Columns.Issue.ID, is.IDs, SearchTypeArray, false}.Apply(query)
 	}
@@ -367,26 +387,30 @@ func (rfs *ReviewFileSearch) Q() applier {
 type ReviewSearch struct {
 	search
 
-	ID            *int
-	ProjectID     *int
-	Title         *string
-	Description   *string
-	ExternalID    *string
-	TrafficLight  *string
-	CommitHash    *string
-	SourceBranch  *string
-	TargetBranch  *string
-	Author        *string
-	CreatedAt     *time.Time
-	DurationMS    *int
-	StatusID      *int
-	PromptID      *int
-	EffortMinutes *int
-	AiSlopScore   *float32
-	IDs           []int
-	IDLt          *int
-	TitleILike    *string
-	AuthorILike   *string
+	ID                 *int
+	ProjectID          *int
+	Title              *string
+	Description        *string
+	ExternalID         *string
+	TrafficLight       *string
+	CommitHash         *string
+	SourceBranch       *string
+	TargetBranch       *string
+	Author             *string
+	CreatedAt          *time.Time
+	DurationMS         *int
+	StatusID           *int
+	PromptID           *int
+	EffortMinutes      *int
+	AiSlopScore        *float32
+	PreviousReviewID   *int
+	PromptRevisionID   *int
+	PromptExperimentID *int
+	PromptVariant      *string
+	IDs                []int
+	IDLt               *int
+	TitleILike         *string
+	AuthorILike        *string
 }
 
 func (rs *ReviewSearch) Apply(query *orm.Query) *orm.Query {
@@ -441,6 +465,18 @@ func (rs *ReviewSearch) Apply(query *orm.Query) *orm.Query {
 	if rs.AiSlopScore != nil {
 		rs.where(query, Tables.Review.Alias, Columns.Review.AiSlopScore, rs.AiSlopScore)
 	}
+	if rs.PreviousReviewID != nil {
+		rs.where(query, Tables.Review.Alias, Columns.Review.PreviousReviewID, rs.PreviousReviewID)
+	}
+	if rs.PromptRevisionID != nil {
+		rs.where(query, Tables.Review.Alias, Columns.Review.PromptRevisionID, rs.PromptRevisionID)
+	}
+	if rs.PromptExperimentID != nil {
+		rs.where(query, Tables.Review.Alias, Columns.Review.PromptExperimentID, rs.PromptExperimentID)
+	}
+	if rs.PromptVariant != nil {
+		rs.where(query, Tables.Review.Alias, Columns.Review.PromptVariant, rs.PromptVariant)
+	}
 	if len(rs.IDs) > 0 {
 		Filter{Columns.Review.ID, rs.IDs, SearchTypeArray, false}.Apply(query)
 	}
@@ -652,6 +688,138 @@ func (ps *PromptSearch) Q() applier {
 	}
 }
 
+type PromptExperimentSearch struct {
+	search
+
+	ID              *int
+	ProjectID       *int
+	Title           *string
+	VariantPromptID *int
+	TrafficPercent  *int
+	CreatedAt       *time.Time
+	StatusID        *int
+	IDs             []int
+	NotID           *int
+	TitleILike      *string
+}
+
+func (pes *PromptExperimentSearch) Apply(query *orm.Query) *orm.Query {
+	if pes == nil {
+		return query
+	}
+	if pes.ID != nil {
+		pes.where(query, Tables.PromptExperiment.Alias, Columns.PromptExperiment.ID, pes.ID)
+	}
+	if pes.ProjectID != nil {
+		pes.where(query, Tables.PromptExperiment.Alias, Columns.PromptExperiment.ProjectID, pes.ProjectID)
+	}
+	if pes.Title != nil {
+		pes.where(query, Tables.PromptExperiment.Alias, Columns.PromptExperiment.Title, pes.Title)
+	}
+	if pes.VariantPromptID != nil {
+		pes.where(query, Tables.PromptExperiment.Alias, Columns.PromptExperiment.VariantPromptID, pes.VariantPromptID)
+	}
+	if pes.TrafficPercent != nil {
+		pes.where(query, Tables.PromptExperiment.Alias, Columns.PromptExperiment.TrafficPercent, pes.TrafficPercent)
+	}
+	if pes.CreatedAt != nil {
+		pes.where(query, Tables.PromptExperiment.Alias, Columns.PromptExperiment.CreatedAt, pes.CreatedAt)
+	}
+	if pes.StatusID != nil {
+		pes.where(query, Tables.PromptExperiment.Alias, Columns.PromptExperiment.StatusID, pes.StatusID)
+	}
+	if len(pes.IDs) > 0 {
+		Filter{Columns.PromptExperiment.ID, pes.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if pes.NotID != nil {
+		Filter{Columns.PromptExperiment.ID, *pes.NotID, SearchTypeEquals, true}.Apply(query)
+	}
+	if pes.TitleILike != nil {
+		Filter{Columns.PromptExperiment.Title, *pes.TitleILike, SearchTypeILike, false}.Apply(query)
+	}
+
+	pes.apply(query)
+
+	return query
+}
+
+func (pes *PromptExperimentSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if pes == nil {
+			return query, nil
+		}
+		return pes.Apply(query), nil
+	}
+}
+
+type PromptRevisionSearch struct {
+	search
+
+	ID           *int
+	PromptID     *int
+	Common       *string
+	Architecture *string
+	Code         *string
+	Security     *string
+	Tests        *string
+	Operability  *string
+	UserID       *int
+	CreatedAt    *time.Time
+	IDs          []int
+}
+
+func (prs *PromptRevisionSearch) Apply(query *orm.Query) *orm.Query {
+	if prs == nil {
+		return query
+	}
+	if prs.ID != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.ID, prs.ID)
+	}
+	if prs.PromptID != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.PromptID, prs.PromptID)
+	}
+	if prs.Common != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.Common, prs.Common)
+	}
+	if prs.Architecture != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.Architecture, prs.Architecture)
+	}
+	if prs.Code != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.Code, prs.Code)
+	}
+	if prs.Security != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.Security, prs.Security)
+	}
+	if prs.Tests != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.Tests, prs.Tests)
+	}
+	if prs.Operability != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.Operability, prs.Operability)
+	}
+	if prs.UserID != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.UserID, prs.UserID)
+	}
+	if prs.CreatedAt != nil {
+		prs.where(query, Tables.PromptRevision.Alias, Columns.PromptRevision.CreatedAt, prs.CreatedAt)
+	}
+	if len(prs.IDs) > 0 {
+		Filter{Columns.PromptRevision.ID, prs.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	prs.apply(query)
+
+	return query
+}
+
+func (prs *PromptRevisionSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if prs == nil {
+			return query, nil
+		}
+		return prs.Apply(query), nil
+	}
+}
+
 type SlackChannelSearch struct {
 	search
 
@@ -712,6 +880,102 @@ func (scs *SlackChannelSearch) Q() applier {
 	}
 }
 
+type SuppressionRuleSearch struct {
+	search
+
+	ID                *int
+	ProjectID         *int
+	PathGlob          *string
+	IssueType         *string
+	ReviewType        *string
+	TitlePattern      *string
+	MaxSeverity       *string
+	Reason            *string
+	Owner             *string
+	ExpiresAt         *time.Time
+	CreatedAt         *time.Time
+	StatusID          *int
+	IDs               []int
+	PathGlobILike     *string
+	TitlePatternILike *string
+	ReasonILike       *string
+	OwnerILike        *string
+	ExpiresAtLt       *time.Time
+}
+
+func (srs *SuppressionRuleSearch) Apply(query *orm.Query) *orm.Query {
+	if srs == nil {
+		return query
+	}
+	if srs.ID != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.ID, srs.ID)
+	}
+	if srs.ProjectID != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.ProjectID, srs.ProjectID)
+	}
+	if srs.PathGlob != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.PathGlob, srs.PathGlob)
+	}
+	if srs.IssueType != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.IssueType, srs.IssueType)
+	}
+	if srs.ReviewType != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.ReviewType, srs.ReviewType)
+	}
+	if srs.TitlePattern != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.TitlePattern, srs.TitlePattern)
+	}
+	if srs.MaxSeverity != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.MaxSeverity, srs.MaxSeverity)
+	}
+	if srs.Reason != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.Reason, srs.Reason)
+	}
+	if srs.Owner != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.Owner, srs.Owner)
+	}
+	if srs.ExpiresAt != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.ExpiresAt, srs.ExpiresAt)
+	}
+	if srs.CreatedAt != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.CreatedAt, srs.CreatedAt)
+	}
+	if srs.StatusID != nil {
+		srs.where(query, Tables.SuppressionRule.Alias, Columns.SuppressionRule.StatusID, srs.StatusID)
+	}
+	if len(srs.IDs) > 0 {
+		Filter{Columns.SuppressionRule.ID, srs.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if srs.PathGlobILike != nil {
+		Filter{Columns.SuppressionRule.PathGlob, *srs.PathGlobILike, SearchTypeILike, false}.Apply(query)
+	}
+	if srs.TitlePatternILike != nil {
+		Filter{Columns.SuppressionRule.TitlePattern, *srs.TitlePatternILike, SearchTypeILike, false}.Apply(query)
+	}
+	if srs.ReasonILike != nil {
+		Filter{Columns.SuppressionRule.Reason, *srs.ReasonILike, SearchTypeILike, false}.Apply(query)
+	}
+	if srs.OwnerILike != nil {
+		Filter{Columns.SuppressionRule.Owner, *srs.OwnerILike, SearchTypeILike, false}.Apply(query)
+	}
+	if srs.ExpiresAtLt != nil {
+		Filter{Columns.SuppressionRule.ExpiresAt, *srs.ExpiresAtLt, SearchTypeLess, false}.Apply(query)
+	}
+
+	srs.apply(query)
+
+	return query
+}
+
+func (srs *SuppressionRuleSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if srs == nil {
+			return query, nil
+		}
+		return srs.Apply(query), nil
+	}
+}
+
 type TaskTrackerSearch struct {
 	search
 
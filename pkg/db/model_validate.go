@@ -119,6 +119,10 @@ func (r Review) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.Review.Author] = ErrMaxLength
 	}
 
+	if r.PromptVariant != nil && utf8.RuneCountInString(*r.PromptVariant) > 8 {
+		errors[Columns.Review.PromptVariant] = ErrMaxLength
+	}
+
 	return errors, len(errors) == 0
 }
 
@@ -150,6 +154,16 @@ func (p Prompt) Validate() (errors map[string]string, valid bool) {
 	return errors, len(errors) == 0
 }
 
+func (pe PromptExperiment) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(pe.Title) > 255 {
+		errors[Columns.PromptExperiment.Title] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
 func (sc SlackChannel) Validate() (errors map[string]string, valid bool) {
 	errors = map[string]string{}
 
@@ -168,6 +182,40 @@ func (sc SlackChannel) Validate() (errors map[string]string, valid bool) {
 	return errors, len(errors) == 0
 }
 
+func (sr SuppressionRule) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if sr.PathGlob != nil && utf8.RuneCountInString(*sr.PathGlob) > 255 {
+		errors[Columns.SuppressionRule.PathGlob] = ErrMaxLength
+	}
+
+	if sr.IssueType != nil && utf8.RuneCountInString(*sr.IssueType) > 32 {
+		errors[Columns.SuppressionRule.IssueType] = ErrMaxLength
+	}
+
+	if sr.ReviewType != nil && utf8.RuneCountInString(*sr.ReviewType) > 32 {
+		errors[Columns.SuppressionRule.ReviewType] = ErrMaxLength
+	}
+
+	if sr.TitlePattern != nil && utf8.RuneCountInString(*sr.TitlePattern) > 255 {
+		errors[Columns.SuppressionRule.TitlePattern] = ErrMaxLength
+	}
+
+	if sr.MaxSeverity != nil && utf8.RuneCountInString(*sr.MaxSeverity) > 16 {
+		errors[Columns.SuppressionRule.MaxSeverity] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(sr.Reason) > 1024 {
+		errors[Columns.SuppressionRule.Reason] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(sr.Owner) > 255 {
+		errors[Columns.SuppressionRule.Owner] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
 func (tt TaskTracker) Validate() (errors map[string]string, valid bool) {
 	errors = map[string]string{}
 
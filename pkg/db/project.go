@@ -20,22 +20,30 @@ func NewProjectRepo(db orm.DB) ProjectRepo {
 	return ProjectRepo{
 		db: db,
 		filters: map[string][]Filter{
-			Tables.Project.Name:      {StatusFilter},
-			Tables.Prompt.Name:       {StatusFilter},
-			Tables.SlackChannel.Name: {StatusFilter},
-			Tables.TaskTracker.Name:  {StatusFilter},
+			Tables.Project.Name:          {StatusFilter},
+			Tables.Prompt.Name:           {StatusFilter},
+			Tables.PromptExperiment.Name: {StatusFilter},
+			Tables.SlackChannel.Name:     {StatusFilter},
+			Tables.SuppressionRule.Name:  {StatusFilter},
+			Tables.TaskTracker.Name:      {StatusFilter},
 		},
 		sort: map[string][]SortField{
-			Tables.Project.Name:      {{Column: Columns.Project.Title, Direction: SortAsc}},
-			Tables.Prompt.Name:       {{Column: Columns.Prompt.CreatedAt, Direction: SortDesc}},
-			Tables.SlackChannel.Name: {{Column: Columns.SlackChannel.Title, Direction: SortAsc}},
-			Tables.TaskTracker.Name:  {{Column: Columns.TaskTracker.CreatedAt, Direction: SortDesc}},
+			Tables.Project.Name:          {{Column: Columns.Project.Title, Direction: SortAsc}},
+			Tables.Prompt.Name:           {{Column: Columns.Prompt.CreatedAt, Direction: SortDesc}},
+			Tables.PromptExperiment.Name: {{Column: Columns.PromptExperiment.CreatedAt, Direction: SortDesc}},
+			Tables.PromptRevision.Name:   {{Column: Columns.PromptRevision.ID, Direction: SortDesc}},
+			Tables.SlackChannel.Name:     {{Column: Columns.SlackChannel.Title, Direction: SortAsc}},
+			Tables.SuppressionRule.Name:  {{Column: Columns.SuppressionRule.CreatedAt, Direction: SortDesc}},
+			Tables.TaskTracker.Name:      {{Column: Columns.TaskTracker.CreatedAt, Direction: SortDesc}},
 		},
 		join: map[string][]string{
-			Tables.Project.Name:      {TableColumns, Columns.Project.Prompt, Columns.Project.TaskTracker, Columns.Project.SlackChannel},
-			Tables.Prompt.Name:       {TableColumns},
-			Tables.SlackChannel.Name: {TableColumns},
-			Tables.TaskTracker.Name:  {TableColumns},
+			Tables.Project.Name:          {TableColumns, Columns.Project.Prompt, Columns.Project.TaskTracker, Columns.Project.SlackChannel},
+			Tables.Prompt.Name:           {TableColumns},
+			Tables.PromptExperiment.Name: {TableColumns, Columns.PromptExperiment.Project, Columns.PromptExperiment.VariantPrompt},
+			Tables.PromptRevision.Name:   {TableColumns, Columns.PromptRevision.User},
+			Tables.SlackChannel.Name:     {TableColumns},
+			Tables.SuppressionRule.Name:  {TableColumns, Columns.SuppressionRule.Project},
+			Tables.TaskTracker.Name:      {TableColumns},
 		},
 	}
 }
@@ -211,6 +219,136 @@ func (pr ProjectRepo) DeletePrompt(ctx context.Context, id int) (deleted bool, e
 	return pr.UpdatePrompt(ctx, prompt, WithColumns(Columns.Prompt.StatusID))
 }
 
+/*** PromptExperiment ***/
+
+// FullPromptExperiment returns full joins with all columns
+func (pr ProjectRepo) FullPromptExperiment() OpFunc {
+	return WithColumns(pr.join[Tables.PromptExperiment.Name]...)
+}
+
+// DefaultPromptExperimentSort returns default sort.
+func (pr ProjectRepo) DefaultPromptExperimentSort() OpFunc {
+	return WithSort(pr.sort[Tables.PromptExperiment.Name]...)
+}
+
+// PromptExperimentByID is a function that returns PromptExperiment by ID(s) or nil.
+func (pr ProjectRepo) PromptExperimentByID(ctx context.Context, id int, ops ...OpFunc) (*PromptExperiment, error) {
+	return pr.OnePromptExperiment(ctx, &PromptExperimentSearch{ID: &id}, ops...)
+}
+
+// OnePromptExperiment is a function that returns one PromptExperiment by filters. It could return pg.ErrMultiRows.
+func (pr ProjectRepo) OnePromptExperiment(ctx context.Context, search *PromptExperimentSearch, ops ...OpFunc) (*PromptExperiment, error) {
+	obj := &PromptExperiment{}
+	err := buildQuery(ctx, pr.db, obj, search, pr.filters[Tables.PromptExperiment.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// PromptExperimentsByFilters returns PromptExperiment list.
+func (pr ProjectRepo) PromptExperimentsByFilters(ctx context.Context, search *PromptExperimentSearch, pager Pager, ops ...OpFunc) (promptExperiments []PromptExperiment, err error) {
+	err = buildQuery(ctx, pr.db, &promptExperiments, search, pr.filters[Tables.PromptExperiment.Name], pager, ops...).Select()
+	return
+}
+
+// CountPromptExperiments returns count
+func (pr ProjectRepo) CountPromptExperiments(ctx context.Context, search *PromptExperimentSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, pr.db, &PromptExperiment{}, search, pr.filters[Tables.PromptExperiment.Name], PagerOne, ops...).Count()
+}
+
+// AddPromptExperiment adds PromptExperiment to DB.
+func (pr ProjectRepo) AddPromptExperiment(ctx context.Context, promptExperiment *PromptExperiment, ops ...OpFunc) (*PromptExperiment, error) {
+	q := pr.db.ModelContext(ctx, promptExperiment)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.PromptExperiment.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return promptExperiment, err
+}
+
+// UpdatePromptExperiment updates PromptExperiment in DB.
+func (pr ProjectRepo) UpdatePromptExperiment(ctx context.Context, promptExperiment *PromptExperiment, ops ...OpFunc) (bool, error) {
+	q := pr.db.ModelContext(ctx, promptExperiment).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.PromptExperiment.ID, Columns.PromptExperiment.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeletePromptExperiment set statusId to deleted in DB.
+func (pr ProjectRepo) DeletePromptExperiment(ctx context.Context, id int) (deleted bool, err error) {
+	promptExperiment := &PromptExperiment{ID: id, StatusID: StatusDeleted}
+
+	return pr.UpdatePromptExperiment(ctx, promptExperiment, WithColumns(Columns.PromptExperiment.StatusID))
+}
+
+/*** PromptRevision ***/
+
+// FullPromptRevision returns full joins with all columns
+func (pr ProjectRepo) FullPromptRevision() OpFunc {
+	return WithColumns(pr.join[Tables.PromptRevision.Name]...)
+}
+
+// DefaultPromptRevisionSort returns default sort.
+func (pr ProjectRepo) DefaultPromptRevisionSort() OpFunc {
+	return WithSort(pr.sort[Tables.PromptRevision.Name]...)
+}
+
+// PromptRevisionByID is a function that returns PromptRevision by ID(s) or nil.
+func (pr ProjectRepo) PromptRevisionByID(ctx context.Context, id int, ops ...OpFunc) (*PromptRevision, error) {
+	return pr.OnePromptRevision(ctx, &PromptRevisionSearch{ID: &id}, ops...)
+}
+
+// OnePromptRevision is a function that returns one PromptRevision by filters. It could return pg.ErrMultiRows.
+func (pr ProjectRepo) OnePromptRevision(ctx context.Context, search *PromptRevisionSearch, ops ...OpFunc) (*PromptRevision, error) {
+	obj := &PromptRevision{}
+	err := buildQuery(ctx, pr.db, obj, search, pr.filters[Tables.PromptRevision.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// PromptRevisionsByFilters returns PromptRevision list.
+func (pr ProjectRepo) PromptRevisionsByFilters(ctx context.Context, search *PromptRevisionSearch, pager Pager, ops ...OpFunc) (promptRevisions []PromptRevision, err error) {
+	err = buildQuery(ctx, pr.db, &promptRevisions, search, pr.filters[Tables.PromptRevision.Name], pager, ops...).Select()
+	return
+}
+
+// CountPromptRevisions returns count
+func (pr ProjectRepo) CountPromptRevisions(ctx context.Context, search *PromptRevisionSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, pr.db, &PromptRevision{}, search, pr.filters[Tables.PromptRevision.Name], PagerOne, ops...).Count()
+}
+
+// AddPromptRevision adds PromptRevision to DB. Revisions are immutable, so there is no update or delete.
+func (pr ProjectRepo) AddPromptRevision(ctx context.Context, promptRevision *PromptRevision, ops ...OpFunc) (*PromptRevision, error) {
+	q := pr.db.ModelContext(ctx, promptRevision)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.PromptRevision.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return promptRevision, err
+}
+
 /*** SlackChannel ***/
 
 // FullSlackChannel returns full joins with all columns
@@ -284,6 +422,82 @@ func (pr ProjectRepo) DeleteSlackChannel(ctx context.Context, id int) (deleted b
 	return pr.UpdateSlackChannel(ctx, slackChannel, WithColumns(Columns.SlackChannel.StatusID))
 }
 
+/*** SuppressionRule ***/
+
+// FullSuppressionRule returns full joins with all columns
+func (pr ProjectRepo) FullSuppressionRule() OpFunc {
+	return WithColumns(pr.join[Tables.SuppressionRule.Name]...)
+}
+
+// DefaultSuppressionRuleSort returns default sort.
+func (pr ProjectRepo) DefaultSuppressionRuleSort() OpFunc {
+	return WithSort(pr.sort[Tables.SuppressionRule.Name]...)
+}
+
+// SuppressionRuleByID is a function that returns SuppressionRule by ID(s) or nil.
+func (pr ProjectRepo) SuppressionRuleByID(ctx context.Context, id int, ops ...OpFunc) (*SuppressionRule, error) {
+	return pr.OneSuppressionRule(ctx, &SuppressionRuleSearch{ID: &id}, ops...)
+}
+
+// OneSuppressionRule is a function that returns one SuppressionRule by filters. It could return pg.ErrMultiRows.
+func (pr ProjectRepo) OneSuppressionRule(ctx context.Context, search *SuppressionRuleSearch, ops ...OpFunc) (*SuppressionRule, error) {
+	obj := &SuppressionRule{}
+	err := buildQuery(ctx, pr.db, obj, search, pr.filters[Tables.SuppressionRule.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// SuppressionRulesByFilters returns SuppressionRule list.
+func (pr ProjectRepo) SuppressionRulesByFilters(ctx context.Context, search *SuppressionRuleSearch, pager Pager, ops ...OpFunc) (suppressionRules []SuppressionRule, err error) {
+	err = buildQuery(ctx, pr.db, &suppressionRules, search, pr.filters[Tables.SuppressionRule.Name], pager, ops...).Select()
+	return
+}
+
+// CountSuppressionRules returns count
+func (pr ProjectRepo) CountSuppressionRules(ctx context.Context, search *SuppressionRuleSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, pr.db, &SuppressionRule{}, search, pr.filters[Tables.SuppressionRule.Name], PagerOne, ops...).Count()
+}
+
+// AddSuppressionRule adds SuppressionRule to DB.
+func (pr ProjectRepo) AddSuppressionRule(ctx context.Context, suppressionRule *SuppressionRule, ops ...OpFunc) (*SuppressionRule, error) {
+	q := pr.db.ModelContext(ctx, suppressionRule)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.SuppressionRule.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return suppressionRule, err
+}
+
+// UpdateSuppressionRule updates SuppressionRule in DB.
+func (pr ProjectRepo) UpdateSuppressionRule(ctx context.Context, suppressionRule *SuppressionRule, ops ...OpFunc) (bool, error) {
+	q := pr.db.ModelContext(ctx, suppressionRule).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.SuppressionRule.ID, Columns.SuppressionRule.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteSuppressionRule set statusId to deleted in DB.
+func (pr ProjectRepo) DeleteSuppressionRule(ctx context.Context, id int) (deleted bool, err error) {
+	suppressionRule := &SuppressionRule{ID: id, StatusID: StatusDeleted}
+
+	return pr.UpdateSuppressionRule(ctx, suppressionRule, WithColumns(Columns.SuppressionRule.StatusID))
+}
+
 /*** TaskTracker ***/
 
 // FullTaskTracker returns full joins with all columns
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// DisableExpiredSuppressionRules disables enabled suppression rules that expired
+// before now and returns them with their projects and Slack channels.
+// The update is atomic, so concurrent callers never get the same rule twice.
+func (pr ProjectRepo) DisableExpiredSuppressionRules(ctx context.Context, now time.Time) ([]SuppressionRule, error) {
+	var ids pg.Ints
+	_, err := pr.db.QueryContext(ctx, &ids,
+		`UPDATE ? SET ? = ? WHERE ? = ? AND ? < ? RETURNING ?`,
+		pg.Ident(Tables.SuppressionRule.Name),
+		pg.Ident(Columns.SuppressionRule.StatusID), StatusDisabled,
+		pg.Ident(Columns.SuppressionRule.StatusID), StatusEnabled,
+		pg.Ident(Columns.SuppressionRule.ExpiresAt), now,
+		pg.Ident(Columns.SuppressionRule.ID),
+	)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	search := &SuppressionRuleSearch{IDs: make([]int, len(ids))}
+	for i, id := range ids {
+		search.IDs[i] = int(id)
+	}
+
+	var rules []SuppressionRule
+	err = buildQuery(ctx, pr.db, &rules, search, nil, PagerNoLimit,
+		WithColumns(TableColumns, Columns.SuppressionRule.Project, Columns.SuppressionRule.Project+"."+Columns.Project.SlackChannel),
+		WithSort(SortField{Column: Columns.SuppressionRule.ID, Direction: SortAsc}),
+	).Select()
+
+	return rules, err
+}
@@ -209,6 +209,234 @@ func WithFakePrompt(t *testing.T, dbo orm.DB, in *db.Prompt) Cleaner {
 	return emptyClean
 }
 
+type PromptExperimentOpFunc func(t *testing.T, dbo orm.DB, in *db.PromptExperiment) Cleaner
+
+func PromptExperiment(t *testing.T, dbo orm.DB, in *db.PromptExperiment, ops ...PromptExperimentOpFunc) (*db.PromptExperiment, Cleaner) {
+	repo := db.NewProjectRepo(dbo)
+	var cleaners []Cleaner
+
+	// Fill the incoming entity
+	if in == nil {
+		in = &db.PromptExperiment{}
+	}
+
+	// Check if PKs are provided
+	if in.ID != 0 {
+		// Fetch the entity by PK
+		promptExperiment, err := repo.PromptExperimentByID(t.Context(), in.ID, repo.FullPromptExperiment())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// We must find the entity by PK
+		if promptExperiment == nil {
+			t.Fatalf("the entity PromptExperiment is not found by provided PKs ID=%v", in.ID)
+		}
+
+		// Return if found without real cleanup
+		return promptExperiment, emptyClean
+	}
+
+	for _, op := range ops {
+		if cl := op(t, dbo, in); cl != nil {
+			cleaners = append(cleaners, cl)
+		}
+	}
+
+	// Create the main entity
+	promptExperiment, err := repo.AddPromptExperiment(t.Context(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return promptExperiment, func() {
+		if _, err := dbo.ModelContext(context.Background(), &db.PromptExperiment{ID: promptExperiment.ID}).WherePK().Delete(); err != nil {
+			t.Fatal(err)
+		}
+		// Clean up related entities from the last to the first
+		for i := len(cleaners) - 1; i >= 0; i-- {
+			cleaners[i]()
+		}
+	}
+}
+
+func WithPromptExperimentRelations(t *testing.T, dbo orm.DB, in *db.PromptExperiment) Cleaner {
+	var cleaners []Cleaner
+
+	// Prepare main relations
+	if in.Project == nil {
+		in.Project = &db.Project{}
+	}
+
+	if in.VariantPrompt == nil {
+		in.VariantPrompt = &db.Prompt{}
+	}
+
+	// Check if all FKs are provided. Fill them into the main struct rels
+
+	if in.ProjectID != 0 {
+		in.Project.ID = in.ProjectID
+	}
+
+	if in.VariantPromptID != 0 {
+		in.VariantPrompt.ID = in.VariantPromptID
+	}
+
+	// Fetch the relation. It creates if the FKs are provided it fetch from DB by PKs. Else it creates new one.
+	{
+		rel, relatedCleaner := Project(t, dbo, in.Project, WithProjectRelations, WithFakeProject)
+		in.Project = rel
+		in.ProjectID = rel.ID
+
+		cleaners = append(cleaners, relatedCleaner)
+	}
+
+	{
+		rel, relatedCleaner := Prompt(t, dbo, in.VariantPrompt, WithFakePrompt)
+		in.VariantPrompt = rel
+		in.VariantPromptID = rel.ID
+
+		cleaners = append(cleaners, relatedCleaner)
+	}
+
+	return func() {
+		// Clean up related entities from the last to the first
+		for i := len(cleaners) - 1; i >= 0; i-- {
+			cleaners[i]()
+		}
+	}
+}
+
+func WithFakePromptExperiment(t *testing.T, dbo orm.DB, in *db.PromptExperiment) Cleaner {
+	if in.Title == "" {
+		in.Title = cutS(gofakeit.Sentence(3), 255)
+	}
+
+	if in.TrafficPercent == 0 {
+		in.TrafficPercent = 50
+	}
+
+	if in.CreatedAt.IsZero() {
+		in.CreatedAt = time.Now()
+	}
+
+	if in.StatusID == 0 {
+		in.StatusID = 1
+	}
+
+	return emptyClean
+}
+
+type PromptRevisionOpFunc func(t *testing.T, dbo orm.DB, in *db.PromptRevision) Cleaner
+
+func PromptRevision(t *testing.T, dbo orm.DB, in *db.PromptRevision, ops ...PromptRevisionOpFunc) (*db.PromptRevision, Cleaner) {
+	repo := db.NewProjectRepo(dbo)
+	var cleaners []Cleaner
+
+	// Fill the incoming entity
+	if in == nil {
+		in = &db.PromptRevision{}
+	}
+
+	// Check if PKs are provided
+	if in.ID != 0 {
+		// Fetch the entity by PK
+		promptRevision, err := repo.PromptRevisionByID(t.Context(), in.ID, repo.FullPromptRevision())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// We must find the entity by PK
+		if promptRevision == nil {
+			t.Fatalf("the entity PromptRevision is not found by provided PKs ID=%v", in.ID)
+		}
+
+		// Return if found without real cleanup
+		return promptRevision, emptyClean
+	}
+
+	for _, op := range ops {
+		if cl := op(t, dbo, in); cl != nil {
+			cleaners = append(cleaners, cl)
+		}
+	}
+
+	// Create the main entity
+	promptRevision, err := repo.AddPromptRevision(t.Context(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return promptRevision, func() {
+		if _, err := dbo.ModelContext(context.Background(), &db.PromptRevision{ID: promptRevision.ID}).WherePK().Delete(); err != nil {
+			t.Fatal(err)
+		}
+		// Clean up related entities from the last to the first
+		for i := len(cleaners) - 1; i >= 0; i-- {
+			cleaners[i]()
+		}
+	}
+}
+
+func WithPromptRevisionRelations(t *testing.T, dbo orm.DB, in *db.PromptRevision) Cleaner {
+	var cleaners []Cleaner
+
+	// Prepare main relations
+	if in.Prompt == nil {
+		in.Prompt = &db.Prompt{}
+	}
+
+	// Check if all FKs are provided. Fill them into the main struct rels
+
+	if in.PromptID != 0 {
+		in.Prompt.ID = in.PromptID
+	}
+
+	// Fetch the relation. It creates if the FKs are provided it fetch from DB by PKs. Else it creates new one.
+	{
+		rel, relatedCleaner := Prompt(t, dbo, in.Prompt, WithFakePrompt)
+		in.Prompt = rel
+		in.PromptID = rel.ID
+
+		cleaners = append(cleaners, relatedCleaner)
+	}
+
+	return func() {
+		// Clean up related entities from the last to the first
+		for i := len(cleaners) - 1; i >= 0; i-- {
+			cleaners[i]()
+		}
+	}
+}
+
+func WithFakePromptRevision(t *testing.T, dbo orm.DB, in *db.PromptRevision) Cleaner {
+	if in.Common == "" {
+		in.Common = cutS(gofakeit.Sentence(10), 0)
+	}
+
+	if in.Architecture == "" {
+		in.Architecture = cutS(gofakeit.Sentence(10), 0)
+	}
+
+	if in.Code == "" {
+		in.Code = cutS(gofakeit.Sentence(10), 0)
+	}
+
+	if in.Security == "" {
+		in.Security = cutS(gofakeit.Sentence(10), 0)
+	}
+
+	if in.Tests == "" {
+		in.Tests = cutS(gofakeit.Sentence(10), 0)
+	}
+
+	if in.CreatedAt.IsZero() {
+		in.CreatedAt = time.Now()
+	}
+
+	return emptyClean
+}
+
 type SlackChannelOpFunc func(t *testing.T, dbo orm.DB, in *db.SlackChannel) Cleaner
 
 func SlackChannel(t *testing.T, dbo orm.DB, in *db.SlackChannel, ops ...SlackChannelOpFunc) (*db.SlackChannel, Cleaner) {
@@ -280,6 +508,117 @@ func WithFakeSlackChannel(t *testing.T, dbo orm.DB, in *db.SlackChannel) Cleaner
 	return emptyClean
 }
 
+type SuppressionRuleOpFunc func(t *testing.T, dbo orm.DB, in *db.SuppressionRule) Cleaner
+
+func SuppressionRule(t *testing.T, dbo orm.DB, in *db.SuppressionRule, ops ...SuppressionRuleOpFunc) (*db.SuppressionRule, Cleaner) {
+	repo := db.NewProjectRepo(dbo)
+	var cleaners []Cleaner
+
+	// Fill the incoming entity
+	if in == nil {
+		in = &db.SuppressionRule{}
+	}
+
+	// Check if PKs are provided
+	if in.ID != 0 {
+		// Fetch the entity by PK
+		suppressionRule, err := repo.SuppressionRuleByID(t.Context(), in.ID, repo.FullSuppressionRule())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// We must find the entity by PK
+		if suppressionRule == nil {
+			t.Fatalf("the entity SuppressionRule is not found by provided PKs ID=%v", in.ID)
+		}
+
+		// Return if found without real cleanup
+		return suppressionRule, emptyClean
+	}
+
+	for _, op := range ops {
+		if cl := op(t, dbo, in); cl != nil {
+			cleaners = append(cleaners, cl)
+		}
+	}
+
+	// Create the main entity
+	suppressionRule, err := repo.AddSuppressionRule(t.Context(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return suppressionRule, func() {
+		if _, err := dbo.ModelContext(context.Background(), &db.SuppressionRule{ID: suppressionRule.ID}).WherePK().Delete(); err != nil {
+			t.Fatal(err)
+		}
+		// Clean up related entities from the last to the first
+		for i := len(cleaners) - 1; i >= 0; i-- {
+			cleaners[i]()
+		}
+	}
+}
+
+func WithSuppressionRuleRelations(t *testing.T, dbo orm.DB, in *db.SuppressionRule) Cleaner {
+	var cleaners []Cleaner
+
+	// Prepare main relations
+	if in.Project == nil {
+		in.Project = &db.Project{}
+	}
+
+	// Check if all FKs are provided. Fill them into the main struct rels
+
+	if in.ProjectID != 0 {
+		in.Project.ID = in.ProjectID
+	}
+
+	// Fetch the relation. It creates if the FKs are provided it fetch from DB by PKs. Else it creates new one.
+	{
+		rel, relatedCleaner := Project(t, dbo, in.Project, WithProjectRelations, WithFakeProject)
+		in.Project = rel
+		in.ProjectID = rel.ID
+
+		cleaners = append(cleaners, relatedCleaner)
+	}
+
+	return func() {
+		// Clean up related entities from the last to the first
+		for i := len(cleaners) - 1; i >= 0; i-- {
+			cleaners[i]()
+		}
+	}
+}
+
+func WithFakeSuppressionRule(t *testing.T, dbo orm.DB, in *db.SuppressionRule) Cleaner {
+	if in.PathGlob == nil {
+		v := cutS(gofakeit.Word(), 255) + "/**"
+		in.PathGlob = &v
+	}
+
+	if in.Reason == "" {
+		in.Reason = cutS(gofakeit.Sentence(10), 1024)
+	}
+
+	if in.Owner == "" {
+		in.Owner = cutS(gofakeit.Username(), 255)
+	}
+
+	if in.ExpiresAt.IsZero() {
+		in.ExpiresAt = time.Now().Add(30 * 24 * time.Hour)
+	}
+
+	if in.CreatedAt.IsZero() {
+		in.CreatedAt = time.Now()
+	}
+
+	if in.StatusID == 0 {
+		in.StatusID = 1
+	}
+
+	return emptyClean
+}
+
 type TaskTrackerOpFunc func(t *testing.T, dbo orm.DB, in *db.TaskTracker) Cleaner
 
 func TaskTracker(t *testing.T, dbo orm.DB, in *db.TaskTracker, ops ...TaskTrackerOpFunc) (*db.TaskTracker, Cleaner) {
@@ -16,18 +16,21 @@ type ReviewDraft struct {
 
 // ReviewDraftMeta is the per-review metadata block.
 type ReviewDraftMeta struct {
-	ExternalID    string             `json:"externalId"`
-	Title         string             `json:"title"`
-	Description   string             `json:"description"`
-	CommitHash    string             `json:"commitHash"`
-	SourceBranch  string             `json:"sourceBranch"`
-	TargetBranch  string             `json:"targetBranch"`
-	Author        string             `json:"author"`
-	CreatedAt     time.Time          `json:"createdAt"`
-	DurationMs    int                `json:"durationMs"`
-	EffortMinutes int                `json:"effortMinutes"`
-	AiSlopScore   float32            `json:"aiSlopScore"`
-	ModelInfo     db.ReviewModelInfo `json:"modelInfo"`
+	ExternalID         string             `json:"externalId"`
+	Title              string             `json:"title"`
+	Description        string             `json:"description"`
+	CommitHash         string             `json:"commitHash"`
+	SourceBranch       string             `json:"sourceBranch"`
+	TargetBranch       string             `json:"targetBranch"`
+	Author             string             `json:"author"`
+	CreatedAt          time.Time          `json:"createdAt"`
+	DurationMs         int                `json:"durationMs"`
+	EffortMinutes      int                `json:"effortMinutes"`
+	AiSlopScore        float32            `json:"aiSlopScore"`
+	ModelInfo          db.ReviewModelInfo `json:"modelInfo"`
+	PromptRevisionID   *int               `json:"promptRevisionId,omitempty"`
+	PromptExperimentID *int               `json:"promptExperimentId,omitempty"`
+	PromptVariant      *string            `json:"promptVariant,omitempty"`
 }
 
 // ReviewDraftFile is one of the five review groups (architecture, code, …).
@@ -49,6 +52,9 @@ type ReviewDraftIssue struct {
 	IssueType    string `json:"issueType"`
 	FileType     string `json:"fileType"`
 	SuggestedFix string `json:"suggestedFix"`
+	// VerifierVerdict is the verifier pass's verdict (confirm, downgrade or
+	// reject); empty when the issue was not verified.
+	VerifierVerdict string `json:"verifierVerdict,omitempty"`
 }
 
 // Validate checks that all reviewType and fileType values are valid.
@@ -75,18 +81,21 @@ func (rd ReviewDraft) Validate() error {
 func (rd ReviewDraft) ToModel() reviewer.Review {
 	rv := reviewer.Review{
 		Review: db.Review{
-			Title:         rd.Review.Title,
-			Description:   rd.Review.Description,
-			ExternalID:    rd.Review.ExternalID,
-			CommitHash:    rd.Review.CommitHash,
-			SourceBranch:  rd.Review.SourceBranch,
-			TargetBranch:  rd.Review.TargetBranch,
-			Author:        rd.Review.Author,
-			CreatedAt:     rd.Review.CreatedAt,
-			DurationMS:    rd.Review.DurationMs,
-			EffortMinutes: ptrInt(rd.Review.EffortMinutes),
-			AiSlopScore:   ptrFloat32(rd.Review.AiSlopScore),
-			ModelInfo:     rd.Review.ModelInfo,
+			Title:              rd.Review.Title,
+			Description:        rd.Review.Description,
+			ExternalID:         rd.Review.ExternalID,
+			CommitHash:         rd.Review.CommitHash,
+			SourceBranch:       rd.Review.SourceBranch,
+			TargetBranch:       rd.Review.TargetBranch,
+			Author:             rd.Review.Author,
+			CreatedAt:          rd.Review.CreatedAt,
+			DurationMS:         rd.Review.DurationMs,
+			EffortMinutes:      ptrInt(rd.Review.EffortMinutes),
+			AiSlopScore:        ptrFloat32(rd.Review.AiSlopScore),
+			ModelInfo:          rd.Review.ModelInfo,
+			PromptRevisionID:   rd.Review.PromptRevisionID,
+			PromptExperimentID: rd.Review.PromptExperimentID,
+			PromptVariant:      rd.Review.PromptVariant,
 		},
 	}
 
@@ -94,15 +103,16 @@ func (rd ReviewDraft) ToModel() reviewer.Review {
 	for _, iss := range rd.Issues {
 		issuesByType[iss.FileType] = append(issuesByType[iss.FileType], reviewer.Issue{
 			Issue: db.Issue{
-				LocalID:      ptrString(iss.LocalID),
-				IssueType:    iss.IssueType,
-				Title:        iss.Title,
-				Severity:     iss.Severity,
-				Description:  iss.Description,
-				Content:      iss.Content,
-				File:         iss.File,
-				Lines:        iss.Lines,
-				SuggestedFix: ptrString(iss.SuggestedFix),
+				LocalID:         ptrString(iss.LocalID),
+				IssueType:       iss.IssueType,
+				Title:           iss.Title,
+				Severity:        iss.Severity,
+				Description:     iss.Description,
+				Content:         iss.Content,
+				File:            iss.File,
+				Lines:           iss.Lines,
+				SuggestedFix:    ptrString(iss.SuggestedFix),
+				VerifierVerdict: ptrString(iss.VerifierVerdict),
 			},
 		})
 	}
@@ -122,6 +132,18 @@ func (rd ReviewDraft) ToModel() reviewer.Review {
 	return rv
 }
 
+// UploadResult is the JSON answer to a review upload, sent when the client
+// accepts application/json; older clients get the bare reviewId.
+type UploadResult struct {
+	ReviewID int `json:"reviewId"`
+	// Suppressed maps the localId of each issue that re-reports an accepted
+	// risk to that risk's issueId. Such issues are not posted to the MR.
+	Suppressed map[string]int `json:"suppressed,omitempty"`
+	// SuppressedByRule maps the localId of each issue matching a suppression
+	// rule to that rule's suppressionRuleId. Such issues are not posted either.
+	SuppressedByRule map[string]int `json:"suppressedByRule,omitempty"`
+}
+
 func ptrString(s string) *string {
 	if s == "" {
 		return nil
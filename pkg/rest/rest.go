@@ -17,6 +17,17 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// Prompt metadata headers set by GetPrompt. reviewctl sends the values back
+// as review.promptRevisionId, promptExperimentId and promptVariant on upload.
+const (
+	HeaderPromptRevisionID   = "X-Prompt-Revision-Id"
+	HeaderPromptExperimentID = "X-Prompt-Experiment-Id"
+	HeaderPromptVariant      = "X-Prompt-Variant"
+)
+
+// QueryExternalID is the GetPrompt query param with the MR ID used to assign an experiment variant.
+const QueryExternalID = "externalId"
+
 type Handler struct {
 	pm       *reviewer.ProjectManager
 	rm       *reviewer.ReviewManager
@@ -52,6 +63,7 @@ func (h *Handler) projectByKey(c echo.Context) (*reviewer.Project, error) {
 }
 
 // CreateReview accepts a review draft via JSON, persists it, and sends a Slack notification.
+// It answers with the reviewId, or with an UploadResult when the client accepts JSON.
 func (h *Handler) CreateReview(c echo.Context) error {
 	project, err := h.projectByKey(c)
 	if err != nil {
@@ -75,6 +87,13 @@ func (h *Handler) CreateReview(c echo.Context) error {
 
 	h.notifySlack(project, rv)
 
+	if strings.Contains(c.Request().Header.Get(echo.HeaderAccept), echo.MIMEApplicationJSON) {
+		return c.JSON(http.StatusOK, UploadResult{
+			ReviewID:         rv.ID,
+			Suppressed:       rv.SuppressedLocalIDs(),
+			SuppressedByRule: rv.SuppressedByRuleLocalIDs(),
+		})
+	}
 	return c.String(http.StatusOK, strconv.Itoa(rv.ID))
 }
 
@@ -193,16 +212,26 @@ func (h *Handler) ProjectInstructionsMarkdown(c echo.Context) error {
 }
 
 // GetPrompt returns the assembled review prompt for the given project.
+// With the externalId query param the MR takes part in the project's prompt experiment.
 func (h *Handler) GetPrompt(c echo.Context) error {
 	project, err := h.projectByKey(c)
 	if err != nil {
 		return err
 	}
 
-	prompt, err := h.pm.Prompt(c.Request().Context(), project.ProjectKey)
+	prompt, err := h.pm.ReviewPrompt(c.Request().Context(), project.ProjectKey, strings.TrimSpace(c.QueryParam(QueryExternalID)))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return c.String(http.StatusOK, prompt)
+	header := c.Response().Header()
+	if prompt.RevisionID != nil {
+		header.Set(HeaderPromptRevisionID, strconv.Itoa(*prompt.RevisionID))
+	}
+	if prompt.ExperimentID != nil && prompt.Variant != nil {
+		header.Set(HeaderPromptExperimentID, strconv.Itoa(*prompt.ExperimentID))
+		header.Set(HeaderPromptVariant, *prompt.Variant)
+	}
+
+	return c.String(http.StatusOK, prompt.Text)
 }
@@ -0,0 +1,417 @@
+package reviewer
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"reviewsrv/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+)
+
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+
+	// maxTrendBuckets bounds a trend period, e.g. about three years of days.
+	maxTrendBuckets = 1100
+)
+
+var (
+	Buckets           = []string{BucketDay, BucketWeek, BucketMonth}
+	ErrInvalidBucket  = errors.New("invalid bucket")
+	ErrInvalidPeriod  = errors.New("invalid period")
+	ErrTooManyBuckets = errors.New("too many buckets")
+)
+
+// TrendSearch contains params for project trends.
+type TrendSearch struct {
+	ProjectIDs []int // empty means all projects
+	From       time.Time
+	To         time.Time
+	Bucket     string
+}
+
+// normalize fills defaults (last year by months up to now) and checks the period.
+func (s *TrendSearch) normalize(now time.Time) error {
+	if s.Bucket == "" {
+		s.Bucket = BucketMonth
+	}
+	if !slices.Contains(Buckets, s.Bucket) {
+		return ErrInvalidBucket
+	}
+	if err := normalizePeriod(&s.From, &s.To, now); err != nil {
+		return err
+	}
+
+	days := s.To.Sub(s.From).Hours() / 24
+	switch s.Bucket {
+	case BucketWeek:
+		days /= 7
+	case BucketMonth:
+		days /= 30
+	}
+	if days > maxTrendBuckets {
+		return ErrTooManyBuckets
+	}
+	return nil
+}
+
+// normalizePeriod defaults to the last year up to now and checks that from is before to.
+func normalizePeriod(from, to *time.Time, now time.Time) error {
+	if to.IsZero() {
+		*to = now
+	}
+	if from.IsZero() {
+		*from = to.AddDate(-1, 0, 0)
+	}
+	if !from.Before(*to) {
+		return ErrInvalidPeriod
+	}
+	return nil
+}
+
+// FeedbackStats counts processed issues by resolution.
+type FeedbackStats struct {
+	Valid         int
+	FalsePositive int
+	Ignored       int
+}
+
+// Processed returns the number of issues with feedback.
+func (f FeedbackStats) Processed() int {
+	return f.Valid + f.FalsePositive + f.Ignored
+}
+
+// Ratio returns n as a share of processed issues, 0 without feedback.
+func (f FeedbackStats) Ratio(n int) float64 {
+	if f.Processed() == 0 {
+		return 0
+	}
+	return float64(n) / float64(f.Processed())
+}
+
+// ProjectTrend contains review stats of a project for one time bucket.
+// Suppressed issues are not counted, as in review stats.
+type ProjectTrend struct {
+	ProjectID          int
+	BucketStart        time.Time
+	ReviewCount        int
+	Issues             IssueStats
+	IssuesByReviewType map[string]int
+	Feedback           FeedbackStats
+	MedianDurationMS   int
+	CostUsd            float64
+}
+
+// CostPerReview returns the average review cost in the bucket.
+func (t ProjectTrend) CostPerReview() float64 {
+	if t.ReviewCount == 0 {
+		return 0
+	}
+	return t.CostUsd / float64(t.ReviewCount)
+}
+
+// trendReviews selects enabled reviews of the period with their bucket.
+// It is shared by the trend queries, params: bucket, status, from, to, len(projectIDs), projectIDs.
+const trendReviews = `
+	WITH rv AS (
+		SELECT "reviewId", "projectId", "durationMS", "modelInfo",
+			date_trunc(?, "createdAt", 'UTC') AS "bucketStart"
+		FROM reviews
+		WHERE "statusId" = ?
+		AND "createdAt" >= ? AND "createdAt" < ?
+		AND (? = 0 OR "projectId" = ANY(?))
+	)`
+
+type trendReviewRow struct {
+	ProjectID        int       `pg:"projectId"`
+	BucketStart      time.Time `pg:"bucketStart"`
+	ReviewCount      int       `pg:"reviewCount"`
+	MedianDurationMS float64   `pg:"medianDurationMs"`
+	CostUsd          float64   `pg:"costUsd"`
+}
+
+type trendIssueRow struct {
+	ProjectID   int       `pg:"projectId"`
+	BucketStart time.Time `pg:"bucketStart"`
+	ReviewType  string    `pg:"reviewType"`
+	Severity    string    `pg:"severity"`
+	StatusID    int       `pg:"statusId"`
+	Count       int       `pg:"count"`
+}
+
+// ProjectTrends returns review stats per project and time bucket, ordered by project and bucket.
+// Buckets start at UTC boundaries (weeks on Monday); buckets without reviews are omitted.
+// An issue persisting across versions of an MR is counted once, by its latest copy.
+// Both queries aggregate in the database over a (projectId, createdAt) range scan.
+func (rm *ReviewManager) ProjectTrends(ctx context.Context, search TrendSearch) ([]ProjectTrend, error) {
+	if err := search.normalize(time.Now()); err != nil {
+		return nil, err
+	}
+	params := []any{search.Bucket, db.StatusEnabled, search.From, search.To, len(search.ProjectIDs), pg.Array(search.ProjectIDs)}
+
+	var reviews []trendReviewRow
+	_, err := rm.Conn().QueryContext(ctx, &reviews, trendReviews+`
+		SELECT "projectId", "bucketStart",
+			count(*) AS "reviewCount",
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY "durationMS") AS "medianDurationMs",
+			coalesce(sum(("modelInfo"->>'costUsd')::numeric), 0) AS "costUsd"
+		FROM rv
+		GROUP BY "projectId", "bucketStart"
+		ORDER BY "projectId", "bucketStart"
+	`, params...)
+	if err != nil || len(reviews) == 0 {
+		return nil, err
+	}
+
+	var issues []trendIssueRow
+	_, err = rm.Conn().QueryContext(ctx, &issues, trendReviews+`
+		SELECT rv."projectId", rv."bucketStart", rf."reviewType", i."severity", i."statusId", count(*) AS "count"
+		FROM rv
+		JOIN issues i ON i."reviewId" = rv."reviewId"
+		JOIN "reviewFiles" rf ON rf."reviewFileId" = i."reviewFileId"
+		WHERE i."statusId" IN (?)
+		AND i."suppressedByIssueId" IS NULL AND i."suppressedByRuleId" IS NULL
+		AND NOT EXISTS (SELECT 1 FROM issues n WHERE n."previousIssueId" = i."issueId")
+		GROUP BY 1, 2, 3, 4, 5
+	`, append(params, pg.In(db.IssueStatusFilter.Value))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProjectTrends(reviews, issues), nil
+}
+
+// newProjectTrends merges per-bucket review and issue rows.
+func newProjectTrends(reviews []trendReviewRow, issues []trendIssueRow) []ProjectTrend {
+	type key struct {
+		projectID int
+		bucket    int64
+	}
+
+	trends := make([]ProjectTrend, len(reviews))
+	idx := make(map[key]int, len(reviews))
+	for i, r := range reviews {
+		trends[i] = ProjectTrend{
+			ProjectID:          r.ProjectID,
+			BucketStart:        r.BucketStart.UTC(),
+			ReviewCount:        r.ReviewCount,
+			IssuesByReviewType: make(map[string]int),
+			MedianDurationMS:   int(math.Round(r.MedianDurationMS)),
+			CostUsd:            r.CostUsd,
+		}
+		idx[key{r.ProjectID, r.BucketStart.Unix()}] = i
+	}
+
+	for _, r := range issues {
+		i, ok := idx[key{r.ProjectID, r.BucketStart.Unix()}]
+		if !ok {
+			continue
+		}
+
+		t := &trends[i]
+		t.Issues.Add(severityStats(r.Severity, r.Count))
+		t.IssuesByReviewType[r.ReviewType] += r.Count
+		switch r.StatusID {
+		case db.StatusValid:
+			t.Feedback.Valid += r.Count
+		case db.StatusFalsePositive:
+			t.Feedback.FalsePositive += r.Count
+		case db.StatusIgnored:
+			t.Feedback.Ignored += r.Count
+		}
+	}
+
+	return trends
+}
+
+// severityStats returns IssueStats with n issues of severity.
+func severityStats(severity string, n int) IssueStats {
+	s := IssueStats{Total: n}
+	switch severity {
+	case SeverityCritical:
+		s.Critical = n
+	case SeverityHigh:
+		s.High = n
+	case SeverityMedium:
+		s.Medium = n
+	case SeverityLow:
+		s.Low = n
+	}
+	return s
+}
+
+const (
+	RankByPrecision         = "precision"
+	RankByIssuesPerReview   = "issuesPerReview"
+	RankByCostPerValidIssue = "costPerValidIssue"
+	RankByLatency           = "latency"
+)
+
+var (
+	LeaderboardRanks = []string{RankByPrecision, RankByIssuesPerReview, RankByCostPerValidIssue, RankByLatency}
+	ErrInvalidRank   = errors.New("invalid rank")
+)
+
+// LeaderboardSearch contains filters for the model leaderboard.
+type LeaderboardSearch struct {
+	ProjectID *int
+	Language  *string // project language, case-insensitive
+	From      time.Time
+	To        time.Time
+	RankBy    string
+}
+
+// LeaderboardEntry contains stats of one (runner, model, prompt) combination.
+// Issues and feedback exclude suppressed issues, as in review stats, and count
+// an issue persisting across versions of an MR once, by its latest copy.
+type LeaderboardEntry struct {
+	Runner           string  `pg:"runner"`
+	Model            string  `pg:"model"`
+	PromptID         int     `pg:"promptId"`
+	PromptTitle      string  `pg:"promptTitle"`
+	ReviewCount      int     `pg:"reviewCount"`
+	IssueCount       int     `pg:"issueCount"`
+	Valid            int     `pg:"valid"`
+	FalsePositive    int     `pg:"falsePositive"`
+	Ignored          int     `pg:"ignored"`
+	CostUsd          float64 `pg:"costUsd"`
+	MedianDurationMS float64 `pg:"medianDurationMs"`
+}
+
+// Feedback returns the feedback counters of the entry.
+func (e LeaderboardEntry) Feedback() FeedbackStats {
+	return FeedbackStats{Valid: e.Valid, FalsePositive: e.FalsePositive, Ignored: e.Ignored}
+}
+
+// Precision returns the share of valid issues among processed ones.
+func (e LeaderboardEntry) Precision() float64 {
+	f := e.Feedback()
+	return f.Ratio(f.Valid)
+}
+
+// IssuesPerReview returns the average number of issues per review.
+func (e LeaderboardEntry) IssuesPerReview() float64 {
+	if e.ReviewCount == 0 {
+		return 0
+	}
+	return float64(e.IssueCount) / float64(e.ReviewCount)
+}
+
+// CostPerValidIssue returns the cost of one valid issue, nil without valid issues.
+func (e LeaderboardEntry) CostPerValidIssue() *float64 {
+	if e.Valid == 0 {
+		return nil
+	}
+	v := e.CostUsd / float64(e.Valid)
+	return &v
+}
+
+// Latency returns the median review duration in milliseconds.
+func (e LeaderboardEntry) Latency() int {
+	return int(math.Round(e.MedianDurationMS))
+}
+
+// Leaderboard returns stats per (runner, model, prompt) of enabled reviews, ranked by search.RankBy.
+// Runner and model come from modelInfo and are empty for reviews uploaded without them.
+func (rm *ReviewManager) Leaderboard(ctx context.Context, search LeaderboardSearch) ([]LeaderboardEntry, error) {
+	if search.RankBy == "" {
+		search.RankBy = RankByPrecision
+	}
+	if !slices.Contains(LeaderboardRanks, search.RankBy) {
+		return nil, ErrInvalidRank
+	}
+	if err := normalizePeriod(&search.From, &search.To, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var projectID int
+	if search.ProjectID != nil {
+		projectID = *search.ProjectID
+	}
+	var language string
+	if search.Language != nil {
+		language = strings.TrimSpace(*search.Language)
+	}
+
+	var entries []LeaderboardEntry
+	_, err := rm.Conn().QueryContext(ctx, &entries, `
+		WITH rv AS (
+			SELECT r."reviewId", r."promptId", r."durationMS",
+				coalesce(r."modelInfo"->>'runner', '') AS "runner",
+				coalesce(r."modelInfo"->>'model', '') AS "model",
+				coalesce((r."modelInfo"->>'costUsd')::numeric, 0) AS "costUsd"
+			FROM reviews r
+			JOIN projects p ON p."projectId" = r."projectId"
+			WHERE r."statusId" = ?
+			AND r."createdAt" >= ? AND r."createdAt" < ?
+			AND (? = 0 OR r."projectId" = ?)
+			AND (? = '' OR lower(p."language") = lower(?))
+		), iss AS (
+			SELECT i."reviewId",
+				count(*) AS "total",
+				count(*) FILTER (WHERE i."statusId" = ?) AS "valid",
+				count(*) FILTER (WHERE i."statusId" = ?) AS "falsePositive",
+				count(*) FILTER (WHERE i."statusId" = ?) AS "ignored"
+			FROM rv
+			JOIN issues i ON i."reviewId" = rv."reviewId"
+			WHERE i."statusId" IN (?)
+			AND i."suppressedByIssueId" IS NULL AND i."suppressedByRuleId" IS NULL
+			AND NOT EXISTS (SELECT 1 FROM issues n WHERE n."previousIssueId" = i."issueId")
+			GROUP BY i."reviewId"
+		)
+		SELECT rv."runner", rv."model", rv."promptId", coalesce(pr."title", '') AS "promptTitle",
+			count(*) AS "reviewCount",
+			coalesce(sum(iss."total"), 0) AS "issueCount",
+			coalesce(sum(iss."valid"), 0) AS "valid",
+			coalesce(sum(iss."falsePositive"), 0) AS "falsePositive",
+			coalesce(sum(iss."ignored"), 0) AS "ignored",
+			sum(rv."costUsd") AS "costUsd",
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY rv."durationMS") AS "medianDurationMs"
+		FROM rv
+		LEFT JOIN iss ON iss."reviewId" = rv."reviewId"
+		LEFT JOIN prompts pr ON pr."promptId" = rv."promptId"
+		GROUP BY rv."runner", rv."model", rv."promptId", pr."title"
+	`, db.StatusEnabled, search.From, search.To, projectID, projectID, language, language,
+		db.StatusValid, db.StatusFalsePositive, db.StatusIgnored, pg.In(db.IssueStatusFilter.Value))
+	if err != nil {
+		return nil, err
+	}
+
+	rankLeaderboard(entries, search.RankBy)
+	return entries, nil
+}
+
+// rankLeaderboard sorts entries best first: higher precision and issues per review,
+// lower cost per valid issue and latency. Ties go to the entry with more reviews.
+func rankLeaderboard(entries []LeaderboardEntry, rankBy string) {
+	metric := func(e LeaderboardEntry) float64 {
+		switch rankBy {
+		case RankByIssuesPerReview:
+			return e.IssuesPerReview()
+		case RankByCostPerValidIssue:
+			if c := e.CostPerValidIssue(); c != nil {
+				return -*c
+			}
+			return math.Inf(-1)
+		case RankByLatency:
+			return -e.MedianDurationMS
+		default:
+			return e.Precision()
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b LeaderboardEntry) int {
+		if c := cmp.Compare(metric(b), metric(a)); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ReviewCount, a.ReviewCount)
+	})
+}
@@ -0,0 +1,219 @@
+package reviewer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"reviewsrv/pkg/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendSearch_normalize(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	s := TrendSearch{}
+	require.NoError(t, s.normalize(now))
+	assert.Equal(t, BucketMonth, s.Bucket)
+	assert.Equal(t, now, s.To)
+	assert.Equal(t, now.AddDate(-1, 0, 0), s.From)
+
+	s = TrendSearch{Bucket: "hour"}
+	require.ErrorIs(t, s.normalize(now), ErrInvalidBucket)
+
+	s = TrendSearch{From: now, To: now.AddDate(0, 0, -1)}
+	require.ErrorIs(t, s.normalize(now), ErrInvalidPeriod)
+
+	s = TrendSearch{Bucket: BucketDay, From: now.AddDate(-10, 0, 0)}
+	require.ErrorIs(t, s.normalize(now), ErrTooManyBuckets)
+
+	s = TrendSearch{Bucket: BucketMonth, From: now.AddDate(-10, 0, 0)}
+	require.NoError(t, s.normalize(now))
+}
+
+func TestNewProjectTrends(t *testing.T) {
+	sep := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	oct := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	trends := newProjectTrends(
+		[]trendReviewRow{
+			{ProjectID: 1, BucketStart: sep, ReviewCount: 2, MedianDurationMS: 1500.5, CostUsd: 3},
+			{ProjectID: 1, BucketStart: oct, ReviewCount: 1, MedianDurationMS: 900, CostUsd: 0.5},
+		},
+		[]trendIssueRow{
+			{ProjectID: 1, BucketStart: sep, ReviewType: ReviewTypeCode, Severity: SeverityHigh, StatusID: db.StatusValid, Count: 3},
+			{ProjectID: 1, BucketStart: sep, ReviewType: ReviewTypeCode, Severity: SeverityLow, StatusID: db.StatusFalsePositive, Count: 1},
+			{ProjectID: 1, BucketStart: sep, ReviewType: ReviewTypeSecurity, Severity: SeverityCritical, StatusID: db.StatusEnabled, Count: 2},
+			{ProjectID: 1, BucketStart: oct, ReviewType: ReviewTypeTests, Severity: SeverityMedium, StatusID: db.StatusIgnored, Count: 1},
+			{ProjectID: 2, BucketStart: oct, ReviewType: ReviewTypeTests, Severity: SeverityMedium, StatusID: db.StatusIgnored, Count: 5},
+		},
+	)
+	require.Len(t, trends, 2)
+
+	got := trends[0]
+	assert.Equal(t, sep, got.BucketStart)
+	assert.Equal(t, IssueStats{Critical: 2, High: 3, Low: 1, Total: 6}, got.Issues)
+	assert.Equal(t, map[string]int{ReviewTypeCode: 4, ReviewTypeSecurity: 2}, got.IssuesByReviewType)
+	assert.Equal(t, FeedbackStats{Valid: 3, FalsePositive: 1}, got.Feedback)
+	assert.InDelta(t, 0.75, got.Feedback.Ratio(got.Feedback.Valid), 1e-9)
+	assert.Equal(t, 1501, got.MedianDurationMS)
+	assert.InDelta(t, 1.5, got.CostPerReview(), 1e-9)
+
+	got = trends[1]
+	assert.Equal(t, IssueStats{Medium: 1, Total: 1}, got.Issues)
+	assert.Equal(t, FeedbackStats{Ignored: 1}, got.Feedback)
+	assert.Zero(t, FeedbackStats{}.Ratio(0))
+}
+
+// createTestReviewVersion uploads the next version of prev's MR repeating its
+// first issue, so that issue persists and inherits its feedback.
+func createTestReviewVersion(t *testing.T, rm *ReviewManager, dbc db.DB, pr *Project, prev *Review) *Review {
+	t.Helper()
+	first := prev.ReviewFiles[0].Issues[0]
+	rv := &Review{
+		Review: db.Review{Title: prev.Title, ExternalID: prev.ExternalID, CommitHash: "def456", SourceBranch: prev.SourceBranch, TargetBranch: prev.TargetBranch, Author: prev.Author, DurationMS: prev.DurationMS},
+		ReviewFiles: ReviewFiles{{
+			ReviewFile: db.ReviewFile{ReviewType: prev.ReviewFiles[0].ReviewType, Content: "content", Summary: "summary"},
+			Issues:     Issues{{db.Issue{Title: first.Title, Severity: first.Severity, IssueType: first.IssueType, Description: first.Description, Content: first.Content, File: first.File, Lines: first.Lines}}},
+		}},
+	}
+	rv, err := rm.CreateReview(t.Context(), pr, rv)
+	require.NoError(t, err)
+	cleanupReview(t, dbc, rv)
+	require.NotNil(t, rv.ReviewFiles[0].Issues[0].PreviousIssueID, "issue persists")
+	return rv
+}
+
+func TestDBReviewManager_ProjectTrends(t *testing.T) {
+	rm, dbc := newTestReviewManager(t)
+	ensureIssueStatuses(t, dbc)
+	pr, prCl := createTestProject(t, dbc)
+	t.Cleanup(prCl)
+	ctx := t.Context()
+
+	rv := createTestReview(t, rm, pr)
+	cleanupReview(t, dbc, rv)
+	_, err := rm.SetFeedback(ctx, rv.ReviewFiles[0].Issues[0].ID, db.StatusValid)
+	require.NoError(t, err)
+	_, err = rm.SetFeedback(ctx, rv.ReviewFiles[1].Issues[0].ID, db.StatusFalsePositive)
+	require.NoError(t, err)
+	_, err = dbc.ExecContext(ctx, `UPDATE reviews SET "modelInfo" = '{"costUsd": 1.25}' WHERE "reviewId" = ?`, rv.ID)
+	require.NoError(t, err)
+
+	trends, err := rm.ProjectTrends(ctx, TrendSearch{ProjectIDs: []int{pr.ID}, Bucket: BucketDay, From: time.Now().AddDate(0, 0, -1), To: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, trends, 1)
+
+	got := trends[0]
+	assert.Equal(t, pr.ID, got.ProjectID)
+	assert.Equal(t, 1, got.ReviewCount)
+	assert.Equal(t, IssueStats{Critical: 1, High: 1, Low: 1, Total: 3}, got.Issues)
+	assert.Equal(t, map[string]int{ReviewTypeCode: 2, ReviewTypeSecurity: 1}, got.IssuesByReviewType)
+	assert.Equal(t, FeedbackStats{Valid: 1, FalsePositive: 1}, got.Feedback)
+	assert.Equal(t, 1000, got.MedianDurationMS)
+	assert.InDelta(t, 1.25, got.CostUsd, 1e-9)
+
+	trends, err = rm.ProjectTrends(ctx, TrendSearch{ProjectIDs: []int{pr.ID}, From: time.Now().AddDate(-2, 0, 0), To: time.Now().AddDate(-1, 0, 0)})
+	require.NoError(t, err)
+	assert.Empty(t, trends)
+
+	t.Run("persisting issue counted once", func(t *testing.T) {
+		createTestReviewVersion(t, rm, dbc, pr, rv)
+
+		trends, err := rm.ProjectTrends(ctx, TrendSearch{ProjectIDs: []int{pr.ID}, Bucket: BucketDay, From: time.Now().AddDate(0, 0, -1), To: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, trends, 1)
+		assert.Equal(t, 2, trends[0].ReviewCount)
+		assert.Equal(t, IssueStats{Critical: 1, High: 1, Low: 1, Total: 3}, trends[0].Issues)
+		assert.Equal(t, FeedbackStats{Valid: 1, FalsePositive: 1}, trends[0].Feedback)
+	})
+}
+
+func TestRankLeaderboard(t *testing.T) {
+	entries := []LeaderboardEntry{
+		{Model: "a", ReviewCount: 10, IssueCount: 30, Valid: 6, FalsePositive: 4, CostUsd: 12, MedianDurationMS: 90000},
+		{Model: "b", ReviewCount: 5, IssueCount: 5, Valid: 4, FalsePositive: 1, CostUsd: 2, MedianDurationMS: 60000},
+		{Model: "c", ReviewCount: 20, IssueCount: 10, FalsePositive: 2, CostUsd: 1, MedianDurationMS: 30000},
+		{Model: "d", ReviewCount: 8, IssueCount: 8, Valid: 4, FalsePositive: 1, CostUsd: 4, MedianDurationMS: 45000},
+	}
+	models := func() []string {
+		out := make([]string, len(entries))
+		for i, e := range entries {
+			out[i] = e.Model
+		}
+		return out
+	}
+
+	rankLeaderboard(entries, RankByPrecision)
+	assert.Equal(t, []string{"d", "b", "a", "c"}, models(), "equal precision: more reviews first")
+
+	rankLeaderboard(entries, RankByIssuesPerReview)
+	assert.Equal(t, []string{"a", "d", "b", "c"}, models())
+
+	rankLeaderboard(entries, RankByCostPerValidIssue)
+	assert.Equal(t, []string{"b", "d", "a", "c"}, models(), "no valid issues: last")
+
+	rankLeaderboard(entries, RankByLatency)
+	assert.Equal(t, []string{"c", "d", "b", "a"}, models())
+
+	e := entries[3]
+	assert.InDelta(t, 0.6, e.Precision(), 1e-9)
+	assert.InDelta(t, 3.0, e.IssuesPerReview(), 1e-9)
+	require.NotNil(t, e.CostPerValidIssue())
+	assert.InDelta(t, 2.0, *e.CostPerValidIssue(), 1e-9)
+	assert.Nil(t, entries[0].CostPerValidIssue())
+	assert.Equal(t, 90000, e.Latency())
+}
+
+func TestDBReviewManager_Leaderboard(t *testing.T) {
+	rm, dbc := newTestReviewManager(t)
+	ensureIssueStatuses(t, dbc)
+	pr, prCl := createTestProject(t, dbc)
+	t.Cleanup(prCl)
+	ctx := t.Context()
+
+	rv := createTestReview(t, rm, pr)
+	cleanupReview(t, dbc, rv)
+	_, err := rm.SetFeedback(ctx, rv.ReviewFiles[0].Issues[0].ID, db.StatusValid)
+	require.NoError(t, err)
+	_, err = rm.SetFeedback(ctx, rv.ReviewFiles[1].Issues[0].ID, db.StatusFalsePositive)
+	require.NoError(t, err)
+	_, err = dbc.ExecContext(ctx, `UPDATE reviews SET "modelInfo" = '{"runner": "claude", "model": "opus", "costUsd": 2}' WHERE "reviewId" = ?`, rv.ID)
+	require.NoError(t, err)
+
+	entries, err := rm.Leaderboard(ctx, LeaderboardSearch{ProjectID: &pr.ID, Language: Ptr(strings.ToUpper(pr.Language))})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	got := entries[0]
+	assert.Equal(t, "claude", got.Runner)
+	assert.Equal(t, "opus", got.Model)
+	assert.Equal(t, pr.PromptID, got.PromptID)
+	assert.Equal(t, 1, got.ReviewCount)
+	assert.Equal(t, 3, got.IssueCount)
+	assert.Equal(t, FeedbackStats{Valid: 1, FalsePositive: 1}, got.Feedback())
+	assert.InDelta(t, 0.5, got.Precision(), 1e-9)
+	assert.Equal(t, 1000, got.Latency())
+
+	entries, err = rm.Leaderboard(ctx, LeaderboardSearch{ProjectID: &pr.ID, Language: Ptr(pr.Language + "x")})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	t.Run("persisting issue counted once", func(t *testing.T) {
+		v2 := createTestReviewVersion(t, rm, dbc, pr, rv)
+		_, err := dbc.ExecContext(ctx, `UPDATE reviews SET "modelInfo" = '{"runner": "claude", "model": "opus", "costUsd": 1}' WHERE "reviewId" = ?`, v2.ID)
+		require.NoError(t, err)
+
+		entries, err := rm.Leaderboard(ctx, LeaderboardSearch{ProjectID: &pr.ID})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].ReviewCount)
+		assert.Equal(t, 3, entries[0].IssueCount)
+		assert.Equal(t, FeedbackStats{Valid: 1, FalsePositive: 1}, entries[0].Feedback())
+	})
+
+	_, err = rm.Leaderboard(ctx, LeaderboardSearch{RankBy: "stars"})
+	require.ErrorIs(t, err, ErrInvalidRank)
+}
@@ -1,11 +1,13 @@
 package reviewer
 
 //go:generate colgen -imports=reviewsrv/pkg/db
-//colgen:Review,ReviewFile,Issue,Project
+//colgen:Review,ReviewFile,Issue,Project,SuppressionRule,PromptRevision
 //colgen:Project:MapP(db)
 //colgen:Issue:MapP(db),Group(ReviewFileID)
 //colgen:ReviewFile:MapP(db),Group(ReviewID)
 //colgen:Review:MapP(db)
+//colgen:SuppressionRule:MapP(db)
+//colgen:PromptRevision:MapP(db)
 
 // MapP converts slice of type T to slice of type M with given converter with pointers.
 func MapP[T, M any](a []T, f func(*T) *M) []M {
@@ -53,6 +53,26 @@ func (ll Projects) Index() map[int]Project {
 
 func NewProjects(in []db.Project) Projects { return MapP(in, NewProject) }
 
+type PromptRevisions []PromptRevision
+
+func (ll PromptRevisions) IDs() []int {
+	r := make([]int, len(ll))
+	for i := range ll {
+		r[i] = ll[i].ID
+	}
+	return r
+}
+
+func (ll PromptRevisions) Index() map[int]PromptRevision {
+	r := make(map[int]PromptRevision, len(ll))
+	for i := range ll {
+		r[ll[i].ID] = ll[i]
+	}
+	return r
+}
+
+func NewPromptRevisions(in []db.PromptRevision) PromptRevisions { return MapP(in, NewPromptRevision) }
+
 type Reviews []Review
 
 func (ll Reviews) IDs() []int {
@@ -100,3 +120,25 @@ func (ll ReviewFiles) GroupByReviewID() map[int]ReviewFiles {
 	}
 	return r
 }
+
+type SuppressionRules []SuppressionRule
+
+func (ll SuppressionRules) IDs() []int {
+	r := make([]int, len(ll))
+	for i := range ll {
+		r[i] = ll[i].ID
+	}
+	return r
+}
+
+func (ll SuppressionRules) Index() map[int]SuppressionRule {
+	r := make(map[int]SuppressionRule, len(ll))
+	for i := range ll {
+		r[ll[i].ID] = ll[i]
+	}
+	return r
+}
+
+func NewSuppressionRules(in []db.SuppressionRule) SuppressionRules {
+	return MapP(in, NewSuppressionRule)
+}
@@ -2,6 +2,7 @@ package ctl
 
 import (
 	"errors"
+	"time"
 
 	"reviewsrv/pkg/reviewer/runner"
 )
@@ -31,9 +32,11 @@ type Config struct {
 	MRTitle      string
 	ExternalID   string
 
-	// Claude session for --resume (reuses prompt cache).
+	// Claude session for --resume (reuses prompt cache); for the direct runner,
+	// a saved session in SessionDir to resume.
 	SessionID       string
 	ContinueSession bool // use --continue instead of --resume
+	SessionDir      string
 
 	// Direct-API runner (--runner direct): provider, endpoint and reasoning effort.
 	// The API key is read from the environment (ANTHROPIC_API_KEY / DEEPSEEK_API_KEY),
@@ -41,6 +44,61 @@ type Config struct {
 	APIProvider string // "deepseek" (default) | "openai-compat" | "anthropic"
 	APIBaseURL  string
 	Effort      string
+	// StreamIdleTimeout aborts a direct-runner round whose response stream goes
+	// silent; zero keeps the runner default.
+	StreamIdleTimeout time.Duration
+	// ContextWindow overrides the direct-runner model's context window in
+	// tokens (sizes preload and compaction); zero uses the built-in table.
+	ContextWindow int
+	// CacheBreakpoints and CacheTTL control Anthropic prompt caching in the
+	// direct runner: "tools,system,preload,history" (empty = all, "none") and
+	// "5m" or "1h".
+	CacheBreakpoints string
+	CacheTTL         string
+	// Replay makes the direct runner answer from a recorded cassette
+	// (direct-cassette.jsonl) instead of calling the API; ReplayStrict fails on
+	// any request the recording doesn't match exactly.
+	Replay       string
+	ReplayStrict bool
+	// Linters is the project's linter spec for the direct runner's run_linter
+	// tool ("name=command args; ..."); LintPreRun also runs them before the
+	// review and puts their findings into the kickoff.
+	Linters    string
+	LintPreRun bool
+	// MCPConfig is a JSON file of external MCP tool servers mounted into the
+	// direct runner (.mcp.json layout plus per-server allow-list and timeouts).
+	MCPConfig string
+	// ToolLimits caps individual direct-runner tools per run
+	// ("tool=calls:N,bytes:N; ...").
+	ToolLimits string
+	// SubAgents makes the direct runner plan the review and run parallel
+	// sub-agents per group or package instead of a single loop.
+	SubAgents bool
+	// ModelCatalog overrides the built-in model catalogue (prices, limits,
+	// features) with a JSON file or URL; see direct.LoadCatalog.
+	ModelCatalog string
+	// SingleShot makes the direct runner review without tools, in one
+	// structured-output answer.
+	SingleShot bool
+	// Structured makes the direct runner review without tools, one
+	// structured-output answer per group, for models with weak function calling.
+	Structured bool
+	// ResponseFormat is how OpenAI-compatible backends constrain those answers:
+	// json_schema (default), json_object or none.
+	ResponseFormat string
+	// Batch runs every direct-runner round as a batch job (half price, no
+	// interactive latency), polled every BatchPoll; pending jobs are kept in
+	// the session dir and picked up again by a re-run.
+	Batch     bool
+	BatchPoll time.Duration
+
+	// Verify re-checks every issue with a verifier model (VerifyModel, default
+	// Model) before upload, via the direct-runner API settings above, whatever
+	// the runner. Rejected issues are flagged in the title, or dropped with
+	// VerifyDrop.
+	Verify      bool
+	VerifyModel string
+	VerifyDrop  bool
 
 	// AllowDangerousPermissions toggles `--dangerously-skip-permissions` for
 	// runners that support it (currently opencode). Defaults to true to match
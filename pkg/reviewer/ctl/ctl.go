@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"reviewsrv/pkg/rest"
+	"reviewsrv/pkg/reviewer/direct"
 	"reviewsrv/pkg/reviewer/runner"
 )
 
@@ -19,6 +20,10 @@ type Controller struct {
 	upload *UploadClient
 	gitlab *GitLabClient
 	runner runner.ReviewRunner
+
+	// Optional verifier pass, see WithVerifier.
+	verifier     *direct.Verifier
+	dropRejected bool
 }
 
 // NewController creates a new Controller from Config.
@@ -75,11 +80,11 @@ func (c *Controller) Review(ctx context.Context) (retErr error) {
 		return fmt.Errorf("write review.json skeleton: %w", err)
 	}
 
-	prompt, err := c.prompt.FetchPrompt(ctx, c.cfg.URL, c.cfg.Key)
+	fetched, err := c.prompt.FetchPrompt(ctx, c.cfg.URL, c.cfg.Key, c.cfg.ExternalID)
 	if err != nil {
 		return fmt.Errorf("fetch prompt: %w", err)
 	}
-	prompt = SubstituteVariables(prompt, c.cfg)
+	prompt := SubstituteVariables(fetched.Text, c.cfg)
 
 	result, err := c.runner.Run(ctx, prompt)
 	if err != nil {
@@ -107,17 +112,21 @@ func (c *Controller) Review(ctx context.Context) (retErr error) {
 		}
 	}
 
+	fetched.ApplyTo(&draft.Review)
+	c.verifyIssues(ctx, draft)
+
 	mdFiles, err := FindMDFiles(c.cfg.Dir)
 	if err != nil {
 		return fmt.Errorf("find md files: %w", err)
 	}
 
-	reviewID, err := c.upload.UploadAll(ctx, c.cfg.URL, c.cfg.Key, draft, mdFiles)
+	uploaded, err := c.upload.UploadAll(ctx, c.cfg.URL, c.cfg.Key, draft, mdFiles)
 	if err != nil {
 		return fmt.Errorf("upload: %w", err)
 	}
+	reviewID := uploaded.ReviewID
 
-	c.postComments(ctx, draft, reviewID)
+	c.postComments(ctx, withoutSuppressed(draft, uploaded.Suppressed, uploaded.SuppressedByRule), reviewID)
 	c.generateHTML(draft, mdFiles)
 
 	c.log.InfoContext(ctx, "review completed", "reviewId", reviewID, "duration", time.Since(start).Round(time.Second), "retried", retried)
@@ -196,12 +205,13 @@ func (c *Controller) Upload(ctx context.Context) error {
 		return fmt.Errorf("find md files: %w", err)
 	}
 
-	reviewID, err := c.upload.UploadAll(ctx, c.cfg.URL, c.cfg.Key, draft, mdFiles)
+	uploaded, err := c.upload.UploadAll(ctx, c.cfg.URL, c.cfg.Key, draft, mdFiles)
 	if err != nil {
 		return fmt.Errorf("upload: %w", err)
 	}
+	reviewID := uploaded.ReviewID
 
-	c.postComments(ctx, draft, reviewID)
+	c.postComments(ctx, withoutSuppressed(draft, uploaded.Suppressed, uploaded.SuppressedByRule), reviewID)
 	c.generateHTML(draft, mdFiles)
 
 	c.log.InfoContext(ctx, "upload completed", "reviewId", reviewID)
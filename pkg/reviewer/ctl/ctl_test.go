@@ -12,6 +12,7 @@ import (
 	"strings"
 	"testing"
 
+	"reviewsrv/pkg/rest"
 	"reviewsrv/pkg/reviewer/runner"
 
 	"github.com/stretchr/testify/assert"
@@ -109,12 +110,17 @@ func TestController_Upload(t *testing.T) {
 func TestController_Review(t *testing.T) {
 	promptCalled := false
 	var uploadedReview bool
+	var uploaded rest.ReviewDraft
 
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 		// GET /v1/prompt/{key}/
 		if strings.HasPrefix(path, "/v1/prompt/") && r.Method == http.MethodGet {
 			promptCalled = true
+			assert.Equal(t, "77", r.URL.Query().Get(rest.QueryExternalID))
+			w.Header().Set(rest.HeaderPromptRevisionID, "5")
+			w.Header().Set(rest.HeaderPromptExperimentID, "2")
+			w.Header().Set(rest.HeaderPromptVariant, "B")
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte("Review %SOURCE_BRANCH% to %TARGET_BRANCH%"))
 			return
@@ -123,6 +129,7 @@ func TestController_Review(t *testing.T) {
 		parts := strings.Split(strings.Trim(path, "/"), "/")
 		if len(parts) == 3 && r.Method == http.MethodPost {
 			uploadedReview = true
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&uploaded))
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte("42"))
 			return
@@ -144,6 +151,7 @@ func TestController_Review(t *testing.T) {
 		Dir:          tmpDir,
 		SourceBranch: "feature/test",
 		TargetBranch: "master",
+		ExternalID:   "77",
 	}
 
 	runner := &testClaudeRunner{fixturePath: "testdata/claude_result.json"}
@@ -154,6 +162,11 @@ func TestController_Review(t *testing.T) {
 
 	assert.True(t, promptCalled, "prompt was not fetched")
 	assert.True(t, uploadedReview, "review was not uploaded")
+	require.NotNil(t, uploaded.Review.PromptRevisionID, "prompt revision was not uploaded back")
+	assert.Equal(t, 5, *uploaded.Review.PromptRevisionID)
+	require.NotNil(t, uploaded.Review.PromptExperimentID, "prompt experiment was not uploaded back")
+	assert.Equal(t, 2, *uploaded.Review.PromptExperimentID)
+	assert.Equal(t, "B", *uploaded.Review.PromptVariant)
 }
 
 func TestController_Review_UploadsDebugBundleOnValidationFailure(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"net/mail"
 	"os"
 	"strconv"
+	"time"
 )
 
 // EnvDefault returns the value of env var key, or fallback when unset/empty.
@@ -30,6 +31,34 @@ func EnvBool(key string, fallback bool) bool {
 	return b
 }
 
+// EnvDuration parses an env var via time.ParseDuration ("90s", "5m"), falling
+// back when the var is unset or unparseable — same contract as EnvBool.
+func EnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+// EnvInt parses an env var via strconv.Atoi, falling back when the var is unset
+// or unparseable — same contract as EnvBool.
+func EnvInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
 // AuthorName extracts the display name from "Name <email>" (CI_COMMIT_AUTHOR
 // format) so the email isn't leaked into Slack notifications and the public
 // API. Returns the input unchanged for plain logins or unparsable values.
@@ -2,6 +2,7 @@ package ctl
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -75,3 +76,37 @@ func TestEnvDefault(t *testing.T) {
 		assert.Equal(t, "actual", EnvDefault("REVIEW_TEST_STR", "fb"))
 	})
 }
+
+func TestEnvDuration(t *testing.T) {
+	t.Run("unset returns fallback", func(t *testing.T) {
+		t.Setenv("REVIEW_TEST_DUR_UNSET", "")
+		assert.Equal(t, time.Minute, EnvDuration("REVIEW_TEST_DUR_UNSET", time.Minute))
+	})
+
+	t.Run("parses duration", func(t *testing.T) {
+		t.Setenv("REVIEW_TEST_DUR", "90s")
+		assert.Equal(t, 90*time.Second, EnvDuration("REVIEW_TEST_DUR", time.Minute))
+	})
+
+	t.Run("unparseable falls back", func(t *testing.T) {
+		t.Setenv("REVIEW_TEST_DUR_BAD", "soon")
+		assert.Equal(t, time.Minute, EnvDuration("REVIEW_TEST_DUR_BAD", time.Minute))
+	})
+}
+
+func TestEnvInt(t *testing.T) {
+	t.Run("unset returns fallback", func(t *testing.T) {
+		t.Setenv("REVIEW_TEST_INT_UNSET", "")
+		assert.Equal(t, 7, EnvInt("REVIEW_TEST_INT_UNSET", 7))
+	})
+
+	t.Run("parses int", func(t *testing.T) {
+		t.Setenv("REVIEW_TEST_INT", "128000")
+		assert.Equal(t, 128000, EnvInt("REVIEW_TEST_INT", 7))
+	})
+
+	t.Run("unparseable falls back", func(t *testing.T) {
+		t.Setenv("REVIEW_TEST_INT_BAD", "128k")
+		assert.Equal(t, 7, EnvInt("REVIEW_TEST_INT_BAD", 7))
+	})
+}
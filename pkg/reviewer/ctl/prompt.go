@@ -6,8 +6,12 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
+
+	"reviewsrv/pkg/rest"
 )
 
 // CI metadata placeholders left in the prompt body and the review.json
@@ -42,33 +46,82 @@ func NewPromptClient(log *slog.Logger) *PromptClient {
 	}
 }
 
-// FetchPrompt fetches the assembled prompt for the given project key.
-func (c *PromptClient) FetchPrompt(ctx context.Context, serverURL, projectKey string) (string, error) {
-	url := fmt.Sprintf("%s/v1/prompt/%s/", strings.TrimRight(serverURL, "/"), projectKey)
+// FetchedPrompt is an assembled prompt with the metadata the server reported for it.
+// Metadata fields are nil for servers that don't report them.
+type FetchedPrompt struct {
+	Text         string
+	RevisionID   *int
+	ExperimentID *int
+	Variant      *string
+}
+
+// ApplyTo records the prompt metadata on the review, so the server can link
+// the review to the prompt revision and experiment variant it was produced with.
+func (p *FetchedPrompt) ApplyTo(meta *rest.ReviewDraftMeta) {
+	meta.PromptRevisionID = p.RevisionID
+	meta.PromptExperimentID = p.ExperimentID
+	meta.PromptVariant = p.Variant
+}
+
+// FetchPrompt fetches the assembled prompt for the given project key. A non-empty
+// externalID (MR ID) lets the server assign the MR to a prompt experiment variant.
+func (c *PromptClient) FetchPrompt(ctx context.Context, serverURL, projectKey, externalID string) (*FetchedPrompt, error) {
+	endpoint := fmt.Sprintf("%s/v1/prompt/%s/", strings.TrimRight(serverURL, "/"), projectKey)
+	if externalID != "" {
+		endpoint += "?" + url.Values{rest.QueryExternalID: {externalID}}.Encode()
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return "", fmt.Errorf("create prompt request: %w", err)
+		return nil, fmt.Errorf("create prompt request: %w", err)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("fetch prompt: %w", err)
+		return nil, fmt.Errorf("fetch prompt: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("read prompt response: %w", err)
+		return nil, fmt.Errorf("read prompt response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("fetch prompt: HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("fetch prompt: HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	p := &FetchedPrompt{
+		Text:         string(body),
+		RevisionID:   c.headerInt(ctx, resp.Header, rest.HeaderPromptRevisionID),
+		ExperimentID: c.headerInt(ctx, resp.Header, rest.HeaderPromptExperimentID),
 	}
+	if v := resp.Header.Get(rest.HeaderPromptVariant); v != "" && p.ExperimentID != nil {
+		p.Variant = &v
+	} else {
+		p.ExperimentID = nil
+	}
+
+	c.log.InfoContext(ctx, "fetched prompt", "projectKey", projectKey, "length", len(body), "revisionId", p.RevisionID,
+		"experimentId", p.ExperimentID, "variant", p.Variant)
+
+	return p, nil
+}
 
-	c.log.InfoContext(ctx, "fetched prompt", "projectKey", projectKey, "length", len(body))
+// headerInt parses an integer header, returning nil if it is absent or invalid.
+func (c *PromptClient) headerInt(ctx context.Context, h http.Header, name string) *int {
+	v := h.Get(name)
+	if v == "" {
+		return nil
+	}
+
+	id, err := strconv.Atoi(v)
+	if err != nil {
+		c.log.WarnContext(ctx, "invalid prompt header", "header", name, "value", v)
+		return nil
+	}
 
-	return string(body), nil
+	return &id
 }
 
 // SubstituteVariables replaces CI placeholders in the prompt text. Empty
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	"reviewsrv/pkg/rest"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,15 +20,49 @@ func TestFetchPrompt(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodGet, r.Method)
 		assert.True(t, strings.HasPrefix(r.URL.Path, "/v1/prompt/"), "path = %q, want prefix /v1/prompt/", r.URL.Path)
+		assert.Equal(t, "!42", r.URL.Query().Get(rest.QueryExternalID))
+		w.Header().Set(rest.HeaderPromptRevisionID, "17")
+		w.Header().Set(rest.HeaderPromptExperimentID, "3")
+		w.Header().Set(rest.HeaderPromptVariant, "B")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(wantPrompt))
 	}))
 	defer srv.Close()
 
 	c := NewPromptClient(slog.Default())
-	got, err := c.FetchPrompt(context.Background(), srv.URL, "test-key")
+	got, err := c.FetchPrompt(context.Background(), srv.URL, "test-key", "!42")
 	require.NoError(t, err)
-	assert.Equal(t, wantPrompt, got)
+	assert.Equal(t, wantPrompt, got.Text)
+	require.NotNil(t, got.RevisionID)
+	assert.Equal(t, 17, *got.RevisionID)
+	require.NotNil(t, got.ExperimentID)
+	assert.Equal(t, 3, *got.ExperimentID)
+	require.NotNil(t, got.Variant)
+	assert.Equal(t, "B", *got.Variant)
+}
+
+func TestFetchPrompt_WithoutRevision(t *testing.T) {
+	for _, header := range []string{"", "abc"} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.RawQuery)
+			if header != "" {
+				w.Header().Set(rest.HeaderPromptRevisionID, header)
+				w.Header().Set(rest.HeaderPromptExperimentID, header)
+				w.Header().Set(rest.HeaderPromptVariant, "A")
+			}
+			w.Write([]byte("prompt"))
+		}))
+
+		c := NewPromptClient(slog.Default())
+		got, err := c.FetchPrompt(context.Background(), srv.URL, "test-key", "")
+		srv.Close()
+
+		require.NoError(t, err)
+		assert.Equal(t, "prompt", got.Text)
+		assert.Nil(t, got.RevisionID, "header %q", header)
+		assert.Nil(t, got.ExperimentID, "header %q", header)
+		assert.Nil(t, got.Variant, "header %q", header)
+	}
 }
 
 func TestFetchPrompt_ServerError(t *testing.T) {
@@ -47,7 +83,7 @@ func TestFetchPrompt_ServerError(t *testing.T) {
 			defer srv.Close()
 
 			c := NewPromptClient(slog.Default())
-			_, err := c.FetchPrompt(context.Background(), srv.URL, "test-key")
+			_, err := c.FetchPrompt(context.Background(), srv.URL, "test-key", "")
 			require.Error(t, err)
 		})
 	}
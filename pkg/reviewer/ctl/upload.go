@@ -45,44 +45,65 @@ func NewUploadClient(log *slog.Logger) *UploadClient {
 	}
 }
 
-// UploadReview uploads review.json and returns the reviewId.
-func (c *UploadClient) UploadReview(ctx context.Context, serverURL, projectKey string, draft *rest.ReviewDraft) (int, error) {
+// UploadReview uploads review.json and returns the reviewId along with the
+// issues the server suppressed as accepted risks.
+func (c *UploadClient) UploadReview(ctx context.Context, serverURL, projectKey string, draft *rest.ReviewDraft) (rest.UploadResult, error) {
 	url := fmt.Sprintf("%s/v1/upload/%s/", strings.TrimRight(serverURL, "/"), projectKey)
 
 	body, err := json.Marshal(draft)
 	if err != nil {
-		return 0, fmt.Errorf("marshal review draft: %w", err)
+		return rest.UploadResult{}, fmt.Errorf("marshal review draft: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return 0, fmt.Errorf("create upload request: %w", err)
+		return rest.UploadResult{}, fmt.Errorf("create upload request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("upload review: %w", err)
+		return rest.UploadResult{}, fmt.Errorf("upload review: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, fmt.Errorf("read upload response: %w", err)
+		return rest.UploadResult{}, fmt.Errorf("read upload response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("upload review: HTTP %d: %s", resp.StatusCode, string(respBody))
+		return rest.UploadResult{}, fmt.Errorf("upload review: HTTP %d: %s", resp.StatusCode, string(respBody))
 	}
 
-	reviewID, err := strconv.Atoi(strings.TrimSpace(string(respBody)))
+	result, err := parseUploadResult(respBody)
 	if err != nil {
-		return 0, fmt.Errorf("parse reviewId: %w", err)
+		return rest.UploadResult{}, err
 	}
 
-	c.log.InfoContext(ctx, "uploaded review", "reviewId", reviewID)
+	c.log.InfoContext(ctx, "uploaded review", "reviewId", result.ReviewID, "suppressed", len(result.Suppressed), "suppressedByRule", len(result.SuppressedByRule))
 
-	return reviewID, nil
+	return result, nil
+}
+
+// parseUploadResult reads the upload answer: an UploadResult, or the bare
+// reviewId of servers that predate it.
+func parseUploadResult(body []byte) (rest.UploadResult, error) {
+	var result rest.UploadResult
+	if trimmed := bytes.TrimSpace(body); bytes.HasPrefix(trimmed, []byte("{")) {
+		if err := json.Unmarshal(trimmed, &result); err != nil {
+			return rest.UploadResult{}, fmt.Errorf("parse upload result: %w", err)
+		}
+		return result, nil
+	}
+
+	reviewID, err := strconv.Atoi(strings.TrimSpace(string(body)))
+	if err != nil {
+		return rest.UploadResult{}, fmt.Errorf("parse reviewId: %w", err)
+	}
+	result.ReviewID = reviewID
+	return result, nil
 }
 
 // UploadFile uploads a single review file (markdown content).
@@ -112,23 +133,49 @@ func (c *UploadClient) UploadFile(ctx context.Context, serverURL, projectKey str
 }
 
 // UploadAll uploads review.json and all R*.md files.
-func (c *UploadClient) UploadAll(ctx context.Context, serverURL, projectKey string, draft *rest.ReviewDraft, mdFiles map[string]string) (int, error) {
-	reviewID, err := c.UploadReview(ctx, serverURL, projectKey, draft)
+func (c *UploadClient) UploadAll(ctx context.Context, serverURL, projectKey string, draft *rest.ReviewDraft, mdFiles map[string]string) (rest.UploadResult, error) {
+	result, err := c.UploadReview(ctx, serverURL, projectKey, draft)
 	if err != nil {
-		return 0, err
+		return rest.UploadResult{}, err
 	}
 
 	for reviewType, filePath := range mdFiles {
 		content, err := os.ReadFile(filePath)
 		if err != nil {
-			return reviewID, fmt.Errorf("read %s: %w", filePath, err)
+			return result, fmt.Errorf("read %s: %w", filePath, err)
 		}
-		if err := c.UploadFile(ctx, serverURL, projectKey, reviewID, reviewType, content); err != nil {
-			return reviewID, err
+		if err := c.UploadFile(ctx, serverURL, projectKey, result.ReviewID, reviewType, content); err != nil {
+			return result, err
 		}
 	}
 
-	return reviewID, nil
+	return result, nil
+}
+
+// withoutSuppressed returns draft without the issues the server suppressed as
+// accepted risks or by suppression rules, so they are not posted to the MR;
+// draft itself is untouched.
+func withoutSuppressed(draft *rest.ReviewDraft, suppressed ...map[string]int) *rest.ReviewDraft {
+	isSuppressed := func(localID string) bool {
+		for _, m := range suppressed {
+			if _, ok := m[localID]; ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	out := *draft
+	out.Issues = make([]rest.ReviewDraftIssue, 0, len(draft.Issues))
+	for _, iss := range draft.Issues {
+		if !isSuppressed(iss.LocalID) {
+			out.Issues = append(out.Issues, iss)
+		}
+	}
+	if len(out.Issues) == len(draft.Issues) {
+		return draft
+	}
+	return &out
 }
 
 // ReadReviewJSON reads and validates review.json from the given directory.
@@ -262,7 +309,7 @@ func buildDebugMultipart(meta DebugMeta, files map[string][]byte) (io.Reader, st
 // directory (the R*.md bodies are matched separately by FindMDFiles). Shared by
 // CollectDebugArtifacts (read for the bundle) and CleanReviewArtifacts (wiped
 // before a run) so the set stays in one place.
-var reviewArtifactFiles = []string{"claude-output.json", "opencode-output.jsonl", "direct-output.jsonl", "review.json"}
+var reviewArtifactFiles = []string{"claude-output.json", "opencode-output.jsonl", "direct-output.jsonl", "direct-cassette.jsonl", "review.json"}
 
 // CollectDebugArtifacts reads the artifacts that reviewctl writes during a run.
 // Missing files are silently skipped — the caller wants whatever is on disk.
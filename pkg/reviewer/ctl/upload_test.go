@@ -40,9 +40,40 @@ func TestUploadReview(t *testing.T) {
 	require.NoError(t, err)
 
 	c := NewUploadClient(slog.Default())
-	reviewID, err := c.UploadReview(context.Background(), srv.URL, "test-key", draft)
+	result, err := c.UploadReview(context.Background(), srv.URL, "test-key", draft)
 	require.NoError(t, err)
-	assert.Equal(t, 42, reviewID)
+	assert.Equal(t, rest.UploadResult{ReviewID: 42}, result)
+}
+
+func TestUploadReview_Suppressed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"reviewId":42,"suppressed":{"C1":7}}`))
+	}))
+	defer srv.Close()
+
+	draft, err := ReadReviewJSON("testdata")
+	require.NoError(t, err)
+
+	c := NewUploadClient(slog.Default())
+	result, err := c.UploadReview(context.Background(), srv.URL, "test-key", draft)
+	require.NoError(t, err)
+	assert.Equal(t, 42, result.ReviewID)
+	assert.Equal(t, map[string]int{"C1": 7}, result.Suppressed)
+}
+
+func TestWithoutSuppressed(t *testing.T) {
+	draft := &rest.ReviewDraft{Issues: []rest.ReviewDraftIssue{{LocalID: "C1"}, {LocalID: "C2"}, {LocalID: "S1"}}}
+
+	assert.Same(t, draft, withoutSuppressed(draft, nil))
+
+	got := withoutSuppressed(draft, map[string]int{"C2": 7})
+	assert.Equal(t, []rest.ReviewDraftIssue{{LocalID: "C1"}, {LocalID: "S1"}}, got.Issues)
+	assert.Len(t, draft.Issues, 3, "original draft untouched")
+
+	got = withoutSuppressed(draft, map[string]int{"C2": 7}, map[string]int{"S1": 3})
+	assert.Equal(t, []rest.ReviewDraftIssue{{LocalID: "C1"}}, got.Issues)
 }
 
 func TestUploadFile(t *testing.T) {
@@ -0,0 +1,121 @@
+package ctl
+
+import (
+	"context"
+	"fmt"
+
+	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/rest"
+	"reviewsrv/pkg/reviewer/direct"
+)
+
+// WithVerifier enables the verifier pass: after the review each issue is
+// re-checked by v before upload. dropRejected removes rejected issues instead
+// of flagging them.
+func (c *Controller) WithVerifier(v *direct.Verifier, dropRejected bool) *Controller {
+	c.verifier = v
+	c.dropRejected = dropRejected
+	return c
+}
+
+// verifyIssues runs the verifier pass over draft's issues, applies the
+// verdicts and adds the pass's spend to ModelInfo. Best-effort: an issue the
+// verifier could not check is kept as the reviewer wrote it.
+func (c *Controller) verifyIssues(ctx context.Context, draft *rest.ReviewDraft) {
+	if c.verifier == nil || len(draft.Issues) == 0 {
+		return
+	}
+	res := c.verifier.Verify(ctx, draft.Issues)
+	for _, v := range res.Verdicts {
+		if v.Err != nil {
+			c.log.WarnContext(ctx, "verifier: issue not verified", "localId", v.LocalID, "err", v.Err)
+		}
+	}
+
+	stats := applyVerdicts(draft, res.Verdicts, c.dropRejected)
+	stats.Model = res.Model
+	addVerifierSpend(&draft.Review.ModelInfo, res)
+	draft.Review.ModelInfo.Verifier = &stats
+
+	c.log.InfoContext(ctx, "verifier pass done", "model", res.Model,
+		"confirmed", stats.Confirmed, "downgraded", stats.Downgraded, "rejected", stats.Rejected,
+		"dropped", stats.Dropped, "failed", stats.Failed, "costUsd", res.CostUsd)
+}
+
+// applyVerdicts applies verdicts (matched to draft.Issues by position) in
+// place: every checked issue records its verdict in VerifierVerdict, a
+// downgrade lowers the severity and a reject drops the issue (dropRejected) or
+// keeps it flagged; both leave the verifier's reason in the description. Titles
+// stay as written, so fingerprints match across versions. The R*.md bodies are
+// left as the reviewer wrote them.
+func applyVerdicts(draft *rest.ReviewDraft, verdicts []direct.Verdict, dropRejected bool) db.VerifierStats {
+	var stats db.VerifierStats
+	kept := draft.Issues[:0]
+	for i, iss := range draft.Issues {
+		if i >= len(verdicts) {
+			kept = append(kept, iss)
+			continue
+		}
+		v := verdicts[i]
+		switch {
+		case v.Err != nil:
+			stats.Failed++
+			kept = append(kept, iss)
+			continue
+		case v.Verdict == direct.VerdictDowngrade:
+			stats.Downgraded++
+			iss.Description = withVerifierNote(iss.Description, fmt.Sprintf("severity lowered from %s: %s", iss.Severity, v.Reason))
+			iss.Severity = v.Severity
+		case v.Verdict == direct.VerdictReject:
+			stats.Rejected++
+			if dropRejected {
+				stats.Dropped++
+				continue
+			}
+			iss.Description = withVerifierNote(iss.Description, "rejected: "+v.Reason)
+		default:
+			stats.Confirmed++
+		}
+		iss.VerifierVerdict = v.Verdict
+		kept = append(kept, iss)
+	}
+	draft.Issues = kept
+	return stats
+}
+
+func withVerifierNote(description, note string) string {
+	if description == "" {
+		return "Verifier: " + note
+	}
+	return description + "\n\nVerifier: " + note
+}
+
+// addVerifierSpend adds the pass's tokens and cost to mi, with a per-model
+// entry for the verifier. A primary run without a per-model breakdown gets
+// one first, so Models keeps adding up to the totals.
+func addVerifierSpend(mi *db.ReviewModelInfo, res *direct.VerifyResult) {
+	if len(mi.Models) == 0 && mi.Model != "" {
+		mi.Models = map[string]db.ModelUseStats{mi.Model: {
+			InputTokens:              mi.InputTokens,
+			OutputTokens:             mi.OutputTokens,
+			CacheReadInputTokens:     mi.CacheReadInputTokens,
+			CacheCreationInputTokens: mi.CacheCreationInputTokens,
+			CostUsd:                  mi.CostUsd,
+		}}
+	}
+	mi.Add(db.ReviewModelInfo{
+		InputTokens:              res.Usage.InputTokens,
+		OutputTokens:             res.Usage.OutputTokens,
+		CacheReadInputTokens:     res.Usage.CacheReadTokens,
+		CacheCreationInputTokens: res.Usage.CacheWriteTokens,
+		CostUsd:                  res.CostUsd,
+		DurationAPIMs:            res.DurationAPIMs,
+		Models: map[string]db.ModelUseStats{res.Model: {
+			InputTokens:              res.Usage.InputTokens,
+			OutputTokens:             res.Usage.OutputTokens,
+			CacheReadInputTokens:     res.Usage.CacheReadTokens,
+			CacheCreationInputTokens: res.Usage.CacheWriteTokens,
+			CostUsd:                  res.CostUsd,
+		}},
+	})
+}
@@ -0,0 +1,66 @@
+package ctl
+
+import (
+	"errors"
+	"testing"
+
+	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/rest"
+	"reviewsrv/pkg/reviewer/direct"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func verifierDraft() *rest.ReviewDraft {
+	return &rest.ReviewDraft{Issues: []rest.ReviewDraftIssue{
+		{LocalID: "C1", Severity: "high", Title: "kept"},
+		{LocalID: "C2", Severity: "high", Title: "lowered", Description: "d"},
+		{LocalID: "C3", Severity: "medium", Title: "wrong"},
+		{LocalID: "C4", Severity: "low", Title: "unchecked"},
+	}}
+}
+
+var testVerdicts = []direct.Verdict{
+	{LocalID: "C1", Verdict: direct.VerdictConfirm},
+	{LocalID: "C2", Verdict: direct.VerdictDowngrade, Severity: "low", Reason: "minor"},
+	{LocalID: "C3", Verdict: direct.VerdictReject, Reason: "handled"},
+	{LocalID: "C4", Verdict: direct.VerdictConfirm, Err: errors.New("timeout")},
+}
+
+func TestApplyVerdicts(t *testing.T) {
+	draft := verifierDraft()
+	stats := applyVerdicts(draft, testVerdicts, false)
+	assert.Equal(t, db.VerifierStats{Confirmed: 1, Downgraded: 1, Rejected: 1, Failed: 1}, stats)
+	require.Len(t, draft.Issues, 4)
+	assert.Equal(t, "low", draft.Issues[1].Severity)
+	assert.Equal(t, "d\n\nVerifier: severity lowered from high: minor", draft.Issues[1].Description)
+	assert.Equal(t, "wrong", draft.Issues[2].Title, "a rejected issue keeps its title")
+	assert.Equal(t, direct.VerdictReject, draft.Issues[2].VerifierVerdict)
+	assert.Equal(t, direct.VerdictDowngrade, draft.Issues[1].VerifierVerdict)
+	assert.Equal(t, direct.VerdictConfirm, draft.Issues[0].VerifierVerdict)
+	assert.Equal(t, "Verifier: rejected: handled", draft.Issues[2].Description)
+	assert.Equal(t, verifierDraft().Issues[3], draft.Issues[3], "unverified issue untouched")
+
+	draft = verifierDraft()
+	stats = applyVerdicts(draft, testVerdicts, true)
+	assert.Equal(t, 1, stats.Dropped)
+	require.Len(t, draft.Issues, 3)
+	assert.Equal(t, "C4", draft.Issues[2].LocalID)
+}
+
+func TestAddVerifierSpend(t *testing.T) {
+	mi := db.ReviewModelInfo{Model: "opus", InputTokens: 1000, OutputTokens: 100, CostUsd: 1}
+	addVerifierSpend(&mi, &direct.VerifyResult{
+		Model:   "cheap",
+		Usage:   direct.Usage{InputTokens: 200, OutputTokens: 20},
+		CostUsd: 0.01,
+	})
+	assert.Equal(t, 1200, mi.InputTokens)
+	assert.InDelta(t, 1.01, mi.CostUsd, 1e-9)
+	assert.Equal(t, "opus", mi.Model)
+	assert.Equal(t, map[string]db.ModelUseStats{
+		"opus":  {InputTokens: 1000, OutputTokens: 100, CostUsd: 1},
+		"cheap": {InputTokens: 200, OutputTokens: 20, CostUsd: 0.01},
+	}, mi.Models)
+}
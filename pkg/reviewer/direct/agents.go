@@ -0,0 +1,420 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Agent names of the fixed loops of a sub-agent run; sub-agents are named by
+// their task.
+const (
+	agentPlanner = "planner"
+	agentLead    = "lead"
+)
+
+// leadBriefClip bounds the merged-review brief handed to the lead loop.
+const leadBriefClip = 60_000
+
+// AgentOptions tunes a sub-agent run (RunAgents).
+type AgentOptions struct {
+	// MaxAgents caps how many tasks the planner may create.
+	MaxAgents int
+	// Parallel is how many sub-agent loops run at once.
+	Parallel int
+	// PlannerRounds and AgentRounds are the MaxRounds of the planner and of
+	// each sub-agent; the lead loop uses Options.MaxRounds.
+	PlannerRounds int
+	AgentRounds   int
+	// AgentTokens is each sub-agent's token budget (Options.MaxTokens); zero
+	// is unlimited.
+	AgentTokens int
+}
+
+// DefaultAgentOptions returns the sub-agent run defaults: at most five tasks
+// (one per review group), three at a time.
+func DefaultAgentOptions() AgentOptions {
+	return AgentOptions{MaxAgents: len(reviewTypes), Parallel: 3, PlannerRounds: 8, AgentRounds: 30}
+}
+
+// AgentUsage is one loop's share of a sub-agent run.
+type AgentUsage struct {
+	Name          string
+	Rounds        int
+	StopReason    string
+	Usage         Usage
+	CostUsd       float64
+	DurationAPIMs int
+}
+
+// agentTask is one slice of the review as planned: the groups it owns and,
+// for a per-package split, the paths it covers.
+type agentTask struct {
+	Name         string   `json:"name"`
+	ReviewTypes  []string `json:"reviewTypes"`
+	Paths        []string `json:"paths,omitempty"`
+	Instructions string   `json:"instructions,omitempty"`
+}
+
+// agentRun is the state of one RunAgents call.
+type agentRun struct {
+	p          LLMProvider
+	cfg        ReviewToolsConfig
+	st         *toolState
+	userPrompt string
+	opts       Options
+	ao         AgentOptions
+
+	sinkMu sync.Mutex
+	mu     sync.Mutex
+	agents []AgentUsage
+}
+
+// RunAgents reviews with a hierarchy of loops instead of one. A planner splits
+// the task by review group or by package; the sub-agents then review their
+// slices concurrently (at most ao.Parallel at once), each with its own registry
+// view, read-dedup and budget. Their set_group/add_issues output is merged into
+// one reviewBuilder, and a lead loop checks it, fills any gap and calls
+// submit_review. Usage of every loop is summed into the Result, with the
+// per-loop breakdown in Result.Agents.
+func RunAgents(ctx context.Context, p LLMProvider, cfg ReviewToolsConfig, userPrompt string, opts Options, ao AgentOptions) (*Result, error) {
+	def := DefaultAgentOptions()
+	ao.MaxAgents = positiveOr(ao.MaxAgents, def.MaxAgents)
+	ao.Parallel = positiveOr(ao.Parallel, def.Parallel)
+	ao.PlannerRounds = positiveOr(ao.PlannerRounds, def.PlannerRounds)
+	ao.AgentRounds = positiveOr(ao.AgentRounds, def.AgentRounds)
+	r := &agentRun{p: p, cfg: cfg, st: newToolState(cfg), userPrompt: userPrompt, opts: opts, ao: ao}
+
+	tasks := r.plan(ctx)
+	if err := ctx.Err(); err != nil {
+		return r.result(nil), err
+	}
+	shared := newReviewBuilder()
+	notes := r.runTasks(ctx, tasks, shared)
+	if err := ctx.Err(); err != nil {
+		return r.result(nil), err
+	}
+
+	reg := newReviewRegistry(cfg, r.st, shared)
+	res, err := Run(ctx, p, reg, SystemPrompt, userPrompt+"\n\n"+leadBrief(shared, tasks, notes), r.options(agentLead))
+	r.record(agentLead, res)
+	return r.result(res), err
+}
+
+// positiveOr returns v, or def when v is not positive.
+func positiveOr[T int | time.Duration](v, def T) T {
+	if v > 0 {
+		return v
+	}
+	return def
+}
+
+// options derives a loop's Options: the run's, with events tagged by agent and
+// serialised, since loops run concurrently.
+func (r *agentRun) options(agent string) Options {
+	o := r.opts
+	if s := r.opts.OnEvent; s != nil {
+		o.OnEvent = func(ev Event) {
+			ev.Agent = agent
+			r.sinkMu.Lock()
+			defer r.sinkMu.Unlock()
+			s(ev)
+		}
+	}
+	return o
+}
+
+// record adds a finished loop to the per-agent breakdown.
+func (r *agentRun) record(name string, res *Result) {
+	if res == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.agents = append(r.agents, AgentUsage{Name: name, Rounds: res.Rounds, StopReason: res.StopReason, Usage: res.Usage, CostUsd: res.CostUsd, DurationAPIMs: res.DurationAPIMs})
+}
+
+// result sums every loop's usage; submission and stop reason are the lead's
+// (nil when the run stopped before it).
+func (r *agentRun) result(lead *Result) *Result {
+	r.mu.Lock()
+	agents := slices.Clone(r.agents)
+	r.mu.Unlock()
+
+	res := &Result{Model: r.p.Model(), StopReason: "cancelled", Agents: agents}
+	if lead != nil {
+		res.StopReason, res.Submitted = lead.StopReason, lead.Submitted
+	}
+	for _, a := range agents {
+		res.Usage = sumUsage(res.Usage, a.Usage)
+		res.Rounds += a.Rounds
+		res.DurationAPIMs += a.DurationAPIMs
+	}
+	res.CostUsd = computeCost(res.Usage, r.p.Pricing())
+	res.Tools = r.st.meter.snapshot()
+	r.opts.OnEvent.emit(Event{Kind: "result", Rounds: res.Rounds, Usage: &res.Usage, StopReason: res.StopReason, CostUsd: res.CostUsd, Submitted: res.Submitted, Model: res.Model, Tools: res.Tools})
+	return res
+}
+
+// plan runs the planner loop. When it fails or never calls plan_review, the
+// review falls back to one task per group.
+func (r *agentRun) plan(ctx context.Context) []agentTask {
+	var (
+		mu    sync.Mutex
+		tasks []agentTask
+	)
+	reg := NewRegistry()
+	registerExploreTools(reg, r.cfg, r.st)
+	reg.Register(planReviewTool(reg, r.ao.MaxAgents, func(t []agentTask) {
+		mu.Lock()
+		defer mu.Unlock()
+		tasks = t
+	}))
+	o := r.options(agentPlanner)
+	o.MaxRounds = r.ao.PlannerRounds
+	res, err := Run(ctx, r.p, reg, PlannerPrompt, r.userPrompt, o)
+	r.record(agentPlanner, res)
+
+	mu.Lock()
+	defer mu.Unlock()
+	ev := Event{Kind: "plan"}
+	if len(tasks) == 0 {
+		tasks = groupPlan()
+		ev.IsError = true
+		ev.Content = "planner produced no plan; reviewing one group per agent"
+		if err != nil {
+			ev.Content += ": " + err.Error()
+		}
+	}
+	data, _ := json.Marshal(tasks)
+	ev.Text = string(data)
+	r.options(agentPlanner).OnEvent.emit(ev)
+	return tasks
+}
+
+// groupPlan is the default split: one task per review group.
+func groupPlan() []agentTask {
+	out := make([]agentTask, 0, len(reviewTypes))
+	for _, rt := range reviewTypes {
+		out = append(out, agentTask{Name: rt, ReviewTypes: []string{rt}})
+	}
+	return out
+}
+
+// runTasks runs one sub-agent per task, at most ao.Parallel at once, and merges
+// their output into shared in plan order, so localId renumbering is
+// deterministic. A sub-agent that fails or runs out of budget still
+// contributes what it set; the lead fills the gaps. Returns each agent's
+// finish_task note.
+func (r *agentRun) runTasks(ctx context.Context, tasks []agentTask, shared *reviewBuilder) []string {
+	builders := make([]*reviewBuilder, len(tasks))
+	notes := make([]string, len(tasks))
+	sem := make(chan struct{}, r.ao.Parallel)
+	var wg sync.WaitGroup
+	for i, t := range tasks {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			b := newReviewBuilder()
+			reg := NewRegistry()
+			registerExploreTools(reg, r.cfg, r.st)
+			reg.Register(setGroupTool(b, t.ReviewTypes...))
+			reg.Register(addIssuesTool(b, t.ReviewTypes...))
+			reg.Register(finishTaskTool(reg, b, t.ReviewTypes, &notes[i]))
+			o := r.options(t.Name)
+			o.MaxRounds = r.ao.AgentRounds
+			o.MaxTokens = r.ao.AgentTokens
+			res, _ := Run(ctx, r.p, reg, AgentPrompt, r.userPrompt+"\n\n"+taskBrief(t, tasks), o)
+			r.record(t.Name, res)
+			builders[i] = b
+		})
+	}
+	wg.Wait()
+	for _, b := range builders {
+		if b != nil {
+			shared.mergeFrom(b)
+		}
+	}
+	return notes
+}
+
+// planReviewTool is the planner's terminal tool: it validates the split and
+// hands it to set.
+func planReviewTool(reg *Registry, maxAgents int, set func([]agentTask)) (ToolDef, Handler) {
+	def := ToolDef{
+		Name: "plan_review",
+		Description: fmt.Sprintf("Submit the review plan (ends planning): 1..%d tasks for parallel reviewer agents. "+
+			"Every review group must be covered by at least one task.", maxAgents),
+		Schema: objSchema(map[string]any{
+			"tasks": arrayOf(objSchema(map[string]any{
+				"name":         strProp("Short unique task name"),
+				"reviewTypes":  arrayOf(reviewTypeProp()),
+				"paths":        arrayOfDesc(primProp(jsString, ""), "Optional: the packages/directories this task covers (per-package split)"),
+				"instructions": strProp("What this agent should focus on"),
+			}, "name", "reviewTypes")),
+		}, "tasks"),
+	}
+	h := func(_ context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			Tasks []agentTask `json:"tasks"`
+		}
+		if err := json.Unmarshal(unwrapJSON(raw), &a); err != nil {
+			return "", fmt.Errorf("plan_review: bad arguments: %w", err)
+		}
+		if err := validatePlan(a.Tasks, maxAgents); err != nil {
+			return "", fmt.Errorf("plan_review: %w (fix and call plan_review again)", err)
+		}
+		set(a.Tasks)
+		reg.markSubmitted()
+		return fmt.Sprintf("plan accepted: %d tasks", len(a.Tasks)), nil
+	}
+	return def, h
+}
+
+// validatePlan checks task count, names, groups and paths, and that every
+// review group is covered.
+func validatePlan(tasks []agentTask, maxAgents int) error {
+	if len(tasks) == 0 || len(tasks) > maxAgents {
+		return fmt.Errorf("want 1..%d tasks, got %d", maxAgents, len(tasks))
+	}
+	names := make(map[string]bool, len(tasks))
+	covered := make(map[string]bool, len(reviewTypes))
+	for i, t := range tasks {
+		name := strings.TrimSpace(t.Name)
+		if name == "" || names[name] {
+			return fmt.Errorf("task %d: name %q is empty or not unique", i, t.Name)
+		}
+		names[name] = true
+		if len(t.ReviewTypes) == 0 {
+			return fmt.Errorf("task %q: no reviewTypes", name)
+		}
+		for _, rt := range t.ReviewTypes {
+			if !slices.Contains(reviewTypes, rt) {
+				return fmt.Errorf("task %q: invalid reviewType %q", name, rt)
+			}
+			covered[rt] = true
+		}
+		for _, p := range t.Paths {
+			if !validPath(p) {
+				return fmt.Errorf("task %q: invalid path %q", name, p)
+			}
+		}
+	}
+	var missing []string
+	for _, rt := range reviewTypes {
+		if !covered[rt] {
+			missing = append(missing, rt)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("groups not covered by any task: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// finishTaskTool is a sub-agent's terminal tool: it succeeds once every group
+// of the slice is set and every finding has an issue, and stores the agent's
+// note for the lead.
+func finishTaskTool(reg *Registry, b *reviewBuilder, types []string, note *string) (ToolDef, Handler) {
+	def := ToolDef{
+		Name:        "finish_task",
+		Description: "Finish your slice of the review (ends your run). Rejected while one of your groups is missing or findings lack issues.",
+		Schema: objSchema(map[string]any{
+			"note": strProp("One line for the lead reviewer: what you covered, anything left open"),
+		}),
+	}
+	h := func(_ context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			Note string `json:"note"`
+		}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &a); err != nil {
+				return "", fmt.Errorf("finish_task: bad arguments: %w", err)
+			}
+		}
+		groups, issues := b.snapshot()
+		if err := checkGroups("finish_task", types, groups, issues); err != nil {
+			return "", err
+		}
+		*note = a.Note
+		reg.markSubmitted()
+		return "slice finished", nil
+	}
+	return def, h
+}
+
+// taskBrief is the kickoff section naming a sub-agent's slice and the others'.
+func taskBrief(t agentTask, all []agentTask) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Твоя часть ревью: %s\n", t.Name)
+	fmt.Fprintf(&b, "Группы: %s\n", strings.Join(t.ReviewTypes, ", "))
+	if len(t.Paths) > 0 {
+		fmt.Fprintf(&b, "Пути: %s — остальные изменённые файлы ревьюят другие агенты.\n", strings.Join(t.Paths, ", "))
+	} else {
+		b.WriteString("Пути: все изменённые файлы.\n")
+	}
+	if s := strings.TrimSpace(t.Instructions); s != "" {
+		fmt.Fprintf(&b, "Фокус: %s\n", s)
+	}
+	b.WriteString("\nДругие агенты (их часть не ревьюй):\n")
+	for _, o := range all {
+		if o.Name == t.Name {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s: %s", o.Name, strings.Join(o.ReviewTypes, ", "))
+		if len(o.Paths) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(o.Paths, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// leadBrief is the lead loop's kickoff section: the merged groups and issues,
+// the agents' notes and the groups still missing.
+func leadBrief(b *reviewBuilder, tasks []agentTask, notes []string) string {
+	groups, issues := b.snapshot()
+	var sb strings.Builder
+	sb.WriteString("## Результаты агентов\n")
+	sb.WriteString("Ревью выполнено параллельно несколькими агентами; их группы и замечания уже внесены — ")
+	sb.WriteString("не повторяй set_group/add_issues для них. Проверь согласованность и дубли между группами; ")
+	sb.WriteString("исправить группу можно через set_group (она заменяется целиком). Затем вызови submit_review ")
+	sb.WriteString("с общим вердиктом.\n\n")
+
+	var missing []string
+	for _, rt := range reviewTypes {
+		if strings.TrimSpace(groups[rt].markdown) == "" {
+			missing = append(missing, rt)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(&sb, "НЕ заполнены (агент не справился) — отревьюй и заполни сам: %s\n\n", strings.Join(missing, ", "))
+	}
+	for i, t := range tasks {
+		if n := strings.TrimSpace(notes[i]); n != "" {
+			fmt.Fprintf(&sb, "- %s: %s\n", t.Name, n)
+		}
+	}
+
+	sb.WriteString("\n### Группы\n")
+	for _, rt := range reviewTypes {
+		g, ok := groups[rt]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&sb, "#### %s (isAccepted=%t): %s\n%s\n\n", rt, g.isAccepted, g.summary, g.markdown)
+	}
+	fmt.Fprintf(&sb, "### Замечания (%d)\n", len(issues))
+	for _, iss := range issues {
+		fmt.Fprintf(&sb, "- %s [%s/%s] %s — %s\n", iss.LocalID, iss.FileType, iss.Severity, iss.Title, iss.File)
+	}
+	return clipN(sb.String(), leadBriefClip)
+}
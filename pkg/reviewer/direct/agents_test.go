@@ -0,0 +1,206 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"reviewsrv/pkg/rest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// agentProvider plays every role of a sub-agent run, routed by system prompt:
+// the planner submits plan (or only talks when plan is nil), each sub-agent sets
+// its groups with one "C1" finding and finishes, the lead submits.
+type agentProvider struct {
+	plan json.RawMessage
+
+	mu       sync.Mutex
+	leadSeen string
+}
+
+func (p *agentProvider) Model() string    { return "fake-model" }
+func (p *agentProvider) Pricing() Pricing { return Pricing{InputPerMTok: 1, OutputPerMTok: 1} }
+
+func (p *agentProvider) Complete(_ context.Context, req Request) (Response, error) {
+	u := Usage{InputTokens: 10, OutputTokens: 1}
+	last := req.Messages[len(req.Messages)-1]
+	switch req.System {
+	case PlannerPrompt:
+		if p.plan == nil {
+			return Response{Text: "thinking about it", Usage: u}, nil
+		}
+		return Response{ToolCalls: []ToolCall{{ID: "p", Name: "plan_review", Args: p.plan}}, Usage: u}, nil
+	case AgentPrompt:
+		if last.Role == RoleTool {
+			return Response{ToolCalls: []ToolCall{{ID: "f", Name: "finish_task", Args: json.RawMessage(`{"note":"done"}`)}}, Usage: u}, nil
+		}
+		return Response{ToolCalls: sliceCalls(req.Messages[0].Text), Usage: u}, nil
+	default:
+		p.mu.Lock()
+		p.leadSeen = req.Messages[0].Text
+		p.mu.Unlock()
+		args := json.RawMessage(`{"review":{"description":"merged","effortMinutes":5,"aiSlopScore":0.1}}`)
+		return Response{ToolCalls: []ToolCall{{ID: "s", Name: toolSubmitReview, Args: args}}, Usage: u}, nil
+	}
+}
+
+// sliceCalls answers a sub-agent's kickoff: set_group for each of its groups
+// (the first one with finding C1) and the matching issue.
+func sliceCalls(kickoff string) []ToolCall {
+	_, rest, _ := strings.Cut(kickoff, "Группы: ")
+	line, _, _ := strings.Cut(rest, "\n")
+	types := strings.Split(line, ", ")
+	var calls []ToolCall
+	for i, rt := range types {
+		md := "nothing in " + rt
+		if i == 0 {
+			md = "### C1. finding in " + rt
+		}
+		args, _ := json.Marshal(map[string]any{fReviewType: rt, fSummary: "sum " + rt, fIsAccepted: i != 0, fMarkdown: md})
+		calls = append(calls, ToolCall{ID: "g" + rt, Name: "set_group", Args: args})
+	}
+	issue, _ := json.Marshal(map[string]any{fIssues: []map[string]any{{
+		fLocalID: "C1", fSeverity: "high", fTitle: "finding in " + types[0], fDescription: "d",
+		fFile: "main.go", "lines": "1", "issueType": "logic", fFileType: types[0],
+	}}})
+	return append(calls, ToolCall{ID: "i", Name: "add_issues", Args: issue})
+}
+
+func TestRunAgentsMergesSlices(t *testing.T) {
+	dir := t.TempDir()
+	prov := &agentProvider{plan: json.RawMessage(`{"tasks":[
+		{"name":"design","reviewTypes":["architecture","code"],"instructions":"layering"},
+		{"name":"safety","reviewTypes":["security","tests","operability"]}]}`)}
+	var mu sync.Mutex
+	agents := map[string]bool{}
+	opts := Options{MaxRounds: 5, OnEvent: func(ev Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		agents[ev.Agent] = true
+	}}
+
+	res, err := RunAgents(context.Background(), prov, ReviewToolsConfig{Dir: dir}, "review this", opts, AgentOptions{})
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+	require.Equal(t, "submitted", res.StopReason)
+
+	// planner 1 round, each agent 2, lead 1 — all summed into the result.
+	require.Len(t, res.Agents, 4)
+	require.Equal(t, 6, res.Rounds)
+	require.Equal(t, Usage{InputTokens: 60, OutputTokens: 6}, res.Usage)
+	require.InDelta(t, 66e-6, res.CostUsd, 1e-12)
+	require.Equal(t, map[string]bool{"": true, agentPlanner: true, "design": true, "safety": true, agentLead: true}, agents)
+
+	// Both agents used C1; the second is renumbered in markdown and issue alike.
+	require.Contains(t, prov.leadSeen, "#### architecture (isAccepted=false): sum architecture\n### C1. finding in architecture")
+	require.Contains(t, prov.leadSeen, "### C2. finding in security")
+	require.Contains(t, prov.leadSeen, "- design: done")
+
+	data, err := os.ReadFile(filepath.Join(dir, "review.json"))
+	require.NoError(t, err)
+	var draft rest.ReviewDraft
+	require.NoError(t, json.Unmarshal(data, &draft))
+	require.Len(t, draft.Files, len(reviewTypes))
+	require.Len(t, draft.Issues, 2)
+	require.Equal(t, "C1", draft.Issues[0].LocalID)
+	require.Equal(t, "C2", draft.Issues[1].LocalID)
+	require.Equal(t, "security", draft.Issues[1].FileType)
+}
+
+func TestRunAgentsFallsBackToGroupPlan(t *testing.T) {
+	dir := t.TempDir()
+	var plan Event
+	opts := Options{MaxRounds: 5, OnEvent: func(ev Event) {
+		if ev.Kind == "plan" {
+			plan = ev
+		}
+	}}
+	res, err := RunAgents(context.Background(), &agentProvider{}, ReviewToolsConfig{Dir: dir}, "review this", opts, AgentOptions{Parallel: 2})
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+	require.True(t, plan.IsError)
+	require.Equal(t, agentPlanner, plan.Agent)
+	require.Len(t, res.Agents, 2+len(reviewTypes), "planner, one agent per group, lead")
+}
+
+func TestValidatePlan(t *testing.T) {
+	all := agentTask{Name: "all", ReviewTypes: reviewTypes}
+	require.NoError(t, validatePlan([]agentTask{all}, 5))
+	require.NoError(t, validatePlan(groupPlan(), 5))
+
+	for name, tasks := range map[string][]agentTask{
+		"empty":     nil,
+		"too many":  append(groupPlan(), agentTask{Name: "extra", ReviewTypes: []string{rtCode}}),
+		"dup name":  {all, all},
+		"bad type":  {{Name: "x", ReviewTypes: []string{"style"}}, all},
+		"bad path":  {{Name: "x", ReviewTypes: reviewTypes, Paths: []string{"../up"}}},
+		"uncovered": {{Name: "x", ReviewTypes: []string{rtCode}}},
+	} {
+		require.Error(t, validatePlan(tasks, 5), name)
+	}
+}
+
+func TestReviewBuilderMergeFrom(t *testing.T) {
+	shared := newReviewBuilder()
+	for i, accepted := range []bool{true, false} {
+		b := newReviewBuilder()
+		b.setGroup(rtCode, fmt.Sprintf("pkg%d", i), accepted, "### C1. one\n\n### C2. two")
+		b.addIssues([]rest.ReviewDraftIssue{{LocalID: "C1"}, {LocalID: "C2"}})
+		shared.mergeFrom(b)
+	}
+	groups, issues := shared.snapshot()
+	g := groups[rtCode]
+	require.Equal(t, "pkg0; pkg1", g.summary)
+	require.False(t, g.isAccepted, "accepted only if every agent accepted")
+	require.Equal(t, "### C1. one\n\n### C2. two\n\n### C3. one\n\n### C4. two", g.markdown)
+	ids := make([]string, 0, len(issues))
+	for _, iss := range issues {
+		ids = append(ids, iss.LocalID)
+	}
+	require.Equal(t, []string{"C1", "C2", "C3", "C4"}, ids)
+}
+
+func TestScopedReviewTools(t *testing.T) {
+	b := newReviewBuilder()
+	_, setGroup := setGroupTool(b, rtCode)
+	_, err := call(t, setGroup, `{"reviewType":"security","summary":"s","isAccepted":true,"markdown":"m"}`)
+	require.ErrorContains(t, err, "allowed: code")
+
+	_, addIssues := addIssuesTool(b, rtCode)
+	_, err = call(t, addIssues, `{"issues":[{"localId":"C1","severity":"high","fileType":"tests"}]}`)
+	require.ErrorContains(t, err, "invalid fileType")
+
+	reg := NewRegistry()
+	var note string
+	_, finish := finishTaskTool(reg, b, []string{rtCode}, &note)
+	_, err = call(t, finish, `{"note":"x"}`)
+	require.ErrorContains(t, err, "finish_task: missing group(s): code")
+	_, err = call(t, setGroup, `{"reviewType":"code","summary":"s","isAccepted":true,"markdown":"m"}`)
+	require.NoError(t, err)
+	_, err = call(t, finish, `{"note":"x"}`)
+	require.NoError(t, err)
+	require.True(t, reg.Submitted())
+	require.Equal(t, "x", note)
+}
+
+func TestRunStopsAtTokenBudget(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644))
+	read := ToolCall{ID: "1", Name: "read_file", Args: json.RawMessage(`{"path":"main.go"}`)}
+	prov := &scriptedProvider{responses: []Response{
+		{ToolCalls: []ToolCall{read}, Usage: Usage{InputTokens: 60, OutputTokens: 10}},
+		{ToolCalls: []ToolCall{read}, Usage: Usage{InputTokens: 60, OutputTokens: 10}},
+		{ToolCalls: []ToolCall{read}, Usage: Usage{InputTokens: 60, OutputTokens: 10}},
+	}}
+	res, err := Run(context.Background(), prov, NewReviewRegistry(ReviewToolsConfig{Dir: dir}), "system", "review this", Options{MaxRounds: 10, MaxTokens: 100})
+	require.ErrorIs(t, err, errBudget)
+	require.Equal(t, "budget", res.StopReason)
+	require.Equal(t, 2, res.Rounds)
+}
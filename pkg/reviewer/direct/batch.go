@@ -0,0 +1,287 @@
+package direct
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBatchPoll is how often a pending batch is checked; batch jobs take
+	// minutes to hours, so there is no point asking more often.
+	defaultBatchPoll = 30 * time.Second
+	// batchDiscount is the batch price relative to the interactive one.
+	batchDiscount = 0.5
+)
+
+// errBatchGone reports a batch that no longer exists or ended without a
+// result (expired, canceled): waiting on it is pointless.
+var errBatchGone = errors.New("batch gone")
+
+// BatchAPI is a provider's asynchronous batch endpoint: a submitted request is
+// answered later, at a discount. BatchProvider drives it one request per job.
+type BatchAPI interface {
+	// SubmitBatch enqueues req and returns the batch ID.
+	SubmitBatch(ctx context.Context, req Request) (string, error)
+	// BatchResult reports whether the batch has ended and, if so, its response.
+	// A batch that is unknown, expired or canceled yields errBatchGone.
+	BatchResult(ctx context.Context, id string) (Response, bool, error)
+}
+
+// BatchHandle is a submitted batch job awaiting its result, keyed by the
+// fingerprint of the request it answers and the endpoint and model it was
+// sent to.
+type BatchHandle struct {
+	ID          string    `json:"id"`
+	Fingerprint string    `json:"fingerprint"`
+	Model       string    `json:"model"`
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// BatchStore persists pending handles as <Dir>/<fingerprint>.json, so a
+// reviewctl that dies while a batch runs picks the same job up on the next
+// run instead of paying for it twice. The store is shared by all runs of a
+// session, whatever their model.
+type BatchStore struct {
+	Dir string
+}
+
+func (st BatchStore) path(fp string) string { return filepath.Join(st.Dir, fp+".json") }
+
+// load returns the pending handle for fp, nil if there is none.
+func (st BatchStore) load(fp string) (*BatchHandle, error) {
+	data, err := os.ReadFile(st.path(fp))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read batch handle: %w", err)
+	}
+	var h BatchHandle
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("decode batch handle %s: %w", fp, err)
+	}
+	return &h, nil
+}
+
+func (st BatchStore) save(h BatchHandle) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(st.Dir, 0o700); err != nil {
+		return fmt.Errorf("batch dir: %w", err)
+	}
+	if err := os.WriteFile(st.path(h.Fingerprint), data, 0o600); err != nil {
+		return fmt.Errorf("write batch handle: %w", err)
+	}
+	return nil
+}
+
+func (st BatchStore) remove(fp string) {
+	_ = os.Remove(st.path(fp))
+}
+
+// BatchOptions configures a BatchProvider.
+type BatchOptions struct {
+	Store BatchStore
+	// Poll is the status check interval; zero = 30s.
+	Poll time.Duration
+	// OnStatus, if set, is told about each job: "submitted", "resumed"
+	// (a stored handle was picked up), "resubmitted" (the stored job was gone)
+	// and "ended".
+	OnStatus func(h BatchHandle, status string)
+}
+
+// BatchProvider is an LLMProvider that answers every Complete through a
+// one-request batch job: submit, persist the handle, poll until it ends. The
+// agent loop runs unchanged on top of it, one batch per round, at the batch
+// price. Responses are not streamed.
+type BatchProvider struct {
+	inner LLMProvider
+	api   BatchAPI
+	opts  BatchOptions
+}
+
+// NewBatchProvider runs p's rounds through api — p's own batch endpoint or a
+// stand-in such as LocalBatch.
+func NewBatchProvider(p LLMProvider, api BatchAPI, opts BatchOptions) *BatchProvider {
+	if opts.Poll <= 0 {
+		opts.Poll = defaultBatchPoll
+	}
+	return &BatchProvider{inner: p, api: api, opts: opts}
+}
+
+func (b *BatchProvider) Model() string       { return b.inner.Model() }
+func (b *BatchProvider) Limits() ModelLimits { return ownLimits(b.inner) }
+func (b *BatchProvider) Unwrap() LLMProvider { return b.inner }
+
+// Pricing is the inner provider's table at the batch discount.
+func (b *BatchProvider) Pricing() Pricing {
+	p := b.inner.Pricing()
+	return Pricing{
+		InputPerMTok:        p.InputPerMTok * batchDiscount,
+		OutputPerMTok:       p.OutputPerMTok * batchDiscount,
+		CacheReadPerMTok:    p.CacheReadPerMTok * batchDiscount,
+		CacheWritePerMTok:   p.CacheWritePerMTok * batchDiscount,
+		CacheWrite1hPerMTok: p.CacheWrite1hPerMTok * batchDiscount,
+	}
+}
+
+// CountTokens forwards to the inner provider when it can count.
+func (b *BatchProvider) CountTokens(ctx context.Context, req Request) (int, error) {
+	if tc, ok := b.inner.(TokenCounter); ok {
+		return tc.CountTokens(ctx, req)
+	}
+	return 0, errors.New("batch: token counting not supported")
+}
+
+// Complete submits req as a batch job, or resumes the stored job for the same
+// request, and waits for its result.
+func (b *BatchProvider) Complete(ctx context.Context, req Request) (Response, error) {
+	fp := b.fingerprint(req)
+	h, err := b.opts.Store.load(fp)
+	if err != nil {
+		return Response{}, err
+	}
+	if h != nil && h.Model != b.inner.Model() {
+		// Another model's job: its answer and its price are not ours.
+		b.opts.Store.remove(fp)
+		h = nil
+	}
+	resumed := h != nil
+	if resumed {
+		b.notify(*h, "resumed")
+	} else if h, err = b.submit(ctx, req, fp); err != nil {
+		return Response{}, err
+	}
+
+	resp, err := b.wait(ctx, h.ID)
+	if errors.Is(err, errBatchGone) && resumed {
+		// The stored job expired or was canceled while nobody waited on it.
+		b.opts.Store.remove(fp)
+		if h, err = b.submit(ctx, req, fp); err != nil {
+			return Response{}, err
+		}
+		b.notify(*h, "resubmitted")
+		resp, err = b.wait(ctx, h.ID)
+	}
+	if err != nil {
+		if !errors.Is(err, ctx.Err()) {
+			b.opts.Store.remove(fp) // a failed job is not worth resuming
+		}
+		return Response{}, fmt.Errorf("batch %s: %w", h.ID, err)
+	}
+	b.opts.Store.remove(fp)
+	b.notify(*h, "ended")
+	return resp, nil
+}
+
+// fingerprint keys the stored handle by the request, the batch endpoint and
+// the model, so runs of the same session with another --model or provider
+// never pick up each other's jobs.
+func (b *BatchProvider) fingerprint(req Request) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%T\x00%s\x00%s", b.api, b.inner.Model(), requestFingerprint(req))))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (b *BatchProvider) submit(ctx context.Context, req Request, fp string) (*BatchHandle, error) {
+	id, err := b.api.SubmitBatch(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("batch: submit: %w", err)
+	}
+	h := &BatchHandle{ID: id, Fingerprint: fp, Model: b.inner.Model(), SubmittedAt: time.Now()}
+	if err := b.opts.Store.save(*h); err != nil {
+		return nil, err
+	}
+	b.notify(*h, "submitted")
+	return h, nil
+}
+
+// wait polls the batch until it ends or ctx is done; a cancelled wait leaves
+// the handle stored for the next run.
+func (b *BatchProvider) wait(ctx context.Context, id string) (Response, error) {
+	t := time.NewTicker(b.opts.Poll)
+	defer t.Stop()
+	for {
+		resp, done, err := b.api.BatchResult(ctx, id)
+		switch {
+		case err != nil:
+			return Response{}, err
+		case done:
+			return resp, nil
+		}
+		select {
+		case <-ctx.Done():
+			return Response{}, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (b *BatchProvider) notify(h BatchHandle, status string) {
+	if b.opts.OnStatus != nil {
+		b.opts.OnStatus(h, status)
+	}
+}
+
+// LocalBatch is a BatchAPI stand-in that answers each job with p.Complete in
+// the background. It lets the batch flow run against any provider — a
+// cassette in --replay, a scripted one in tests — but its jobs live only as
+// long as the process.
+func LocalBatch(p LLMProvider) BatchAPI {
+	return &localBatch{p: p, jobs: map[string]*localJob{}}
+}
+
+type localBatch struct {
+	p LLMProvider
+
+	mu   sync.Mutex
+	seq  int
+	jobs map[string]*localJob
+}
+
+type localJob struct {
+	done chan struct{}
+	resp Response
+	err  error
+}
+
+func (l *localBatch) SubmitBatch(ctx context.Context, req Request) (string, error) {
+	l.mu.Lock()
+	l.seq++
+	id := "local-" + strconv.Itoa(l.seq)
+	job := &localJob{done: make(chan struct{})}
+	l.jobs[id] = job
+	l.mu.Unlock()
+
+	req.OnDelta = nil // batch responses are not streamed
+	go func() {
+		defer close(job.done)
+		job.resp, job.err = l.p.Complete(context.WithoutCancel(ctx), req)
+	}()
+	return id, nil
+}
+
+func (l *localBatch) BatchResult(_ context.Context, id string) (Response, bool, error) {
+	l.mu.Lock()
+	job := l.jobs[id]
+	l.mu.Unlock()
+	if job == nil {
+		return Response{}, false, fmt.Errorf("%w: unknown batch %s", errBatchGone, id)
+	}
+	select {
+	case <-job.done:
+		return job.resp, true, job.err
+	default:
+		return Response{}, false, nil
+	}
+}
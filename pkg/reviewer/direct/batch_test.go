@@ -0,0 +1,120 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchProviderRunsLoop(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644))
+
+	inner := &scriptedProvider{responses: []Response{
+		{ToolCalls: []ToolCall{{ID: "1", Name: "read_file", Args: json.RawMessage(`{"path":"main.go"}`)}}, Usage: Usage{InputTokens: 100}},
+		{ToolCalls: []ToolCall{{ID: "2", Name: "submit_review", Args: validSubmitArgs(t, "high")}}, Usage: Usage{InputTokens: 100}},
+	}}
+	store := BatchStore{Dir: filepath.Join(t.TempDir(), "batches")}
+	var statuses []string
+	prov := NewBatchProvider(inner, LocalBatch(inner), BatchOptions{
+		Store: store,
+		Poll:  time.Millisecond,
+		OnStatus: func(h BatchHandle, status string) {
+			require.NotEmpty(t, h.ID)
+			statuses = append(statuses, status)
+		},
+	})
+
+	reg := NewReviewRegistry(ReviewToolsConfig{Dir: dir})
+	res, err := Run(context.Background(), prov, reg, "system", "review this", Options{MaxRounds: 10})
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+	require.Equal(t, []string{"submitted", "ended", "submitted", "ended"}, statuses)
+	require.InEpsilon(t, 0.0001, res.CostUsd, 1e-9, "200 input tokens at half of $1/MTok")
+
+	// Finished jobs leave no handles behind.
+	left, err := os.ReadDir(store.Dir)
+	require.NoError(t, err)
+	require.Empty(t, left)
+}
+
+func TestBatchProviderResumesStoredHandle(t *testing.T) {
+	inner := &scriptedProvider{responses: []Response{{Text: "first"}, {Text: "second"}}}
+	api := LocalBatch(inner)
+	store := BatchStore{Dir: t.TempDir()}
+	req := Request{System: "system", Messages: []Message{{Role: RoleUser, Text: "review this"}}}
+
+	// A previous run submitted the request and died.
+	id, err := api.SubmitBatch(context.Background(), req)
+	require.NoError(t, err)
+	var statuses []string
+	prov := NewBatchProvider(inner, api, BatchOptions{Store: store, Poll: time.Millisecond,
+		OnStatus: func(_ BatchHandle, status string) { statuses = append(statuses, status) }})
+	fp := prov.fingerprint(req)
+	require.NoError(t, store.save(BatchHandle{ID: id, Fingerprint: fp, Model: "fake-model"}))
+	resp, err := prov.Complete(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.Text)
+	require.Equal(t, []string{"resumed", "ended"}, statuses)
+	require.Len(t, inner.seen, 1, "the stored job is not paid for twice")
+
+	// A stored job the endpoint no longer knows is submitted again.
+	require.NoError(t, store.save(BatchHandle{ID: "local-gone", Fingerprint: fp, Model: "fake-model"}))
+	statuses = nil
+	resp, err = prov.Complete(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.Text)
+	require.Equal(t, []string{"resumed", "submitted", "resubmitted", "ended"}, statuses)
+	h, err := store.load(fp)
+	require.NoError(t, err)
+	require.Nil(t, h)
+}
+
+func TestBatchProviderSkipsOtherModelsHandle(t *testing.T) {
+	req := Request{System: "system", Messages: []Message{{Role: RoleUser, Text: "review this"}}}
+	store := BatchStore{Dir: t.TempDir()}
+
+	// A run under model A submitted the request and died.
+	a := &scriptedProvider{model: "model-a", responses: []Response{{Text: "from a"}}}
+	apiA := LocalBatch(a)
+	id, err := apiA.SubmitBatch(context.Background(), req)
+	require.NoError(t, err)
+	provA := NewBatchProvider(a, apiA, BatchOptions{Store: store})
+	require.NoError(t, store.save(BatchHandle{ID: id, Fingerprint: provA.fingerprint(req), Model: "model-a"}))
+
+	// The next run under model B submits its own job.
+	b := &scriptedProvider{model: "model-b", responses: []Response{{Text: "from b"}}}
+	var statuses []string
+	provB := NewBatchProvider(b, LocalBatch(b), BatchOptions{Store: store, Poll: time.Millisecond,
+		OnStatus: func(_ BatchHandle, status string) { statuses = append(statuses, status) }})
+	require.NotEqual(t, provA.fingerprint(req), provB.fingerprint(req))
+	resp, err := provB.Complete(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "from b", resp.Text)
+	require.Equal(t, []string{"submitted", "ended"}, statuses)
+
+	// Model A's job is still there for a run under model A.
+	h, err := store.load(provA.fingerprint(req))
+	require.NoError(t, err)
+	require.Equal(t, id, h.ID)
+
+	// A handle of another model under our key is dropped, not resumed.
+	require.NoError(t, store.save(BatchHandle{ID: id, Fingerprint: provB.fingerprint(req), Model: "model-a"}))
+	b.responses = append(b.responses, Response{Text: "from b again"})
+	statuses = nil
+	resp, err = provB.Complete(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "from b again", resp.Text)
+	require.Equal(t, []string{"submitted", "ended"}, statuses)
+}
+
+func TestBatchProviderPricing(t *testing.T) {
+	prov := NewBatchProvider(&scriptedProvider{}, nil, BatchOptions{})
+	require.Equal(t, Pricing{InputPerMTok: 0.5, OutputPerMTok: 0.5}, prov.Pricing())
+	require.Equal(t, "fake-model", prov.Model())
+}
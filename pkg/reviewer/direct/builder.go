@@ -11,29 +11,91 @@ type ReviewToolsConfig struct {
 	// files); read-dedup is seeded with them so the model is not re-served their
 	// content.
 	PreloadedPaths []string
+	// Linters are the project-configured analyzers offered via run_linter;
+	// LinterResults seeds its cache with the outputs of a pre-run.
+	Linters       []Linter
+	LinterResults map[string]string
+	// MCP are the connected external MCP servers whose tools are mounted
+	// next to the built-in ones (see ConnectMCP); the caller closes them.
+	MCP *MCPTools
+	// ToolLimits caps individual tools per run (see ParseToolLimits).
+	ToolLimits map[string]ToolLimit
 }
 
 // NewReviewRegistry builds the narrow review tool set: read_file, read_files,
-// glob, grep, git_diff and the terminal submit_review.
+// glob, grep, git_diff, the git history tools (git_log, git_blame, git_show)
+// and the terminal submit_review.
 func NewReviewRegistry(cfg ReviewToolsConfig) *Registry {
+	return newReviewRegistry(cfg, newToolState(cfg), newReviewBuilder())
+}
+
+// newReviewRegistry is NewReviewRegistry over shared tool state and a given
+// builder — the lead loop of a sub-agent run submits what the agents built.
+func newReviewRegistry(cfg ReviewToolsConfig, st *toolState, b *reviewBuilder) *Registry {
 	reg := NewRegistry()
+	registerExploreTools(reg, cfg, st)
+	// Review output: streamed in small pieces (set_group ×5, add_issues) and
+	// finalized by submit_review — a monolithic payload overflows small models'
+	// output cap and arrives as truncated JSON.
+	reg.Register(setGroupTool(b))
+	reg.Register(addIssuesTool(b))
+	reg.Register(submitReviewTool(cfg.Dir, b, reg))
+	return reg
+}
+
+// toolState is per-review tool state shared by every registry view of a run,
+// so sub-agents reuse one Go index and one linter cache instead of rebuilding
+// them, and count tool use against one set of limits. Read-dedup stays per
+// view: each loop has its own context.
+type toolState struct {
+	goIdx *goIndex   // nil outside a Go module
+	lint  *linterSet // nil without configured linters
+	meter *toolMeter
+}
+
+func newToolState(cfg ReviewToolsConfig) *toolState {
+	st := &toolState{meter: newToolMeter(cfg.ToolLimits)}
+	if hasGoModule(cfg.Dir) {
+		st.goIdx = newGoIndex(cfg.Dir)
+	}
+	if len(cfg.Linters) > 0 {
+		st.lint = newLinterSet(cfg.Dir, cfg.DiffBase, cfg.DiffHead, cfg.Linters, cfg.LinterResults)
+	}
+	return st
+}
+
+// registerExploreTools adds the read-only tools every review loop gets — the
+// planner and sub-agents included.
+func registerExploreTools(reg *Registry, cfg ReviewToolsConfig, st *toolState) {
+	reg.useMeter(st.meter)
 	rt := newReadTracker(cfg.PreloadedPaths)
+	reg.onCompact(rt.reset)
 	reg.Register(readFileTool(cfg.Dir, rt))
 	reg.Register(readFilesTool(cfg.Dir, rt))
 	reg.Register(globTool(cfg.Dir))
 	reg.Register(grepTool(cfg.Dir))
 	reg.Register(gitDiffTool(cfg.Dir, cfg.DiffBase, cfg.DiffHead))
+	reg.Register(gitLogTool(cfg.Dir))
+	reg.Register(gitBlameTool(cfg.Dir))
+	reg.Register(gitShowTool(cfg.Dir))
 	// AST-index navigation tools — only when the binary is available; otherwise
 	// the model stays on grep/read (graceful degradation).
 	if astIndexAvailable() {
 		registerAstTools(reg, cfg.Dir, cfg.DiffBase)
 	}
-	// Review output: streamed in small pieces (set_group ×5, add_issues) and
-	// finalized by submit_review — a monolithic payload overflows small models'
-	// output cap and arrives as truncated JSON.
-	b := newReviewBuilder()
-	reg.Register(setGroupTool(b))
-	reg.Register(addIssuesTool(b))
-	reg.Register(submitReviewTool(cfg.Dir, b, reg))
-	return reg
+	// Built-in Go navigation (go/types) — needs no binary, so Go modules always
+	// get precise definition/reference/caller lookup.
+	if st.goIdx != nil {
+		registerGoTools(reg, st.goIdx, cfg.DiffBase, cfg.DiffHead)
+	}
+	// Linters only when the project configured some — the commands come from
+	// configuration, the model merely picks one by name.
+	if st.lint != nil {
+		reg.Register(runLinterTool(st.lint))
+	}
+	// External MCP tools, under their server prefix so they can't shadow a
+	// built-in one.
+	if cfg.MCP != nil {
+		cfg.MCP.register(reg)
+	}
 }
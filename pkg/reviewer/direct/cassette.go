@@ -0,0 +1,322 @@
+package direct
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+// CassetteFile is the recording the direct runner keeps next to its transcript:
+// every provider call of the run, replayable offline with a CassettePlayer.
+const CassetteFile = "direct-cassette.jsonl"
+
+// errCassetteMiss is returned by a strict player when no recorded call matches
+// the request (or the recording is exhausted).
+var errCassetteMiss = errors.New("cassette: no recorded response for request")
+
+// Cassette line kinds.
+const (
+	cassetteHeader = "header"
+	cassetteCall   = "call"
+)
+
+// cassetteLine is one JSONL record: a header with the recorded provider's
+// identity, then one line per Complete call in call order.
+type cassetteLine struct {
+	Kind string `json:"kind"`
+
+	// header
+	Model   string       `json:"model,omitempty"`
+	Pricing *Pricing     `json:"pricing,omitempty"`
+	Limits  *ModelLimits `json:"limits,omitempty"`
+
+	// call
+	Seq         int    `json:"seq,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Messages    int    `json:"messages,omitempty"` // history length, for reading the file
+
+	// Request content as a delta against the previous call: the system prompt
+	// when it changed, and the history from index From on (everything before
+	// From is unchanged). Replaying the deltas in order rebuilds every request.
+	System   string    `json:"system,omitempty"`
+	From     int       `json:"from,omitempty"`
+	Appended []Message `json:"appended,omitempty"`
+
+	Response *cassetteResponse `json:"response,omitempty"`
+	Error    string            `json:"error,omitempty"`
+
+	// rebuilt by LoadCassette
+	system  string
+	history []Message
+}
+
+// cassetteResponse is a Response with its Raw turn serialised.
+type cassetteResponse struct {
+	Text       string     `json:"text,omitempty"`
+	ToolCalls  []ToolCall `json:"toolCalls,omitempty"`
+	Usage      Usage      `json:"usage"`
+	StopReason string     `json:"stopReason,omitempty"`
+	Raw        *rawTurn   `json:"raw,omitempty"`
+}
+
+// requestFingerprint identifies a request by what the model sees: system
+// prompt, effort, tool names and the neutral history. Raw turns are left out —
+// they mirror Text+ToolCalls and carry provider signatures that differ per run.
+func requestFingerprint(req Request) string {
+	tools := make([]string, 0, len(req.Tools))
+	for _, d := range req.Tools {
+		tools = append(tools, d.Name)
+	}
+	data, _ := json.Marshal(struct {
+		System   string    `json:"system"`
+		Effort   string    `json:"effort"`
+		Tools    []string  `json:"tools"`
+		Messages []Message `json:"messages"`
+	}{req.System, req.Effort, tools, req.Messages})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// sameMessage compares messages the way requestFingerprint sees them.
+func sameMessage(a, b Message) bool {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return string(ja) == string(jb)
+}
+
+// commonPrefix returns how many leading messages a and b share.
+func commonPrefix(a, b []Message) int {
+	n := 0
+	for n < len(a) && n < len(b) && sameMessage(a[n], b[n]) {
+		n++
+	}
+	return n
+}
+
+// CassetteRecorder is an LLMProvider that forwards to another provider and
+// appends every request (as a delta against the previous one) and response
+// (or error) to a cassette file.
+type CassetteRecorder struct {
+	inner LLMProvider
+
+	mu     sync.Mutex
+	f      *os.File
+	enc    *json.Encoder
+	seq    int
+	system string
+	prev   []Message
+}
+
+// NewCassetteRecorder wraps inner, writing the cassette to path (truncated).
+// Close flushes it.
+func NewCassetteRecorder(inner LLMProvider, path string) (*CassetteRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create cassette: %w", err)
+	}
+	r := &CassetteRecorder{inner: inner, f: f, enc: json.NewEncoder(f)}
+	pricing, limits := inner.Pricing(), ownLimits(inner)
+	if err := r.enc.Encode(cassetteLine{Kind: cassetteHeader, Model: inner.Model(), Pricing: &pricing, Limits: &limits}); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("write cassette header: %w", err)
+	}
+	return r, nil
+}
+
+func (r *CassetteRecorder) Model() string       { return r.inner.Model() }
+func (r *CassetteRecorder) Pricing() Pricing    { return r.inner.Pricing() }
+func (r *CassetteRecorder) Limits() ModelLimits { return ownLimits(r.inner) }
+func (r *CassetteRecorder) Close() error        { return r.f.Close() }
+
+// CountTokens delegates to the wrapped provider's counter, if any.
+func (r *CassetteRecorder) CountTokens(ctx context.Context, req Request) (int, error) {
+	if tc, ok := r.inner.(TokenCounter); ok {
+		return tc.CountTokens(ctx, req)
+	}
+	return 0, errors.New("cassette: wrapped provider cannot count tokens")
+}
+
+// Complete forwards req and records the outcome. A recording failure never
+// fails the call — the cassette is a diagnostic side channel.
+func (r *CassetteRecorder) Complete(ctx context.Context, req Request) (Response, error) {
+	resp, err := r.inner.Complete(ctx, req)
+
+	line := cassetteLine{Kind: cassetteCall, Fingerprint: requestFingerprint(req), Messages: len(req.Messages)}
+	if err != nil {
+		line.Error = err.Error()
+	} else {
+		raw, _ := encodeRaw(resp.Raw)
+		line.Response = &cassetteResponse{Text: resp.Text, ToolCalls: resp.ToolCalls, Usage: resp.Usage, StopReason: resp.StopReason, Raw: raw}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	line.Seq = r.seq
+	if req.System != r.system {
+		line.System, r.system = req.System, req.System
+	}
+	line.From = commonPrefix(r.prev, req.Messages)
+	line.Appended = req.Messages[line.From:]
+	r.prev = slices.Clone(req.Messages)
+	_ = r.enc.Encode(line)
+	return resp, err
+}
+
+// CassettePlayer is an LLMProvider that answers from a recorded cassette. A
+// request gets the first unused call with the same fingerprint; on a miss a
+// strict player fails with errCassetteMiss, a lenient one returns the next
+// unused call in recorded order — what you want when replaying a customer run
+// against a tree whose tool output differs slightly.
+type CassettePlayer struct {
+	model   string
+	pricing Pricing
+	limits  ModelLimits
+	strict  bool
+
+	mu    sync.Mutex
+	calls []cassetteLine
+	used  []bool
+}
+
+// LoadCassette reads a cassette into memory.
+func LoadCassette(path string, strict bool) (*CassettePlayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open cassette: %w", err)
+	}
+	defer f.Close()
+
+	p := &CassettePlayer{strict: strict}
+	var (
+		system  string
+		history []Message
+	)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 1<<20), 64<<20)
+	for n := 1; sc.Scan(); n++ {
+		var line cassetteLine
+		if err := json.Unmarshal(sc.Bytes(), &line); err != nil {
+			return nil, fmt.Errorf("cassette line %d: %w", n, err)
+		}
+		switch line.Kind {
+		case cassetteHeader:
+			p.model = line.Model
+			if line.Pricing != nil {
+				p.pricing = *line.Pricing
+			}
+			if line.Limits != nil {
+				p.limits = *line.Limits
+			}
+		case cassetteCall:
+			if line.From > len(history) {
+				return nil, fmt.Errorf("cassette line %d: history delta starts at %d, previous call has %d messages", n, line.From, len(history))
+			}
+			if line.System != "" {
+				system = line.System
+			}
+			history = append(slices.Clip(history[:line.From]), line.Appended...)
+			line.system, line.history = system, history
+			p.calls = append(p.calls, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	p.used = make([]bool, len(p.calls))
+	return p, nil
+}
+
+func (p *CassettePlayer) Model() string       { return p.model }
+func (p *CassettePlayer) Pricing() Pricing    { return p.pricing }
+func (p *CassettePlayer) Limits() ModelLimits { return p.limits }
+
+// Remaining reports how many recorded calls were not replayed.
+func (p *CassettePlayer) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, u := range p.used {
+		if !u {
+			n++
+		}
+	}
+	return n
+}
+
+// Complete replays the recorded response for req, streaming its text through
+// OnDelta in one piece so progress reporting behaves as in the live run.
+func (p *CassettePlayer) Complete(ctx context.Context, req Request) (Response, error) {
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
+	}
+	line, err := p.take(req)
+	if err != nil {
+		return Response{}, err
+	}
+	if line.Error != "" || line.Response == nil {
+		return Response{}, fmt.Errorf("cassette call %d: recorded error: %s", line.Seq, line.Error)
+	}
+	r := line.Response
+	raw, err := decodeRaw(r.Raw)
+	if err != nil {
+		return Response{}, fmt.Errorf("cassette call %d: %w", line.Seq, err)
+	}
+	emitDelta(req.OnDelta, StreamDelta{Kind: DeltaText, Text: r.Text})
+	return Response{Text: r.Text, ToolCalls: r.ToolCalls, Usage: r.Usage, StopReason: r.StopReason, Raw: raw}, nil
+}
+
+// take claims the call to replay for req.
+func (p *CassettePlayer) take(req Request) (cassetteLine, error) {
+	fp := requestFingerprint(req)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	next := -1
+	for i, c := range p.calls {
+		if p.used[i] {
+			continue
+		}
+		if c.Fingerprint == fp {
+			p.used[i] = true
+			return c, nil
+		}
+		if next < 0 {
+			next = i
+		}
+	}
+	if next < 0 {
+		return cassetteLine{}, fmt.Errorf("%w (fingerprint %s, recording exhausted)", errCassetteMiss, fp)
+	}
+	if p.strict {
+		c := p.calls[next]
+		return cassetteLine{}, fmt.Errorf("%w (fingerprint %s): next recorded call %d: %s", errCassetteMiss, fp, c.Seq, c.divergence(req))
+	}
+	p.used[next] = true
+	return p.calls[next], nil
+}
+
+// divergence describes where req first differs from the recorded request.
+func (c cassetteLine) divergence(req Request) string {
+	if c.Messages != len(c.history) {
+		return "recording has no request content"
+	}
+	if req.System != c.system {
+		return "system prompt differs"
+	}
+	n := commonPrefix(c.history, req.Messages)
+	switch {
+	case n < len(c.history) && n < len(req.Messages):
+		return fmt.Sprintf("message %d (%s) differs", n, req.Messages[n].Role)
+	case len(req.Messages) != len(c.history):
+		return fmt.Sprintf("request has %d messages, recorded %d", len(req.Messages), len(c.history))
+	default:
+		return "effort or tools differ"
+	}
+}
@@ -0,0 +1,134 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/stretchr/testify/require"
+)
+
+// recordRun records a read_file → submit_review run into a cassette and returns
+// its path and result.
+func recordRun(t *testing.T) (string, *Result) {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644))
+	path := filepath.Join(t.TempDir(), CassetteFile)
+
+	rec, err := NewCassetteRecorder(&scriptedProvider{responses: []Response{
+		{ToolCalls: []ToolCall{{ID: "1", Name: "read_file", Args: json.RawMessage(`{"path":"main.go"}`)}}, Usage: Usage{InputTokens: 100, OutputTokens: 10}},
+		{ToolCalls: []ToolCall{{ID: "2", Name: "submit_review", Args: validSubmitArgs(t, "high")}}, Usage: Usage{InputTokens: 50, OutputTokens: 20}},
+	}}, path)
+	require.NoError(t, err)
+	res, err := Run(context.Background(), rec, NewReviewRegistry(ReviewToolsConfig{Dir: dir}), "system", "review this", Options{MaxRounds: 10})
+	require.NoError(t, err)
+	require.NoError(t, rec.Close())
+	return path, res
+}
+
+func TestCassetteReplaysRun(t *testing.T) {
+	path, recorded := recordRun(t)
+
+	player, err := LoadCassette(path, true)
+	require.NoError(t, err)
+	require.Equal(t, "fake-model", player.Model())
+	require.Equal(t, Pricing{InputPerMTok: 1, OutputPerMTok: 1}, player.Pricing())
+
+	// Same tree, no network: the replayed run matches the recorded one call for call.
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644))
+	res, err := Run(context.Background(), player, NewReviewRegistry(ReviewToolsConfig{Dir: dir}), "system", "review this", Options{MaxRounds: 10})
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+	require.Equal(t, recorded.Rounds, res.Rounds)
+	require.Equal(t, recorded.Usage, res.Usage)
+	require.InDelta(t, recorded.CostUsd, res.CostUsd, 1e-12)
+	require.Zero(t, player.Remaining())
+	_, err = os.Stat(filepath.Join(dir, "review.json"))
+	require.NoError(t, err, "replayed submit_review wrote review.json")
+}
+
+func TestCassetteRecordsRequestDeltas(t *testing.T) {
+	path, _ := recordRun(t)
+	player, err := LoadCassette(path, true)
+	require.NoError(t, err)
+	require.Len(t, player.calls, 2)
+
+	first, second := player.calls[0], player.calls[1]
+	require.Equal(t, "system", first.System)
+	require.Zero(t, first.From)
+	require.Len(t, first.Appended, 1)
+	require.Equal(t, "review this", first.Appended[0].Text)
+
+	require.Empty(t, second.System, "unchanged system prompt is not repeated")
+	require.Equal(t, 1, second.From)
+	require.Len(t, second.Appended, 2, "assistant tool call and its result")
+	require.Equal(t, RoleTool, second.Appended[1].Role)
+	require.Len(t, second.history, 3)
+	require.Equal(t, "system", second.system)
+}
+
+func TestCassetteMissStrictAndLenient(t *testing.T) {
+	path, _ := recordRun(t)
+	// A different tree changes the read_file result, so the second request no
+	// longer matches its recorded fingerprint.
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package changed\n"), 0o644))
+
+	strict, err := LoadCassette(path, true)
+	require.NoError(t, err)
+	_, err = Run(context.Background(), strict, NewReviewRegistry(ReviewToolsConfig{Dir: dir}), "system", "review this", Options{MaxRounds: 10})
+	require.ErrorIs(t, err, errCassetteMiss)
+	require.ErrorContains(t, err, "next recorded call 2: message 2 (tool) differs")
+
+	lenient, err := LoadCassette(path, false)
+	require.NoError(t, err)
+	res, err := Run(context.Background(), lenient, NewReviewRegistry(ReviewToolsConfig{Dir: dir}), "system", "review this", Options{MaxRounds: 10})
+	require.NoError(t, err)
+	require.True(t, res.Submitted, "lenient replay falls back to recorded order")
+}
+
+// rawProvider returns one Anthropic-style turn with a signed thinking block, or
+// an error.
+type rawProvider struct {
+	scriptedProvider
+	err error
+}
+
+func (r *rawProvider) Complete(context.Context, Request) (Response, error) {
+	if r.err != nil {
+		return Response{}, r.err
+	}
+	raw := anthropic.NewAssistantMessage(anthropic.NewThinkingBlock("sig", "thought"), anthropic.NewTextBlock("hi"))
+	return Response{Text: "hi", Raw: raw, StopReason: "end_turn"}, nil
+}
+
+func TestCassetteRawAndErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CassetteFile)
+	inner := &rawProvider{}
+	rec, err := NewCassetteRecorder(inner, path)
+	require.NoError(t, err)
+	want, err := rec.Complete(context.Background(), Request{System: "s"})
+	require.NoError(t, err)
+	inner.err = errors.New("overloaded")
+	_, err = rec.Complete(context.Background(), Request{System: "s2"})
+	require.Error(t, err)
+	require.NoError(t, rec.Close())
+
+	player, err := LoadCassette(path, true)
+	require.NoError(t, err)
+	got, err := player.Complete(context.Background(), Request{System: "s"})
+	require.NoError(t, err)
+	require.IsType(t, anthropic.MessageParam{}, got.Raw)
+	wantJSON, _ := json.Marshal(want.Raw)
+	gotJSON, _ := json.Marshal(got.Raw)
+	require.JSONEq(t, string(wantJSON), string(gotJSON), "signed thinking survives the round-trip")
+
+	_, err = player.Complete(context.Background(), Request{System: "s2"})
+	require.ErrorContains(t, err, "overloaded", "recorded provider errors replay")
+}
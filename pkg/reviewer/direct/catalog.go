@@ -0,0 +1,212 @@
+package direct
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// catalogFetchTimeout bounds fetching a catalogue override from a URL.
+const catalogFetchTimeout = 30 * time.Second
+
+//go:embed catalog.json
+var defaultCatalogJSON []byte
+
+// ModelSpec is one model catalogue entry: per-MTok prices, context limits and
+// the API features the model supports. Zero limits mean unknown.
+type ModelSpec struct {
+	// Match is a model name prefix; the longest matching entry wins, so
+	// "gpt-5.4-mini" overrides "gpt-5.4" and "claude-" is a catch-all.
+	Match               string  `json:"match"`
+	InputPerMTok        float64 `json:"inputPerMTok,omitempty"`
+	OutputPerMTok       float64 `json:"outputPerMTok,omitempty"`
+	CacheReadPerMTok    float64 `json:"cacheReadPerMTok,omitempty"`
+	CacheWritePerMTok   float64 `json:"cacheWritePerMTok,omitempty"`
+	CacheWrite1hPerMTok float64 `json:"cacheWrite1hPerMTok,omitempty"`
+	ContextWindow       int     `json:"contextWindow,omitempty"`
+	MaxOutput           int     `json:"maxOutput,omitempty"` // per-response cap sent as max_tokens
+	Effort              bool    `json:"effort,omitempty"`    // accepts an effort level
+	Thinking            bool    `json:"thinking,omitempty"`  // extended/adaptive thinking
+	Caching             bool    `json:"caching,omitempty"`   // prompt caching
+}
+
+// Pricing is the entry's price table.
+func (s ModelSpec) Pricing() Pricing {
+	return Pricing{
+		InputPerMTok:        s.InputPerMTok,
+		OutputPerMTok:       s.OutputPerMTok,
+		CacheReadPerMTok:    s.CacheReadPerMTok,
+		CacheWritePerMTok:   s.CacheWritePerMTok,
+		CacheWrite1hPerMTok: s.CacheWrite1hPerMTok,
+	}
+}
+
+// Limits is the entry's context budget.
+func (s ModelSpec) Limits() ModelLimits {
+	return ModelLimits{ContextWindow: s.ContextWindow, MaxOutput: s.MaxOutput}
+}
+
+// Priced reports whether the entry has prices; without them cost is 0.
+func (s ModelSpec) Priced() bool { return s.InputPerMTok > 0 || s.OutputPerMTok > 0 }
+
+// Cost is the USD cost of u at the entry's prices.
+func (s ModelSpec) Cost(u Usage) float64 { return computeCost(u, s.Pricing()) }
+
+// Catalog is the model catalogue every runner prices and sizes models by: the
+// embedded defaults (catalog.json), optionally overridden per entry by a file
+// or URL (LoadCatalog), so a price change or a new model needs no release.
+type Catalog struct {
+	Models []ModelSpec `json:"models"`
+}
+
+var defaultCatalog = sync.OnceValue(func() *Catalog {
+	c, err := ParseCatalog(defaultCatalogJSON)
+	if err != nil {
+		panic("direct: embedded catalog.json: " + err.Error())
+	}
+	return c
+})
+
+// DefaultCatalog returns the embedded catalogue.
+func DefaultCatalog() *Catalog { return defaultCatalog() }
+
+// ParseCatalog decodes and checks a catalogue document.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("model catalog: %w", err)
+	}
+	seen := make(map[string]bool, len(c.Models))
+	for i, m := range c.Models {
+		switch {
+		case strings.TrimSpace(m.Match) == "":
+			return nil, fmt.Errorf("model catalog: entry %d has no match", i)
+		case seen[m.Match]:
+			return nil, fmt.Errorf("model catalog: duplicate entry %q", m.Match)
+		case m.InputPerMTok < 0 || m.OutputPerMTok < 0 || m.CacheReadPerMTok < 0 || m.CacheWritePerMTok < 0 || m.CacheWrite1hPerMTok < 0:
+			return nil, fmt.Errorf("model catalog: %q has a negative price", m.Match)
+		case m.ContextWindow < 0 || m.MaxOutput < 0:
+			return nil, fmt.Errorf("model catalog: %q has negative limits", m.Match)
+		}
+		seen[m.Match] = true
+	}
+	return &c, nil
+}
+
+// LoadCatalog returns the embedded catalogue with the entries of src laid
+// over it: an entry with the same match replaces the default, others are
+// added. src is a file path or an http(s) URL (e.g. a catalogue the review
+// server publishes); empty means the defaults alone.
+func LoadCatalog(ctx context.Context, src string) (*Catalog, error) {
+	if src == "" {
+		return DefaultCatalog(), nil
+	}
+	var data []byte
+	var err error
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+		data, err = fetchCatalog(ctx, src)
+	} else {
+		data, err = os.ReadFile(src)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("model catalog %s: %w", src, err)
+	}
+	over, err := ParseCatalog(data)
+	if err != nil {
+		return nil, err
+	}
+	return DefaultCatalog().Merge(over), nil
+}
+
+func fetchCatalog(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, catalogFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+}
+
+// Merge returns c with o's entries laid over it.
+func (c *Catalog) Merge(o *Catalog) *Catalog {
+	out := &Catalog{Models: append([]ModelSpec(nil), c.catalog().Models...)}
+	for _, m := range o.catalog().Models {
+		i := indexOfMatch(out.Models, m.Match)
+		if i < 0 {
+			out.Models = append(out.Models, m)
+			continue
+		}
+		out.Models[i] = m
+	}
+	return out
+}
+
+func indexOfMatch(ms []ModelSpec, match string) int {
+	for i, m := range ms {
+		if m.Match == match {
+			return i
+		}
+	}
+	return -1
+}
+
+// Lookup returns the entry for model: the longest match that prefixes it,
+// tried on the full name and on the part after a "provider/" prefix. A nil
+// catalogue is the default one.
+func (c *Catalog) Lookup(model string) (ModelSpec, bool) {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return ModelSpec{}, false
+	}
+	names := []string{model}
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		names = append(names, model[i+1:])
+	}
+	var best ModelSpec
+	found := false
+	for _, name := range names {
+		for _, m := range c.catalog().Models {
+			if strings.HasPrefix(name, m.Match) && len(m.Match) > len(best.Match) {
+				best, found = m, true
+			}
+		}
+	}
+	return best, found
+}
+
+// catalog resolves a nil catalogue to the default one.
+func (c *Catalog) catalog() *Catalog {
+	if c == nil {
+		return DefaultCatalog()
+	}
+	return c
+}
+
+// errNoPricing is reported by CheckPricing.
+var errNoPricing = errors.New("no pricing in the model catalog; its cost is reported as 0")
+
+// CheckPricing returns an error when model has no priced catalogue entry, so
+// callers can warn before a run whose cost would silently read 0.
+func (c *Catalog) CheckPricing(model string) error {
+	if m, ok := c.Lookup(model); !ok || !m.Priced() {
+		return fmt.Errorf("model %q: %w", model, errNoPricing)
+	}
+	return nil
+}
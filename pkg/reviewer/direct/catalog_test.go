@@ -0,0 +1,126 @@
+package direct
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogLookup(t *testing.T) {
+	c := DefaultCatalog()
+	mini, ok := c.Lookup("gpt-5.4-mini")
+	require.True(t, ok)
+	require.Equal(t, "gpt-5.4-mini", mini.Match, "longest match wins over gpt-5.4")
+
+	spec, ok := c.Lookup("anthropic/claude-sonnet-4-6")
+	require.True(t, ok, "provider prefix is stripped")
+	require.InEpsilon(t, 3.0, spec.InputPerMTok, 1e-9)
+
+	spec, ok = c.Lookup("claude-nova-1")
+	require.True(t, ok)
+	require.False(t, spec.Priced(), "the claude- catch-all knows limits, not prices")
+	require.Equal(t, 200_000, spec.ContextWindow)
+	require.ErrorContains(t, c.CheckPricing("claude-nova-1"), "no pricing")
+	require.ErrorContains(t, c.CheckPricing("qwen3-coder"), "no pricing")
+	require.NoError(t, c.CheckPricing("deepseek-chat"))
+
+	var nilCat *Catalog
+	_, ok = nilCat.Lookup("claude-opus-4-8")
+	require.True(t, ok, "nil is the default catalogue")
+
+	require.InEpsilon(t, 15.0, ModelSpec{InputPerMTok: 5, OutputPerMTok: 25}.Cost(Usage{InputTokens: 500_000, OutputTokens: 500_000}), 1e-9)
+}
+
+func TestDefaultCatalogPricing(t *testing.T) {
+	opus := Pricing{InputPerMTok: 5, OutputPerMTok: 25, CacheReadPerMTok: 0.5, CacheWritePerMTok: 6.25, CacheWrite1hPerMTok: 10}
+	for model, check := range map[string]func(Pricing){
+		"claude-opus-4-8":   func(p Pricing) { require.Equal(t, opus, p) },
+		"claude-fable-5":    func(p Pricing) { require.Equal(t, opus, p) },
+		"claude-sonnet-4-6": func(p Pricing) { require.InEpsilon(t, 3.0, p.InputPerMTok, 1e-9) },
+		"claude-haiku-4-5":  func(p Pricing) { require.InEpsilon(t, 1.0, p.InputPerMTok, 1e-9) },
+		"deepseek-v4-pro": func(p Pricing) {
+			require.InEpsilon(t, 0.435, p.InputPerMTok, 1e-9)
+			require.InEpsilon(t, 0.003625, p.CacheReadPerMTok, 1e-9)
+		},
+		"deepseek-v4-flash": func(p Pricing) { require.InEpsilon(t, 0.14, p.InputPerMTok, 1e-9) },
+		// Legacy deepseek-chat/reasoner aliases price as V4 Flash.
+		"deepseek-chat":     func(p Pricing) { require.InEpsilon(t, 0.14, p.InputPerMTok, 1e-9) },
+		"deepseek-reasoner": func(p Pricing) { require.InEpsilon(t, 0.0028, p.CacheReadPerMTok, 1e-9) },
+		// Unknown model -> zero table (cost reported as 0), no panic.
+		"gpt-4o": func(p Pricing) { require.Equal(t, Pricing{}, p) },
+		"":       func(p Pricing) { require.Equal(t, Pricing{}, p) },
+	} {
+		spec, _ := DefaultCatalog().Lookup(model)
+		check(spec.Pricing())
+	}
+}
+
+func TestParseCatalogRejects(t *testing.T) {
+	for doc, msg := range map[string]string{
+		`{"models":[{"inputPerMTok":1}]}`:               "no match",
+		`{"models":[{"match":"a"},{"match":"a"}]}`:      "duplicate",
+		`{"models":[{"match":"a","outputPerMTok":-1}]}`: "negative price",
+		`{"models":[{"match":"a","contextWindow":-5}]}`: "negative limits",
+		`{"models":`: "model catalog",
+	} {
+		_, err := ParseCatalog([]byte(doc))
+		require.ErrorContains(t, err, msg, doc)
+	}
+}
+
+func TestLoadCatalogOverrides(t *testing.T) {
+	const doc = `{"models":[
+		{"match":"claude-sonnet","inputPerMTok":2,"outputPerMTok":10,"contextWindow":1000000,"maxOutput":32000,"thinking":true},
+		{"match":"qwen3-coder","contextWindow":65536,"maxOutput":4096}
+	]}`
+	path := filepath.Join(t.TempDir(), "models.json")
+	require.NoError(t, os.WriteFile(path, []byte(doc), 0o644))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(doc))
+	}))
+	defer srv.Close()
+
+	for _, src := range []string{path, srv.URL} {
+		c, err := LoadCatalog(context.Background(), src)
+		require.NoError(t, err)
+		sonnet, _ := c.Lookup("claude-sonnet-4-6")
+		require.InEpsilon(t, 2.0, sonnet.InputPerMTok, 1e-9, "override replaces the default entry")
+		require.Equal(t, 1_000_000, sonnet.ContextWindow)
+		qwen, ok := c.Lookup("qwen3-coder-30b")
+		require.True(t, ok, "override adds new models")
+		require.Equal(t, 65536, qwen.ContextWindow)
+		_, ok = c.Lookup("deepseek-v4-pro")
+		require.True(t, ok, "defaults stay")
+	}
+
+	_, err := LoadCatalog(context.Background(), filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	c, err := LoadCatalog(context.Background(), "")
+	require.NoError(t, err)
+	require.Same(t, DefaultCatalog(), c)
+}
+
+func TestNewProviderUsesCatalog(t *testing.T) {
+	cat := DefaultCatalog().Merge(&Catalog{Models: []ModelSpec{
+		{Match: "claude-sonnet", InputPerMTok: 2, OutputPerMTok: 10, ContextWindow: 1_000_000, MaxOutput: 32_000, Thinking: true},
+		{Match: "qwen3", InputPerMTok: 0.1, ContextWindow: 65_536, MaxOutput: 4096},
+	}})
+
+	p, err := NewProvider(ProviderConfig{Provider: "anthropic", Model: "claude-sonnet-4-6", APIKey: "k", Catalog: cat})
+	require.NoError(t, err)
+	require.InEpsilon(t, 2.0, p.Pricing().InputPerMTok, 1e-9)
+	require.Equal(t, ModelLimits{ContextWindow: 1_000_000, MaxOutput: 32_000}, LimitsOf(p, cat))
+	params := p.(*anthropicProvider).params(Request{Effort: "high", Messages: []Message{{Role: RoleUser, Text: "x"}}})
+	require.NotNil(t, params.Thinking.OfAdaptive)
+	require.Empty(t, params.OutputConfig.Effort, "the entry does not support effort")
+	require.Equal(t, AnthropicCache{}, p.(*anthropicProvider).cache, "nor caching")
+
+	p, err = NewProvider(ProviderConfig{Provider: "openai-compat", Model: "qwen3-coder", APIKey: "k", Catalog: cat})
+	require.NoError(t, err)
+	require.Equal(t, ModelLimits{ContextWindow: 65_536, MaxOutput: 4096}, LimitsOf(p, cat))
+}
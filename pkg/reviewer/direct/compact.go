@@ -0,0 +1,133 @@
+package direct
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// compactPrompt asks the model to summarise the conversation before its middle
+// is pruned, so it keeps what it learned rather than forgetting which files it
+// inspected and what it concluded.
+const compactPrompt = "CONTEXT COMPACTION: the earlier part of this conversation is about to be removed to fit the context window. " +
+	"Do NOT call any tools now. Reply with a concise summary that will replace it, using exactly these sections:\n" +
+	"## Files inspected\n- path — what it does / what matters for the review (one line each)\n" +
+	"## Findings so far\n- severity, file:lines, one-line description of each problem found (incl. ones not yet sent via add_issues)\n" +
+	"## Review progress\n- which groups were already sent via set_group, how many issues via add_issues\n" +
+	"## Open questions\n- what still needs checking and where\n" +
+	"Be specific (paths, symbols, line numbers); omit file contents."
+
+// compactSummaryClip bounds the summary folded into the head message.
+const compactSummaryClip = 20_000
+
+// compactHistory prunes the middle of the conversation. With CompactSummary set
+// it first asks the provider for a structured summary of the history and folds
+// it into the kept head; on failure it falls back to a plain marker. The
+// summary request is the current history plus one note, with the same system
+// prompt and tools, so it reads the whole prefix from the prompt cache.
+// Returns the new history and the tokens the summary call spent.
+func compactHistory(ctx context.Context, p LLMProvider, reg *Registry, system string, msgs []Message, opts Options, round int) ([]Message, Usage) {
+	cut := compactCut(msgs, opts.KeepTail)
+	if cut == 0 {
+		return msgs, Usage{}
+	}
+
+	var summary, failure string
+	var used Usage
+	if opts.CompactSummary {
+		resp, err := p.Complete(ctx, Request{
+			System:      system,
+			Messages:    withHarnessNote(msgs, compactPrompt),
+			Tools:       reg.Defs(),
+			Effort:      opts.Effort,
+			IdleTimeout: opts.StreamIdleTimeout,
+		})
+		if err == nil {
+			summary = strings.TrimSpace(resp.Text)
+			used = resp.Usage
+		} else {
+			failure = "summary failed: " + err.Error()
+		}
+	}
+
+	out := foldCompacted(msgs, cut, clipN(summary, compactSummaryClip))
+	reg.compacted()
+	opts.OnEvent.emit(Event{Round: round, Kind: "compaction", Text: summary, Content: failure, IsError: failure != "", Dropped: cut - 1, Usage: &used})
+	return out, used
+}
+
+// withHarnessNote returns a copy of msgs with note delivered as the next user
+// input: folded into a trailing tool or user turn (keeping roles alternating),
+// or appended as a new user message after an assistant turn.
+func withHarnessNote(msgs []Message, note string) []Message {
+	out := append([]Message(nil), msgs...)
+	if len(out) == 0 {
+		return []Message{{Role: RoleUser, Text: note}}
+	}
+	last := &out[len(out)-1]
+	switch last.Role {
+	case RoleTool, RoleUser:
+		last.Text = strings.TrimSpace(last.Text + "\n\n" + note)
+	default:
+		out = append(out, Message{Role: RoleUser, Text: note})
+	}
+	return out
+}
+
+// compactMessages prunes the middle of the conversation, keeping the first
+// message (the task) and the last keepTail messages, with a plain marker in
+// place of the dropped turns.
+func compactMessages(msgs []Message, keepTail int) []Message {
+	cut := compactCut(msgs, keepTail)
+	if cut == 0 {
+		return msgs
+	}
+	return foldCompacted(msgs, cut, "")
+}
+
+// compactCut returns the index at which the kept tail starts, or 0 when there is
+// nothing worth compacting. The tail boundary is moved forward off a tool
+// message so a tool result never leads without its assistant tool_use turn
+// (which would break provider translation).
+func compactCut(msgs []Message, keepTail int) int {
+	const keepHead = 1
+	if keepTail <= 0 {
+		keepTail = DefaultOptions().KeepTail
+	}
+	if len(msgs) <= keepHead+keepTail+1 {
+		return 0
+	}
+	cut := len(msgs) - keepTail
+	// The kept head is the user task, so the tail must resume on an assistant
+	// turn: advance off any leading tool result (which would lead without its
+	// tool_use turn) AND any leading user message (which would collide with the
+	// head into two consecutive user messages — the Anthropic API rejects that).
+	for cut < len(msgs) && msgs[cut].Role != RoleAssistant {
+		cut++
+	}
+	// If no assistant turn remains in the tail, advancing past everything would
+	// drop it all — skip compaction this round rather than lose the tail.
+	if cut >= len(msgs) || cut-keepHead <= 0 {
+		return 0
+	}
+	return cut
+}
+
+// foldCompacted keeps the head and msgs[cut:], folding the compaction marker
+// (and the summary, when there is one) into the head message rather than
+// inserting a separate one — a standalone marker after the head user turn would
+// put two consecutive user messages in the history, which the Anthropic API
+// rejects (roles must alternate).
+func foldCompacted(msgs []Message, cut int, summary string) []Message {
+	head := msgs[0]
+	marker := fmt.Sprintf("\n\n[compacted: %d earlier messages omitted to fit context]", cut-1)
+	if summary != "" {
+		marker = fmt.Sprintf("\n\n[compacted: %d earlier messages replaced by your own summary of them — "+
+			"files listed there are no longer in context; re-read them if you need their content]\n%s", cut-1, summary)
+	}
+	head.Text = strings.TrimSpace(head.Text + marker)
+	out := make([]Message, 0, 1+(len(msgs)-cut))
+	out = append(out, head)
+	out = append(out, msgs[cut:]...)
+	return out
+}
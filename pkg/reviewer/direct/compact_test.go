@@ -0,0 +1,199 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactMessages(t *testing.T) {
+	// mk builds a realistic history: user task, then alternating assistant/tool
+	// turns (odd index = assistant, even = tool).
+	mk := func(n int) []Message {
+		m := make([]Message, n)
+		m[0] = Message{Role: RoleUser, Text: "task"}
+		for i := 1; i < n; i++ {
+			if i%2 == 1 {
+				m[i] = Message{Role: RoleAssistant, Text: fmt.Sprintf("a%d", i)}
+			} else {
+				m[i] = Message{Role: RoleTool}
+			}
+		}
+		return m
+	}
+
+	// Short conversation: returned unchanged.
+	require.Len(t, compactMessages(mk(3), 12), 3)
+
+	// Long: head kept with the marker folded in (no separate user message), tail
+	// resumes on an assistant turn so head+tail never collide into two users.
+	out := compactMessages(mk(40), 5)
+	require.Contains(t, out[0].Text, "task")
+	require.Contains(t, out[0].Text, "compacted")
+	require.Equal(t, RoleUser, out[0].Role)
+	require.Equal(t, RoleAssistant, out[1].Role, "tail must resume on an assistant turn")
+	require.Len(t, out, 1+5)
+
+	// C3: a tail boundary landing on a mid-history user message (e.g. a nudge)
+	// must advance to the next assistant turn, never leaving two consecutive
+	// user messages (which the Anthropic API rejects).
+	withNudge := []Message{
+		{Role: RoleUser, Text: "task"},
+		{Role: RoleAssistant, Text: "a1"},
+		{Role: RoleTool},
+		{Role: RoleUser, Text: "nudge"},
+		{Role: RoleAssistant, Text: "a2"},
+		{Role: RoleTool},
+	}
+	got := compactMessages(withNudge, 3) // cut lands on the nudge(user)
+	require.Equal(t, RoleUser, got[0].Role)
+	require.NotEqual(t, RoleUser, got[1].Role, "must not produce two consecutive user messages")
+
+	// Tail entirely tool messages: no assistant to resume on, so compaction is
+	// skipped rather than dropping the whole tail.
+	allTail := make([]Message, 20)
+	allTail[0] = Message{Role: RoleUser, Text: "task"}
+	for i := 1; i < 20; i++ {
+		allTail[i] = Message{Role: RoleTool}
+	}
+	require.Len(t, compactMessages(allTail, 3), 20)
+}
+
+func TestFoldCompactedWithSummary(t *testing.T) {
+	msgs := []Message{
+		{Role: RoleUser, Text: "task"},
+		{Role: RoleAssistant, Text: "a1"},
+		{Role: RoleTool},
+		{Role: RoleAssistant, Text: "a2"},
+		{Role: RoleTool},
+	}
+	out := foldCompacted(msgs, 3, "## Files inspected\n- main.go")
+	require.Len(t, out, 3)
+	require.True(t, strings.HasPrefix(out[0].Text, "task"))
+	require.Contains(t, out[0].Text, "replaced by your own summary")
+	require.Contains(t, out[0].Text, "- main.go")
+	require.Equal(t, "a2", out[1].Text)
+	require.Equal(t, "task", msgs[0].Text, "input history must not be mutated")
+}
+
+func TestWithHarnessNote(t *testing.T) {
+	tool := []Message{{Role: RoleUser, Text: "task"}, {Role: RoleAssistant}, {Role: RoleTool}}
+	out := withHarnessNote(tool, "note")
+	require.Len(t, out, 3, "note rides on the trailing tool turn")
+	require.Equal(t, "note", out[2].Text)
+	require.Empty(t, tool[2].Text, "input history must not be mutated")
+
+	asst := []Message{{Role: RoleUser, Text: "task"}, {Role: RoleAssistant, Text: "done"}}
+	out = withHarnessNote(asst, "note")
+	require.Len(t, out, 3)
+	require.Equal(t, RoleUser, out[2].Role)
+}
+
+// summarisingProvider answers compaction requests with a fixed summary (or an
+// error) and delegates every other request to the script.
+type summarisingProvider struct {
+	scriptedProvider
+	summary  string
+	fail     bool
+	summReqs []Request
+}
+
+func (s *summarisingProvider) Complete(ctx context.Context, req Request) (Response, error) {
+	if m := req.Messages; strings.Contains(m[len(m)-1].Text, compactPrompt) {
+		s.summReqs = append(s.summReqs, req)
+		if s.fail {
+			return Response{}, errors.New("boom")
+		}
+		return Response{Text: s.summary, Usage: Usage{InputTokens: 7, OutputTokens: 3}}, nil
+	}
+	return s.scriptedProvider.Complete(ctx, req)
+}
+
+// longReadHistory scripts n read_file rounds over padded files, enough to
+// exceed a small CompactAt, followed by a valid submit.
+func longReadHistory(t *testing.T, dir string, n int) []Response {
+	t.Helper()
+	resps := make([]Response, 0, n+1)
+	for i := range n {
+		name := fmt.Sprintf("f%d.go", i)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("package main\n// "+strings.Repeat("x", 400)+"\n"), 0o644))
+		resps = append(resps, Response{ToolCalls: []ToolCall{{ID: strconv.Itoa(i), Name: "read_file", Args: json.RawMessage(`{"path":"` + name + `"}`)}}})
+	}
+	return append(resps, Response{ToolCalls: []ToolCall{{ID: "s", Name: "submit_review", Args: validSubmitArgs(t, "low")}}})
+}
+
+func compactEvents(events []Event) []Event {
+	var out []Event
+	for _, e := range events {
+		if e.Kind == "compaction" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func TestRunCompactsWithSummary(t *testing.T) {
+	dir := t.TempDir()
+	reg := NewReviewRegistry(ReviewToolsConfig{Dir: dir})
+	prov := &summarisingProvider{scriptedProvider: scriptedProvider{responses: longReadHistory(t, dir, 8)}, summary: "## Files inspected\n- f0.go"}
+
+	var events []Event
+	opts := Options{MaxRounds: 20, CompactAt: 500, KeepTail: 4, CompactSummary: true, OnEvent: func(e Event) { events = append(events, e) }}
+	res, err := Run(context.Background(), prov, reg, "system", "review this", opts)
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+
+	// The summary request reuses the system prompt and tools, so the history
+	// prefix stays cache-hot.
+	require.NotEmpty(t, prov.summReqs, "no summary request sent")
+	require.Equal(t, "system", prov.summReqs[0].System)
+	require.NotEmpty(t, prov.summReqs[0].Tools)
+
+	// Later requests start with the summary folded into the head.
+	last := prov.seen[len(prov.seen)-1]
+	require.Contains(t, last.Messages[0].Text, "- f0.go")
+	require.Equal(t, RoleAssistant, last.Messages[1].Role)
+	require.GreaterOrEqual(t, res.Usage.InputTokens, 7*len(prov.summReqs), "summary usage is accounted")
+
+	ce := compactEvents(events)
+	require.Len(t, ce, len(prov.summReqs))
+	require.Positive(t, ce[0].Dropped)
+	require.Contains(t, ce[0].Text, "f0.go")
+}
+
+func TestRunCompactsWithoutSummaryOnError(t *testing.T) {
+	dir := t.TempDir()
+	reg := NewReviewRegistry(ReviewToolsConfig{Dir: dir})
+	prov := &summarisingProvider{scriptedProvider: scriptedProvider{responses: longReadHistory(t, dir, 8)}, fail: true}
+
+	var events []Event
+	opts := Options{MaxRounds: 20, CompactAt: 500, KeepTail: 4, CompactSummary: true, OnEvent: func(e Event) { events = append(events, e) }}
+	res, err := Run(context.Background(), prov, reg, "system", "review this", opts)
+	require.NoError(t, err)
+	require.True(t, res.Submitted, "a failed summary must not abort the run")
+
+	last := prov.seen[len(prov.seen)-1]
+	require.Contains(t, last.Messages[0].Text, "earlier messages omitted")
+	ce := compactEvents(events)
+	require.NotEmpty(t, ce)
+	require.True(t, ce[0].IsError)
+}
+
+func TestReadTrackerResetKeepsSeed(t *testing.T) {
+	rt := newReadTracker([]string{"seed.go"})
+	rt.mu.Lock()
+	rt.seen["other.go"] = true
+	rt.mu.Unlock()
+
+	rt.reset()
+	require.True(t, rt.seen["seed.go"])
+	require.False(t, rt.seen["other.go"])
+}
@@ -0,0 +1,561 @@
+package direct
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	goIndexMaxFiles = 5_000
+	goIndexTimeout  = 2 * time.Minute
+)
+
+// errGoIndexTooLarge keeps in-process type checking off monorepos where it
+// would take minutes and gigabytes; the model falls back to grep there.
+var errGoIndexTooLarge = errors.New("repository has too many Go files for semantic navigation")
+
+// goIndex is a type-checked view of every Go package under root, built on first
+// use and cached for the rest of the run (a review never edits the tree).
+//
+// Only packages inside the repository are type-checked from source. Imports of
+// the standard library and third-party modules resolve to empty stub packages,
+// so nothing is compiled or downloaded; selections on them stay unresolved,
+// which navigation inside the repository does not need. Type errors are
+// ignored — a partially typed package is still navigable.
+type goIndex struct {
+	root string
+
+	mu    sync.Mutex
+	ready bool
+	err   error
+
+	fset   *token.FileSet
+	pkgs   []*goPkg          // repository packages, in load order
+	byPath map[string]*goPkg // import path → package (non-test)
+	stubs  map[string]*types.Package
+	info   *types.Info
+	src    map[string][]byte // repo-relative file → content
+}
+
+// goPkg is one repository package (or external test package).
+type goPkg struct {
+	path     string
+	name     string
+	files    []*ast.File
+	types    *types.Package
+	checking bool
+}
+
+func newGoIndex(root string) *goIndex {
+	return &goIndex{root: root}
+}
+
+// hasGoModule reports whether root is a Go module — the go_* tools are offered
+// only then.
+func hasGoModule(root string) bool {
+	_, err := os.Stat(filepath.Join(root, "go.mod"))
+	return err == nil
+}
+
+// get builds the index once. A build cut short by ctx is not cached, so a later
+// call may retry; any other failure is.
+func (x *goIndex) get(ctx context.Context) (*goIndex, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.ready {
+		return x, x.err
+	}
+	bctx, cancel := context.WithTimeout(ctx, goIndexTimeout)
+	defer cancel()
+	err := x.build(bctx)
+	if err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+	x.ready, x.err = true, err
+	return x, err
+}
+
+func (x *goIndex) build(ctx context.Context) error {
+	x.fset = token.NewFileSet()
+	x.byPath = make(map[string]*goPkg)
+	x.stubs = make(map[string]*types.Package)
+	x.src = make(map[string][]byte)
+	x.info = &types.Info{
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+
+	dirs, modules, err := goSourceDirs(x.root)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		x.parseDir(dir, importPathFor(dir.rel, modules))
+	}
+
+	for _, p := range x.pkgs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		x.check(p)
+	}
+	return nil
+}
+
+// goDir is one directory's buildable Go files (repo-relative, slash-separated).
+type goDir struct {
+	rel   string
+	files []string
+}
+
+// goSourceDirs walks root for buildable Go files (current GOOS/GOARCH tags,
+// tests included), skipping vendored, generated and hidden trees and never
+// following symlinks, and collects the module path of every go.mod it meets.
+func goSourceDirs(root string) ([]goDir, map[string]string, error) {
+	modules := make(map[string]string)
+	byDir := make(map[string][]string)
+	total := 0
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, werr error) error {
+		if werr != nil {
+			return nil //nolint:nilerr // unreadable entries are skipped
+		}
+		rel, _ := filepath.Rel(root, p)
+		rel = filepath.ToSlash(rel)
+		name := d.Name()
+		if d.IsDir() {
+			if rel != "." && (skipDirs[name] || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		dir := path.Dir(rel)
+		switch {
+		case name == "go.mod":
+			if mod := modulePath(p); mod != "" {
+				modules[dir] = mod
+			}
+		case strings.HasSuffix(name, ".go"):
+			if ok, _ := build.Default.MatchFile(filepath.Dir(p), name); ok {
+				byDir[dir] = append(byDir[dir], rel)
+				if total++; total > goIndexMaxFiles {
+					return errGoIndexTooLarge
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	dirs := make([]goDir, 0, len(byDir))
+	for d, files := range byDir {
+		dirs = append(dirs, goDir{rel: d, files: files})
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].rel < dirs[j].rel })
+	return dirs, modules, nil
+}
+
+// modulePath reads the module directive of a go.mod file.
+func modulePath(gomod string) string {
+	f, err := os.Open(gomod)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(sc.Text()), "module"); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// importPathFor maps a repo-relative directory to its import path via the
+// nearest enclosing module.
+func importPathFor(dir string, modules map[string]string) string {
+	for d := dir; ; d = path.Dir(d) {
+		if mod, ok := modules[d]; ok {
+			if d == dir {
+				return mod
+			}
+			return mod + "/" + strings.TrimPrefix(dir, d+"/")
+		}
+		if d == "." || d == "/" {
+			return dir
+		}
+	}
+}
+
+// parseDir parses a directory's files into its package and, when present, the
+// external _test package.
+func (x *goIndex) parseDir(dir goDir, importPath string) {
+	byName := make(map[string]*goPkg)
+	for _, rel := range dir.files {
+		src, err := os.ReadFile(filepath.Join(x.root, filepath.FromSlash(rel)))
+		if err != nil {
+			continue
+		}
+		// A file with syntax errors still yields a partial AST worth navigating.
+		f, _ := parser.ParseFile(x.fset, rel, src, parser.ParseComments|parser.SkipObjectResolution)
+		if f == nil || f.Name == nil {
+			continue
+		}
+		x.src[rel] = src
+		name := f.Name.Name
+		p := byName[name]
+		if p == nil {
+			p = &goPkg{name: name, path: importPath}
+			if strings.HasSuffix(name, "_test") {
+				p.path += "_test"
+			}
+			byName[name] = p
+			x.pkgs = append(x.pkgs, p)
+			if _, taken := x.byPath[p.path]; !taken {
+				x.byPath[p.path] = p
+			}
+		}
+		p.files = append(p.files, f)
+	}
+}
+
+// check type-checks p (and, through Import, the repository packages it
+// imports) once.
+func (x *goIndex) check(p *goPkg) *types.Package {
+	if p.types != nil || p.checking {
+		if p.types == nil { // import cycle: hand out a stub
+			return x.stub(p.path)
+		}
+		return p.types
+	}
+	p.checking = true
+	conf := &types.Config{Importer: x, Error: func(error) {}, FakeImportC: true}
+	pkg, _ := conf.Check(p.path, x.fset, p.files, x.info)
+	p.checking = false
+	p.types = pkg
+	return pkg
+}
+
+// Import implements types.Importer: repository packages are type-checked from
+// source, everything else is an empty stub.
+func (x *goIndex) Import(importPath string) (*types.Package, error) {
+	if importPath == "unsafe" {
+		return types.Unsafe, nil
+	}
+	if p, ok := x.byPath[importPath]; ok {
+		return x.check(p), nil
+	}
+	return x.stub(importPath), nil
+}
+
+func (x *goIndex) stub(importPath string) *types.Package {
+	if s, ok := x.stubs[importPath]; ok {
+		return s
+	}
+	name := path.Base(importPath)
+	if strings.HasPrefix(name, "v") && strings.Trim(name[1:], "0123456789") == "" && path.Dir(importPath) != "." {
+		name = path.Base(path.Dir(importPath)) // example.com/mod/v2 → mod
+	}
+	name = strings.TrimPrefix(strings.ReplaceAll(name, "-", "_"), "go_")
+	s := types.NewPackage(importPath, name)
+	s.MarkComplete()
+	x.stubs[importPath] = s
+	return s
+}
+
+// own reports whether obj belongs to a repository package.
+func (x *goIndex) own(obj types.Object) bool {
+	if obj == nil || obj.Pkg() == nil {
+		return false
+	}
+	_, ok := x.byPath[strings.TrimSuffix(obj.Pkg().Path(), "_test")]
+	return ok
+}
+
+// lookup resolves a symbol reference — Name, Type.Method, pkg.Name,
+// pkg.Type.Method, path/to/pkg.Name, (*T).M — to repository objects.
+func (x *goIndex) lookup(symbol string) []types.Object {
+	symbol = strings.NewReplacer("(", "", ")", "", "*", "").Replace(strings.TrimSpace(symbol))
+	head := ""
+	if i := strings.LastIndex(symbol, "/"); i >= 0 {
+		head, symbol = symbol[:i+1], symbol[i+1:]
+	}
+	parts := strings.Split(symbol, ".")
+	var out []types.Object
+	for _, p := range x.pkgs {
+		if p.types == nil {
+			continue
+		}
+		rest := parts
+		if len(parts) > 1 && pkgMatches(p, head+parts[0]) {
+			rest = parts[1:]
+		} else if head != "" {
+			continue
+		}
+		out = append(out, scopeLookup(p.types, rest)...)
+	}
+	if len(out) == 0 && len(parts) == 1 && head == "" {
+		out = x.methodsNamed(parts[0])
+	}
+	return dedupeObjects(out)
+}
+
+func pkgMatches(p *goPkg, q string) bool {
+	path := strings.TrimSuffix(p.path, "_test")
+	return p.name == q || path == q || strings.HasSuffix(path, "/"+q)
+}
+
+// scopeLookup resolves Name or Type.Member in pkg.
+func scopeLookup(pkg *types.Package, parts []string) []types.Object {
+	obj := pkg.Scope().Lookup(parts[0])
+	if obj == nil {
+		return nil
+	}
+	if len(parts) == 1 {
+		return []types.Object{obj}
+	}
+	tn, ok := obj.(*types.TypeName)
+	if !ok || len(parts) != 2 {
+		return nil
+	}
+	m, _, _ := types.LookupFieldOrMethod(types.NewPointer(tn.Type()), true, pkg, parts[1])
+	if m == nil {
+		return nil
+	}
+	return []types.Object{m}
+}
+
+// methodsNamed finds methods (including interface methods) called name on any
+// repository type — the fallback for a bare method name.
+func (x *goIndex) methodsNamed(name string) []types.Object {
+	var out []types.Object
+	x.eachNamed(func(tn *types.TypeName, named *types.Named) {
+		for m := range named.Methods() {
+			if m.Name() == name {
+				out = append(out, m)
+			}
+		}
+		if iface, ok := named.Underlying().(*types.Interface); ok {
+			for m := range iface.ExplicitMethods() {
+				if m.Name() == name {
+					out = append(out, m)
+				}
+			}
+		}
+	})
+	return out
+}
+
+// eachNamed calls fn for every package-level named type in the repository.
+func (x *goIndex) eachNamed(fn func(*types.TypeName, *types.Named)) {
+	for _, p := range x.pkgs {
+		if p.types == nil {
+			continue
+		}
+		scope := p.types.Scope()
+		for _, n := range scope.Names() {
+			tn, ok := scope.Lookup(n).(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+			if named, ok := tn.Type().(*types.Named); ok {
+				fn(tn, named)
+			}
+		}
+	}
+}
+
+func dedupeObjects(in []types.Object) []types.Object {
+	seen := make(map[types.Object]bool, len(in))
+	out := in[:0]
+	for _, o := range in {
+		if !seen[o] {
+			seen[o] = true
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// origin maps an instantiated generic function or field to its declaration.
+func origin(obj types.Object) types.Object {
+	switch o := obj.(type) {
+	case *types.Func:
+		return o.Origin()
+	case *types.Var:
+		return o.Origin()
+	default:
+		return obj
+	}
+}
+
+// pos formats a position as path:line.
+func (x *goIndex) pos(p token.Pos) string {
+	ps := x.fset.Position(p)
+	return fmt.Sprintf("%s:%d", ps.Filename, ps.Line)
+}
+
+// line returns the trimmed source line at p.
+func (x *goIndex) line(p token.Pos) string {
+	ps := x.fset.Position(p)
+	src := x.src[ps.Filename]
+	start := ps.Offset - (ps.Column - 1)
+	if start < 0 || start > len(src) {
+		return ""
+	}
+	end := start
+	for end < len(src) && src[end] != '\n' {
+		end++
+	}
+	return strings.TrimSpace(string(src[start:end]))
+}
+
+// text returns the source between two positions of one file.
+func (x *goIndex) text(from, to token.Pos) string {
+	a, b := x.fset.Position(from), x.fset.Position(to)
+	src := x.src[a.Filename]
+	if a.Filename != b.Filename || a.Offset < 0 || b.Offset > len(src) || a.Offset > b.Offset {
+		return ""
+	}
+	return string(src[a.Offset:b.Offset])
+}
+
+// fileOf returns the parsed file containing p.
+func (x *goIndex) fileOf(p token.Pos) *ast.File {
+	for _, pkg := range x.pkgs {
+		for _, f := range pkg.files {
+			if f.FileStart <= p && p <= f.FileEnd {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// declOf returns the declaration node of obj (FuncDecl, GenDecl/TypeSpec,
+// ValueSpec or Field) and its doc comment, for showing its source.
+func (x *goIndex) declOf(obj types.Object) (ast.Node, *ast.CommentGroup) {
+	f := x.fileOf(obj.Pos())
+	if f == nil {
+		return nil, nil
+	}
+	var node ast.Node
+	var doc *ast.CommentGroup
+	var gen *ast.GenDecl
+	ast.Inspect(f, func(n ast.Node) bool {
+		if node != nil || n == nil {
+			return false
+		}
+		switch d := n.(type) {
+		case *ast.GenDecl:
+			gen = d
+		case *ast.FuncDecl:
+			if d.Name.Pos() == obj.Pos() {
+				node, doc = d, d.Doc
+			}
+		case *ast.TypeSpec:
+			if d.Name.Pos() == obj.Pos() {
+				node, doc = d, cmpDoc(d.Doc, gen)
+				if gen != nil && len(gen.Specs) == 1 {
+					node = gen
+				}
+			}
+		case *ast.ValueSpec:
+			for _, n := range d.Names {
+				if n.Pos() == obj.Pos() {
+					node, doc = d, cmpDoc(d.Doc, gen)
+				}
+			}
+		case *ast.Field:
+			for _, n := range d.Names {
+				if n.Pos() == obj.Pos() {
+					node, doc = d, d.Doc
+				}
+			}
+		}
+		return true
+	})
+	return node, doc
+}
+
+func cmpDoc(doc *ast.CommentGroup, gen *ast.GenDecl) *ast.CommentGroup {
+	if doc == nil && gen != nil {
+		return gen.Doc
+	}
+	return doc
+}
+
+// enclosingFuncs maps every function body span in a file to its display name,
+// for naming the caller of a call site.
+type funcSpan struct {
+	from, to token.Pos
+	name     string
+}
+
+func enclosingFunc(spans []funcSpan, p token.Pos) string {
+	for _, s := range spans {
+		if s.from <= p && p <= s.to {
+			return s.name
+		}
+	}
+	return "(package scope)"
+}
+
+func funcSpans(f *ast.File) []funcSpan {
+	var spans []funcSpan
+	for _, d := range f.Decls {
+		if fd, ok := d.(*ast.FuncDecl); ok && fd.Body != nil {
+			spans = append(spans, funcSpan{fd.Pos(), fd.End(), funcDeclName(fd)})
+		}
+	}
+	return spans
+}
+
+// funcDeclName renders a FuncDecl as Name or (*Recv).Name.
+func funcDeclName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+	return "(" + types.ExprString(fd.Recv.List[0].Type) + ")." + fd.Name.Name
+}
+
+// calleeIdent returns the identifier naming the function a call invokes.
+func calleeIdent(fun ast.Expr) *ast.Ident {
+	for {
+		switch e := fun.(type) {
+		case *ast.ParenExpr:
+			fun = e.X
+		case *ast.IndexExpr:
+			fun = e.X
+		case *ast.IndexListExpr:
+			fun = e.X
+		case *ast.SelectorExpr:
+			return e.Sel
+		case *ast.Ident:
+			return e
+		default:
+			return nil
+		}
+	}
+}
@@ -12,6 +12,9 @@ import (
 // errMaxRounds is returned when the loop exhausts MaxRounds without a submit.
 var errMaxRounds = errors.New("direct: max rounds reached without submit_review")
 
+// errBudget is returned when the loop spends Options.MaxTokens without a submit.
+var errBudget = errors.New("direct: token budget exhausted without submit_review")
+
 // nudgeSubmit is injected once if the model stops producing tool calls before
 // submitting the review.
 const nudgeSubmit = "You have not called submit_review yet. " +
@@ -21,11 +24,16 @@ const nudgeSubmit = "You have not called submit_review yet. " +
 type Result struct {
 	Usage         Usage
 	Rounds        int
-	StopReason    string // "submitted" | "end_turn" | "max_rounds" | "error"
+	StopReason    string // "submitted" | "end_turn" | "max_rounds" | "budget" | "error"
 	Submitted     bool
 	Model         string
 	CostUsd       float64
 	DurationAPIMs int // cumulative time spent in provider Complete calls
+	// Agents is the per-loop breakdown of a sub-agent run (RunAgents); Usage,
+	// Rounds and CostUsd above are its totals. Nil for a single loop.
+	Agents []AgentUsage
+	// Tools is the per-tool use over the whole run (Registry.ToolStats).
+	Tools map[string]ToolStats
 }
 
 // Run drives the agent loop: send system + history + tools to the provider, run
@@ -37,6 +45,9 @@ func Run(ctx context.Context, p LLMProvider, reg *Registry, system, userPrompt s
 	}
 
 	msgs := []Message{{Role: RoleUser, Text: userPrompt}}
+	if len(opts.History) > 0 {
+		msgs = withHarnessNote(opts.History, userPrompt)
+	}
 	var total Usage
 	var apiMs int // cumulative provider Complete time (vs total wall-clock)
 	nudged := false
@@ -51,16 +62,31 @@ func Run(ctx context.Context, p LLMProvider, reg *Registry, system, userPrompt s
 	finish := func(rounds int, stop string, submitted bool) *Result {
 		r := makeResult(total, rounds, stop, submitted, p)
 		r.DurationAPIMs = apiMs
-		opts.OnEvent.emit(Event{Kind: "result", Rounds: r.Rounds, Usage: &r.Usage, StopReason: r.StopReason, CostUsd: r.CostUsd, Submitted: r.Submitted, Model: r.Model})
+		r.Tools = reg.ToolStats()
+		opts.checkpoint(msgs)
+		opts.OnEvent.emit(Event{Kind: "result", Rounds: r.Rounds, Usage: &r.Usage, StopReason: r.StopReason, CostUsd: r.CostUsd, Submitted: r.Submitted, Model: r.Model, Tools: r.Tools})
 		return r
 	}
 
+	if err := preflight(ctx, p, reg, system, msgs, opts.Limits); err != nil {
+		return finish(0, "error", false), err
+	}
+
+	var measured Usage // last round's billed usage, for the compaction trigger
+	measuredAt := 0    // len(msgs) the measurement covers
 	for round := range opts.MaxRounds {
 		if err := ctx.Err(); err != nil {
 			return finish(round, "cancelled", reg.Submitted()), err
 		}
 		t0 := time.Now()
-		resp, err := p.Complete(ctx, Request{System: system, Messages: msgs, Tools: reg.Defs(), Effort: opts.Effort})
+		resp, err := p.Complete(ctx, Request{
+			System:      system,
+			Messages:    msgs,
+			Tools:       reg.Defs(),
+			Effort:      opts.Effort,
+			OnDelta:     deltaSink(opts.OnEvent, round),
+			IdleTimeout: opts.StreamIdleTimeout,
+		})
 		apiMs += int(time.Since(t0).Milliseconds())
 		if err != nil {
 			return finish(round, "error", reg.Submitted()), fmt.Errorf("round %d: %w", round, err)
@@ -68,6 +94,7 @@ func Run(ctx context.Context, p LLMProvider, reg *Registry, system, userPrompt s
 		total = sumUsage(total, resp.Usage)
 		emitRound(opts.OnEvent, round, resp)
 		msgs = append(msgs, Message{Role: RoleAssistant, Text: resp.Text, ToolCalls: resp.ToolCalls, Raw: resp.Raw})
+		measured, measuredAt = resp.Usage, len(msgs)
 
 		if len(resp.ToolCalls) == 0 {
 			// Model produced only text. If it hasn't submitted, nudge once; on a
@@ -89,14 +116,38 @@ func Run(ctx context.Context, p LLMProvider, reg *Registry, system, userPrompt s
 		if reg.Submitted() {
 			return finish(round+1, "submitted", true), nil
 		}
-		if opts.CompactAt > 0 && estimateTokens(msgs) > opts.CompactAt {
-			msgs = compactMessages(msgs, opts.KeepTail)
+		if opts.MaxTokens > 0 && promptTokens(total)+total.OutputTokens >= opts.MaxTokens {
+			return finish(round+1, "budget", false), errBudget
+		}
+		if opts.CompactAt > 0 && contextTokens(msgs, measured, measuredAt) > opts.CompactAt {
+			var cu Usage
+			msgs, cu = compactHistory(ctx, p, reg, system, msgs, opts, round)
+			total = sumUsage(total, cu)
+			measured = Usage{} // stale: re-estimate until the next round reports
 		}
+		opts.checkpoint(msgs)
 	}
 
 	return finish(opts.MaxRounds, "max_rounds", reg.Submitted()), errMaxRounds
 }
 
+// preflight refuses a kickoff that cannot fit the model's input budget, so an
+// oversized preload fails fast with a clear error instead of a provider 400 (or
+// a silently truncated prompt on some OpenAI-compatible servers). Skipped when
+// the limits are unknown.
+func preflight(ctx context.Context, p LLMProvider, reg *Registry, system string, msgs []Message, limits ModelLimits) error {
+	budget := limits.InputBudget()
+	if budget <= 0 {
+		return nil
+	}
+	n := requestTokens(ctx, p, Request{System: system, Messages: msgs, Tools: reg.Defs()})
+	if n > budget {
+		return fmt.Errorf("%w: %d tokens, %s accepts %d (window %d − max output %d)",
+			errContextOverflow, n, p.Model(), budget, limits.ContextWindow, limits.MaxOutput)
+	}
+	return nil
+}
+
 // emitRound records the model's text, requested tool calls and per-round usage.
 func emitRound(s Sink, round int, resp Response) {
 	if s == nil {
@@ -112,6 +163,24 @@ func emitRound(s Sink, round int, resp Response) {
 	s.emit(Event{Round: round, Kind: "round", Usage: &u, StopReason: resp.StopReason})
 }
 
+// deltaKinds maps a streamed delta onto its transcript event kind.
+var deltaKinds = map[string]string{
+	DeltaText:     "text_delta",
+	DeltaThinking: "thinking_delta",
+	DeltaToolCall: "tool_delta",
+}
+
+// deltaSink turns streamed partial output into transcript events for round, so
+// a long round shows live progress. Nil when there is no sink.
+func deltaSink(s Sink, round int) func(StreamDelta) {
+	if s == nil {
+		return nil
+	}
+	return func(d StreamDelta) {
+		s.emit(Event{Round: round, Kind: deltaKinds[d.Kind], Text: d.Text, Tool: d.Tool})
+	}
+}
+
 func makeResult(total Usage, rounds int, stop string, submitted bool, p LLMProvider) *Result {
 	return &Result{
 		Usage:      total,
@@ -160,60 +229,3 @@ func dispatchParallel(ctx context.Context, reg *Registry, calls []ToolCall) []To
 	wg.Wait()
 	return results
 }
-
-// estimateTokens is a rough char/4 heuristic used only to trigger compaction.
-func estimateTokens(msgs []Message) int {
-	n := 0
-	for _, m := range msgs {
-		n += len(m.Text) / 4
-		for _, tc := range m.ToolCalls {
-			n += len(tc.Args)/4 + len(tc.Name)
-		}
-		for _, tr := range m.ToolResults {
-			n += len(tr.Content) / 4
-		}
-	}
-	return n
-}
-
-// compactMessages prunes the middle of the conversation, keeping the first
-// message (the task) and the last keepTail messages. The tail boundary is moved
-// forward off a tool message so a tool result never leads without its assistant
-// tool_use turn (which would break provider translation).
-func compactMessages(msgs []Message, keepTail int) []Message {
-	const keepHead = 1
-	if keepTail <= 0 {
-		keepTail = DefaultOptions().KeepTail
-	}
-	if len(msgs) <= keepHead+keepTail+1 {
-		return msgs
-	}
-	cut := len(msgs) - keepTail
-	// The kept head is the user task, so the tail must resume on an assistant
-	// turn: advance off any leading tool result (which would lead without its
-	// tool_use turn) AND any leading user message (which would collide with the
-	// head into two consecutive user messages — the Anthropic API rejects that).
-	for cut < len(msgs) && msgs[cut].Role != RoleAssistant {
-		cut++
-	}
-	// If no assistant turn remains in the tail, advancing past everything would
-	// drop it all — skip compaction this round rather than lose the tail.
-	if cut >= len(msgs) {
-		return msgs
-	}
-	dropped := cut - keepHead
-	if dropped <= 0 {
-		return msgs
-	}
-	// Fold the compaction marker into the head (kept) message rather than
-	// inserting a separate one — a standalone marker after the head user turn
-	// would put two consecutive user messages in the history, which the Anthropic
-	// API rejects (roles must alternate).
-	head := msgs[0] // keepHead == 1
-	head.Text = strings.TrimSpace(head.Text +
-		fmt.Sprintf("\n\n[compacted: %d earlier messages omitted to fit context]", dropped))
-	out := make([]Message, 0, 1+(len(msgs)-cut))
-	out = append(out, head)
-	out = append(out, msgs[cut:]...)
-	return out
-}
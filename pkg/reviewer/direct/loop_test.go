@@ -3,10 +3,10 @@ package direct
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -17,6 +17,7 @@ type scriptedProvider struct {
 	responses []Response
 	i         int
 	seen      []Request
+	model     string // "fake-model" when empty
 }
 
 func (s *scriptedProvider) Complete(_ context.Context, req Request) (Response, error) {
@@ -29,7 +30,13 @@ func (s *scriptedProvider) Complete(_ context.Context, req Request) (Response, e
 	return r, nil
 }
 
-func (s *scriptedProvider) Model() string    { return "fake-model" }
+func (s *scriptedProvider) Model() string {
+	if s.model == "" {
+		return "fake-model"
+	}
+	return s.model
+}
+
 func (s *scriptedProvider) Pricing() Pricing { return Pricing{InputPerMTok: 1, OutputPerMTok: 1} }
 
 func validSubmitArgs(t *testing.T, severity string) json.RawMessage {
@@ -162,59 +169,6 @@ func TestRunEmitsTranscript(t *testing.T) {
 	require.Equal(t, 15, last.Usage.InputTokens) // 10 + 5 accumulated
 }
 
-func TestCompactMessages(t *testing.T) {
-	// mk builds a realistic history: user task, then alternating assistant/tool
-	// turns (odd index = assistant, even = tool).
-	mk := func(n int) []Message {
-		m := make([]Message, n)
-		m[0] = Message{Role: RoleUser, Text: "task"}
-		for i := 1; i < n; i++ {
-			if i%2 == 1 {
-				m[i] = Message{Role: RoleAssistant, Text: fmt.Sprintf("a%d", i)}
-			} else {
-				m[i] = Message{Role: RoleTool}
-			}
-		}
-		return m
-	}
-
-	// Short conversation: returned unchanged.
-	require.Len(t, compactMessages(mk(3), 12), 3)
-
-	// Long: head kept with the marker folded in (no separate user message), tail
-	// resumes on an assistant turn so head+tail never collide into two users.
-	out := compactMessages(mk(40), 5)
-	require.Contains(t, out[0].Text, "task")
-	require.Contains(t, out[0].Text, "compacted")
-	require.Equal(t, RoleUser, out[0].Role)
-	require.Equal(t, RoleAssistant, out[1].Role, "tail must resume on an assistant turn")
-	require.Len(t, out, 1+5)
-
-	// C3: a tail boundary landing on a mid-history user message (e.g. a nudge)
-	// must advance to the next assistant turn, never leaving two consecutive
-	// user messages (which the Anthropic API rejects).
-	withNudge := []Message{
-		{Role: RoleUser, Text: "task"},
-		{Role: RoleAssistant, Text: "a1"},
-		{Role: RoleTool},
-		{Role: RoleUser, Text: "nudge"},
-		{Role: RoleAssistant, Text: "a2"},
-		{Role: RoleTool},
-	}
-	got := compactMessages(withNudge, 3) // cut lands on the nudge(user)
-	require.Equal(t, RoleUser, got[0].Role)
-	require.NotEqual(t, RoleUser, got[1].Role, "must not produce two consecutive user messages")
-
-	// Tail entirely tool messages: no assistant to resume on, so compaction is
-	// skipped rather than dropping the whole tail.
-	allTail := make([]Message, 20)
-	allTail[0] = Message{Role: RoleUser, Text: "task"}
-	for i := 1; i < 20; i++ {
-		allTail[i] = Message{Role: RoleTool}
-	}
-	require.Len(t, compactMessages(allTail, 3), 20)
-}
-
 func TestRunRespectsContextCancel(t *testing.T) {
 	dir := t.TempDir()
 	reg := NewReviewRegistry(ReviewToolsConfig{Dir: dir})
@@ -266,3 +220,36 @@ func TestDispatchParallelRecoversPanic(t *testing.T) {
 	require.False(t, results[1].IsError)
 	require.Equal(t, "fine", results[1].Content)
 }
+
+// streamingProvider replays a scripted response as text deltas before returning
+// it, like a real streaming provider would.
+type streamingProvider struct{ scriptedProvider }
+
+func (s *streamingProvider) Complete(ctx context.Context, req Request) (Response, error) {
+	resp, err := s.scriptedProvider.Complete(ctx, req)
+	if err == nil && req.OnDelta != nil {
+		req.OnDelta(StreamDelta{Kind: DeltaThinking, Text: "hmm"})
+		req.OnDelta(StreamDelta{Kind: DeltaText, Text: resp.Text})
+	}
+	return resp, err
+}
+
+func TestRunForwardsStreamDeltas(t *testing.T) {
+	reg := NewReviewRegistry(ReviewToolsConfig{Dir: t.TempDir()})
+	prov := &streamingProvider{scriptedProvider{responses: []Response{
+		{Text: "checking", ToolCalls: []ToolCall{{ID: "1", Name: "submit_review", Args: validSubmitArgs(t, "low")}}},
+	}}}
+
+	var deltas []Event
+	opts := Options{MaxRounds: 3, StreamIdleTimeout: time.Minute, OnEvent: func(ev Event) {
+		if ev.Kind == "text_delta" || ev.Kind == "thinking_delta" {
+			deltas = append(deltas, ev)
+		}
+	}}
+	_, err := Run(context.Background(), prov, reg, "sys", "go", opts)
+	require.NoError(t, err)
+	require.Len(t, deltas, 2)
+	require.Equal(t, "thinking_delta", deltas[0].Kind)
+	require.Equal(t, "checking", deltas[1].Text)
+	require.Equal(t, time.Minute, prov.seen[0].IdleTimeout, "idle timeout is passed through to the provider")
+}
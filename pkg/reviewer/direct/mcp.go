@@ -0,0 +1,428 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// mcpProtocolVersion is the MCP revision the client speaks.
+	mcpProtocolVersion = "2025-06-18"
+	// mcpConnectTimeout bounds starting a server and listing its tools.
+	mcpConnectTimeout = 30 * time.Second
+	// mcpCallTimeout is the default per-call timeout.
+	mcpCallTimeout = time.Minute
+	// mcpToolSep joins the server prefix and the tool name. Built-in tools never
+	// contain it, so a mounted tool can't shadow one.
+	mcpToolSep = "__"
+	// mcpDescClip caps a server-supplied tool description.
+	mcpDescClip = 2_000
+)
+
+var (
+	mcpServerNameRe = regexp.MustCompile(`^[A-Za-z][\w-]*$`)
+	// toolNameRe is what every provider accepts as a tool name.
+	toolNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+// MCPServer is an external MCP tool server mounted into the review loop.
+// Exactly one of Command (stdio) or URL (streamable HTTP) is set.
+type MCPServer struct {
+	// Name prefixes the server's tools: tool "search" of server "adr" is
+	// offered to the model as "adr__search".
+	Name    string
+	Command []string
+	// Env is added to a stdio server's environment.
+	Env     map[string]string
+	URL     string
+	Headers map[string]string
+	// Allow restricts the tools offered to the model (server-side names);
+	// empty offers every tool the server lists.
+	Allow []string
+	// Timeout bounds one tool call (0 = 1m); ToolTimeouts overrides it per
+	// tool (server-side names).
+	Timeout      time.Duration
+	ToolTimeouts map[string]time.Duration
+}
+
+// mcpConfigFile is the --mcp-config file: the .mcp.json layout ("mcpServers"
+// keyed by name, "command" + "args" or "url"), plus allow-list and timeouts.
+// "${VAR}" in env, url and header values is expanded from the environment so
+// tokens stay out of the file.
+type mcpConfigFile struct {
+	Servers map[string]struct {
+		Command      string            `json:"command"`
+		Args         []string          `json:"args"`
+		Env          map[string]string `json:"env"`
+		URL          string            `json:"url"`
+		Headers      map[string]string `json:"headers"`
+		Allow        []string          `json:"allow"`
+		Timeout      string            `json:"timeout"`
+		ToolTimeouts map[string]string `json:"toolTimeouts"`
+	} `json:"mcpServers"`
+}
+
+// LoadMCPConfig reads an MCP server config file.
+func LoadMCPConfig(path string) ([]MCPServer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMCPConfig(data)
+}
+
+// ParseMCPConfig parses MCP server config (see mcpConfigFile). Servers are
+// returned sorted by name, so the tool list — and the prompt cache — is stable.
+func ParseMCPConfig(data []byte) ([]MCPServer, error) {
+	var f mcpConfigFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decode mcp config: %w", err)
+	}
+	var out []MCPServer
+	for _, name := range slices.Sorted(maps.Keys(f.Servers)) {
+		c := f.Servers[name]
+		s := MCPServer{Name: name, Env: expandValues(c.Env), URL: os.ExpandEnv(c.URL), Headers: expandValues(c.Headers), Allow: c.Allow}
+		if c.Command != "" {
+			s.Command = append([]string{c.Command}, c.Args...)
+		}
+		var err error
+		if s.Timeout, err = parseTimeout(c.Timeout); err != nil {
+			return nil, fmt.Errorf("mcp server %q: timeout: %w", name, err)
+		}
+		for tool, v := range c.ToolTimeouts {
+			d, err := parseTimeout(v)
+			if err != nil {
+				return nil, fmt.Errorf("mcp server %q: tool %q timeout: %w", name, tool, err)
+			}
+			if s.ToolTimeouts == nil {
+				s.ToolTimeouts = map[string]time.Duration{}
+			}
+			s.ToolTimeouts[tool] = d
+		}
+		if err := s.validate(); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func (s MCPServer) validate() error {
+	switch {
+	case !mcpServerNameRe.MatchString(s.Name):
+		return fmt.Errorf("mcp server %q: invalid name", s.Name)
+	case (len(s.Command) == 0) == (s.URL == ""):
+		return fmt.Errorf("mcp server %q: set exactly one of command or url", s.Name)
+	case s.URL != "" && !strings.HasPrefix(s.URL, "http://") && !strings.HasPrefix(s.URL, "https://"):
+		return fmt.Errorf("mcp server %q: url must be http(s)", s.Name)
+	}
+	return nil
+}
+
+func parseTimeout(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err == nil && d <= 0 {
+		err = errors.New("must be positive")
+	}
+	return d, err
+}
+
+func expandValues(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = os.ExpandEnv(v)
+	}
+	return out
+}
+
+// callTimeout is the timeout for one call of the named tool.
+func (s MCPServer) callTimeout(tool string) time.Duration {
+	if d, ok := s.ToolTimeouts[tool]; ok {
+		return d
+	}
+	return positiveOr(s.Timeout, mcpCallTimeout)
+}
+
+// mcpClient is a connected MCP server.
+type mcpClient struct {
+	server MCPServer
+	t      mcpTransport
+	nextID atomic.Int64
+}
+
+// mcpTool is a tool listed by a server.
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// connectMCP starts (or dials) the server, runs the initialize handshake and
+// lists its tools.
+func connectMCP(ctx context.Context, dir string, s MCPServer) (*mcpClient, []mcpTool, error) {
+	c := &mcpClient{server: s}
+	if len(s.Command) > 0 {
+		t, err := startStdio(dir, s.Command, s.Env)
+		if err != nil {
+			return nil, nil, fmt.Errorf("start: %w", err)
+		}
+		c.t = t
+	} else {
+		c.t = newHTTPTransport(s.URL, s.Headers)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mcpConnectTimeout)
+	defer cancel()
+	tools, err := c.handshake(ctx)
+	if err != nil {
+		_ = c.t.close()
+		return nil, nil, err
+	}
+	return c, tools, nil
+}
+
+func (c *mcpClient) handshake(ctx context.Context) ([]mcpTool, error) {
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	err := c.request(ctx, "initialize", map[string]any{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": "reviewctl", "version": "1"},
+	}, &init)
+	if err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+	if h, ok := c.t.(*httpTransport); ok {
+		h.setVersion(init.ProtocolVersion)
+	}
+	if _, err := c.t.send(ctx, &rpcMessage{JSONRPC: "2.0", Method: "notifications/initialized"}); err != nil {
+		return nil, fmt.Errorf("initialized: %w", err)
+	}
+
+	var tools []mcpTool
+	cursor := ""
+	for {
+		var page struct {
+			Tools      []mcpTool `json:"tools"`
+			NextCursor string    `json:"nextCursor"`
+		}
+		var params any
+		if cursor != "" {
+			params = map[string]any{"cursor": cursor}
+		}
+		if err := c.request(ctx, "tools/list", params, &page); err != nil {
+			return nil, fmt.Errorf("tools/list: %w", err)
+		}
+		tools = append(tools, page.Tools...)
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return tools, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// request sends a JSON-RPC request and decodes its result into out.
+func (c *mcpClient) request(ctx context.Context, method string, params, out any) error {
+	id := strconv.FormatInt(c.nextID.Add(1), 10)
+	resp, err := c.t.send(ctx, &rpcMessage{JSONRPC: "2.0", ID: json.RawMessage(id), Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return fmt.Errorf("decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// callTool runs a tool and renders its content for the model. A result the
+// server flags isError comes back as an error carrying that text.
+func (c *mcpClient) callTool(ctx context.Context, name string, args json.RawMessage) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.server.callTimeout(name))
+	defer cancel()
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage(`{}`)
+	}
+	var res struct {
+		Content []struct {
+			Type     string `json:"type"`
+			Text     string `json:"text"`
+			Resource *struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"resource"`
+		} `json:"content"`
+		StructuredContent json.RawMessage `json:"structuredContent"`
+		IsError           bool            `json:"isError"`
+	}
+	if err := c.request(ctx, "tools/call", map[string]any{"name": name, "arguments": args}, &res); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil {
+			return "", fmt.Errorf("timed out after %s", c.server.callTimeout(name))
+		}
+		return "", err
+	}
+
+	parts := make([]string, 0, len(res.Content))
+	for _, p := range res.Content {
+		switch {
+		case p.Type == "text":
+			parts = append(parts, p.Text)
+		case p.Type == "resource" && p.Resource != nil && p.Resource.Text != "":
+			parts = append(parts, fmt.Sprintf("[%s]\n%s", p.Resource.URI, p.Resource.Text))
+		default:
+			parts = append(parts, fmt.Sprintf("[%s content omitted]", p.Type))
+		}
+	}
+	if len(parts) == 0 && len(res.StructuredContent) > 0 {
+		parts = append(parts, string(res.StructuredContent))
+	}
+	out := clip(strings.Join(parts, "\n"))
+	if res.IsError {
+		return "", errors.New(out)
+	}
+	return out, nil
+}
+
+// MCPTools is the set of connected MCP servers of one review and the tools
+// they offer. It is shared by every loop of the run (planner and sub-agents
+// included) and must be closed when the review ends.
+type MCPTools struct {
+	clients []*mcpClient
+	tools   []mcpMounted
+}
+
+// mcpMounted is one server tool as offered to the model.
+type mcpMounted struct {
+	def    ToolDef
+	client *mcpClient
+	name   string // server-side name
+}
+
+// ConnectMCP connects the configured servers, in dir for stdio ones. It is
+// best-effort: a server that fails to start, or a tool that can't be offered,
+// is reported as an "mcp" event on sink and skipped — the review goes on with
+// the rest.
+func ConnectMCP(ctx context.Context, dir string, servers []MCPServer, sink Sink) *MCPTools {
+	m := &MCPTools{}
+	clients := make([]*mcpClient, len(servers))
+	listed := make([][]mcpTool, len(servers))
+	errs := make([]error, len(servers))
+	var wg sync.WaitGroup
+	for i, s := range servers {
+		wg.Go(func() { clients[i], listed[i], errs[i] = connectMCP(ctx, dir, s) })
+	}
+	wg.Wait()
+
+	for i, s := range servers {
+		if errs[i] != nil {
+			sink.emit(Event{Kind: "mcp", Text: s.Name, Content: errs[i].Error(), IsError: true})
+			continue
+		}
+		m.clients = append(m.clients, clients[i])
+		var mounted, skipped []string
+		for _, t := range listed[i] {
+			if len(s.Allow) > 0 && !slices.Contains(s.Allow, t.Name) {
+				continue
+			}
+			name := s.Name + mcpToolSep + t.Name
+			if !toolNameRe.MatchString(name) {
+				skipped = append(skipped, t.Name)
+				continue
+			}
+			m.tools = append(m.tools, mcpMounted{def: mcpToolDef(name, s.Name, t), client: clients[i], name: t.Name})
+			mounted = append(mounted, name)
+		}
+		for _, a := range s.Allow {
+			if !slices.ContainsFunc(listed[i], func(t mcpTool) bool { return t.Name == a }) {
+				skipped = append(skipped, a+" (not offered by the server)")
+			}
+		}
+		ev := Event{Kind: "mcp", Text: s.Name, Content: "tools: " + strings.Join(mounted, ", ")}
+		if len(skipped) > 0 {
+			ev.Content += "; skipped: " + strings.Join(skipped, ", ")
+		}
+		sink.emit(ev)
+	}
+	return m
+}
+
+// mcpToolDef turns a server tool into a ToolDef under its prefixed name.
+func mcpToolDef(name, server string, t mcpTool) ToolDef {
+	desc := fmt.Sprintf("[MCP server %s] %s", server, clipN(strings.TrimSpace(t.Description), mcpDescClip))
+	return ToolDef{Name: name, Description: desc, Schema: mcpSchema(t.InputSchema)}
+}
+
+// mcpSchema normalises a server's input schema to what the providers expect:
+// an object schema with properties and "required" as []string.
+func mcpSchema(in map[string]any) map[string]any {
+	s := maps.Clone(in)
+	if s == nil {
+		s = map[string]any{}
+	}
+	s[jsType] = jsObject
+	if _, ok := s[jsProps].(map[string]any); !ok {
+		s[jsProps] = map[string]any{}
+	}
+	if req, ok := s[jsRequired].([]any); ok {
+		names := make([]string, 0, len(req))
+		for _, r := range req {
+			if n, ok := r.(string); ok {
+				names = append(names, n)
+			}
+		}
+		s[jsRequired] = names
+	}
+	return s
+}
+
+// register adds the mounted tools to reg.
+func (m *MCPTools) register(reg *Registry) {
+	for _, t := range m.tools {
+		h := func(ctx context.Context, args json.RawMessage) (string, error) {
+			out, err := t.client.callTool(ctx, t.name, args)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", t.def.Name, err)
+			}
+			return out, nil
+		}
+		reg.Register(t.def, h)
+	}
+}
+
+// Names returns the mounted tool names, as offered to the model.
+func (m *MCPTools) Names() []string {
+	out := make([]string, 0, len(m.tools))
+	for _, t := range m.tools {
+		out = append(out, t.def.Name)
+	}
+	return out
+}
+
+// Close shuts every server down.
+func (m *MCPTools) Close() error {
+	var errs []error
+	for _, c := range m.clients {
+		errs = append(errs, c.t.close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,220 @@
+package direct
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubMCPEnv makes the test binary act as a stdio MCP server (TestMCPStubProcess).
+const stubMCPEnv = "DIRECT_MCP_STUB"
+
+// stubMCPAnswer is a tiny MCP server: tools "search" (echoes its query),
+// "slow" (sleeps past any test timeout), "fail" (an isError result) and
+// "bad name" (not a valid model tool name).
+func stubMCPAnswer(m rpcMessage) *rpcMessage {
+	if len(m.ID) == 0 {
+		return nil
+	}
+	out := &rpcMessage{JSONRPC: "2.0", ID: m.ID}
+	params, _ := json.Marshal(m.Params)
+	switch m.Method {
+	case "initialize":
+		out.Result = json.RawMessage(`{"protocolVersion":"2025-06-18","capabilities":{"tools":{}},"serverInfo":{"name":"stub","version":"1"}}`)
+	case "tools/list":
+		out.Result = json.RawMessage(`{"tools":[
+			{"name":"search","description":"Search ADRs","inputSchema":{"type":"object","properties":{"query":{"type":"string"}},"required":["query"]}},
+			{"name":"slow","inputSchema":{"type":"object"}},
+			{"name":"fail","inputSchema":{"type":"object"}},
+			{"name":"bad name","inputSchema":{"type":"object"}}]}`)
+	case "tools/call":
+		var p struct {
+			Name      string `json:"name"`
+			Arguments struct {
+				Query string `json:"query"`
+			} `json:"arguments"`
+		}
+		_ = json.Unmarshal(params, &p)
+		switch p.Name {
+		case "slow":
+			time.Sleep(time.Second)
+			out.Result = json.RawMessage(`{"content":[]}`)
+		case "fail":
+			out.Result = json.RawMessage(`{"content":[{"type":"text","text":"index offline"}],"isError":true}`)
+		default:
+			res, _ := json.Marshal(map[string]any{"content": []map[string]any{
+				{"type": "text", "text": "found: " + p.Arguments.Query},
+				{"type": "image", "data": "AAAA", "mimeType": "image/png"},
+			}})
+			out.Result = res
+		}
+	default:
+		out.Error = &rpcError{Code: rpcMethodNotFound, Message: "unknown method"}
+	}
+	return out
+}
+
+// TestMCPStubProcess is not a test: run with stubMCPEnv set it serves
+// stubMCPAnswer over stdio, one goroutine per request like a real server.
+func TestMCPStubProcess(t *testing.T) {
+	if os.Getenv(stubMCPEnv) == "" {
+		t.Skip("helper process")
+	}
+	fmt.Println("stub: not json, ignored")
+	enc := json.NewEncoder(os.Stdout)
+	out := make(chan *rpcMessage)
+	go func() {
+		for m := range out {
+			_ = enc.Encode(m)
+		}
+	}()
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		var m rpcMessage
+		if json.Unmarshal(sc.Bytes(), &m) != nil {
+			continue
+		}
+		go func() {
+			if resp := stubMCPAnswer(m); resp != nil {
+				out <- resp
+			}
+		}()
+	}
+	os.Exit(0)
+}
+
+func stubStdioServer(t *testing.T) MCPServer {
+	t.Helper()
+	return MCPServer{
+		Name:    "adr",
+		Command: []string{os.Args[0], "-test.run=^TestMCPStubProcess$"},
+		Env:     map[string]string{stubMCPEnv: "1"},
+	}
+}
+
+func TestParseMCPConfig(t *testing.T) {
+	t.Setenv("ADR_TOKEN", "secret")
+	servers, err := ParseMCPConfig([]byte(`{"mcpServers":{
+		"schemas":{"url":"https://schemas.local/mcp","headers":{"Authorization":"Bearer ${ADR_TOKEN}"},"timeout":"10s"},
+		"adr":{"command":"adr-mcp","args":["--stdio"],"allow":["search"],"toolTimeouts":{"search":"2m"}}}}`))
+	require.NoError(t, err)
+	require.Equal(t, []MCPServer{
+		{Name: "adr", Command: []string{"adr-mcp", "--stdio"}, Allow: []string{"search"}, ToolTimeouts: map[string]time.Duration{"search": 2 * time.Minute}},
+		{Name: "schemas", URL: "https://schemas.local/mcp", Headers: map[string]string{"Authorization": "Bearer secret"}, Timeout: 10 * time.Second},
+	}, servers)
+	require.Equal(t, 2*time.Minute, servers[0].callTimeout("search"))
+	require.Equal(t, mcpCallTimeout, servers[0].callTimeout("other"))
+
+	for _, bad := range []string{
+		`{"mcpServers":{"x":{}}}`,
+		`{"mcpServers":{"x":{"command":"a","url":"http://b"}}}`,
+		`{"mcpServers":{"x":{"url":"file:///etc"}}}`,
+		`{"mcpServers":{"a b":{"command":"a"}}}`,
+		`{"mcpServers":{"x":{"command":"a","timeout":"soon"}}}`,
+		`{"mcpServers":{"x":{"command":"a","toolTimeouts":{"t":"-1s"}}}}`,
+	} {
+		_, err = ParseMCPConfig([]byte(bad))
+		require.Error(t, err, bad)
+	}
+}
+
+func TestMCPStdioServer(t *testing.T) {
+	s := stubStdioServer(t)
+	s.Allow = []string{"search", "slow", "bad name", "missing"}
+	s.ToolTimeouts = map[string]time.Duration{"slow": 50 * time.Millisecond}
+	var events []Event
+	m := ConnectMCP(context.Background(), t.TempDir(), []MCPServer{s}, func(ev Event) { events = append(events, ev) })
+	defer func() { require.NoError(t, m.Close()) }()
+
+	require.Equal(t, []string{"adr__search", "adr__slow"}, m.Names(), "allow-listed, valid names only")
+	require.Len(t, events, 1)
+	require.Equal(t, "mcp", events[0].Kind)
+	require.False(t, events[0].IsError)
+	require.Equal(t, "tools: adr__search, adr__slow; skipped: bad name, missing (not offered by the server)", events[0].Content)
+
+	// Mounted after the built-in tools of a review registry.
+	reg := NewReviewRegistry(ReviewToolsConfig{Dir: t.TempDir(), MCP: m})
+	defs := reg.Defs()
+	require.Equal(t, "adr__slow", defs[len(defs)-4].Name)
+	search := defs[len(defs)-5]
+	require.Equal(t, "adr__search", search.Name)
+	require.Equal(t, "[MCP server adr] Search ADRs", search.Description)
+	require.Equal(t, []string{"query"}, search.Schema[jsRequired])
+
+	out, err := reg.Dispatch(context.Background(), "adr__search", json.RawMessage(`{"query":"caching"}`))
+	require.NoError(t, err)
+	require.Equal(t, "found: caching\n[image content omitted]", out)
+
+	_, err = reg.Dispatch(context.Background(), "adr__slow", nil)
+	require.ErrorContains(t, err, "adr__slow: timed out after 50ms")
+
+	// The server survives a timed-out call.
+	out, err = reg.Dispatch(context.Background(), "adr__search", json.RawMessage(`{"query":"again"}`))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out, "found: again"))
+}
+
+func TestMCPHTTPServer(t *testing.T) {
+	var sawSession, sawVersion, sawAuth bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			return
+		}
+		var m rpcMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&m))
+		sawAuth = r.Header.Get("Authorization") == "Bearer t"
+		if m.Method == "initialize" {
+			w.Header().Set("Mcp-Session-Id", "s-1")
+		} else {
+			sawSession = r.Header.Get("Mcp-Session-Id") == "s-1"
+			sawVersion = r.Header.Get("Mcp-Protocol-Version") == mcpProtocolVersion
+		}
+		resp := stubMCPAnswer(m)
+		switch {
+		case resp == nil:
+			w.WriteHeader(http.StatusAccepted)
+		case m.Method == "tools/call": // answered as an event stream, after a notification
+			w.Header().Set("Content-Type", "text/event-stream")
+			data, _ := json.Marshal(resp)
+			fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resp)
+		}
+	}))
+	defer srv.Close()
+
+	var events []Event
+	sink := func(ev Event) { events = append(events, ev) }
+	servers := []MCPServer{
+		{Name: "catalog", URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer t"}, Allow: []string{"search", "fail"}},
+		{Name: "broken", Command: []string{"/nonexistent/mcp-server"}},
+	}
+	m := ConnectMCP(context.Background(), t.TempDir(), servers, sink)
+	defer func() { require.NoError(t, m.Close()) }()
+	require.Equal(t, []string{"catalog__search", "catalog__fail"}, m.Names())
+	require.Len(t, events, 2)
+	require.True(t, events[1].IsError)
+	require.Equal(t, "broken", events[1].Text)
+
+	reg := NewRegistry()
+	m.register(reg)
+	out, err := reg.Dispatch(context.Background(), "catalog__search", json.RawMessage(`{"query":"orders"}`))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out, "found: orders"))
+	_, err = reg.Dispatch(context.Background(), "catalog__fail", json.RawMessage(`{}`))
+	require.EqualError(t, err, "catalog__fail: index offline")
+	require.True(t, sawSession)
+	require.True(t, sawVersion)
+	require.True(t, sawAuth)
+}
@@ -0,0 +1,371 @@
+package direct
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// mcpMaxMessage bounds one JSON-RPC message read from a server.
+	mcpMaxMessage = 16 << 20
+	// mcpStderrTail is how much of a stdio server's stderr is kept for errors.
+	mcpStderrTail = 2_000
+	// mcpStopGrace is how long a stdio server gets to exit after stdin closes.
+	mcpStopGrace = 2 * time.Second
+)
+
+// JSON-RPC 2.0 error codes answered to server-initiated requests.
+const rpcMethodNotFound = -32601
+
+// rpcMessage is any JSON-RPC 2.0 message: a request (Method and ID), a
+// notification (Method, no ID) or a response (ID with Result or Error).
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  any             `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return fmt.Sprintf("%s (code %d)", e.Message, e.Code) }
+
+// isResponse reports whether m answers one of our requests.
+func (m *rpcMessage) isResponse() bool { return m.Method == "" && len(m.ID) > 0 }
+
+// mcpTransport carries JSON-RPC messages to one MCP server. send returns the
+// response to a request, or nil for a notification (a message without ID).
+// It must be safe for concurrent use: the loop dispatches tool calls in
+// parallel.
+type mcpTransport interface {
+	send(ctx context.Context, msg *rpcMessage) (*rpcMessage, error)
+	close() error
+}
+
+// stdioTransport runs the server as a subprocess speaking newline-delimited
+// JSON-RPC on stdin/stdout. A reader goroutine routes responses to the waiting
+// requests by ID.
+type stdioTransport struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr *tailBuffer
+	done   chan struct{} // closed when stdout ends
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	pending map[string]chan *rpcMessage
+	readErr error
+}
+
+func startStdio(dir string, argv []string, env map[string]string) (*stdioTransport, error) {
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = os.Environ()
+		for _, k := range slices.Sorted(maps.Keys(env)) {
+			cmd.Env = append(cmd.Env, k+"="+env[k])
+		}
+	}
+	t := &stdioTransport{cmd: cmd, stderr: &tailBuffer{max: mcpStderrTail}, done: make(chan struct{}), pending: map[string]chan *rpcMessage{}}
+	cmd.Stderr = t.stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	t.stdin = stdin
+	go t.read(stdout)
+	return t, nil
+}
+
+// read dispatches the server's output until it closes stdout, then fails
+// every request still waiting.
+func (t *stdioTransport) read(stdout io.Reader) {
+	sc := bufio.NewScanner(stdout)
+	sc.Buffer(make([]byte, 0, 64<<10), mcpMaxMessage)
+	for sc.Scan() {
+		var m rpcMessage
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			continue // not JSON-RPC: stray log output
+		}
+		switch {
+		case m.isResponse():
+			t.mu.Lock()
+			ch := t.pending[string(m.ID)]
+			delete(t.pending, string(m.ID))
+			t.mu.Unlock()
+			if ch != nil {
+				ch <- &m
+			}
+		case m.Method != "" && len(m.ID) > 0:
+			_ = t.write(answerServerRequest(&m))
+		}
+	}
+	t.mu.Lock()
+	t.readErr = sc.Err()
+	if t.readErr == nil {
+		t.readErr = io.EOF
+	}
+	if tail := strings.TrimSpace(t.stderr.String()); tail != "" {
+		t.readErr = fmt.Errorf("server exited: %w; stderr: %s", t.readErr, tail)
+	} else {
+		t.readErr = fmt.Errorf("server exited: %w", t.readErr)
+	}
+	t.pending = nil
+	t.mu.Unlock()
+	close(t.done)
+}
+
+func (t *stdioTransport) write(m *rpcMessage) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_, err = t.stdin.Write(append(data, '\n'))
+	return err
+}
+
+func (t *stdioTransport) send(ctx context.Context, msg *rpcMessage) (*rpcMessage, error) {
+	if len(msg.ID) == 0 {
+		return nil, t.write(msg)
+	}
+	ch := make(chan *rpcMessage, 1)
+	t.mu.Lock()
+	if t.pending == nil {
+		err := t.readErr
+		t.mu.Unlock()
+		return nil, err
+	}
+	t.pending[string(msg.ID)] = ch
+	t.mu.Unlock()
+	forget := func() {
+		t.mu.Lock()
+		if t.pending != nil {
+			delete(t.pending, string(msg.ID))
+		}
+		t.mu.Unlock()
+	}
+
+	if err := t.write(msg); err != nil {
+		forget()
+		return nil, err
+	}
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-t.done:
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return nil, t.readErr
+	case <-ctx.Done():
+		forget()
+		return nil, ctx.Err()
+	}
+}
+
+// close ends stdin (the stdio shutdown signal) and kills the server if it
+// does not exit within mcpStopGrace.
+func (t *stdioTransport) close() error {
+	_ = t.stdin.Close()
+	exited := make(chan struct{})
+	go func() {
+		_ = t.cmd.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+	case <-time.After(mcpStopGrace):
+		_ = t.cmd.Process.Kill()
+		<-exited
+	}
+	return nil
+}
+
+// answerServerRequest replies to a request the server sent us: ping is
+// answered, anything else (sampling, roots, elicitation) is not supported.
+func answerServerRequest(m *rpcMessage) *rpcMessage {
+	if m.Method == "ping" {
+		return &rpcMessage{JSONRPC: "2.0", ID: m.ID, Result: json.RawMessage(`{}`)}
+	}
+	return &rpcMessage{JSONRPC: "2.0", ID: m.ID, Error: &rpcError{Code: rpcMethodNotFound, Message: "method not supported by client: " + m.Method}}
+}
+
+// httpTransport speaks the streamable HTTP transport: every message is a POST
+// and the response is either a JSON body or an SSE stream that carries it.
+type httpTransport struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+
+	mu        sync.Mutex
+	sessionID string
+	version   string
+}
+
+func newHTTPTransport(url string, headers map[string]string) *httpTransport {
+	return &httpTransport{url: url, headers: headers, client: &http.Client{}}
+}
+
+// setVersion records the negotiated protocol version, sent on every later request.
+func (t *httpTransport) setVersion(v string) {
+	t.mu.Lock()
+	t.version = v
+	t.mu.Unlock()
+}
+
+func (t *httpTransport) newRequest(ctx context.Context, method string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.mu.Lock()
+	if t.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", t.sessionID)
+	}
+	if t.version != "" {
+		req.Header.Set("Mcp-Protocol-Version", t.version)
+	}
+	t.mu.Unlock()
+	return req, nil
+}
+
+func (t *httpTransport) send(ctx context.Context, msg *rpcMessage) (*rpcMessage, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := t.newRequest(ctx, http.MethodPost, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if id := resp.Header.Get("Mcp-Session-Id"); id != "" {
+		t.mu.Lock()
+		t.sessionID = id
+		t.mu.Unlock()
+	}
+	if resp.StatusCode/100 != 2 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, mcpStderrTail))
+		return nil, fmt.Errorf("http %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if len(msg.ID) == 0 {
+		return nil, nil
+	}
+
+	mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mt == "text/event-stream" {
+		return readSSEResponse(resp.Body, msg.ID)
+	}
+	var out rpcMessage
+	if err := json.NewDecoder(io.LimitReader(resp.Body, mcpMaxMessage)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &out, nil
+}
+
+// readSSEResponse reads SSE events until the response with the given ID;
+// server notifications and requests on the stream are skipped.
+func readSSEResponse(r io.Reader, id json.RawMessage) (*rpcMessage, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), mcpMaxMessage)
+	var data strings.Builder
+	for sc.Scan() {
+		line := sc.Text()
+		if v, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimPrefix(v, " "))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+		var m rpcMessage
+		err := json.Unmarshal([]byte(data.String()), &m)
+		data.Reset()
+		if err == nil && m.isResponse() && bytes.Equal(m.ID, id) {
+			return &m, nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read event stream: %w", err)
+	}
+	return nil, errors.New("event stream ended without a response")
+}
+
+// close ends the HTTP session, best-effort.
+func (t *httpTransport) close() error {
+	t.mu.Lock()
+	id := t.sessionID
+	t.mu.Unlock()
+	if id == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), mcpStopGrace)
+	defer cancel()
+	req, err := t.newRequest(ctx, http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if resp, err := t.client.Do(req); err == nil {
+		_ = resp.Body.Close()
+	}
+	return nil
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if over := len(b.buf) - b.max; over > 0 {
+		b.buf = append(b.buf[:0], b.buf[over:]...)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
@@ -0,0 +1,136 @@
+package direct
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ToolLimit caps one tool's use across a run (all loops of a sub-agent run
+// together). Zero fields are unlimited.
+type ToolLimit struct {
+	MaxCalls int
+	MaxBytes int // result bytes returned to the model
+}
+
+// ToolStats is one tool's use in a run.
+type ToolStats struct {
+	Calls      int `json:"calls"`
+	Errors     int `json:"errors,omitempty"`
+	Capped     int `json:"capped,omitempty"` // calls refused or clipped by the ToolLimit
+	Bytes      int `json:"bytes"`            // result bytes returned to the model
+	DurationMs int `json:"durationMs"`
+}
+
+// ParseToolLimits parses a tool limit spec: "tool=key:value,..." entries
+// separated by ";", keys "calls" and "bytes", e.g.
+// "read_file=bytes:2000000; grep=calls:40,bytes:200000".
+func ParseToolLimits(spec string) (map[string]ToolLimit, error) {
+	out := map[string]ToolLimit{}
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, caps, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		switch {
+		case !ok || strings.TrimSpace(caps) == "":
+			return nil, fmt.Errorf("tool limit %q: want tool=calls:N,bytes:N", strings.TrimSpace(entry))
+		case !toolNameRe.MatchString(name):
+			return nil, fmt.Errorf("tool limit %q: invalid tool name", name)
+		}
+		if _, dup := out[name]; dup {
+			return nil, fmt.Errorf("tool limit %q: duplicate tool", name)
+		}
+		var l ToolLimit
+		for _, kv := range strings.Split(caps, ",") {
+			k, v, _ := strings.Cut(strings.TrimSpace(kv), ":")
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("tool limit %q: %q is not a positive number", name, v)
+			}
+			switch strings.TrimSpace(k) {
+			case "calls":
+				l.MaxCalls = n
+			case "bytes":
+				l.MaxBytes = n
+			default:
+				return nil, fmt.Errorf("tool limit %q: unknown key %q (want calls or bytes)", name, k)
+			}
+		}
+		out[name] = l
+	}
+	return out, nil
+}
+
+// toolMeter counts tool use and enforces the per-tool limits. One meter is
+// shared by every registry view of a run (see toolState), so the limits hold
+// for the run as a whole.
+type toolMeter struct {
+	mu     sync.Mutex
+	limits map[string]ToolLimit
+	stats  map[string]ToolStats
+}
+
+func newToolMeter(limits map[string]ToolLimit) *toolMeter {
+	return &toolMeter{limits: limits, stats: map[string]ToolStats{}}
+}
+
+// admit reserves a call of the tool, or explains why its limit refuses it.
+// A refused call is still counted: the model asked for it.
+func (m *toolMeter) admit(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, l := m.stats[name], m.limits[name]
+	s.Calls++
+	var err error
+	switch {
+	case l.MaxCalls > 0 && s.Calls > l.MaxCalls:
+		err = fmt.Errorf("%s: per-run limit of %d calls reached; continue with what you already have", name, l.MaxCalls)
+	case l.MaxBytes > 0 && s.Bytes >= l.MaxBytes:
+		err = fmt.Errorf("%s: per-run limit of %d output bytes reached; continue with what you already have", name, l.MaxBytes)
+	}
+	if err != nil {
+		s.Errors++
+		s.Capped++
+	}
+	m.stats[name] = s
+	return err
+}
+
+// done records an admitted call's outcome and clips out to the tool's
+// remaining byte budget. Concurrent calls are clipped in completion order.
+func (m *toolMeter) done(name, out string, err error, took time.Duration) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, l := m.stats[name], m.limits[name]
+	if err != nil {
+		s.Errors++
+	}
+	// Bytes counts the tool's own output that reached the model, not the
+	// notice appended to a cut one.
+	if rest := l.MaxBytes - s.Bytes; l.MaxBytes > 0 && len(out) > rest {
+		kept := truncateUTF8(out, rest)
+		s.Bytes += len(kept)
+		out = kept + fmt.Sprintf("\n... [cut: per-run limit of %d output bytes for %s reached]", l.MaxBytes, name)
+		s.Capped++
+	} else {
+		s.Bytes += len(out)
+	}
+	s.DurationMs += int(took.Milliseconds())
+	m.stats[name] = s
+	return out
+}
+
+// snapshot returns a copy of the stats, nil when no tool was called.
+func (m *toolMeter) snapshot() map[string]ToolStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.stats) == 0 {
+		return nil
+	}
+	return maps.Clone(m.stats)
+}
@@ -0,0 +1,82 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToolLimits(t *testing.T) {
+	limits, err := ParseToolLimits(" read_file=bytes:2000; grep=calls:3,bytes:500 ;")
+	require.NoError(t, err)
+	require.Equal(t, map[string]ToolLimit{
+		"read_file": {MaxBytes: 2000},
+		"grep":      {MaxCalls: 3, MaxBytes: 500},
+	}, limits)
+
+	limits, err = ParseToolLimits("")
+	require.NoError(t, err)
+	require.Empty(t, limits)
+
+	for _, bad := range []string{"grep", "grep=", "grep=calls", "grep=calls:0", "grep=lines:5", "a b=calls:1", "grep=calls:1;grep=bytes:1"} {
+		_, err = ParseToolLimits(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestRegistryToolStatsAndLimits(t *testing.T) {
+	st := newToolState(ReviewToolsConfig{ToolLimits: map[string]ToolLimit{
+		"echo": {MaxBytes: 10},
+		"fail": {MaxCalls: 2},
+	}})
+	// Two registry views of one run share the stats and the limits.
+	views := []*Registry{NewRegistry(), NewRegistry()}
+	for _, reg := range views {
+		reg.useMeter(st.meter)
+		reg.Register(ToolDef{Name: "echo"}, func(_ context.Context, args json.RawMessage) (string, error) { return string(args), nil })
+		reg.Register(ToolDef{Name: "fail"}, func(context.Context, json.RawMessage) (string, error) { return "", errors.New("boom") })
+	}
+	ctx := context.Background()
+
+	out, err := views[0].Dispatch(ctx, "echo", json.RawMessage(`"abcdef"`))
+	require.NoError(t, err)
+	require.Equal(t, `"abcdef"`, out)
+	out, err = views[1].Dispatch(ctx, "echo", json.RawMessage(`"abcdef"`))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out, `"a`+"\n... [cut: per-run limit of 10 output bytes for echo"), out)
+	_, err = views[0].Dispatch(ctx, "echo", json.RawMessage(`"x"`))
+	require.ErrorContains(t, err, "echo: per-run limit of 10 output bytes reached")
+
+	for range 3 {
+		_, err = views[1].Dispatch(ctx, "fail", nil)
+		require.Error(t, err)
+	}
+	require.ErrorContains(t, err, "fail: per-run limit of 2 calls reached")
+
+	_, err = views[0].Dispatch(ctx, "nope", nil)
+	require.ErrorContains(t, err, "unknown tool")
+
+	stats := views[0].ToolStats()
+	require.Equal(t, stats, views[1].ToolStats())
+	require.Len(t, stats, 2, "unknown tools are not counted")
+	echo := stats["echo"]
+	require.Equal(t, 3, echo.Calls)
+	require.Equal(t, 1, echo.Errors)
+	require.Equal(t, 2, echo.Capped)
+	require.Equal(t, 10, echo.Bytes, "the cut notice is not counted")
+	require.Equal(t, ToolStats{Calls: 3, Errors: 3, Capped: 1}, stats["fail"])
+}
+
+func TestToolMeterCutKeepsRunes(t *testing.T) {
+	m := newToolMeter(map[string]ToolLimit{"read": {MaxBytes: 5}})
+	require.NoError(t, m.admit("read"))
+	out := m.done("read", "ёжик", nil, 0)
+	require.True(t, strings.HasPrefix(out, "ёж\n... [cut:"), out)
+	require.True(t, utf8.ValidString(out))
+	require.Equal(t, len("ёж"), m.snapshot()["read"].Bytes)
+}
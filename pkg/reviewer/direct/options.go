@@ -1,24 +1,72 @@
 package direct
 
+import "time"
+
 // Options tunes the agent loop.
 type Options struct {
 	// MaxRounds caps how many provider round-trips the loop may make before
 	// giving up — a backstop against a model that never calls submit_review.
 	MaxRounds int
-	// CompactAt is the estimated-token threshold above which the middle of the
-	// conversation is pruned. Zero disables compaction.
+	// MaxTokens caps the billed tokens (prompt incl. cache, plus output) the loop
+	// may spend before stopping with errBudget. Zero is unlimited; sub-agents
+	// get one each so a runaway slice can't eat the review's budget.
+	MaxTokens int
+	// CompactAt is the token threshold above which the middle of the
+	// conversation is pruned. Zero disables compaction. WithLimits derives it
+	// from the model's context window.
 	CompactAt int
 	// KeepTail is how many trailing messages compaction preserves verbatim.
 	KeepTail int
+	// CompactSummary makes compaction ask the model for a structured summary of
+	// the pruned turns (files read, findings, open questions) and keep it in the
+	// head message. Off, the pruned turns are replaced by a bare marker.
+	CompactSummary bool
+	// Limits is the model's context budget. When known, the kickoff request is
+	// checked against it before the first round (errContextOverflow).
+	Limits ModelLimits
 	// Effort is passed to providers that support it (Anthropic output_config.effort).
 	Effort string
+	// StreamIdleTimeout aborts a round whose streamed response stalls for this
+	// long — separate from the run-level deadline, which bounds the whole review.
+	// Zero disables it.
+	StreamIdleTimeout time.Duration
 	// OnEvent, if set, receives a transcript event per assistant turn, tool call,
-	// tool result, round and final result. Used to persist the session for later
-	// analysis. Called only from the loop's main goroutine.
+	// tool result, round and final result, plus partial-output deltas while a
+	// round streams. Used to persist the session for later analysis and to show
+	// live progress. Called only from the loop's main goroutine.
 	OnEvent Sink
+	// History is the conversation of a resumed run (see RunSession): the
+	// userPrompt is then delivered as the next user input instead of starting
+	// a new conversation.
+	History []Message
+	// OnCheckpoint, if set, receives the conversation after every round whose
+	// tool results are in and once more when the loop ends — enough to resume
+	// the run later. Called only from the loop's main goroutine.
+	OnCheckpoint func([]Message)
 }
 
+// defaultStreamIdleTimeout is generous: adaptive thinking streams thinking
+// deltas, so even a long xhigh round is rarely silent for more than a minute.
+const defaultStreamIdleTimeout = 5 * time.Minute
+
 // DefaultOptions returns sensible loop defaults for a review run.
 func DefaultOptions() Options {
-	return Options{MaxRounds: 60, CompactAt: 150_000, KeepTail: 12}
+	return Options{MaxRounds: 60, CompactAt: 150_000, KeepTail: 12, CompactSummary: true, StreamIdleTimeout: defaultStreamIdleTimeout}
+}
+
+// WithLimits sets the model's context budget and, when the window is known,
+// scales the compaction threshold to it.
+func (o Options) WithLimits(l ModelLimits) Options {
+	o.Limits = l
+	if t := l.CompactThreshold(); t > 0 {
+		o.CompactAt = t
+	}
+	return o
+}
+
+// checkpoint hands msgs to OnCheckpoint, if set.
+func (o Options) checkpoint(msgs []Message) {
+	if o.OnCheckpoint != nil {
+		o.OnCheckpoint(msgs)
+	}
 }
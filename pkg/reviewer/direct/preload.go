@@ -9,17 +9,31 @@ import (
 )
 
 const (
-	preloadMaxBytes = 250_000
-	preloadPerFile  = 60_000
-	preloadMaxFiles = 50
+	// preloadMaxTokens is the preload budget when the model's window is unknown.
+	preloadMaxTokens = 60_000
+	preloadMaxFiles  = 50
+	// preloadRelatedShare is the part of the budget kept for the related
+	// context; it also gets whatever the changed files leave over, up to
+	// preloadRelatedMax.
+	preloadRelatedShare = 8
+	preloadRelatedMax   = 4
 )
 
 // PreloadContext returns a kickoff block with the diff under review and the full
 // current content of every changed file, so the model can review without reading
-// them via tools (the main source of one-call-per-turn round-trips). It also
-// returns the list of files actually shown, so read-dedup can be seeded with
-// them. Best-effort: returns "", nil if git is unavailable or nothing changed.
-func PreloadContext(ctx context.Context, root, base, head string) (string, []string) {
+// them via tools (the main source of one-call-per-turn round-trips). Files are
+// inlined most relevant first (rankChangedFiles), so on a big MR the budget
+// goes to the risky, heavily changed ones. A related-context section follows:
+// the types and interfaces the changed code uses and the tests of changed
+// sources (relatedContext). It also returns the list of files actually shown,
+// so read-dedup can be seeded with them. maxTokens bounds the block (see
+// ModelLimits.PreloadTokens; 0 = default): the diff gets up to half, a single
+// file up to a quarter, the related context an eighth or more. Best-effort:
+// returns "", nil if git is unavailable or nothing changed.
+func PreloadContext(ctx context.Context, root, base, head string, maxTokens int) (string, []string) {
+	if maxTokens <= 0 {
+		maxTokens = preloadMaxTokens
+	}
 	diff, derr := gitDiff(ctx, root, base, head, "")
 	files, ferr := changedFiles(ctx, root, base, head)
 	if (derr != nil || strings.TrimSpace(diff) == "" || diff == emptyDiff) && (ferr != nil || len(files) == 0) {
@@ -33,14 +47,17 @@ func PreloadContext(ctx context.Context, root, base, head string) (string, []str
 
 	if strings.TrimSpace(diff) != "" && diff != emptyDiff {
 		b.WriteString("### Diff\n```diff\n")
-		b.WriteString(clipN(diff, preloadMaxBytes/2))
+		b.WriteString(clipTokens(diff, maxTokens/2))
 		b.WriteString("\n```\n\n")
 	}
 
-	b.WriteString("### Изменённые файлы (полное содержимое)\n")
+	files = rankChangedFiles(files, fileChurn(ctx, root, base, head, files))
+	b.WriteString("### Изменённые файлы (полное содержимое, самые важные первыми)\n")
 	preloaded := make([]string, 0, len(files))
+	used := CountTokens(b.String())
+	filesBudget := maxTokens - maxTokens/preloadRelatedShare
 	for i, f := range files {
-		if len(preloaded) >= preloadMaxFiles || b.Len() > preloadMaxBytes {
+		if len(preloaded) >= preloadMaxFiles || used > filesBudget {
 			rest := files[i:]
 			fmt.Fprintf(&b, "\n... [ещё %d изменённых файлов не инлайнятся из-за лимита — их ПОЛНОЕ "+
 				"содержимое читай через read_files, а изменения — через git_diff(path=...):\n%s\n]\n",
@@ -52,11 +69,22 @@ func PreloadContext(ctx context.Context, root, base, head string) (string, []str
 		if err != nil {
 			continue // deleted / binary / unreadable — skip silently
 		}
-		fmt.Fprintf(&b, "===== %s =====\n", f)
-		b.WriteString(clipN(string(data), preloadPerFile))
-		b.WriteString("\n\n")
+		body := clipTokens(string(data), maxTokens/4)
+		fmt.Fprintf(&b, "===== %s =====\n%s\n\n", f, body)
+		used += CountTokens(body) + CountTokens(f) + 4
 		preloaded = append(preloaded, f)
 	}
+
+	skip := make(map[string]bool, len(files))
+	for _, f := range files {
+		skip[f] = true
+	}
+	related, tests := relatedContext(root, files, skip, min(maxTokens-used, maxTokens/preloadRelatedMax))
+	if related != "" {
+		b.WriteString("\n")
+		b.WriteString(related)
+		preloaded = append(preloaded, tests...)
+	}
 	return b.String(), preloaded
 }
 
@@ -0,0 +1,165 @@
+package direct
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// pathWeights raise (or lower) a changed file's preload rank by what its path
+// says about it: auth and data access first, generated files and lockfiles
+// last. The first matching pattern wins.
+var pathWeights = []struct {
+	re     *regexp.Regexp
+	weight float64
+}{
+	{regexp.MustCompile(`(?i)(^|/)vendor/|\.pb(\.gw)?\.go$|_gen(erated)?\.|\.generated\.|(^|/)go\.sum$|package-lock\.json$|yarn\.lock$|pnpm-lock\.yaml$|\.min\.(js|css)$|\.snap$`), -8},
+	{regexp.MustCompile(`(?i)auth|login|passw|secret|token|crypt|session|permission|acl|jwt|oauth`), 3},
+	{regexp.MustCompile(`(?i)\.sql$|migrat|(^|/)(db|store|storage|repo|repository)/|quer(y|ies)`), 2.5},
+	{regexp.MustCompile(`(?i)handler|controller|rout(er|es)|middleware|(^|/)(api|rpc|rest)/|server`), 2},
+	{regexp.MustCompile(`(?i)\.(md|txt|rst)$|(^|/)docs?/`), -2},
+}
+
+// pathWeight is the risk part of a file's preload rank.
+func pathWeight(f string) float64 {
+	for _, w := range pathWeights {
+		if w.re.MatchString(f) {
+			return w.weight
+		}
+	}
+	return 0
+}
+
+// rankChangedFiles orders changed files for the preload: by diff size (log
+// scale, so one huge file does not bury everything) plus pathWeight. A changed
+// test follows its changed source directly, so the two are read together.
+func rankChangedFiles(files []string, churn map[string]int) []string {
+	changed := make(map[string]bool, len(files))
+	for _, f := range files {
+		changed[f] = true
+	}
+	score := func(f string) float64 { return math.Log2(1+float64(churn[f])) + pathWeight(f) }
+	type ranked struct {
+		file, anchor string // anchor: the source a test sorts with
+		score        float64
+		test         bool
+	}
+	rs := make([]ranked, 0, len(files))
+	for _, f := range files {
+		r := ranked{file: f, anchor: f, score: score(f)}
+		if src := sourceOfTest(f); src != "" {
+			r.test = true
+			if changed[src] {
+				r.anchor, r.score = src, score(src)
+			}
+		}
+		rs = append(rs, r)
+	}
+	slices.SortStableFunc(rs, func(a, b ranked) int {
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			strings.Compare(a.anchor, b.anchor),
+			cmpBool(a.test, b.test),
+		)
+	})
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = r.file
+	}
+	return out
+}
+
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// testNamings maps a test file suffix to the source suffix it tests.
+var testNamings = []struct{ test, src string }{
+	{"_test.go", ".go"},
+	{".test.ts", ".ts"}, {".spec.ts", ".ts"},
+	{".test.tsx", ".tsx"}, {".spec.tsx", ".tsx"},
+	{".test.js", ".js"}, {".spec.js", ".js"},
+	{"_test.py", ".py"},
+}
+
+// sourceOfTest returns the source file a test file tests by naming
+// convention, "" when f is not a test.
+func sourceOfTest(f string) string {
+	for _, n := range testNamings {
+		if base, ok := strings.CutSuffix(f, n.test); ok {
+			return base + n.src
+		}
+	}
+	dir, name := path.Split(f)
+	if rest, ok := strings.CutPrefix(name, "test_"); ok && strings.HasSuffix(name, ".py") {
+		return dir + rest
+	}
+	return ""
+}
+
+// testsOf lists the conventional test file names of a source file.
+func testsOf(f string) []string {
+	if sourceOfTest(f) != "" {
+		return nil
+	}
+	var out []string
+	for _, n := range testNamings {
+		if base, ok := strings.CutSuffix(f, n.src); ok {
+			out = append(out, base+n.test)
+		}
+	}
+	if strings.HasSuffix(f, ".py") {
+		dir, name := path.Split(f)
+		out = append(out, dir+"test_"+name)
+	}
+	return out
+}
+
+// fileChurn returns added+deleted lines per changed file, over the same range
+// as changedFiles; untracked files count their length. Best-effort: nil on
+// error, which leaves the git order.
+func fileChurn(ctx context.Context, root, base, head string, files []string) map[string]int {
+	args := []string{gitNoPager, gitDiffCmd, "--numstat", "--no-renames"}
+	switch {
+	case base != "" && head != "":
+		args = append(args, base+"..."+head)
+	case base != "":
+		args = append(args, base)
+	}
+	out, err := runGit(ctx, root, false, withExcludes(args...)...)
+	if err != nil {
+		return nil
+	}
+	churn := make(map[string]int)
+	for _, l := range splitLines(out) {
+		fields := strings.SplitN(l, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		added, _ := strconv.Atoi(fields[0]) // "-" for binary files
+		deleted, _ := strconv.Atoi(fields[1])
+		churn[fields[2]] = added + deleted
+	}
+	for _, f := range files {
+		if _, ok := churn[f]; ok {
+			continue
+		}
+		if data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(f))); err == nil {
+			churn[f] = strings.Count(string(data), "\n") + 1
+		}
+	}
+	return churn
+}
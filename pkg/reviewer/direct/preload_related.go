@@ -0,0 +1,244 @@
+package direct
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// relatedMaxDecls caps the type declarations in the related context.
+	relatedMaxDecls = 30
+	// relatedDeclMaxLines clips one declaration (a big struct or interface).
+	relatedDeclMaxLines = 80
+)
+
+// relatedContext is the preload's "related context": the declarations of the
+// repository types and interfaces the changed Go files use, then the existing
+// tests of changed sources, within budget tokens. Files in skip (already shown)
+// are left out. It returns the block and the test files inlined whole.
+func relatedContext(root string, changed []string, skip map[string]bool, budget int) (string, []string) {
+	if budget <= 0 {
+		return "", nil
+	}
+	var b strings.Builder
+	used := 0
+	fits := func(s string) bool {
+		n := CountTokens(s)
+		if used+n > budget {
+			return false
+		}
+		used += n
+		return true
+	}
+
+	var decls strings.Builder
+	for _, d := range goTypeDeps(root, changed, skip) {
+		entry := fmt.Sprintf("===== %s: %s (%s) =====\n%s\n\n", d.file, d.name, d.kind, clipLines(d.src, relatedDeclMaxLines))
+		if !fits(entry) {
+			break
+		}
+		decls.WriteString(entry)
+	}
+
+	var tests strings.Builder
+	var shown []string
+	for _, f := range changed {
+		for _, tf := range testsOf(f) {
+			if skip[tf] || slices.Contains(shown, tf) {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(tf)))
+			if err != nil {
+				continue
+			}
+			entry := fmt.Sprintf("===== %s =====\n%s\n\n", tf, clipTokens(string(data), budget/4))
+			if !fits(entry) {
+				continue // a smaller test further down may still fit
+			}
+			tests.WriteString(entry)
+			shown = append(shown, tf)
+		}
+	}
+
+	if decls.Len() == 0 && tests.Len() == 0 {
+		return "", nil
+	}
+	b.WriteString("### Связанный контекст\n")
+	b.WriteString("Определения типов и интерфейсов, которые использует изменённый код, и существующие тесты изменённых файлов. ")
+	b.WriteString("Сами они не менялись — это контекст, а не предмет ревью.\n\n")
+	if decls.Len() > 0 {
+		b.WriteString("#### Используемые типы и интерфейсы\n")
+		b.WriteString(decls.String())
+	}
+	if tests.Len() > 0 {
+		b.WriteString("#### Тесты изменённых файлов\n")
+		b.WriteString(tests.String())
+	}
+	return b.String(), shown
+}
+
+// typeDecl is one repository type declaration used by the changed code.
+type typeDecl struct {
+	file, name, kind string // kind: "interface" | "struct" | "type"
+	src              string
+	refs             int
+}
+
+// goTypeDeps finds the type declarations the changed Go files refer to — by
+// bare name in their own package, or as pkg.Name of a package of this module —
+// outside the files in skip. Parse-only (no type checking): a name counts when
+// some type with that name is declared there. Interfaces come first, then the
+// most referenced.
+func goTypeDeps(root string, changed []string, skip map[string]bool) []typeDecl {
+	mod := modulePath(filepath.Join(root, "go.mod"))
+	if mod == "" {
+		return nil
+	}
+	fset := token.NewFileSet()
+	refs := make(map[string]map[string]int) // package dir → name → references
+	ref := func(dir, name string) {
+		if refs[dir] == nil {
+			refs[dir] = make(map[string]int)
+		}
+		refs[dir][name]++
+	}
+	for _, f := range changed {
+		if !strings.HasSuffix(f, ".go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(root, filepath.FromSlash(f)), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		imports := repoImports(file, mod)
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				if x, ok := n.X.(*ast.Ident); ok {
+					if dir, ok := imports[x.Name]; ok {
+						ref(dir, n.Sel.Name)
+					}
+				}
+				return false
+			case *ast.Ident:
+				ref(path.Dir(f), n.Name)
+			}
+			return true
+		})
+	}
+
+	var out []typeDecl
+	for dir, names := range refs {
+		for _, d := range packageTypeDecls(root, dir, skip) {
+			if n := names[d.name]; n > 0 {
+				d.refs = n
+				out = append(out, d)
+			}
+		}
+	}
+	slices.SortFunc(out, func(a, b typeDecl) int {
+		return cmp.Or(
+			cmpBool(a.kind != "interface", b.kind != "interface"),
+			cmp.Compare(b.refs, a.refs),
+			strings.Compare(a.file, b.file),
+			strings.Compare(a.name, b.name),
+		)
+	})
+	return out[:min(len(out), relatedMaxDecls)]
+}
+
+// repoImports maps the local names of a file's imports from module mod to
+// their repo-relative directories.
+func repoImports(file *ast.File, mod string) map[string]string {
+	out := make(map[string]string)
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		rel, ok := strings.CutPrefix(p, mod+"/")
+		if !ok {
+			continue
+		}
+		name := path.Base(p)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		out[name] = rel
+	}
+	return out
+}
+
+// packageTypeDecls returns the type declarations of the non-test Go files in
+// a repo-relative directory, except the files in skip.
+func packageTypeDecls(root, dir string, skip map[string]bool) []typeDecl {
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+	if err != nil {
+		return nil
+	}
+	var out []typeDecl
+	for _, e := range entries {
+		name := e.Name()
+		rel := path.Join(dir, name)
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || skip[rel] {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			continue
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, rel, src, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				out = append(out, typeDecl{file: rel, name: ts.Name.Name, kind: typeKindName(ts.Type), src: typeDeclSource(fset, src, gd, ts)})
+			}
+		}
+	}
+	return out
+}
+
+// typeDeclSource renders one type spec as a standalone declaration with its
+// doc comment, also when it sits in a grouped type ( ... ) block.
+func typeDeclSource(fset *token.FileSet, src []byte, gd *ast.GenDecl, ts *ast.TypeSpec) string {
+	at := func(p token.Pos) int { return fset.Position(p).Offset }
+	doc := ts.Doc
+	if doc == nil && !gd.Lparen.IsValid() {
+		doc = gd.Doc
+	}
+	var b strings.Builder
+	if doc != nil {
+		b.Write(src[at(doc.Pos()):at(doc.End())])
+		b.WriteByte('\n')
+	}
+	b.WriteString("type ")
+	b.Write(src[at(ts.Pos()):at(ts.End())])
+	return b.String()
+}
+
+func typeKindName(e ast.Expr) string {
+	switch e.(type) {
+	case *ast.InterfaceType:
+		return "interface"
+	case *ast.StructType:
+		return "struct"
+	}
+	return "type"
+}
@@ -3,6 +3,7 @@ package direct
 import (
 	"context"
 	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -24,7 +25,7 @@ func TestPreloadContext(t *testing.T) {
 	write(t, dir, "tracked.go", "package x\n// edited\n")
 	write(t, dir, "newpkg/brand.go", "package newpkg\n// brand new\n")
 
-	pc, preloaded := PreloadContext(context.Background(), dir, "", "") // working tree vs HEAD + untracked
+	pc, preloaded := PreloadContext(context.Background(), dir, "", "", 0) // working tree vs HEAD + untracked
 	require.Contains(t, pc, "### Diff")
 	require.Contains(t, pc, "// edited", "uncommitted edit in the diff")
 	require.Contains(t, pc, "===== tracked.go =====")
@@ -51,3 +52,48 @@ func TestChangedFilesIncludesUntracked(t *testing.T) {
 	require.NoError(t, err)
 	require.ElementsMatch(t, []string{"a.go", "b.go"}, files)
 }
+
+func TestRankChangedFiles(t *testing.T) {
+	files := []string{"README.md", "go.sum", "util/strings.go", "util/strings_test.go", "api/handler.go", "internal/auth/jwt.go"}
+	churn := map[string]int{"README.md": 40, "go.sum": 300, "util/strings.go": 120, "util/strings_test.go": 5, "api/handler.go": 10, "internal/auth/jwt.go": 10}
+
+	got := rankChangedFiles(files, churn)
+	require.Equal(t, []string{"util/strings.go", "util/strings_test.go", "internal/auth/jwt.go", "api/handler.go", "README.md", "go.sum"}, got)
+}
+
+func TestTestsOf(t *testing.T) {
+	require.Equal(t, []string{"a/b_test.go"}, testsOf("a/b.go"))
+	require.Equal(t, []string{"web/x.test.ts", "web/x.spec.ts"}, testsOf("web/x.ts"))
+	require.Equal(t, []string{"p/m_test.py", "p/test_m.py"}, testsOf("p/m.py"))
+	require.Nil(t, testsOf("a/b_test.go"))
+	require.Equal(t, "p/m.py", sourceOfTest("p/test_m.py"))
+}
+
+func TestPreloadRelatedContext(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitExec(t, dir, "init", "-q")
+	gitExec(t, dir, "config", "user.email", "t@t")
+	gitExec(t, dir, "config", "user.name", "t")
+	write(t, dir, "go.mod", "module example.com/m\n\ngo 1.22\n")
+	write(t, dir, "store/store.go", "package store\n\n// Store keeps items.\ntype Store interface {\n\tGet(id int) (Item, error)\n}\n\ntype (\n\t// Item is one stored thing.\n\tItem struct{ ID int }\n\tunused int\n)\n")
+	write(t, dir, "api/config.go", "package api\n\ntype Config struct{ Addr string }\n")
+	write(t, dir, "api/handler.go", "package api\n\nfunc Handle() {}\n")
+	write(t, dir, "api/handler_test.go", "package api\n\nfunc TestHandle() {}\n")
+	gitExec(t, dir, "add", ".")
+	gitExec(t, dir, "commit", "-q", "-m", "init")
+
+	write(t, dir, "api/handler.go", "package api\n\nimport \"example.com/m/store\"\n\nfunc Handle(s store.Store, c Config) store.Item { it, _ := s.Get(1); return it }\n")
+
+	pc, preloaded := PreloadContext(context.Background(), dir, "", "", 0)
+	require.Contains(t, pc, "### Связанный контекст")
+	require.Contains(t, pc, "===== store/store.go: Store (interface) =====\n// Store keeps items.\ntype Store interface {")
+	require.Contains(t, pc, "===== store/store.go: Item (struct) =====\n// Item is one stored thing.\ntype Item struct{ ID int }")
+	require.Contains(t, pc, "===== api/config.go: Config (struct) =====\ntype Config struct{ Addr string }")
+	require.NotContains(t, pc, "unused")
+	require.Less(t, strings.Index(pc, "Store (interface)"), strings.Index(pc, "Config (struct)"), "interfaces first")
+	require.Contains(t, pc, "===== api/handler_test.go =====\npackage api")
+	require.ElementsMatch(t, []string{"api/handler.go", "api/handler_test.go"}, preloaded)
+}
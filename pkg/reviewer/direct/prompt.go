@@ -12,8 +12,9 @@ package direct
 // stays valid.
 const SystemPrompt = `EXECUTION MODE — how this review runs (the task below is authoritative for WHAT to review).
 
-You run with a FIXED tool set: git_diff, read_file, read_files, glob, grep, the
-ast_* navigation tools (when offered), set_group, add_issues, submit_review.
+You run with a FIXED tool set: git_diff, git_log, git_blame, git_show, read_file,
+read_files, glob, grep, the go_* and ast_* navigation tools and run_linter (when
+offered), set_group, add_issues, submit_review.
 You CANNOT create or edit files. There is NO "Step 1 / Step 2"; you do NOT write
 R*.md files or review.json yourself — the review tools do that.
 
@@ -28,8 +29,14 @@ EFFICIENCY — minimise round-trips:
 - Do not re-read a file you have already seen; reason from its content. grep
   already searches the whole repository in a single call.
 - For symbol navigation (a definition, its references, its callers, a file's
-  outline), prefer the ast_* tools when they are offered — they are precise and
-  cheaper than grepping; fall back to grep when they are absent.
+  outline), prefer the go_* tools (type-checked Go) or the ast_* tools when they
+  are offered — they are precise and cheaper than grepping; fall back to grep
+  when they are absent.
+- Before flagging a pattern as a mistake, git_log / git_blame / git_show tell you
+  whether it is deliberate and long-standing; use them sparingly, for findings
+  that hinge on intent.
+- When run_linter is offered, confirm a suspected vet/lint-class issue with it
+  rather than speculating, and cite its output instead of restating it.
 
 The task may be written for a CLI flow ("write R*.md files, then fill
 review.json"). Deliver the review through the review tools in AS FEW STEPS as
@@ -58,3 +65,109 @@ the result. submit_review is rejected if a group is missing its summary or
 markdown, or if there are more markdown findings than issues. The run ends only
 when submit_review succeeds. (You MAY instead pass files/issues/markdown directly
 in submit_review for a one-shot submit, but only if your output is small enough.)`
+
+// SingleShotPrompt is the execution contract of a single-shot review
+// (RunSingleShot): no tools, the whole review in one JSON reply.
+const SingleShotPrompt = `EXECUTION MODE — single-shot review (the task below is authoritative for WHAT to review).
+
+You have NO tools: you cannot read more files, search or run anything. Review
+from the diff and the file contents provided in the task. Ignore any instruction
+in the task to write R*.md files or review.json — answer with ONE JSON object
+and nothing else:
+
+- review: description (overall verdict), effortMinutes, aiSlopScore (0.0-1.0),
+  optionally title.
+- files: one entry per review group (architecture, code, security, tests,
+  operability) with reviewType, a one-line summary and isAccepted.
+- markdown: the full markdown body of each of the five groups, keyed by group.
+  Head every finding with "### C1. Title" (a localId).
+- issues: one issue per finding, 1:1 with the markdown headers (localId,
+  severity, title, description, file, lines, issueType, fileType = the finding's
+  group, suggestedFix).
+
+Take the review groups, severity scale, personas and every other rule from the
+task itself. The answer is rejected when a group is missing its summary or
+markdown, or when there are more markdown findings than issues; you then get the
+error and answer again with the complete, corrected object.`
+
+// StructuredPrompt is the execution contract of a structured review
+// (RunStructured): no tools, one JSON reply per review group, then the verdict.
+const StructuredPrompt = `EXECUTION MODE — structured review (the task below is authoritative for WHAT to review).
+
+You have NO tools: you cannot read more files, search or run anything. Review
+from the diff and the file contents provided in the task. Ignore any instruction
+in the task to write R*.md files or review.json. You answer in several turns,
+each turn with ONE JSON object and nothing else:
+
+- One turn per review group (architecture, code, security, tests, operability),
+  in the order asked: summary (one line), isAccepted, markdown (the group's
+  full markdown body; head every finding with "### C1. Title", a localId) and
+  issues (one per finding, 1:1 with the headers: localId, severity, title,
+  description, file, lines, issueType, fileType = the group, suggestedFix).
+- A final turn with the overall verdict: review with description,
+  effortMinutes, aiSlopScore (0.0-1.0) and optionally title.
+
+Take the severity scale, personas and every other rule from the task itself.
+An answer is rejected when its summary or markdown is empty or when there are
+more markdown findings than issues; you then get the error and answer again.`
+
+// structuredGroupAsk asks a structured review for one group.
+const structuredGroupAsk = "Answer now for the %s group only."
+
+// structuredVerdictAsk asks a structured review for the overall verdict.
+const structuredVerdictAsk = "All groups are in. Answer now with the overall verdict (review)."
+
+// PlannerPrompt is the execution contract of a sub-agent run's planner: it
+// splits the review into tasks for parallel sub-agents and does not review.
+const PlannerPrompt = `EXECUTION MODE — you PLAN this review; other agents carry it out.
+
+Several reviewer agents will work in parallel, each on one slice of the review
+task below, with the same diff, files and read-only tools. Split the work with
+ONE plan_review call:
+- By review group (architecture, code, security, tests, operability) for a
+  normal change — one task per group, or merge light groups into one task.
+- By package or directory for a large change, each task covering the groups
+  for its paths (pass them in paths).
+Every group must be covered by at least one task. Give each task a short name
+and instructions naming what to look at and what matters most there.
+
+Do NOT review yourself and do not read files one by one: plan from the diff and
+file list in the task. Explore only when the split is genuinely unclear.`
+
+// AgentPrompt is the execution contract of one sub-agent: it reviews its slice
+// (named at the end of the task) and finishes with finish_task.
+const AgentPrompt = `EXECUTION MODE — you review ONE slice of this review, alongside other agents.
+
+Your slice (its groups, paths and focus) is at the end of the task below; the
+other groups and paths are reviewed by other agents — stay inside yours. The task
+is authoritative for WHAT to review (groups, severity scale, personas).
+You CANNOT create or edit files. Tools: read_file, read_files, glob, grep,
+git_diff, git_log, git_blame, git_show, the go_* and ast_* navigation tools and
+run_linter (when offered), set_group, add_issues, finish_task.
+
+EFFICIENCY — the diff and the changed files are in the task; do not re-read them.
+Batch independent tool calls into the same step.
+
+1. set_group — once for EACH of your groups: one-line summary, isAccepted and the
+   markdown body for your slice. Head every finding with "### C1. Title".
+2. add_issues — one issue per finding, 1:1 with the markdown headers, fileType =
+   the finding's group.
+3. finish_task — a one-line note for the lead reviewer. It is rejected while one
+   of your groups is missing or findings lack issues. Your run ends when it
+   succeeds.`
+
+// VerifierPrompt is the contract of the post-review verifier: one finding and
+// the code it cites in, one verdict out.
+const VerifierPrompt = `You double-check ONE finding of an automated code review before it reaches the
+developers. False positives cost their trust, so be sceptical — but a real bug
+must survive.
+
+The finding, the cited lines and the surrounding code are below. Judge only from
+them; you have no other tools.
+
+Answer by calling verdict exactly once:
+- confirm   — the finding is correct and its severity fits.
+- downgrade — the finding is real but overstated; pass the lower severity.
+- reject    — the finding is wrong: the cited code does not do what it claims,
+  the case is already handled, or it is a matter of taste, not a defect.
+Give a one- or two-sentence reason that points at the code.`
@@ -1,6 +1,9 @@
 package direct
 
-import "context"
+import (
+	"cmp"
+	"context"
+)
 
 // LLMProvider is one backend (Anthropic native, DeepSeek/OpenAI-compatible, …).
 // Complete runs a single round: the model sees the system prompt, history and
@@ -19,13 +22,17 @@ type Pricing struct {
 	OutputPerMTok     float64
 	CacheReadPerMTok  float64
 	CacheWritePerMTok float64
+	// CacheWrite1hPerMTok prices 1h-TTL cache writes; zero = CacheWritePerMTok.
+	CacheWrite1hPerMTok float64
 }
 
 // computeCost returns the USD cost of u under pricing p.
 func computeCost(u Usage, p Pricing) float64 {
 	const m = 1_000_000.0
+	write1h := cmp.Or(p.CacheWrite1hPerMTok, p.CacheWritePerMTok)
 	return float64(u.InputTokens)/m*p.InputPerMTok +
 		float64(u.OutputTokens)/m*p.OutputPerMTok +
 		float64(u.CacheReadTokens)/m*p.CacheReadPerMTok +
-		float64(u.CacheWriteTokens)/m*p.CacheWritePerMTok
+		float64(u.CacheWriteTokens-u.CacheWrite1hTokens)/m*p.CacheWritePerMTok +
+		float64(u.CacheWrite1hTokens)/m*write1h
 }
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/anthropics/anthropic-sdk-go"
@@ -24,16 +26,107 @@ type AnthropicConfig struct {
 	Pricing   Pricing
 	Effort    string
 	MaxTokens int
+	// ContextWindow is the model's context window in tokens; 0 = unknown.
+	ContextWindow int
+	// Cache places the prompt-cache breakpoints; nil = DefaultAnthropicCache.
+	Cache *AnthropicCache
+	// NoThinking and NoEffort leave out adaptive thinking and the effort level
+	// for models that reject them (ModelSpec.Thinking, ModelSpec.Effort).
+	NoThinking bool
+	NoEffort   bool
+}
+
+// AnthropicCache selects where prompt-cache breakpoints go and how long the
+// cached prefixes live. A breakpoint caches everything before it (tools, then
+// system, then messages), so each one is a prefix a later request can reuse:
+//   - Tools:   the tool list;
+//   - System:  tools + system prompt;
+//   - Preload: + the kickoff (task, preloaded diff/files, linter findings) —
+//     shared by every round and, within the TTL, by a repeated review of the
+//     same MR;
+//   - History: a rolling breakpoint on the last block of the conversation, so
+//     each round reads the previous rounds from cache and writes only the delta.
+//
+// The four fit the API limit of four breakpoints per request.
+type AnthropicCache struct {
+	Tools   bool
+	System  bool
+	Preload bool
+	History bool
+	// TTL is "5m" (default) or "1h". A 1h write costs more (Pricing) but
+	// survives the gap between a review and its re-run.
+	TTL string
+}
+
+// Cache breakpoint names of ParseAnthropicCache.
+const (
+	cacheTools   = "tools"
+	cacheSystem  = "system"
+	cachePreload = "preload"
+	cacheHistory = "history"
+)
+
+// DefaultAnthropicCache sets every breakpoint with the 5m TTL.
+func DefaultAnthropicCache() AnthropicCache {
+	return AnthropicCache{Tools: true, System: true, Preload: true, History: true, TTL: string(anthropic.CacheControlEphemeralTTLTTL5m)}
+}
+
+// ParseAnthropicCache parses a comma-separated breakpoint list ("tools,
+// system,preload,history"; empty = all, "none" = no caching) and a TTL
+// ("5m", "1h"; empty = 5m).
+func ParseAnthropicCache(breakpoints, ttl string) (AnthropicCache, error) {
+	c := DefaultAnthropicCache()
+	switch ttl {
+	case "":
+	case string(anthropic.CacheControlEphemeralTTLTTL5m), string(anthropic.CacheControlEphemeralTTLTTL1h):
+		c.TTL = ttl
+	default:
+		return c, fmt.Errorf("cache TTL %q: want 5m or 1h", ttl)
+	}
+	switch strings.TrimSpace(breakpoints) {
+	case "":
+		return c, nil
+	case "none":
+		return AnthropicCache{TTL: c.TTL}, nil
+	}
+	c = AnthropicCache{TTL: c.TTL}
+	for _, name := range strings.Split(breakpoints, ",") {
+		switch strings.TrimSpace(name) {
+		case cacheTools:
+			c.Tools = true
+		case cacheSystem:
+			c.System = true
+		case cachePreload:
+			c.Preload = true
+		case cacheHistory:
+			c.History = true
+		default:
+			return c, fmt.Errorf("cache breakpoint %q: want %s, %s, %s or %s", strings.TrimSpace(name), cacheTools, cacheSystem, cachePreload, cacheHistory)
+		}
+	}
+	return c, nil
+}
+
+// control is the cache_control of every breakpoint.
+func (c AnthropicCache) control() anthropic.CacheControlEphemeralParam {
+	cc := anthropic.NewCacheControlEphemeralParam()
+	cc.TTL = anthropic.CacheControlEphemeralTTL(c.TTL)
+	return cc
 }
 
 // anthropicProvider drives the native Anthropic Messages API, with prompt
-// caching on the system prompt + tools and adaptive thinking.
+// caching (AnthropicCache) and adaptive thinking.
 type anthropicProvider struct {
 	client    anthropic.Client
 	model     string
 	pricing   Pricing
 	effort    string
 	maxTokens int64
+	window    int
+	cache     AnthropicCache
+	// noThinking, noEffort: see AnthropicConfig.
+	noThinking bool
+	noEffort   bool
 }
 
 // NewAnthropicProvider builds the native Anthropic provider.
@@ -52,56 +145,174 @@ func NewAnthropicProvider(cfg AnthropicConfig) (LLMProvider, error) {
 	if mt <= 0 {
 		mt = defaultAnthropicMaxTokens
 	}
+	cache := DefaultAnthropicCache()
+	if cfg.Cache != nil {
+		cache = *cfg.Cache
+	}
 	return &anthropicProvider{
 		client:    anthropic.NewClient(opts...),
 		model:     cfg.Model,
 		pricing:   cfg.Pricing,
 		effort:    cfg.Effort,
 		maxTokens: mt,
+		window:    cfg.ContextWindow,
+		cache:     cache,
+
+		noThinking: cfg.NoThinking,
+		noEffort:   cfg.NoEffort,
 	}, nil
 }
 
 func (p *anthropicProvider) Model() string    { return p.model }
 func (p *anthropicProvider) Pricing() Pricing { return p.pricing }
 
-func (p *anthropicProvider) Complete(ctx context.Context, req Request) (Response, error) {
+// Limits reports the configured window and the actual per-response cap.
+func (p *anthropicProvider) Limits() ModelLimits {
+	return ModelLimits{ContextWindow: p.window, MaxOutput: int(p.maxTokens)}
+}
+
+// params builds the Messages API request for req.
+func (p *anthropicProvider) params(req Request) anthropic.MessageNewParams {
 	params := anthropic.MessageNewParams{
 		Model:     p.model,
 		MaxTokens: p.maxTokens,
-		Messages:  toAnthropicMessages(req),
-		Thinking:  anthropic.ThinkingConfigParamUnion{OfAdaptive: &anthropic.ThinkingConfigAdaptiveParam{}},
+		Messages:  toAnthropicMessages(req, p.cache),
+	}
+	if !p.noThinking {
+		params.Thinking = anthropic.ThinkingConfigParamUnion{OfAdaptive: &anthropic.ThinkingConfigAdaptiveParam{}}
 	}
 	if req.System != "" {
-		// Cache the system prompt (stable prefix) — tools are cached too via the
-		// cache_control on the last tool below.
-		params.System = []anthropic.TextBlockParam{{
-			Text:         req.System,
-			CacheControl: anthropic.NewCacheControlEphemeralParam(),
-		}}
-	}
-	if tools := toAnthropicTools(req.Tools); len(tools) > 0 {
+		params.System = []anthropic.TextBlockParam{{Text: req.System}}
+		if p.cache.System {
+			params.System[0].CacheControl = p.cache.control()
+		}
+	}
+	if tools := toAnthropicTools(req.Tools, p.cache); len(tools) > 0 {
 		params.Tools = tools
 	}
-	if eff := cmp.Or(req.Effort, p.effort); eff != "" {
-		params.OutputConfig = anthropic.OutputConfigParam{Effort: anthropic.OutputConfigEffort(eff)}
+	if eff := cmp.Or(req.Effort, p.effort); eff != "" && !p.noEffort {
+		params.OutputConfig.Effort = anthropic.OutputConfigEffort(eff)
 	}
+	if req.ResponseSchema != nil {
+		params.OutputConfig.Format = anthropic.JSONOutputFormatParam{Schema: req.ResponseSchema}
+	}
+	return params
+}
+
+// batchCustomID names the single request of a batch job.
+const batchCustomID = "review"
+
+// SubmitBatch implements BatchAPI via the Message Batches endpoint, with the
+// request Complete would send.
+func (p *anthropicProvider) SubmitBatch(ctx context.Context, req Request) (string, error) {
+	params := p.params(req)
+	b, err := p.client.Messages.Batches.New(ctx, anthropic.MessageBatchNewParams{
+		Requests: []anthropic.MessageBatchNewParamsRequest{{
+			CustomID: batchCustomID,
+			Params: anthropic.MessageBatchNewParamsRequestParams{
+				Model:        params.Model,
+				MaxTokens:    params.MaxTokens,
+				Messages:     params.Messages,
+				System:       params.System,
+				Tools:        params.Tools,
+				Thinking:     params.Thinking,
+				OutputConfig: params.OutputConfig,
+			},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("anthropic: %w", err)
+	}
+	return b.ID, nil
+}
+
+// BatchResult implements BatchAPI: the batch status, then the result of its
+// one request once processing has ended.
+func (p *anthropicProvider) BatchResult(ctx context.Context, id string) (Response, bool, error) {
+	b, err := p.client.Messages.Batches.Get(ctx, id)
+	var apiErr *anthropic.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return Response{}, false, fmt.Errorf("%w: %s not found", errBatchGone, id)
+	}
+	if err != nil {
+		return Response{}, false, fmt.Errorf("anthropic: %w", err)
+	}
+	if b.ProcessingStatus != anthropic.MessageBatchProcessingStatusEnded {
+		return Response{}, false, nil
+	}
+	stream := p.client.Messages.Batches.ResultsStreaming(ctx, id)
+	defer stream.Close()
+	for stream.Next() {
+		r := stream.Current()
+		if r.CustomID != batchCustomID {
+			continue
+		}
+		switch r.Result.Type {
+		case "succeeded":
+			return anthropicResponse(r.Result.Message), true, nil
+		case "errored":
+			return Response{}, true, fmt.Errorf("anthropic: batch request failed: %s", r.Result.Error.Error.Message)
+		default: // canceled, expired
+			return Response{}, true, fmt.Errorf("%w: %s %s", errBatchGone, id, r.Result.Type)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return Response{}, true, fmt.Errorf("anthropic: batch results: %w", err)
+	}
+	return Response{}, true, fmt.Errorf("anthropic: batch %s ended without a result", id)
+}
+
+// CountTokens implements TokenCounter via the count_tokens endpoint, with the
+// same system prompt, tools and thinking config as Complete would send.
+func (p *anthropicProvider) CountTokens(ctx context.Context, req Request) (int, error) {
+	params := p.params(req)
+	cp := anthropic.MessageCountTokensParams{
+		Model:        params.Model,
+		Messages:     params.Messages,
+		Thinking:     params.Thinking,
+		OutputConfig: params.OutputConfig,
+		System:       anthropic.MessageCountTokensParamsSystemUnion{OfTextBlockArray: params.System},
+	}
+	for _, t := range params.Tools {
+		cp.Tools = append(cp.Tools, anthropic.MessageCountTokensToolUnionParam{OfTool: t.OfTool})
+	}
+	res, err := p.client.Messages.CountTokens(ctx, cp)
+	if err != nil {
+		return 0, fmt.Errorf("anthropic: count tokens: %w", err)
+	}
+	return int(res.InputTokens), nil
+}
+
+func (p *anthropicProvider) Complete(ctx context.Context, req Request) (Response, error) {
+	params := p.params(req)
 
 	// Stream the response and accumulate it into a complete message. Streaming
 	// avoids the non-streaming request timeout on heavy rounds (high effort +
 	// adaptive thinking), where a single round can take a minute or more of
-	// output — a non-streamed POST would risk an HTTP read timeout.
-	stream := p.client.Messages.NewStreaming(ctx, params)
+	// output — a non-streamed POST would risk an HTTP read timeout. The idle
+	// watchdog aborts a stream that stops delivering events (pings included).
+	sctx, touch, stop := withIdleTimeout(ctx, req.IdleTimeout)
+	defer stop()
+	stream := p.client.Messages.NewStreaming(sctx, params)
 	defer stream.Close()
 	var resp anthropic.Message
 	for stream.Next() {
-		if err := resp.Accumulate(stream.Current()); err != nil {
+		touch()
+		ev := stream.Current()
+		if err := resp.Accumulate(ev); err != nil {
 			return Response{}, fmt.Errorf("anthropic: accumulate: %w", err)
 		}
+		emitAnthropicDelta(req.OnDelta, ev)
 	}
 	if err := stream.Err(); err != nil {
-		return Response{}, fmt.Errorf("anthropic: %w", err)
+		return Response{}, fmt.Errorf("anthropic: %w", streamErr(sctx, err))
 	}
 
+	return anthropicResponse(resp), nil
+}
+
+// anthropicResponse converts a complete message into the neutral Response.
+func anthropicResponse(resp anthropic.Message) Response {
 	out := Response{StopReason: string(resp.StopReason)}
 	for _, block := range resp.Content {
 		switch v := block.AsAny().(type) {
@@ -120,15 +331,41 @@ func (p *anthropicProvider) Complete(ctx context.Context, req Request) (Response
 		OutputTokens:     int(resp.Usage.OutputTokens),
 		CacheReadTokens:  int(resp.Usage.CacheReadInputTokens),
 		CacheWriteTokens: int(resp.Usage.CacheCreationInputTokens),
+
+		CacheWrite1hTokens: int(resp.Usage.CacheCreation.Ephemeral1hInputTokens),
 	}
 	// Keep the exact assistant turn (incl. signed thinking blocks) so the next
 	// request replays it verbatim — rebuilding from Text+ToolCalls would drop the
 	// thinking blocks that adaptive thinking + tool use round-trips.
 	out.Raw = resp.ToParam()
-	return out, nil
+	return out
 }
 
-func toAnthropicMessages(req Request) []anthropic.MessageParam {
+// emitAnthropicDelta forwards the partial output carried by one stream event:
+// text and thinking fragments, and tool-input JSON (the tool name is announced
+// when its block starts).
+func emitAnthropicDelta(fn func(StreamDelta), ev anthropic.MessageStreamEventUnion) {
+	if fn == nil {
+		return
+	}
+	switch v := ev.AsAny().(type) {
+	case anthropic.ContentBlockStartEvent:
+		if tu, ok := v.ContentBlock.AsAny().(anthropic.ToolUseBlock); ok {
+			emitDelta(fn, StreamDelta{Kind: DeltaToolCall, Tool: tu.Name})
+		}
+	case anthropic.ContentBlockDeltaEvent:
+		switch d := v.Delta.AsAny().(type) {
+		case anthropic.TextDelta:
+			emitDelta(fn, StreamDelta{Kind: DeltaText, Text: d.Text})
+		case anthropic.ThinkingDelta:
+			emitDelta(fn, StreamDelta{Kind: DeltaThinking, Text: d.Thinking})
+		case anthropic.InputJSONDelta:
+			emitDelta(fn, StreamDelta{Kind: DeltaToolCall, Text: d.PartialJSON})
+		}
+	}
+}
+
+func toAnthropicMessages(req Request, cache AnthropicCache) []anthropic.MessageParam {
 	var msgs []anthropic.MessageParam
 	for _, m := range req.Messages {
 		switch m.Role {
@@ -158,44 +395,64 @@ func toAnthropicMessages(req Request) []anthropic.MessageParam {
 			for _, tr := range m.ToolResults {
 				blocks = append(blocks, anthropic.NewToolResultBlock(tr.CallID, tr.Content, tr.IsError))
 			}
+			// A harness note rides in the same user turn after the results —
+			// a separate user message would break role alternation.
+			if strings.TrimSpace(m.Text) != "" {
+				blocks = append(blocks, anthropic.NewTextBlock(m.Text))
+			}
 			if len(blocks) > 0 {
 				msgs = append(msgs, anthropic.NewUserMessage(blocks...))
 			}
 		}
 	}
+	// Preload breakpoint: the kickoff is the big stable part of the prompt, so
+	// it stays a cached prefix of its own once the rolling breakpoint moves on
+	// (and after compaction rewrites the turns behind it).
+	if cache.Preload && len(msgs) > 0 {
+		msgs[0] = markLastBlockCacheable(msgs[0], cache.control())
+	}
 	// Rolling cache breakpoint: mark the last block of the conversation so the
-	// whole history prefix (the big preload in msg[0] + all accumulated tool
-	// results) is cached. Next round reuses it as a prefix — reading it at the
-	// cache-read rate (0.1x) and writing only the new delta — instead of
-	// re-sending the entire history at the full input price every round.
-	markLastBlockCacheable(msgs)
+	// whole history prefix (the preload + all accumulated tool results) is
+	// cached. Next round reuses it as a prefix — reading it at the cache-read
+	// rate (0.1x) and writing only the new delta — instead of re-sending the
+	// entire history at the full input price every round.
+	if cache.History && len(msgs) > 0 {
+		msgs[len(msgs)-1] = markLastBlockCacheable(msgs[len(msgs)-1], cache.control())
+	}
 	return msgs
 }
 
-// markLastBlockCacheable sets ephemeral cache_control on the final content block
-// of the final message, caching the conversation prefix up to and including it.
-func markLastBlockCacheable(msgs []anthropic.MessageParam) {
-	if len(msgs) == 0 {
-		return
-	}
-	blocks := msgs[len(msgs)-1].Content
-	if len(blocks) == 0 {
-		return
+// markLastBlockCacheable returns m with cache_control set on its final content
+// block, caching the conversation prefix up to and including it. The block is
+// copied first: m may be a kept Raw assistant turn shared with the history, and
+// marking it in place would pile breakpoints up over the rounds.
+func markLastBlockCacheable(m anthropic.MessageParam, cc anthropic.CacheControlEphemeralParam) anthropic.MessageParam {
+	if len(m.Content) == 0 {
+		return m
 	}
-	cc := anthropic.NewCacheControlEphemeralParam()
-	switch b := &blocks[len(blocks)-1]; {
+	m.Content = slices.Clone(m.Content)
+	switch b := &m.Content[len(m.Content)-1]; {
 	case b.OfText != nil:
-		b.OfText.CacheControl = cc
+		blk := *b.OfText
+		blk.CacheControl = cc
+		b.OfText = &blk
 	case b.OfToolResult != nil:
-		b.OfToolResult.CacheControl = cc
+		blk := *b.OfToolResult
+		blk.CacheControl = cc
+		b.OfToolResult = &blk
 	case b.OfToolUse != nil:
-		b.OfToolUse.CacheControl = cc
+		blk := *b.OfToolUse
+		blk.CacheControl = cc
+		b.OfToolUse = &blk
 	case b.OfImage != nil:
-		b.OfImage.CacheControl = cc
+		blk := *b.OfImage
+		blk.CacheControl = cc
+		b.OfImage = &blk
 	}
+	return m
 }
 
-func toAnthropicTools(defs []ToolDef) []anthropic.ToolUnionParam {
+func toAnthropicTools(defs []ToolDef, cache AnthropicCache) []anthropic.ToolUnionParam {
 	if len(defs) == 0 {
 		return nil
 	}
@@ -213,9 +470,9 @@ func toAnthropicTools(defs []ToolDef) []anthropic.ToolUnionParam {
 				Required:   required,
 			},
 		}
-		// cache_control on the last tool caches the whole tools+system prefix.
-		if i == len(defs)-1 {
-			tp.CacheControl = anthropic.NewCacheControlEphemeralParam()
+		// cache_control on the last tool caches the whole tool list.
+		if cache.Tools && i == len(defs)-1 {
+			tp.CacheControl = cache.control()
 		}
 		out = append(out, anthropic.ToolUnionParam{OfTool: tp})
 	}
@@ -15,7 +15,7 @@ func TestToAnthropicMessagesReplaysRawAssistant(t *testing.T) {
 		// Text here must be ignored in favour of the verbatim Raw turn.
 		{Role: RoleAssistant, Text: "rebuilt-should-not-be-used", Raw: raw},
 		{Role: RoleTool, ToolResults: []ToolResult{{CallID: "1", Content: "ok"}}},
-	}})
+	}}, DefaultAnthropicCache())
 
 	require.Len(t, out, 3)
 	require.Equal(t, raw, out[1], "assistant turn must be replayed verbatim from Raw")
@@ -24,7 +24,102 @@ func TestToAnthropicMessagesReplaysRawAssistant(t *testing.T) {
 func TestToAnthropicMessagesRebuildsWithoutRaw(t *testing.T) {
 	out := toAnthropicMessages(Request{Messages: []Message{
 		{Role: RoleAssistant, Text: "hi", ToolCalls: []ToolCall{{ID: "1", Name: "t", Args: []byte(`{}`)}}},
-	}})
+	}}, DefaultAnthropicCache())
 	require.Len(t, out, 1)
 	require.Equal(t, anthropic.MessageParamRoleAssistant, out[0].Role)
 }
+
+func TestToAnthropicMessagesToolNote(t *testing.T) {
+	out := toAnthropicMessages(Request{Messages: []Message{
+		{Role: RoleTool, Text: "note", ToolResults: []ToolResult{{CallID: "1", Content: "ok"}}},
+	}}, DefaultAnthropicCache())
+	require.Len(t, out, 1)
+	require.Len(t, out[0].Content, 2, "tool results followed by the note text block")
+	require.NotNil(t, out[0].Content[1].OfText)
+	require.Equal(t, "note", out[0].Content[1].OfText.Text)
+}
+
+func TestParseAnthropicCache(t *testing.T) {
+	c, err := ParseAnthropicCache("", "")
+	require.NoError(t, err)
+	require.Equal(t, DefaultAnthropicCache(), c)
+
+	c, err = ParseAnthropicCache("system, preload", "1h")
+	require.NoError(t, err)
+	require.Equal(t, AnthropicCache{System: true, Preload: true, TTL: "1h"}, c)
+
+	c, err = ParseAnthropicCache("none", "")
+	require.NoError(t, err)
+	require.Equal(t, AnthropicCache{TTL: "5m"}, c)
+
+	_, err = ParseAnthropicCache("prefix", "")
+	require.ErrorContains(t, err, `cache breakpoint "prefix"`)
+	_, err = ParseAnthropicCache("", "24h")
+	require.ErrorContains(t, err, "want 5m or 1h")
+}
+
+func TestAnthropicCacheBreakpoints(t *testing.T) {
+	req := Request{
+		System: "contract",
+		Tools:  []ToolDef{{Name: "a"}, {Name: "b"}},
+		Messages: []Message{
+			{Role: RoleUser, Text: "kickoff with preload"},
+			{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "1", Name: "a", Args: []byte(`{}`)}}},
+			{Role: RoleTool, ToolResults: []ToolResult{{CallID: "1", Content: "ok"}}},
+		},
+	}
+	cached := func(cc anthropic.CacheControlEphemeralParam) string { return string(cc.TTL) }
+
+	p := &anthropicProvider{model: "m", cache: AnthropicCache{Tools: true, System: true, Preload: true, History: true, TTL: "1h"}}
+	params := p.params(req)
+	require.Equal(t, "1h", cached(params.System[0].CacheControl))
+	require.Empty(t, cached(params.Tools[0].OfTool.CacheControl))
+	require.Equal(t, "1h", cached(params.Tools[1].OfTool.CacheControl))
+	require.Equal(t, "1h", cached(params.Messages[0].Content[0].OfText.CacheControl), "preload breakpoint")
+	require.Empty(t, cached(params.Messages[1].Content[0].OfToolUse.CacheControl))
+	require.Equal(t, "1h", cached(params.Messages[2].Content[0].OfToolResult.CacheControl), "rolling breakpoint")
+
+	p.cache = AnthropicCache{Preload: true, TTL: "5m"}
+	params = p.params(req)
+	require.Empty(t, cached(params.System[0].CacheControl))
+	require.Empty(t, cached(params.Tools[1].OfTool.CacheControl))
+	require.Equal(t, "5m", cached(params.Messages[0].Content[0].OfText.CacheControl))
+	require.Empty(t, cached(params.Messages[2].Content[0].OfToolResult.CacheControl))
+}
+
+func TestAnthropicCacheBreakpointsLeaveHistoryAlone(t *testing.T) {
+	raw := anthropic.NewAssistantMessage(anthropic.NewTextBlock("thinking it over"))
+	req := Request{Messages: []Message{
+		{Role: RoleUser, Text: "kickoff"},
+		{Role: RoleAssistant, Text: "thinking it over", Raw: raw},
+	}}
+	p := &anthropicProvider{model: "m", cache: AnthropicCache{History: true, TTL: "5m"}}
+	params := p.params(req)
+	require.Equal(t, "5m", string(params.Messages[1].Content[0].OfText.CacheControl.TTL))
+
+	// The kept turn is not marked, so the next round sets a single rolling
+	// breakpoint and the request fingerprint does not shift.
+	require.Empty(t, string(raw.Content[0].OfText.CacheControl.TTL))
+	req.Messages = append(req.Messages, Message{Role: RoleUser, Text: "go on"})
+	params = p.params(req)
+	require.Empty(t, string(params.Messages[1].Content[0].OfText.CacheControl.TTL))
+	require.Equal(t, "5m", string(params.Messages[2].Content[0].OfText.CacheControl.TTL))
+}
+
+func TestComputeCostCacheTTL(t *testing.T) {
+	haiku, _ := DefaultCatalog().Lookup("claude-haiku-4-5")
+	p := haiku.Pricing()
+	u := Usage{InputTokens: 1_000_000, CacheReadTokens: 1_000_000, CacheWriteTokens: 2_000_000, CacheWrite1hTokens: 1_000_000}
+	require.InDelta(t, 1+0.1+1.25+2, computeCost(u, p), 1e-9)
+	require.InDelta(t, 0.25, u.CacheHitRatio(), 1e-9)
+	require.Zero(t, Usage{}.CacheHitRatio())
+}
+
+func TestAnthropicResponseSchema(t *testing.T) {
+	p := &anthropicProvider{model: "m"}
+	req := Request{System: "s", Messages: []Message{{Role: RoleUser, Text: "review"}}}
+	require.Nil(t, p.params(req).OutputConfig.Format.Schema)
+
+	req.ResponseSchema = singleShotSchema()
+	require.Equal(t, req.ResponseSchema, p.params(req).OutputConfig.Format.Schema)
+}
@@ -16,15 +16,32 @@ type ProviderConfig struct {
 	BaseURL     string
 	APIKey      string
 	Temperature float32
-	Pricing     Pricing // optional override; falls back to pricingFor(Model)
+	Pricing     Pricing // optional override; falls back to the Catalog entry
+	// ContextWindow overrides the model's context window in tokens; falls back
+	// to the Catalog entry. Needed for self-hosted OpenAI-compatible models.
+	ContextWindow int
+	// Catalog prices and sizes the model; nil = DefaultCatalog.
+	Catalog *Catalog
+	// Cache places Anthropic prompt-cache breakpoints; nil = all, 5m TTL.
+	// Other providers cache automatically and ignore it.
+	Cache *AnthropicCache
+	// ResponseFormat is how OpenAI-compatible backends get a structured-output
+	// schema (OpenAIConfig.ResponseFormat); Anthropic always sends the schema.
+	ResponseFormat string
 }
 
-// NewProvider builds an LLMProvider from cfg. The native Anthropic provider is
-// added in M1; until then "anthropic" returns an error.
+// NewProvider builds an LLMProvider from cfg. Prices, limits and the
+// Anthropic features sent (thinking, effort, caching) come from the catalogue
+// entry of the model; an unknown model gets zero prices and every feature.
 func NewProvider(cfg ProviderConfig) (LLMProvider, error) {
+	spec, known := cfg.Catalog.Lookup(cfg.Model)
 	pricing := cfg.Pricing
 	if pricing == (Pricing{}) {
-		pricing = pricingFor(cfg.Model)
+		pricing = spec.Pricing()
+	}
+	window := cfg.ContextWindow
+	if window <= 0 {
+		window = spec.ContextWindow
 	}
 	switch strings.ToLower(cfg.Provider) {
 	case "", providerDeepSeek:
@@ -32,40 +49,20 @@ func NewProvider(cfg ProviderConfig) (LLMProvider, error) {
 		if base == "" {
 			base = "https://api.deepseek.com"
 		}
-		return NewOpenAIProvider(OpenAIConfig{APIKey: cfg.APIKey, BaseURL: base, Model: cfg.Model, Pricing: pricing, Temperature: cfg.Temperature})
+		return NewOpenAIProvider(OpenAIConfig{APIKey: cfg.APIKey, BaseURL: base, Model: cfg.Model, Pricing: pricing, Temperature: cfg.Temperature, MaxTokens: spec.MaxOutput, ContextWindow: window, ResponseFormat: cfg.ResponseFormat})
 	case "openai", "openai-compat":
-		return NewOpenAIProvider(OpenAIConfig{APIKey: cfg.APIKey, BaseURL: cfg.BaseURL, Model: cfg.Model, Pricing: pricing, Temperature: cfg.Temperature})
+		return NewOpenAIProvider(OpenAIConfig{APIKey: cfg.APIKey, BaseURL: cfg.BaseURL, Model: cfg.Model, Pricing: pricing, Temperature: cfg.Temperature, MaxTokens: spec.MaxOutput, ContextWindow: window, ResponseFormat: cfg.ResponseFormat})
 	case "anthropic":
 		// effort flows through Request.Effort (from DirectRunner.Effort).
-		return NewAnthropicProvider(AnthropicConfig{APIKey: cfg.APIKey, BaseURL: cfg.BaseURL, Model: cfg.Model, Pricing: pricing})
+		ac := AnthropicConfig{APIKey: cfg.APIKey, BaseURL: cfg.BaseURL, Model: cfg.Model, Pricing: pricing, MaxTokens: spec.MaxOutput, ContextWindow: window, Cache: cfg.Cache}
+		if known {
+			ac.NoThinking, ac.NoEffort = !spec.Thinking, !spec.Effort
+			if !spec.Caching {
+				ac.Cache = &AnthropicCache{}
+			}
+		}
+		return NewAnthropicProvider(ac)
 	default:
 		return nil, fmt.Errorf("unknown provider %q", cfg.Provider)
 	}
 }
-
-// pricingFor returns published Claude per-MTok pricing for known model families,
-// or a zero table (cost reported as 0) for anything else, e.g. DeepSeek — set
-// ProviderConfig.Pricing to override.
-func pricingFor(model string) Pricing {
-	switch {
-	case strings.HasPrefix(model, "claude-opus"), strings.HasPrefix(model, "claude-fable"):
-		return Pricing{InputPerMTok: 5, OutputPerMTok: 25, CacheReadPerMTok: 0.5, CacheWritePerMTok: 6.25}
-	case strings.HasPrefix(model, "claude-sonnet"):
-		return Pricing{InputPerMTok: 3, OutputPerMTok: 15, CacheReadPerMTok: 0.3, CacheWritePerMTok: 3.75}
-	case strings.HasPrefix(model, "claude-haiku"):
-		return Pricing{InputPerMTok: 1, OutputPerMTok: 5, CacheReadPerMTok: 0.1, CacheWritePerMTok: 1.25}
-	case strings.HasPrefix(model, "deepseek-v4-pro"):
-		// V4 Pro current (permanently-discounted) rates, USD/MTok. Cache hits are
-		// billed at CacheRead; DeepSeek has no separate cache-write charge (a miss
-		// is just the input price).
-		return Pricing{InputPerMTok: 0.435, OutputPerMTok: 0.87, CacheReadPerMTok: 0.003625, CacheWritePerMTok: 0.435}
-	case strings.HasPrefix(model, "deepseek-v4-flash"):
-		// V4 Flash rates (also the deepseek-chat/reasoner compatibility aliases).
-		return Pricing{InputPerMTok: 0.14, OutputPerMTok: 0.28, CacheReadPerMTok: 0.0028, CacheWritePerMTok: 0.14}
-	case strings.HasPrefix(model, providerDeepSeek):
-		// Legacy deepseek-chat/reasoner alias to V4 Flash (deprecating 2026-07-24).
-		return Pricing{InputPerMTok: 0.14, OutputPerMTok: 0.28, CacheReadPerMTok: 0.0028, CacheWritePerMTok: 0.14}
-	default:
-		return Pricing{}
-	}
-}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	openai "github.com/sashabaranov/go-openai"
@@ -28,8 +30,25 @@ type OpenAIConfig struct {
 	Temperature float32
 	MaxRetries  int // transient (429/5xx/network) retry budget; 0 -> defaultMaxRetries
 	MaxTokens   int // per-response output cap; 0 -> defaultMaxTokens
+	// ContextWindow is the model's context window in tokens; 0 = unknown.
+	ContextWindow int
+	// ResponseFormat is how a Request.ResponseSchema is sent: ResponseFormatSchema
+	// (default), ResponseFormatObject or ResponseFormatNone.
+	ResponseFormat string
 }
 
+// OpenAI-compatible response_format modes for structured-output requests.
+const (
+	// ResponseFormatSchema sends the schema as response_format json_schema;
+	// vLLM and llama.cpp servers compile it into a grammar.
+	ResponseFormatSchema = "json_schema"
+	// ResponseFormatObject only asks for some JSON object, for servers that
+	// reject schemas; the prompt carries the shape.
+	ResponseFormatObject = "json_object"
+	// ResponseFormatNone sends no response_format at all.
+	ResponseFormatNone = "none"
+)
+
 // openaiProvider drives an OpenAI-compatible chat-completions API.
 type openaiProvider struct {
 	client      *openai.Client
@@ -38,6 +57,8 @@ type openaiProvider struct {
 	temperature float32
 	maxRetries  int
 	maxTokens   int
+	window      int
+	format      string
 }
 
 // NewOpenAIProvider builds a provider for DeepSeek / OpenAI-compatible endpoints.
@@ -60,6 +81,15 @@ func NewOpenAIProvider(cfg OpenAIConfig) (LLMProvider, error) {
 	if maxTokens <= 0 {
 		maxTokens = defaultMaxTokens
 	}
+	format := cfg.ResponseFormat
+	switch format {
+	case "":
+		format = ResponseFormatSchema
+	case ResponseFormatSchema, ResponseFormatObject, ResponseFormatNone:
+	default:
+		return nil, fmt.Errorf("openai provider: unknown response format %q (want %s, %s or %s)",
+			format, ResponseFormatSchema, ResponseFormatObject, ResponseFormatNone)
+	}
 	return &openaiProvider{
 		client:      openai.NewClientWithConfig(conf),
 		model:       cfg.Model,
@@ -67,12 +97,19 @@ func NewOpenAIProvider(cfg OpenAIConfig) (LLMProvider, error) {
 		temperature: cfg.Temperature,
 		maxRetries:  maxRetries,
 		maxTokens:   maxTokens,
+		window:      cfg.ContextWindow,
+		format:      format,
 	}, nil
 }
 
 func (p *openaiProvider) Model() string    { return p.model }
 func (p *openaiProvider) Pricing() Pricing { return p.pricing }
 
+// Limits reports the configured window and the actual per-response cap.
+func (p *openaiProvider) Limits() ModelLimits {
+	return ModelLimits{ContextWindow: p.window, MaxOutput: p.maxTokens}
+}
+
 func (p *openaiProvider) Complete(ctx context.Context, req Request) (Response, error) {
 	creq := openai.ChatCompletionRequest{
 		Model:       p.model,
@@ -80,17 +117,20 @@ func (p *openaiProvider) Complete(ctx context.Context, req Request) (Response, e
 		Tools:       toOpenAITools(req.Tools),
 		Temperature: p.temperature,
 		MaxTokens:   p.maxTokens,
+		// Usage arrives on the final chunk only when explicitly requested.
+		StreamOptions:  &openai.StreamOptions{IncludeUsage: true},
+		ResponseFormat: p.responseFormat(req.ResponseSchema),
 	}
 
-	// Retry transient errors (429 / 5xx / network) with exponential backoff.
-	// A non-transient error (400, auth) fails immediately.
-	var resp openai.ChatCompletionResponse
+	// Retry transient errors (429 / 5xx / network / stalled stream) with
+	// exponential backoff. A non-transient error (400, auth) fails immediately.
+	var out Response
 	var err error
 	backoff := 500 * time.Millisecond
 	for attempt := 0; ; attempt++ {
-		resp, err = p.client.CreateChatCompletion(ctx, creq)
+		out, err = p.stream(ctx, creq, req)
 		if err == nil {
-			break
+			return out, nil
 		}
 		if attempt >= p.maxRetries || ctx.Err() != nil || !isTransientErr(err) {
 			return Response{}, fmt.Errorf("openai: %w", err)
@@ -102,36 +142,186 @@ func (p *openaiProvider) Complete(ctx context.Context, req Request) (Response, e
 		}
 		backoff *= 2
 	}
-	if len(resp.Choices) == 0 {
-		return Response{}, errors.New("openai: response had no choices")
+}
+
+// responseFormat maps a structured-output schema onto response_format; nil
+// when the request has no schema or the format is off.
+func (p *openaiProvider) responseFormat(schema map[string]any) *openai.ChatCompletionResponseFormat {
+	if schema == nil {
+		return nil
+	}
+	switch p.format {
+	case ResponseFormatObject:
+		return &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	case ResponseFormatNone:
+		return nil
 	}
+	// Not strict: OpenAI's strict mode wants every property required, and the
+	// local servers enforce the schema through their grammar either way.
+	return &openai.ChatCompletionResponseFormat{
+		Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+		JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+			Name:   "review",
+			Schema: jsonSchema(schema),
+		},
+	}
+}
 
-	ch := resp.Choices[0]
-	out := Response{Text: ch.Message.Content, StopReason: string(ch.FinishReason)}
-	for _, tc := range ch.Message.ToolCalls {
-		out.ToolCalls = append(out.ToolCalls, ToolCall{
-			ID:   tc.ID,
-			Name: tc.Function.Name,
-			Args: json.RawMessage(tc.Function.Arguments),
-		})
+// jsonSchema lets a schema map stand in for go-openai's json.Marshaler field.
+type jsonSchema map[string]any
+
+func (s jsonSchema) MarshalJSON() ([]byte, error) { return json.Marshal(map[string]any(s)) }
+
+// stream runs one streamed chat completion and assembles it into a Response,
+// forwarding partial output to req.OnDelta as it arrives. Streaming keeps a long
+// reasoning round from sitting on a silent connection that proxies cut.
+func (p *openaiProvider) stream(ctx context.Context, creq openai.ChatCompletionRequest, req Request) (Response, error) {
+	sctx, touch, stop := withIdleTimeout(ctx, req.IdleTimeout)
+	defer stop()
+	st, err := p.client.CreateChatCompletionStream(sctx, creq)
+	if err != nil {
+		return Response{}, streamErr(sctx, err)
+	}
+	defer st.Close()
+
+	acc := newOpenAIStreamAcc(req.OnDelta)
+	for {
+		chunk, rerr := st.Recv()
+		if errors.Is(rerr, io.EOF) {
+			break
+		}
+		if rerr != nil {
+			return Response{}, streamErr(sctx, rerr)
+		}
+		touch()
+		acc.add(chunk)
+	}
+	return acc.response()
+}
+
+// openaiStreamAcc assembles streamed chat-completion chunks into one Response.
+// Tool calls arrive as fragments keyed by index: the first fragment carries the
+// id and name, later ones append to the JSON arguments.
+type openaiStreamAcc struct {
+	onDelta   func(StreamDelta)
+	text      strings.Builder
+	reasoning strings.Builder
+	calls     []*openaiToolCallAcc
+	finish    string
+	usage     *openai.Usage
+	choices   int
+}
+
+// openaiTurn is the OpenAI-compatible provider's Message.Raw: what the neutral
+// message can't carry. DeepSeek's thinking mode requires an assistant turn's
+// reasoning_content to be sent back with it inside a tool-call loop.
+type openaiTurn struct {
+	ReasoningContent string `json:"reasoningContent,omitempty"`
+}
+
+type openaiToolCallAcc struct {
+	id   string
+	name string
+	args strings.Builder
+}
+
+func newOpenAIStreamAcc(onDelta func(StreamDelta)) *openaiStreamAcc {
+	return &openaiStreamAcc{onDelta: onDelta}
+}
+
+// add folds one chunk into the accumulator.
+func (a *openaiStreamAcc) add(chunk openai.ChatCompletionStreamResponse) {
+	if chunk.Usage != nil {
+		a.usage = chunk.Usage
+	}
+	for _, ch := range chunk.Choices {
+		if ch.Index != 0 {
+			continue // n=1: only the first choice is requested
+		}
+		a.choices++
+		d := ch.Delta
+		if d.ReasoningContent != "" {
+			a.reasoning.WriteString(d.ReasoningContent)
+			emitDelta(a.onDelta, StreamDelta{Kind: DeltaThinking, Text: d.ReasoningContent})
+		}
+		if d.Content != "" {
+			a.text.WriteString(d.Content)
+			emitDelta(a.onDelta, StreamDelta{Kind: DeltaText, Text: d.Content})
+		}
+		for _, tc := range d.ToolCalls {
+			a.addToolCall(tc)
+		}
+		if ch.FinishReason != "" {
+			a.finish = string(ch.FinishReason)
+		}
+	}
+}
+
+func (a *openaiStreamAcc) addToolCall(tc openai.ToolCall) {
+	idx := len(a.calls)
+	if tc.Index != nil {
+		idx = *tc.Index
+	} else if tc.ID == "" && idx > 0 {
+		idx-- // index-less continuation fragment: append to the last call
+	}
+	for len(a.calls) <= idx {
+		a.calls = append(a.calls, &openaiToolCallAcc{})
+	}
+	c := a.calls[idx]
+	if tc.ID != "" {
+		c.id = tc.ID
+	}
+	if tc.Function.Name != "" && c.name == "" {
+		c.name = tc.Function.Name
+		emitDelta(a.onDelta, StreamDelta{Kind: DeltaToolCall, Tool: c.name})
 	}
+	if tc.Function.Arguments != "" {
+		c.args.WriteString(tc.Function.Arguments)
+		emitDelta(a.onDelta, StreamDelta{Kind: DeltaToolCall, Text: tc.Function.Arguments})
+	}
+}
 
-	// Split cached prompt tokens out of the input count so cost matches the
-	// Anthropic semantics (InputTokens = uncached remainder).
+// response builds the final Response from the accumulated stream.
+func (a *openaiStreamAcc) response() (Response, error) {
+	if a.choices == 0 {
+		return Response{}, errors.New("response had no choices")
+	}
+	out := Response{Text: a.text.String(), StopReason: a.finish}
+	if a.reasoning.Len() > 0 {
+		out.Raw = openaiTurn{ReasoningContent: a.reasoning.String()}
+	}
+	for _, c := range a.calls {
+		if c.name == "" {
+			continue // an index gap (never filled) — nothing to dispatch
+		}
+		args := c.args.String()
+		if strings.TrimSpace(args) == "" {
+			args = "{}"
+		}
+		out.ToolCalls = append(out.ToolCalls, ToolCall{ID: c.id, Name: c.name, Args: json.RawMessage(args)})
+	}
+	if a.usage != nil {
+		out.Usage = openaiUsage(*a.usage)
+	}
+	return out, nil
+}
+
+// openaiUsage splits cached prompt tokens out of the input count so cost matches
+// the Anthropic semantics (InputTokens = uncached remainder).
+func openaiUsage(u openai.Usage) Usage {
 	cached := 0
-	if resp.Usage.PromptTokensDetails != nil {
-		cached = resp.Usage.PromptTokensDetails.CachedTokens
+	if u.PromptTokensDetails != nil {
+		cached = u.PromptTokensDetails.CachedTokens
 	}
-	input := resp.Usage.PromptTokens - cached
+	input := u.PromptTokens - cached
 	if input < 0 {
-		input, cached = resp.Usage.PromptTokens, 0
+		input, cached = u.PromptTokens, 0
 	}
-	out.Usage = Usage{
+	return Usage{
 		InputTokens:     input,
-		OutputTokens:    resp.Usage.CompletionTokens,
+		OutputTokens:    u.CompletionTokens,
 		CacheReadTokens: cached,
 	}
-	return out, nil
 }
 
 func toOpenAIMessages(req Request) []openai.ChatCompletionMessage {
@@ -148,6 +338,9 @@ func toOpenAIMessages(req Request) []openai.ChatCompletionMessage {
 			msgs = append(msgs, openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: m.Text})
 		case RoleAssistant:
 			am := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: m.Text}
+			if t, ok := m.Raw.(openaiTurn); ok {
+				am.ReasoningContent = t.ReasoningContent
+			}
 			for _, tc := range m.ToolCalls {
 				am.ToolCalls = append(am.ToolCalls, openai.ToolCall{
 					ID:       tc.ID,
@@ -171,14 +364,22 @@ func toOpenAIMessages(req Request) []openai.ChatCompletionMessage {
 					Content:    tr.Content,
 				})
 			}
+			// A harness note follows the tool messages as a user turn, which the
+			// chat-completions protocol allows after tool results.
+			if strings.TrimSpace(m.Text) != "" {
+				msgs = append(msgs, openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: m.Text})
+			}
 		}
 	}
 	return msgs
 }
 
 // isTransientErr reports whether err is worth retrying: an HTTP 429 / 5xx
-// response or a network-level request error.
+// response, a network-level request error or a stalled stream.
 func isTransientErr(err error) bool {
+	if errors.Is(err, errStreamIdle) {
+		return true
+	}
 	var apiErr *openai.APIError
 	if errors.As(err, &apiErr) {
 		return apiErr.HTTPStatusCode == 429 || apiErr.HTTPStatusCode >= 500
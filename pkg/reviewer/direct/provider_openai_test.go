@@ -1,9 +1,15 @@
 package direct
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	openai "github.com/sashabaranov/go-openai"
 	"github.com/stretchr/testify/require"
@@ -62,3 +68,157 @@ func TestToOpenAIMessagesSkipsBareAssistant(t *testing.T) {
 	}
 	require.Len(t, msgs, 2)
 }
+
+func TestOpenAIStreamAccAssemblesToolCalls(t *testing.T) {
+	var deltas []StreamDelta
+	acc := newOpenAIStreamAcc(func(d StreamDelta) { deltas = append(deltas, d) })
+	idx0, idx1 := 0, 1
+	chunks := []openai.ChatCompletionStreamResponse{
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{ReasoningContent: "think"}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: "hel"}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: "lo"}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{
+			{Index: &idx0, ID: "a", Function: openai.FunctionCall{Name: "glob", Arguments: `{"pat`}},
+		}}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{
+			{Index: &idx1, ID: "b", Function: openai.FunctionCall{Name: "grep"}},
+			{Index: &idx0, Function: openai.FunctionCall{Arguments: `tern":"*"}`}},
+		}}}}},
+		{Choices: []openai.ChatCompletionStreamChoice{{FinishReason: openai.FinishReasonToolCalls}}},
+		{Usage: &openai.Usage{PromptTokens: 100, CompletionTokens: 7, PromptTokensDetails: &openai.PromptTokensDetails{CachedTokens: 60}}},
+	}
+	for _, c := range chunks {
+		acc.add(c)
+	}
+
+	resp, err := acc.response()
+	require.NoError(t, err)
+	require.Equal(t, "hello", resp.Text)
+	require.Equal(t, "tool_calls", resp.StopReason)
+	require.Len(t, resp.ToolCalls, 2)
+	require.Equal(t, ToolCall{ID: "a", Name: "glob", Args: json.RawMessage(`{"pattern":"*"}`)}, resp.ToolCalls[0])
+	// A call that never received arguments gets an empty object, not "".
+	require.JSONEq(t, `{}`, string(resp.ToolCalls[1].Args))
+	require.Equal(t, Usage{InputTokens: 40, OutputTokens: 7, CacheReadTokens: 60}, resp.Usage)
+	require.Equal(t, openaiTurn{ReasoningContent: "think"}, resp.Raw, "reasoning kept for replay")
+
+	kinds := map[string]int{}
+	for _, d := range deltas {
+		kinds[d.Kind]++
+	}
+	require.Equal(t, 1, kinds[DeltaThinking])
+	require.Equal(t, 2, kinds[DeltaText])
+	require.Equal(t, 4, kinds[DeltaToolCall], "two names + two argument fragments")
+}
+
+func TestToOpenAIMessagesReplaysReasoning(t *testing.T) {
+	msgs := toOpenAIMessages(Request{Messages: []Message{
+		{Role: RoleUser, Text: "go"},
+		{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "a", Name: "glob", Args: json.RawMessage(`{}`)}}, Raw: openaiTurn{ReasoningContent: "plan"}},
+		{Role: RoleTool, ToolResults: []ToolResult{{CallID: "a", Name: "glob", Content: "x"}}},
+		{Role: RoleAssistant, Text: "done"},
+	}})
+	require.Len(t, msgs, 4)
+	require.Equal(t, "plan", msgs[1].ReasoningContent)
+	require.Empty(t, msgs[3].ReasoningContent)
+}
+
+func TestOpenAIStreamAccNoChoices(t *testing.T) {
+	_, err := newOpenAIStreamAcc(nil).response()
+	require.ErrorContains(t, err, "no choices")
+}
+
+func TestOpenAIProviderStreamsOverSSE(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, true, body["stream"])
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, line := range []string{
+			`{"choices":[{"index":0,"delta":{"content":"ok"}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"1","type":"function","function":{"name":"glob","arguments":"{}"}}]},"finish_reason":"tool_calls"}]}`,
+			`{"choices":[],"usage":{"prompt_tokens":10,"completion_tokens":2}}`,
+		} {
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", line)
+		}
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	p, err := NewOpenAIProvider(OpenAIConfig{APIKey: "k", BaseURL: srv.URL, Model: "m"})
+	require.NoError(t, err)
+	var text strings.Builder
+	resp, err := p.Complete(context.Background(), Request{OnDelta: func(d StreamDelta) {
+		if d.Kind == DeltaText {
+			text.WriteString(d.Text)
+		}
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp.Text)
+	require.Equal(t, "ok", text.String())
+	require.Len(t, resp.ToolCalls, 1)
+	require.Equal(t, 10, resp.Usage.InputTokens)
+}
+
+func TestOpenAIProviderIdleStreamTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		<-release // never send a chunk
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	p, err := NewOpenAIProvider(OpenAIConfig{APIKey: "k", BaseURL: srv.URL, Model: "m", MaxRetries: 1})
+	require.NoError(t, err)
+	p.(*openaiProvider).maxRetries = 0 // fail on the first stall
+	_, err = p.Complete(context.Background(), Request{IdleTimeout: 50 * time.Millisecond})
+	require.ErrorIs(t, err, errStreamIdle)
+}
+
+func TestToOpenAIMessagesToolNote(t *testing.T) {
+	msgs := toOpenAIMessages(Request{Messages: []Message{
+		{Role: RoleTool, Text: "note", ToolResults: []ToolResult{{CallID: "a", Content: "ok"}}},
+	}})
+	require.Len(t, msgs, 2)
+	require.Equal(t, openai.ChatMessageRoleTool, msgs[0].Role)
+	require.Equal(t, openai.ChatMessageRoleUser, msgs[1].Role)
+	require.Equal(t, "note", msgs[1].Content)
+}
+
+func TestOpenAIProviderResponseFormat(t *testing.T) {
+	var format any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		format = body["response_format"]
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"{}\"}}]}\n\ndata: [DONE]\n\n")
+	}))
+	defer srv.Close()
+	schema := objSchema(map[string]any{fSummary: strProp("s")}, fSummary)
+
+	complete := func(mode string, req Request) any {
+		t.Helper()
+		format = nil
+		p, err := NewOpenAIProvider(OpenAIConfig{APIKey: "k", BaseURL: srv.URL, Model: "m", ResponseFormat: mode})
+		require.NoError(t, err)
+		_, err = p.Complete(context.Background(), req)
+		require.NoError(t, err)
+		return format
+	}
+
+	require.Nil(t, complete("", Request{}), "no schema, no response_format")
+	got := complete("", Request{ResponseSchema: schema})
+	require.Equal(t, "json_schema", got.(map[string]any)["type"])
+	js := got.(map[string]any)["json_schema"].(map[string]any)
+	require.Equal(t, "review", js["name"])
+	require.Equal(t, []any{fSummary}, js["schema"].(map[string]any)["required"])
+
+	require.Equal(t, map[string]any{"type": "json_object"}, complete(ResponseFormatObject, Request{ResponseSchema: schema}))
+	require.Nil(t, complete(ResponseFormatNone, Request{ResponseSchema: schema}))
+
+	_, err := NewOpenAIProvider(OpenAIConfig{APIKey: "k", Model: "m", ResponseFormat: "gbnf"})
+	require.ErrorContains(t, err, "unknown response format")
+}
@@ -0,0 +1,66 @@
+package direct
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anthropics/anthropic-sdk-go"
+)
+
+// Raw turn kinds: a serialised anthropic.MessageParam or openaiTurn.
+const (
+	rawAnthropic = "anthropic"
+	rawOpenAI    = "openai"
+)
+
+// rawTurn is the on-disk form of Message.Raw / Response.Raw: the provider-native
+// assistant turn tagged with its type, so it decodes back to the exact value the
+// provider replays (signed thinking blocks included).
+type rawTurn struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// encodeRaw serialises a provider-native turn. Unknown types are dropped (nil):
+// the provider then rebuilds the turn from Text+ToolCalls, as for loop messages.
+func encodeRaw(v any) (*rawTurn, error) {
+	var kind string
+	switch v.(type) {
+	case nil:
+		return nil, nil
+	case anthropic.MessageParam:
+		kind = rawAnthropic
+	case openaiTurn:
+		kind = rawOpenAI
+	default:
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s raw turn: %w", kind, err)
+	}
+	return &rawTurn{Kind: kind, Data: data}, nil
+}
+
+// decodeRaw restores a turn serialised by encodeRaw.
+func decodeRaw(r *rawTurn) (any, error) {
+	if r == nil {
+		return nil, nil
+	}
+	switch r.Kind {
+	case rawAnthropic:
+		var m anthropic.MessageParam
+		if err := json.Unmarshal(r.Data, &m); err != nil {
+			return nil, fmt.Errorf("decode %s raw turn: %w", r.Kind, err)
+		}
+		return m, nil
+	case rawOpenAI:
+		var t openaiTurn
+		if err := json.Unmarshal(r.Data, &t); err != nil {
+			return nil, fmt.Errorf("decode %s raw turn: %w", r.Kind, err)
+		}
+		return t, nil
+	default:
+		return nil, fmt.Errorf("unknown raw turn kind %q", r.Kind)
+	}
+}
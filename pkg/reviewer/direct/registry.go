@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // Handler executes a tool call: it receives the raw JSON arguments and returns
@@ -21,13 +22,16 @@ type ToolDef struct {
 }
 
 // Registry holds the tool set for one run and dispatches calls. It also records
-// whether submit_review has fired so the loop can terminate deterministically.
+// whether submit_review has fired so the loop can terminate deterministically,
+// and meters every call (see ToolStats).
 type Registry struct {
 	mu        sync.Mutex
 	order     []string
 	defs      map[string]ToolDef
 	handlers  map[string]Handler
 	submitted bool
+	compactFn []func()
+	meter     *toolMeter
 }
 
 // NewRegistry returns an empty registry.
@@ -35,6 +39,7 @@ func NewRegistry() *Registry {
 	return &Registry{
 		defs:     map[string]ToolDef{},
 		handlers: map[string]Handler{},
+		meter:    newToolMeter(nil),
 	}
 }
 
@@ -61,15 +66,57 @@ func (r *Registry) Defs() []ToolDef {
 	return out
 }
 
-// Dispatch runs the named tool's handler.
+// Dispatch runs the named tool's handler, counting the call and holding it to
+// the tool's limit: past the call cap it is refused, past the byte cap its
+// output is cut.
 func (r *Registry) Dispatch(ctx context.Context, name string, args json.RawMessage) (string, error) {
 	r.mu.Lock()
 	h, ok := r.handlers[name]
+	m := r.meter
 	r.mu.Unlock()
 	if !ok {
 		return "", fmt.Errorf("unknown tool %q", name)
 	}
-	return h(ctx, args)
+	if err := m.admit(name); err != nil {
+		return "", err
+	}
+	start := time.Now()
+	out, err := h(ctx, args)
+	return m.done(name, out, err, time.Since(start)), err
+}
+
+// ToolStats returns per-tool call counts, output bytes, errors and latency of
+// the calls dispatched so far; nil before the first call.
+func (r *Registry) ToolStats() map[string]ToolStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.meter.snapshot()
+}
+
+// useMeter makes the registry count into (and be limited by) m, shared with
+// the other registry views of a run.
+func (r *Registry) useMeter(m *toolMeter) {
+	r.mu.Lock()
+	r.meter = m
+	r.mu.Unlock()
+}
+
+// onCompact registers fn to run when the loop compacts the conversation, so a
+// tool can drop per-run state that referred to the pruned turns.
+func (r *Registry) onCompact(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.compactFn = append(r.compactFn, fn)
+}
+
+// compacted runs the compaction hooks.
+func (r *Registry) compacted() {
+	r.mu.Lock()
+	hooks := append([]func(){}, r.compactFn...)
+	r.mu.Unlock()
+	for _, fn := range hooks {
+		fn()
+	}
 }
 
 // markSubmitted records that submit_review wrote its artifacts successfully.
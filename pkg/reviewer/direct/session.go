@@ -0,0 +1,255 @@
+package direct
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"reviewsrv/pkg/rest"
+	"reviewsrv/pkg/reviewer"
+)
+
+// ErrNoSession is returned by SessionStore.Latest when there is nothing to
+// continue.
+var ErrNoSession = errors.New("direct: no saved session")
+
+// resumePrompt is delivered instead of the original task when a session is
+// resumed with it (or with the Step 2 retry prompt): the task, the preload and
+// the work so far are already in the history.
+const resumePrompt = "The previous run stopped before submit_review. Continue the review from where you " +
+	"stopped: the task, the diff, the files you read and the groups/issues you already set are kept. " +
+	"Do not start over — fill in what is missing with set_group/add_issues and call submit_review."
+
+var sessionIDRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Session is a resumable direct run: the conversation (provider-native Raw
+// turns included, so Anthropic thinking signatures and DeepSeek reasoning
+// replay) and the review assembled so far by set_group/add_issues. RunSession
+// checkpoints it to a SessionStore after every round.
+type Session struct {
+	ID    string
+	Model string
+	// Task is the prompt the session started with, before the preload.
+	Task string
+	// Preloaded are the files shown in the kickoff, re-seeding read-dedup on resume.
+	Preloaded  []string
+	Submitted  bool
+	StopReason string
+	UpdatedAt  time.Time
+
+	history []Message
+	groups  map[string]groupData
+	issues  []rest.ReviewDraftIssue
+}
+
+// NewSession starts a session with a random ID.
+func NewSession(task string, preloaded []string) *Session {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return &Session{ID: hex.EncodeToString(b[:]), Task: task, Preloaded: preloaded}
+}
+
+// Resumable reports whether the session has a conversation to continue.
+func (s *Session) Resumable() bool { return len(s.history) > 0 }
+
+// sessionFile is the on-disk form of a Session.
+type sessionFile struct {
+	ID         string                  `json:"id"`
+	Model      string                  `json:"model"`
+	Task       string                  `json:"task"`
+	Preloaded  []string                `json:"preloaded,omitempty"`
+	Submitted  bool                    `json:"submitted"`
+	StopReason string                  `json:"stopReason,omitempty"`
+	UpdatedAt  time.Time               `json:"updatedAt"`
+	Messages   []sessionMessage        `json:"messages"`
+	Groups     map[string]sessionGroup `json:"groups,omitempty"`
+	Issues     []rest.ReviewDraftIssue `json:"issues,omitempty"`
+}
+
+// sessionMessage is a Message with its Raw turn serialised.
+type sessionMessage struct {
+	Message
+	Raw *rawTurn `json:"raw,omitempty"`
+}
+
+type sessionGroup struct {
+	Summary    string `json:"summary"`
+	IsAccepted bool   `json:"isAccepted"`
+	Markdown   string `json:"markdown"`
+}
+
+// SessionStore keeps sessions as <Dir>/<id>.json.
+type SessionStore struct {
+	Dir string
+}
+
+// DefaultSessionDir is the user cache dir's reviewctl/direct-sessions — outside
+// the reviewed tree, so the review tools never see saved conversations.
+func DefaultSessionDir() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "reviewctl", "direct-sessions")
+}
+
+func (st SessionStore) path(id string) (string, error) {
+	if !sessionIDRe.MatchString(id) {
+		return "", fmt.Errorf("invalid session id %q", id)
+	}
+	return filepath.Join(st.Dir, id+".json"), nil
+}
+
+// Save writes s atomically (temp file + rename). The file holds repository
+// code, so it is private to the user.
+func (st SessionStore) Save(s *Session) error {
+	path, err := st.path(s.ID)
+	if err != nil {
+		return err
+	}
+	f := sessionFile{
+		ID: s.ID, Model: s.Model, Task: s.Task, Preloaded: s.Preloaded,
+		Submitted: s.Submitted, StopReason: s.StopReason, UpdatedAt: s.UpdatedAt,
+		Messages: make([]sessionMessage, 0, len(s.history)),
+	}
+	for _, m := range s.history {
+		raw, err := encodeRaw(m.Raw)
+		if err != nil {
+			return err
+		}
+		f.Messages = append(f.Messages, sessionMessage{Message: m, Raw: raw})
+	}
+	if len(s.groups) > 0 {
+		f.Groups = make(map[string]sessionGroup, len(s.groups))
+		for rt, g := range s.groups {
+			f.Groups[rt] = sessionGroup{Summary: g.summary, IsAccepted: g.isAccepted, Markdown: g.markdown}
+		}
+	}
+	f.Issues = s.issues
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("encode session: %w", err)
+	}
+	if err := os.MkdirAll(st.Dir, 0o700); err != nil {
+		return fmt.Errorf("session dir: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write session: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write session: %w", err)
+	}
+	return nil
+}
+
+// Load reads the session with the given ID.
+func (st SessionStore) Load(id string) (*Session, error) {
+	path, err := st.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read session: %w", err)
+	}
+	var f sessionFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decode session %s: %w", id, err)
+	}
+	s := &Session{
+		ID: f.ID, Model: f.Model, Task: f.Task, Preloaded: f.Preloaded,
+		Submitted: f.Submitted, StopReason: f.StopReason, UpdatedAt: f.UpdatedAt,
+		history: make([]Message, 0, len(f.Messages)),
+		groups:  make(map[string]groupData, len(f.Groups)),
+		issues:  f.Issues,
+	}
+	for i, m := range f.Messages {
+		msg := m.Message
+		if msg.Raw, err = decodeRaw(m.Raw); err != nil {
+			return nil, fmt.Errorf("session %s message %d: %w", id, i, err)
+		}
+		s.history = append(s.history, msg)
+	}
+	for rt, g := range f.Groups {
+		s.groups[rt] = groupData{summary: g.Summary, isAccepted: g.IsAccepted, markdown: g.Markdown}
+	}
+	return s, nil
+}
+
+// Latest loads the most recently saved session (ErrNoSession if none).
+func (st SessionStore) Latest() (*Session, error) {
+	entries, err := os.ReadDir(st.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSession
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	var latest string
+	var latestAt time.Time
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		if fi, err := e.Info(); err == nil && fi.ModTime().After(latestAt) {
+			latest, latestAt = id, fi.ModTime()
+		}
+	}
+	if latest == "" {
+		return nil, ErrNoSession
+	}
+	return st.Load(latest)
+}
+
+// RunSession runs the single review loop as session s: a resumable session
+// continues its conversation and review state, and every round is
+// checkpointed to store so a run that stops on max rounds, the token budget
+// or a provider error can be resumed. Resuming with the original task (or the
+// Step 2 retry prompt) sends a short "continue" note instead. A failed save is
+// reported as a "session" event and never fails the review.
+func RunSession(ctx context.Context, p LLMProvider, cfg ReviewToolsConfig, store SessionStore, s *Session, userPrompt string, opts Options) (*Result, error) {
+	b := newReviewBuilder()
+	for rt, g := range s.groups {
+		b.setGroup(rt, g.summary, g.isAccepted, g.markdown)
+	}
+	b.addIssues(s.issues)
+	reg := newReviewRegistry(cfg, newToolState(cfg), b)
+
+	if s.Resumable() && (userPrompt == s.Task || userPrompt == reviewer.PromptStep2Retry) {
+		userPrompt = resumePrompt
+	}
+	s.Model = p.Model()
+	opts.History = s.history
+	save := func(msgs []Message) {
+		s.history = msgs
+		s.groups, s.issues = b.snapshot()
+		s.Submitted = reg.Submitted()
+		s.UpdatedAt = time.Now()
+		if err := store.Save(s); err != nil {
+			opts.OnEvent.emit(Event{Kind: "session", Text: s.ID, Content: err.Error(), IsError: true})
+		}
+	}
+	next := opts.OnCheckpoint
+	opts.OnCheckpoint = func(msgs []Message) {
+		save(msgs)
+		if next != nil {
+			next(msgs)
+		}
+	}
+
+	res, err := Run(ctx, p, reg, SystemPrompt, userPrompt, opts)
+	s.StopReason = res.StopReason
+	save(s.history)
+	return res, err
+}
@@ -0,0 +1,113 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"reviewsrv/pkg/rest"
+	"reviewsrv/pkg/reviewer"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStoreRoundTrip(t *testing.T) {
+	store := SessionStore{Dir: t.TempDir()}
+	thinking := anthropic.NewAssistantMessage(anthropic.NewThinkingBlock("sig", "thought"), anthropic.NewTextBlock("hi"))
+	s := NewSession("task", []string{"a.go"})
+	s.Model = "m"
+	s.history = []Message{
+		{Role: RoleUser, Text: "task"},
+		{Role: RoleAssistant, Text: "hi", Raw: thinking},
+		{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "1", Name: "glob", Args: json.RawMessage(`{"pattern":"*"}`)}}, Raw: openaiTurn{ReasoningContent: "why"}},
+		{Role: RoleTool, ToolResults: []ToolResult{{CallID: "1", Name: "glob", Content: "a.go"}}},
+	}
+	s.groups = map[string]groupData{rtCode: {summary: "s", isAccepted: true, markdown: "### C1. x"}}
+	s.issues = []rest.ReviewDraftIssue{{LocalID: "C1", Severity: "low"}}
+	require.NoError(t, store.Save(s))
+
+	got, err := store.Load(s.ID)
+	require.NoError(t, err)
+	require.True(t, got.Resumable())
+	require.Equal(t, "task", got.Task)
+	require.Equal(t, []string{"a.go"}, got.Preloaded)
+	require.Equal(t, s.groups, got.groups)
+	require.Equal(t, s.issues, got.issues)
+	require.Len(t, got.history, 4)
+	require.Equal(t, s.history[3], got.history[3])
+	require.Equal(t, openaiTurn{ReasoningContent: "why"}, got.history[2].Raw, "DeepSeek reasoning replays")
+	wantJSON, _ := json.Marshal(thinking)
+	gotJSON, _ := json.Marshal(got.history[1].Raw)
+	require.JSONEq(t, string(wantJSON), string(gotJSON), "signed thinking survives the round-trip")
+
+	other := NewSession("other", nil)
+	require.NoError(t, store.Save(other))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(store.Dir, s.ID+".json"), old, old))
+	latest, err := store.Latest()
+	require.NoError(t, err)
+	require.Equal(t, other.ID, latest.ID)
+
+	_, err = store.Load("../escape")
+	require.ErrorContains(t, err, "invalid session id")
+	_, err = SessionStore{Dir: filepath.Join(store.Dir, "none")}.Latest()
+	require.ErrorIs(t, err, ErrNoSession)
+}
+
+func TestRunSessionResumesAfterError(t *testing.T) {
+	dir := t.TempDir()
+	store := SessionStore{Dir: t.TempDir()}
+	cfg := ReviewToolsConfig{Dir: dir}
+	setCode := ToolCall{ID: "g", Name: "set_group", Args: json.RawMessage(`{"reviewType":"code","summary":"from run 1","isAccepted":true,"markdown":"no findings"}`)}
+
+	// Run 1 sets one group, then the provider fails.
+	first := &scriptedProvider{responses: []Response{{ToolCalls: []ToolCall{setCode}, Raw: openaiTurn{ReasoningContent: "r1"}}}}
+	s := NewSession("review task", nil)
+	res, err := RunSession(context.Background(), first, cfg, store, s, "review task\n\npreload", Options{MaxRounds: 5})
+	require.Error(t, err)
+	require.Equal(t, "error", res.StopReason)
+
+	saved, err := store.Load(s.ID)
+	require.NoError(t, err)
+	require.Equal(t, "error", saved.StopReason)
+	require.False(t, saved.Submitted)
+	require.Len(t, saved.history, 3, "kickoff, set_group turn, its result")
+	require.Equal(t, "from run 1", saved.groups[rtCode].summary)
+
+	// Run 2 resumes with the Step 2 retry prompt and submits the rest.
+	md := map[string]string{}
+	var files []map[string]any
+	for _, rt := range reviewTypes {
+		if rt != rtCode {
+			md[rt] = "no findings"
+			files = append(files, map[string]any{fReviewType: rt, fSummary: "run 2", fIsAccepted: true})
+		}
+	}
+	args, _ := json.Marshal(map[string]any{"review": map[string]any{fDescription: "ok"}, "files": files, fMarkdown: md})
+	second := &scriptedProvider{responses: []Response{{ToolCalls: []ToolCall{{ID: "s", Name: toolSubmitReview, Args: args}}}}}
+	res, err = RunSession(context.Background(), second, cfg, store, saved, reviewer.PromptStep2Retry, Options{MaxRounds: 5})
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+
+	sent := second.seen[0].Messages
+	require.Len(t, sent, 3)
+	require.Equal(t, openaiTurn{ReasoningContent: "r1"}, sent[1].Raw)
+	require.Equal(t, RoleTool, sent[2].Role)
+	require.Equal(t, resumePrompt, sent[2].Text, "the note rides on the trailing tool turn")
+
+	data, err := os.ReadFile(filepath.Join(dir, "review.json"))
+	require.NoError(t, err)
+	var draft rest.ReviewDraft
+	require.NoError(t, json.Unmarshal(data, &draft))
+	require.Equal(t, "from run 1", draft.Files[1].Summary, "set_group of the first run kept")
+
+	final, err := store.Load(s.ID)
+	require.NoError(t, err)
+	require.True(t, final.Submitted)
+	require.Equal(t, "submitted", final.StopReason)
+	require.Len(t, final.history, 5)
+}
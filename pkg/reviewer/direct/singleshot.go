@@ -0,0 +1,138 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// singleShotAttempts bounds each structured-output question: the first answer
+// plus repair attempts for answers that fail validation.
+const singleShotAttempts = 3
+
+// singleShotRetry is sent back with the validation error of a rejected answer.
+const singleShotRetry = "Your answer was rejected: %v\n\nThere are no tools in this mode — ignore any tool names in the " +
+	"error. Reply again with the complete, corrected JSON object."
+
+// singleShotSchema is the reply of a single-shot review: submit_review's
+// one-shot arguments, all required, with the markdown keyed by group.
+func singleShotSchema() map[string]any {
+	md := make(map[string]any, len(reviewTypes))
+	for _, rt := range reviewTypes {
+		md[rt] = strProp("Markdown body of the " + rt + " group; head each finding with ### C1. Title")
+	}
+	return objSchema(map[string]any{
+		"review":  reviewMetaProp(),
+		"files":   arrayOf(fileItemSchema()),
+		fIssues:   arrayOf(issueItemSchema()),
+		fMarkdown: objSchema(md, reviewTypes...),
+	}, "review", "files", fIssues, fMarkdown)
+}
+
+// RunSingleShot reviews without tools: the model gets the kickoff and answers
+// with the whole review as one JSON object — schema-constrained where the
+// provider supports structured output — which goes through submit_review's
+// validation and write path. A rejected answer is sent back with the error,
+// up to singleShotAttempts rounds. Not resumable.
+func RunSingleShot(ctx context.Context, p LLMProvider, dir, userPrompt string, opts Options) (*Result, error) {
+	s := newStructuredRun(p, SingleShotPrompt, opts)
+	_, submit := submitReviewTool(dir, newReviewBuilder(), s.reg)
+	if err := s.preflight(ctx, userPrompt); err != nil {
+		return s.finish("error"), err
+	}
+	ok, err := s.ask(ctx, userPrompt, singleShotSchema(), toolSubmitReview, submit)
+	return s.result(ok, err)
+}
+
+// structuredRun is a tool-less conversation of schema-constrained answers,
+// each checked by a handler of the review tools.
+type structuredRun struct {
+	p      LLMProvider
+	system string
+	opts   Options
+	reg    *Registry // tracks submission; registers no tools
+	msgs   []Message
+	total  Usage
+	apiMs  int
+	rounds int
+}
+
+func newStructuredRun(p LLMProvider, system string, opts Options) *structuredRun {
+	opts.OnEvent.emit(Event{Kind: "system", Text: system})
+	return &structuredRun{p: p, system: system, opts: opts, reg: NewRegistry()}
+}
+
+// preflight checks that the kickoff fits the model's window.
+func (s *structuredRun) preflight(ctx context.Context, userPrompt string) error {
+	return preflight(ctx, s.p, s.reg, s.system, []Message{{Role: RoleUser, Text: userPrompt}}, s.opts.Limits)
+}
+
+// ask sends text as the next user turn and asks for a JSON answer until
+// accept takes it, up to singleShotAttempts rounds; a rejected answer is sent
+// back with the error. It reports false when every answer was rejected.
+func (s *structuredRun) ask(ctx context.Context, text string, schema map[string]any, tool string, accept Handler) (bool, error) {
+	s.opts.OnEvent.emit(Event{Round: s.rounds, Kind: "user", Text: text})
+	s.msgs = append(s.msgs, Message{Role: RoleUser, Text: text})
+	for range singleShotAttempts {
+		round := s.rounds
+		s.rounds++
+		t0 := time.Now()
+		resp, err := s.p.Complete(ctx, Request{
+			System:         s.system,
+			Messages:       s.msgs,
+			Effort:         s.opts.Effort,
+			ResponseSchema: schema,
+			OnDelta:        deltaSink(s.opts.OnEvent, round),
+			IdleTimeout:    s.opts.StreamIdleTimeout,
+		})
+		s.apiMs += int(time.Since(t0).Milliseconds())
+		if err != nil {
+			s.rounds = round
+			return false, fmt.Errorf("round %d: %w", round, err)
+		}
+		s.total = sumUsage(s.total, resp.Usage)
+		emitRound(s.opts.OnEvent, round, resp)
+		s.msgs = append(s.msgs, Message{Role: RoleAssistant, Text: resp.Text, Raw: resp.Raw})
+
+		out, err := accept(ctx, replyJSON(resp.Text))
+		if err == nil {
+			s.opts.OnEvent.emit(Event{Round: round, Kind: "tool_result", Tool: tool, Content: out})
+			return true, nil
+		}
+		s.opts.OnEvent.emit(Event{Round: round, Kind: "tool_result", Tool: tool, Content: err.Error(), IsError: true})
+		s.msgs = append(s.msgs, Message{Role: RoleUser, Text: fmt.Sprintf(singleShotRetry, err)})
+	}
+	return false, nil
+}
+
+// result finishes the run after its last ask.
+func (s *structuredRun) result(ok bool, err error) (*Result, error) {
+	switch {
+	case err != nil:
+		return s.finish("error"), err
+	case !ok:
+		return s.finish("invalid_output"), nil
+	}
+	return s.finish("submitted"), nil
+}
+
+func (s *structuredRun) finish(stop string) *Result {
+	r := makeResult(s.total, s.rounds, stop, s.reg.Submitted(), s.p)
+	r.DurationAPIMs = s.apiMs
+	s.opts.OnEvent.emit(Event{Kind: "result", Rounds: r.Rounds, Usage: &r.Usage, StopReason: r.StopReason, CostUsd: r.CostUsd, Submitted: r.Submitted, Model: r.Model})
+	return r
+}
+
+// replyJSON extracts the JSON object from a reply. Schema-constrained output
+// is the object itself; without it models like to wrap it in prose or a
+// ```json fence.
+func replyJSON(text string) json.RawMessage {
+	text = strings.TrimSpace(text)
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return json.RawMessage(text)
+	}
+	return json.RawMessage(text[start : end+1])
+}
@@ -0,0 +1,45 @@
+package direct
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSingleShot(t *testing.T) {
+	dir := t.TempDir()
+	prov := &scriptedProvider{responses: []Response{
+		{Text: "Here is the review: {\"review\": {}}"},
+		{Text: "```json\n" + string(validSubmitArgs(t, "high")) + "\n```"},
+	}}
+
+	res, err := RunSingleShot(context.Background(), prov, dir, "review this", Options{})
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+	require.Equal(t, "submitted", res.StopReason)
+	require.Equal(t, 2, res.Rounds)
+
+	// Every round asks for the schema; the rejected answer goes back with the error.
+	require.Len(t, prov.seen, 2)
+	require.NotNil(t, prov.seen[0].ResponseSchema)
+	require.Empty(t, prov.seen[0].Tools)
+	retry := prov.seen[1].Messages
+	require.Len(t, retry, 3)
+	require.Equal(t, RoleAssistant, retry[1].Role)
+	require.Contains(t, retry[2].Text, "Your answer was rejected")
+
+	_, err = os.Stat(filepath.Join(dir, "review.json"))
+	require.NoError(t, err)
+}
+
+func TestRunSingleShotGivesUp(t *testing.T) {
+	prov := &scriptedProvider{responses: []Response{{Text: "no"}, {Text: "no"}, {Text: "no"}}}
+	res, err := RunSingleShot(context.Background(), prov, t.TempDir(), "review this", Options{})
+	require.NoError(t, err)
+	require.False(t, res.Submitted)
+	require.Equal(t, "invalid_output", res.StopReason)
+	require.Equal(t, singleShotAttempts, res.Rounds)
+}
@@ -8,26 +8,49 @@ import "encoding/json"
 //
 // Kinds:
 //   - "system"      — the system contract sent on every request (Text)
-//   - "user"        — the kickoff user task incl. preloaded diff/files (Text)
+//   - "user"        — the kickoff user task incl. preloaded diff/files (Text);
+//     in a resumed session, the input appended to the saved history
 //   - "assistant"   — model text for a round (Text)
 //   - "tool_call"   — a tool the model requested (Tool, Args)
 //   - "tool_result" — the tool's output, truncated (Tool, Content, IsError)
 //   - "round"       — per-round usage and stop reason (Usage, StopReason)
-//   - "result"      — final totals (Rounds, Usage, CostUsd, Submitted, Model, StopReason)
+//   - "compaction"  — history pruned (Dropped, summary in Text, summary-call Usage;
+//     IsError+Content when the summary call failed and a bare marker was used)
+//   - "result"      — final totals (Rounds, Usage, CostUsd, Submitted, Model,
+//     StopReason, per-tool Tools)
+//   - "plan"        — a sub-agent run's task split (Text; IsError+Content when
+//     the planner failed and the default per-group split was used)
+//   - "session"     — a RunSession checkpoint could not be saved (session ID in
+//     Text, error in Content, IsError)
+//   - "mcp"         — an MCP server was mounted (server name in Text, its tools
+//     in Content) or could not be (IsError, error in Content); calls to its
+//     tools are ordinary "tool_call"/"tool_result" events
+//
+// In a sub-agent run (RunAgents) every event carries the Agent that emitted it
+// ("planner", a task name, "lead"); the run's closing "result" has no Agent.
+// Loops run concurrently there, so RunAgents serialises the sink itself.
+//
+// While a round streams, partial output arrives as "text_delta",
+// "thinking_delta" and "tool_delta" (Text, Tool) events. They are high-volume
+// and redundant with the round's "assistant"/"tool_call" events, so a
+// persisting sink may skip them; they exist for live progress reporting.
 type Event struct {
-	Round      int             `json:"round"`
-	Kind       string          `json:"kind"`
-	Text       string          `json:"text,omitempty"`
-	Tool       string          `json:"tool,omitempty"`
-	Args       json.RawMessage `json:"args,omitempty"`
-	Content    string          `json:"content,omitempty"`
-	IsError    bool            `json:"isError,omitempty"`
-	Usage      *Usage          `json:"usage,omitempty"`
-	StopReason string          `json:"stopReason,omitempty"`
-	Rounds     int             `json:"rounds,omitempty"`
-	CostUsd    float64         `json:"costUsd,omitempty"`
-	Submitted  bool            `json:"submitted,omitempty"`
-	Model      string          `json:"model,omitempty"`
+	Agent      string               `json:"agent,omitempty"`
+	Round      int                  `json:"round"`
+	Kind       string               `json:"kind"`
+	Text       string               `json:"text,omitempty"`
+	Tool       string               `json:"tool,omitempty"`
+	Args       json.RawMessage      `json:"args,omitempty"`
+	Content    string               `json:"content,omitempty"`
+	IsError    bool                 `json:"isError,omitempty"`
+	Usage      *Usage               `json:"usage,omitempty"`
+	StopReason string               `json:"stopReason,omitempty"`
+	Rounds     int                  `json:"rounds,omitempty"`
+	CostUsd    float64              `json:"costUsd,omitempty"`
+	Submitted  bool                 `json:"submitted,omitempty"`
+	Model      string               `json:"model,omitempty"`
+	Dropped    int                  `json:"dropped,omitempty"`
+	Tools      map[string]ToolStats `json:"tools,omitempty"`
 }
 
 // Sink receives transcript events. A nil Sink is a no-op.
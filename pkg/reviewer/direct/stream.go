@@ -0,0 +1,58 @@
+package direct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Stream delta kinds carried by StreamDelta.Kind.
+const (
+	DeltaText     = "text"
+	DeltaThinking = "thinking"
+	DeltaToolCall = "tool_call"
+)
+
+// errStreamIdle is the cancellation cause when a streamed response produces no
+// chunk within the idle timeout — a stalled upstream or a proxy that silently
+// dropped the connection. It is distinct from the run-level deadline.
+var errStreamIdle = errors.New("stream idle timeout")
+
+// StreamDelta is one incremental piece of a response while the provider streams
+// it: a text or thinking fragment, or a fragment of a tool call's JSON input.
+type StreamDelta struct {
+	Kind string // DeltaText | DeltaThinking | DeltaToolCall
+	Text string // appended text / thinking / partial tool-input JSON
+	Tool string // tool name, set on DeltaToolCall
+}
+
+// emitDelta forwards d to fn, skipping empty fragments.
+func emitDelta(fn func(StreamDelta), d StreamDelta) {
+	if fn != nil && (d.Text != "" || d.Tool != "") {
+		fn(d)
+	}
+}
+
+// withIdleTimeout derives a context that is cancelled with errStreamIdle when
+// touch is not called for d. Callers touch on every received chunk and call stop
+// when done. A non-positive d disables the watchdog (touch is a no-op).
+func withIdleTimeout(parent context.Context, d time.Duration) (ctx context.Context, touch, stop func()) {
+	ctx, cancel := context.WithCancelCause(parent)
+	if d <= 0 {
+		return ctx, func() {}, func() { cancel(nil) }
+	}
+	t := time.AfterFunc(d, func() {
+		cancel(fmt.Errorf("%w: no data for %s", errStreamIdle, d))
+	})
+	return ctx, func() { t.Reset(d) }, func() { t.Stop(); cancel(nil) }
+}
+
+// streamErr prefers the idle-timeout cause over the generic "context canceled"
+// the SDK reports when the watchdog fired.
+func streamErr(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); errors.Is(cause, errStreamIdle) {
+		return cause
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+package direct
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithIdleTimeout(t *testing.T) {
+	// Touching keeps the context alive past the idle window.
+	ctx, touch, stop := withIdleTimeout(context.Background(), 40*time.Millisecond)
+	for range 4 {
+		time.Sleep(15 * time.Millisecond)
+		touch()
+	}
+	require.NoError(t, ctx.Err())
+	stop()
+
+	// Silence fires the watchdog with errStreamIdle as the cause.
+	ctx, _, stop = withIdleTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	<-ctx.Done()
+	require.ErrorIs(t, streamErr(ctx, ctx.Err()), errStreamIdle)
+
+	// Disabled watchdog never fires on its own.
+	ctx, _, stop = withIdleTimeout(context.Background(), 0)
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, ctx.Err())
+	stop()
+}
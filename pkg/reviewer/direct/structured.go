@@ -0,0 +1,92 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// groupAnswerSchema is a structured review's answer for one group: set_group's
+// arguments without the reviewType, plus the group's issues.
+func groupAnswerSchema(rt string) map[string]any {
+	return objSchema(map[string]any{
+		fSummary:    strProp("One-line summary for the " + rt + " group"),
+		fIsAccepted: boolProp(),
+		fMarkdown:   strProp("Full markdown body; head each finding with ### C1. Title"),
+		fIssues:     arrayOf(issueItemSchema(rt)),
+	}, fSummary, fIsAccepted, fMarkdown, fIssues)
+}
+
+// verdictSchema is a structured review's final answer: submit_review's
+// metadata only.
+func verdictSchema() map[string]any {
+	return objSchema(map[string]any{"review": reviewMetaProp()}, "review")
+}
+
+// RunStructured reviews without tools for models whose function calling is
+// weak or missing (local vLLM or llama.cpp servers): one schema-constrained
+// answer per review group, then the verdict. Each group answer goes through
+// set_group, add_issues and the completeness check for that group; the verdict
+// through submit_review, which writes review.json and R*.md. Small answers
+// suit the small output caps of local models better than RunSingleShot's one
+// big object. A rejected answer is sent back with the error, up to
+// singleShotAttempts rounds per question. Not resumable.
+func RunStructured(ctx context.Context, p LLMProvider, dir, userPrompt string, opts Options) (*Result, error) {
+	s := newStructuredRun(p, StructuredPrompt, opts)
+	b := newReviewBuilder()
+	_, submit := submitReviewTool(dir, b, s.reg)
+	if err := s.preflight(ctx, userPrompt); err != nil {
+		return s.finish("error"), err
+	}
+	for i, rt := range reviewTypes {
+		text := fmt.Sprintf(structuredGroupAsk, rt)
+		if i == 0 {
+			text = userPrompt + "\n\n" + text
+		}
+		if ok, err := s.ask(ctx, text, groupAnswerSchema(rt), "set_group", groupAnswer(b, rt)); err != nil || !ok {
+			return s.result(ok, err)
+		}
+	}
+	return s.result(s.ask(ctx, structuredVerdictAsk, verdictSchema(), toolSubmitReview, submit))
+}
+
+// groupAnswer checks one group's answer with the set_group and add_issues
+// handlers on a scratch builder and merges it into b only when the group is
+// complete, so a rejected answer leaves nothing behind. Colliding localIds are
+// renumbered by the merge.
+func groupAnswer(b *reviewBuilder, rt string) Handler {
+	return func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			Summary    string          `json:"summary"`
+			IsAccepted bool            `json:"isAccepted"`
+			Markdown   string          `json:"markdown"`
+			Issues     json.RawMessage `json:"issues"`
+		}
+		if err := json.Unmarshal(raw, &a); err != nil {
+			return "", fmt.Errorf("bad answer: %w", err)
+		}
+		one := newReviewBuilder()
+		_, setGroup := setGroupTool(one, rt)
+		_, addIssues := addIssuesTool(one, rt)
+		group, err := json.Marshal(map[string]any{
+			fReviewType: rt, fSummary: a.Summary, fIsAccepted: a.IsAccepted, fMarkdown: a.Markdown,
+		})
+		if err != nil {
+			return "", err
+		}
+		if _, err := setGroup(ctx, group); err != nil {
+			return "", err
+		}
+		if issues := unwrapJSON(a.Issues); len(issues) > 0 && string(issues) != "null" {
+			if _, err := addIssues(ctx, json.RawMessage(`{"issues":`+string(issues)+`}`)); err != nil {
+				return "", err
+			}
+		}
+		groups, issues := one.snapshot()
+		if err := checkGroups("set_group", []string{rt}, groups, issues); err != nil {
+			return "", err
+		}
+		b.mergeFrom(one)
+		return fmt.Sprintf("group %q set with %d issues", rt, len(issues)), nil
+	}
+}
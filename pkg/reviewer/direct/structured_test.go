@@ -0,0 +1,83 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"reviewsrv/pkg/rest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func groupReply(t *testing.T, rt string, findings int) Response {
+	t.Helper()
+	md := "# " + rt
+	issues := []map[string]any{}
+	for range findings {
+		md += "\n\n### C1. bug"
+		issues = append(issues, map[string]any{
+			"localId": "C1", "severity": "medium", "title": "bug", "description": "desc",
+			"file": "main.go", "lines": "1", "fileType": rt,
+		})
+	}
+	b, err := json.Marshal(map[string]any{"summary": rt + " ok", "isAccepted": findings == 0, "markdown": md, "issues": issues})
+	require.NoError(t, err)
+	return Response{Text: string(b)}
+}
+
+func TestRunStructured(t *testing.T) {
+	dir := t.TempDir()
+	var responses []Response
+	for i, rt := range reviewTypes {
+		if i == 1 {
+			// Markdown with a finding but no issue: rejected, asked again.
+			responses = append(responses, Response{Text: `{"summary":"x","isAccepted":true,"markdown":"### C1. bug","issues":[]}`})
+		}
+		responses = append(responses, groupReply(t, rt, min(i, 1)))
+	}
+	responses = append(responses, Response{Text: `{"review":{"description":"fine","effortMinutes":10,"aiSlopScore":0.1}}`})
+	prov := &scriptedProvider{responses: responses}
+
+	res, err := RunStructured(context.Background(), prov, dir, "review this", Options{})
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+	require.Equal(t, "submitted", res.StopReason)
+	require.Equal(t, len(reviewTypes)+2, res.Rounds)
+
+	first := prov.seen[0]
+	require.Len(t, first.Messages, 1)
+	require.Contains(t, first.Messages[0].Text, "review this")
+	require.Contains(t, first.Messages[0].Text, "architecture group")
+	require.Equal(t, groupAnswerSchema(rtArchitecture), first.ResponseSchema)
+	require.Contains(t, prov.seen[2].Messages[len(prov.seen[2].Messages)-1].Text, "Your answer was rejected")
+	require.Equal(t, verdictSchema(), prov.seen[len(prov.seen)-1].ResponseSchema)
+
+	// One finding in each of the last four groups, all "C1": renumbered apart,
+	// and the rejected answer left nothing behind.
+	data, err := os.ReadFile(filepath.Join(dir, "review.json"))
+	require.NoError(t, err)
+	var draft rest.ReviewDraft
+	require.NoError(t, json.Unmarshal(data, &draft))
+	require.Len(t, draft.Issues, len(reviewTypes)-1)
+	ids := map[string]bool{}
+	for _, iss := range draft.Issues {
+		ids[iss.LocalID] = true
+	}
+	require.Len(t, ids, len(reviewTypes)-1)
+	require.Equal(t, "fine", draft.Review.Description)
+	md, err := os.ReadFile(filepath.Join(dir, "R2"+mdSuffix))
+	require.NoError(t, err)
+	require.Contains(t, string(md), "### C1. bug")
+}
+
+func TestRunStructuredGivesUp(t *testing.T) {
+	prov := &scriptedProvider{responses: []Response{{Text: "{}"}, {Text: "{}"}, {Text: "{}"}}}
+	res, err := RunStructured(context.Background(), prov, t.TempDir(), "review this", Options{})
+	require.NoError(t, err)
+	require.False(t, res.Submitted)
+	require.Equal(t, "invalid_output", res.StopReason)
+	require.Equal(t, singleShotAttempts, res.Rounds)
+}
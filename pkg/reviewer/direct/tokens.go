@@ -0,0 +1,225 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"unicode"
+	"unicode/utf8"
+)
+
+// errContextOverflow is returned by the pre-flight check when the kickoff
+// request alone does not fit the model's input budget.
+var errContextOverflow = errors.New("direct: first request exceeds the model context window")
+
+// ModelLimits is a model's context budget in tokens. A zero ContextWindow means
+// unknown: the loop then keeps its fixed defaults and skips the pre-flight check.
+type ModelLimits struct {
+	ContextWindow int // total tokens the model accepts (input + output)
+	MaxOutput     int // tokens reserved for one response (the provider's max_tokens)
+}
+
+// InputBudget is how many prompt tokens fit next to a full-size response.
+func (l ModelLimits) InputBudget() int {
+	if l.ContextWindow <= 0 {
+		return 0
+	}
+	return max(l.ContextWindow-l.MaxOutput, 0)
+}
+
+// CompactThreshold is the history size that triggers compaction: three quarters
+// of the input budget, leaving room for the tool results of the next round.
+func (l ModelLimits) CompactThreshold() int {
+	return l.InputBudget() * 3 / 4
+}
+
+// PreloadTokens is the kickoff budget for the pre-loaded diff and files: a third
+// of the input budget, so the review still has room to read and reason.
+func (l ModelLimits) PreloadTokens() int {
+	return l.InputBudget() / 3
+}
+
+// TokenCounter is implemented by providers that can count a request's prompt
+// tokens exactly (Anthropic count_tokens). Others fall back to CountTokens.
+type TokenCounter interface {
+	CountTokens(ctx context.Context, req Request) (int, error)
+}
+
+// LimitsOf returns the context limits of p: its own when it reports a window
+// (configured window, actual max_tokens), otherwise the entry of cat for its
+// model (nil = DefaultCatalog) — the same catalogue its prices come from.
+func LimitsOf(p LLMProvider, cat *Catalog) ModelLimits {
+	own := ownLimits(p)
+	if own.ContextWindow > 0 {
+		return own
+	}
+	spec, _ := cat.Lookup(p.Model())
+	l := spec.Limits()
+	if own.MaxOutput > 0 {
+		l.MaxOutput = own.MaxOutput
+	}
+	return l
+}
+
+// ownLimits returns the limits p reports itself, zero when it reports none.
+// Wrapping providers forward it so LimitsOf still sees the inner provider.
+func ownLimits(p LLMProvider) ModelLimits {
+	if l, ok := p.(interface{ Limits() ModelLimits }); ok {
+		return l.Limits()
+	}
+	return ModelLimits{}
+}
+
+// CountTokens approximates the token count of s with a rune-class heuristic; it
+// is not a tokenizer and can be off by tens of percent for a given model family.
+// The rules follow the pre-tokenizer of modern BPE tokenizers: short ASCII words
+// are one token and long identifiers split every few letters, digits group by
+// three, punctuation merges at most in pairs, and non-Latin letters (Cyrillic)
+// cost well over a token per word — which the bytes/4 rule underestimates for
+// code and Russian prompts. Exact counts come from a TokenCounter provider;
+// decisions gated on the estimate add estimateMargin.
+func CountTokens(s string) int {
+	n := 0
+	for len(s) > 0 {
+		r, _ := utf8.DecodeRuneInString(s)
+		cls := runeClass(r)
+		run, runes := 0, 0
+		for run < len(s) {
+			c, size := utf8.DecodeRuneInString(s[run:])
+			if runeClass(c) != cls {
+				break
+			}
+			run += size
+			runes++
+		}
+		n += classTokens(cls, s[:run], runes)
+		s = s[run:]
+	}
+	return n
+}
+
+// Rune classes for CountTokens.
+const (
+	clsWord = iota
+	clsDigit
+	clsSpace
+	clsPunct
+	clsLetter // non-ASCII letters (Cyrillic, Greek, …)
+	clsOther  // CJK, emoji, symbols — roughly a token per rune
+)
+
+func runeClass(r rune) int {
+	switch {
+	case r < utf8.RuneSelf && (r == '_' || unicode.IsLetter(r)):
+		return clsWord
+	case r >= '0' && r <= '9':
+		return clsDigit
+	case unicode.IsSpace(r):
+		return clsSpace
+	case r < utf8.RuneSelf:
+		return clsPunct
+	case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+		return clsOther
+	case unicode.IsLetter(r):
+		return clsLetter
+	default:
+		return clsOther
+	}
+}
+
+func classTokens(cls int, run string, runes int) int {
+	switch cls {
+	case clsWord:
+		return 1 + (runes-1)/6
+	case clsDigit:
+		return (runes + 2) / 3
+	case clsSpace:
+		// A single space merges into the next word; newlines and indentation
+		// runs are a token of their own.
+		if run == " " {
+			return 0
+		}
+		return 1
+	case clsPunct:
+		return (runes + 1) / 2
+	case clsLetter:
+		return (2*runes + 2) / 3
+	default:
+		return runes
+	}
+}
+
+// clipTokens clips s to roughly maxTokens estimated tokens (byte-proportional
+// cut), with the same truncation note as clipN.
+func clipTokens(s string, maxTokens int) string {
+	n := CountTokens(s)
+	if n <= maxTokens {
+		return s
+	}
+	return clipN(s, len(s)*maxTokens/n)
+}
+
+// estimateMargin pads local estimates where they gate compaction, so a history
+// the heuristic undercounts is still compacted before it overflows the window.
+const estimateMargin = 0.25
+
+// withMargin adds estimateMargin to a local token estimate.
+func withMargin(n int) int {
+	return n + int(float64(n)*estimateMargin)
+}
+
+// estimateTokens is the local token estimate of a history.
+func estimateTokens(msgs []Message) int {
+	n := 0
+	for _, m := range msgs {
+		n += CountTokens(m.Text) + 4 // per-message role/framing overhead
+		for _, tc := range m.ToolCalls {
+			n += CountTokens(string(tc.Args)) + CountTokens(tc.Name)
+		}
+		for _, tr := range m.ToolResults {
+			n += CountTokens(tr.Content)
+		}
+	}
+	return n
+}
+
+// estimateRequestTokens is the local token estimate of a whole request: system
+// prompt, tool schemas and history.
+func estimateRequestTokens(req Request) int {
+	n := CountTokens(req.System) + estimateTokens(req.Messages)
+	for _, d := range req.Tools {
+		schema, _ := json.Marshal(d.Schema)
+		n += CountTokens(d.Name) + CountTokens(d.Description) + CountTokens(string(schema))
+	}
+	return n
+}
+
+// requestTokens counts req via the provider when it can, falling back to the
+// local estimate when it can't or the count call fails (pre-flight must not
+// depend on an extra endpoint being reachable).
+func requestTokens(ctx context.Context, p LLMProvider, req Request) int {
+	if tc, ok := p.(TokenCounter); ok {
+		if n, err := tc.CountTokens(ctx, req); err == nil && n > 0 {
+			return n
+		}
+	}
+	return estimateRequestTokens(req)
+}
+
+// promptTokens is the full prompt size of a round as the provider billed it —
+// uncached, cache-read and cache-written input together.
+func promptTokens(u Usage) int {
+	return u.InputTokens + u.CacheReadTokens + u.CacheWriteTokens
+}
+
+// contextTokens is the size of msgs as the model sees it: the last round's
+// billed prompt and output plus the local estimate of messages appended since
+// (msgs[measuredAt:]). Without a measurement (no usage reported, or just after
+// compaction) it is the local estimate of the whole history. Estimated parts
+// include estimateMargin, since the result gates compaction.
+func contextTokens(msgs []Message, last Usage, measuredAt int) int {
+	if promptTokens(last) == 0 || measuredAt > len(msgs) {
+		return withMargin(estimateTokens(msgs))
+	}
+	return promptTokens(last) + last.OutputTokens + withMargin(estimateTokens(msgs[measuredAt:]))
+}
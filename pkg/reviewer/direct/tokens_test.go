@@ -0,0 +1,114 @@
+package direct
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountTokens(t *testing.T) {
+	require.Equal(t, 0, CountTokens(""))
+	require.Equal(t, 2, CountTokens("hello world"))
+	require.Equal(t, 2, CountTokens("123456"), "digits group by three")
+
+	// Cyrillic costs more than the bytes/4 rule assumed (2 bytes per rune).
+	ru := "проверка изменённых файлов в репозитории"
+	require.Greater(t, CountTokens(ru), len(ru)/4)
+
+	// Dense code: punctuation is not free.
+	code := "if err != nil { return fmt.Errorf(\"x: %w\", err) }"
+	require.Greater(t, CountTokens(code), 15)
+}
+
+func TestClipTokens(t *testing.T) {
+	s := strings.Repeat("word ", 1000)
+	require.Equal(t, s, clipTokens(s, 5000))
+	clipped := clipTokens(s, 100)
+	require.Less(t, len(clipped), len(s))
+	require.Contains(t, clipped, "truncated")
+}
+
+func TestClipNKeepsRunes(t *testing.T) {
+	s := "ёжик" // 2 bytes per rune
+	require.Equal(t, "ёж\n... [truncated 4 bytes]", clipN(s, 5), "a cut inside a rune backs off to its start")
+	require.Equal(t, "ёж", truncateUTF8(s, 4))
+	require.Empty(t, truncateUTF8(s, 1))
+	require.Empty(t, truncateUTF8(s, -3))
+	require.True(t, utf8.ValidString(clipTokens(strings.Repeat("проверка ", 500), 50)))
+}
+
+func TestModelLimits(t *testing.T) {
+	l := ModelLimits{ContextWindow: 200_000, MaxOutput: 20_000}
+	require.Equal(t, 180_000, l.InputBudget())
+	require.Equal(t, 135_000, l.CompactThreshold())
+	require.Equal(t, 60_000, l.PreloadTokens())
+	require.Zero(t, ModelLimits{}.InputBudget(), "unknown window disables budgeting")
+
+	opts := DefaultOptions().WithLimits(ModelLimits{})
+	require.Equal(t, DefaultOptions().CompactAt, opts.CompactAt, "unknown window keeps the default threshold")
+	require.Equal(t, 135_000, DefaultOptions().WithLimits(l).CompactAt)
+
+	opus, _ := DefaultCatalog().Lookup("claude-opus-4-7")
+	require.Equal(t, 200_000, opus.Limits().ContextWindow)
+	require.Zero(t, LimitsOf(&scriptedProvider{}, nil).ContextWindow, "unknown model")
+}
+
+func TestLimitsOfProvider(t *testing.T) {
+	p, err := NewProvider(ProviderConfig{Provider: "openai-compat", Model: "qwen", APIKey: "k", ContextWindow: 32_000})
+	require.NoError(t, err)
+	require.Equal(t, ModelLimits{ContextWindow: 32_000, MaxOutput: defaultMaxTokens}, LimitsOf(p, nil))
+
+	// A provider without a window falls back to the given catalogue, keeping
+	// its own max_tokens.
+	require.Zero(t, LimitsOf(&scriptedProvider{}, nil).ContextWindow)
+	cat := DefaultCatalog().Merge(&Catalog{Models: []ModelSpec{{Match: "fake-model", ContextWindow: 64_000, MaxOutput: 8_000}}})
+	require.Equal(t, ModelLimits{ContextWindow: 64_000, MaxOutput: 8_000}, LimitsOf(&scriptedProvider{}, cat))
+	p, err = NewProvider(ProviderConfig{Provider: "openai-compat", Model: "fake-model", APIKey: "k"})
+	require.NoError(t, err)
+	require.Equal(t, ModelLimits{ContextWindow: 64_000, MaxOutput: defaultMaxTokens}, LimitsOf(p, cat))
+}
+
+func TestContextTokensPrefersMeasuredUsage(t *testing.T) {
+	msgs := []Message{
+		{Role: RoleUser, Text: "task"},
+		{Role: RoleAssistant, Text: "a"},
+		{Role: RoleTool, ToolResults: []ToolResult{{Content: "result"}}},
+	}
+	require.Equal(t, withMargin(estimateTokens(msgs)), contextTokens(msgs, Usage{}, 2))
+	require.Greater(t, contextTokens(msgs, Usage{}, 2), estimateTokens(msgs), "estimates are padded")
+
+	u := Usage{InputTokens: 100, CacheReadTokens: 900, OutputTokens: 50}
+	require.Equal(t, 1050+withMargin(estimateTokens(msgs[2:])), contextTokens(msgs, u, 2), "measured usage is not padded")
+}
+
+// countingProvider is a scriptedProvider that counts tokens exactly.
+type countingProvider struct {
+	scriptedProvider
+	tokens int
+	err    error
+}
+
+func (c *countingProvider) CountTokens(context.Context, Request) (int, error) { return c.tokens, c.err }
+
+func TestRunPreflightRejectsOversizedKickoff(t *testing.T) {
+	reg := NewReviewRegistry(ReviewToolsConfig{Dir: t.TempDir()})
+	prov := &countingProvider{tokens: 190_000}
+	opts := Options{MaxRounds: 5, Limits: ModelLimits{ContextWindow: 200_000, MaxOutput: 16_000}}
+
+	res, err := Run(context.Background(), prov, reg, "system", "review this", opts)
+	require.ErrorIs(t, err, errContextOverflow)
+	require.Equal(t, "error", res.StopReason)
+	require.Empty(t, prov.seen, "no completion request after a failed pre-flight")
+
+	// A failing count endpoint falls back to the local estimate, which fits.
+	prov = &countingProvider{err: errors.New("unavailable"), scriptedProvider: scriptedProvider{responses: []Response{
+		{ToolCalls: []ToolCall{{ID: "1", Name: "submit_review", Args: validSubmitArgs(t, "low")}}},
+	}}}
+	res, err = Run(context.Background(), prov, reg, "system", "review this", opts)
+	require.NoError(t, err)
+	require.True(t, res.Submitted)
+}
@@ -39,15 +39,27 @@ var skipDirs = map[string]bool{".git": true, "node_modules": true, "vendor": tru
 // read tools run in parallel.
 type readTracker struct {
 	mu   sync.Mutex
+	seed []string
 	seen map[string]bool
 }
 
 func newReadTracker(seed []string) *readTracker {
-	t := &readTracker{seen: make(map[string]bool, len(seed))}
-	for _, p := range seed {
+	t := &readTracker{seed: seed}
+	t.reset()
+	return t
+}
+
+// reset forgets every read except the seed. Called when compaction drops the
+// turns that carried those reads: their content is no longer in the
+// conversation, so a repeat read must be served in full again. The pre-loaded
+// seed lives in the kept head message and stays deduplicated.
+func (t *readTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seen = make(map[string]bool, len(t.seed))
+	for _, p := range t.seed {
 		t.seen[normPath(p)] = true
 	}
-	return t
 }
 
 // firstRead marks path as fully read and reports whether this is the first time.
@@ -0,0 +1,170 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// historyClip caps git_log / git_blame / git_show output; history is context,
+	// not the change under review, so it gets a tighter budget than git_diff.
+	historyClip = 60_000
+	// logDefaultCount / logMaxCount bound how many commits git_log lists.
+	logDefaultCount = 20
+	logMaxCount     = 100
+	// blameMaxLines bounds the git_blame line range.
+	blameMaxLines = 200
+	// gitNoColor keeps ANSI escapes out of the model's context whatever the
+	// user's color.ui setting.
+	gitNoColor = "--no-color"
+)
+
+// gitLogTool lists commits touching a path and/or a ref range, with messages,
+// so the model can see why code looks the way it does.
+func gitLogTool(root string) (ToolDef, Handler) {
+	def := ToolDef{
+		Name: "git_log",
+		Description: "List commits (hash, date, author, full message), newest first. Scope by path and/or a ref range " +
+			"(e.g. main..feature). Use it to tell a deliberate, long-standing pattern from an accident before flagging it.",
+		Schema: objSchema(map[string]any{
+			"path":     strProp("Optional: only commits touching this path (relative to the repository root)."),
+			"range":    strProp("Optional: ref or range (e.g. HEAD~10..HEAD, main..feature). Defaults to HEAD."),
+			"maxCount": intProp(fmt.Sprintf("Maximum commits to list (default %d, max %d)", logDefaultCount, logMaxCount)),
+		}),
+	}
+	h := func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			Path     string `json:"path"`
+			Range    string `json:"range"`
+			MaxCount int    `json:"maxCount"`
+		}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &a); err != nil {
+				return "", fmt.Errorf("git_log: bad arguments: %w", err)
+			}
+		}
+		if !validRef(a.Range) {
+			return "", fmt.Errorf("git_log: invalid range %q", a.Range)
+		}
+		if a.Path != "" && !validPath(a.Path) {
+			return "", fmt.Errorf("git_log: invalid path %q", a.Path)
+		}
+		n := a.MaxCount
+		if n <= 0 {
+			n = logDefaultCount
+		}
+		n = min(n, logMaxCount)
+
+		args := []string{gitNoPager, "log", gitNoColor, "--date=short", "--max-count=" + strconv.Itoa(n),
+			"--format=commit %h  %ad  %an%n%w(0,4,4)%B"}
+		if a.Range != "" {
+			args = append(args, a.Range)
+		}
+		if a.Path != "" {
+			args = append(args, "--", a.Path)
+		}
+		out, err := runGit(ctx, root, false, args...)
+		if err != nil {
+			return "", fmt.Errorf("git_log: %w", err)
+		}
+		if strings.TrimSpace(out) == "" {
+			return "no commits", nil
+		}
+		return clipN(out, historyClip), nil
+	}
+	return def, h
+}
+
+// gitBlameTool shows who last changed each line of a range and in which commit.
+// Without a ref it blames the working tree (uncommitted lines show as
+// "Not Committed Yet").
+func gitBlameTool(root string) (ToolDef, Handler) {
+	def := ToolDef{
+		Name: "git_blame",
+		Description: fmt.Sprintf("Show the last commit, author and date for each line of a file range (at most %d lines). "+
+			"Follow up with git_show on a hash to read why the line was written.", blameMaxLines),
+		Schema: objSchema(map[string]any{
+			fPath:       strProp("File path relative to the repository root"),
+			"startLine": intProp("1-based first line"),
+			"endLine":   intProp("1-based last line (inclusive)"),
+			"ref":       strProp("Optional: blame the file as of this ref instead of the working tree"),
+		}, fPath, "startLine", "endLine"),
+	}
+	h := func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			Path      string `json:"path"`
+			StartLine int    `json:"startLine"`
+			EndLine   int    `json:"endLine"`
+			Ref       string `json:"ref"`
+		}
+		if err := json.Unmarshal(raw, &a); err != nil {
+			return "", fmt.Errorf("git_blame: bad arguments: %w", err)
+		}
+		if !validPath(a.Path) {
+			return "", fmt.Errorf("git_blame: invalid path %q", a.Path)
+		}
+		if !validRef(a.Ref) {
+			return "", fmt.Errorf("git_blame: invalid ref %q", a.Ref)
+		}
+		if a.StartLine < 1 || a.EndLine < a.StartLine {
+			return "", fmt.Errorf("git_blame: invalid line range %d-%d", a.StartLine, a.EndLine)
+		}
+		end := min(a.EndLine, a.StartLine+blameMaxLines-1)
+
+		args := []string{gitNoPager, "blame", "--date=short", "-L", fmt.Sprintf("%d,%d", a.StartLine, end)}
+		if a.Ref != "" {
+			args = append(args, a.Ref)
+		}
+		out, err := runGit(ctx, root, false, append(args, "--", a.Path)...)
+		if err != nil {
+			return "", fmt.Errorf("git_blame: %w", err)
+		}
+		if end < a.EndLine {
+			out += fmt.Sprintf("... [range capped at %d lines; request lines %d-%d separately]\n", blameMaxLines, end+1, a.EndLine)
+		}
+		return clipN(out, historyClip), nil
+	}
+	return def, h
+}
+
+// gitShowTool shows one commit: its message, a file summary and the patch,
+// optionally limited to a single path.
+func gitShowTool(root string) (ToolDef, Handler) {
+	def := ToolDef{
+		Name:        "git_show",
+		Description: "Show a commit: full message, changed-file stats and patch. Pass path to limit the patch to one file.",
+		Schema: objSchema(map[string]any{
+			"ref": strProp("Commit hash or ref (e.g. from git_log or git_blame)"),
+			fPath: strProp("Optional: limit the patch to this path (relative to the repository root)."),
+		}, "ref"),
+	}
+	h := func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			Ref  string `json:"ref"`
+			Path string `json:"path"`
+		}
+		if err := json.Unmarshal(raw, &a); err != nil {
+			return "", fmt.Errorf("git_show: bad arguments: %w", err)
+		}
+		if a.Ref == "" || !validRef(a.Ref) {
+			return "", fmt.Errorf("git_show: invalid ref %q", a.Ref)
+		}
+		if a.Path != "" && !validPath(a.Path) {
+			return "", fmt.Errorf("git_show: invalid path %q", a.Path)
+		}
+
+		args := []string{gitNoPager, "show", gitNoColor, "--date=short", "--stat", "--patch", a.Ref}
+		if a.Path != "" {
+			args = append(args, "--", a.Path)
+		}
+		out, err := runGit(ctx, root, false, args...)
+		if err != nil {
+			return "", fmt.Errorf("git_show: %w", err)
+		}
+		return clipN(out, historyClip), nil
+	}
+	return def, h
+}
@@ -0,0 +1,494 @@
+package direct
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	goSemClip       = 60_000
+	goSemMaxResults = 200
+	goDefMaxLines   = 80
+	fSymbol         = "symbol"
+)
+
+// goSymbolRe accepts symbol references such as Run, direct.Run,
+// (*Registry).Dispatch or pkg/reviewer/direct.Registry.Dispatch.
+var goSymbolRe = regexp.MustCompile(`^[A-Za-z_(*][\w./*()]*$`)
+
+// registerGoTools adds the built-in Go navigation tools (go/types, no external
+// binary). Only called for Go modules. The index is built on the first call and
+// shared by all tools for the rest of the run.
+func registerGoTools(reg *Registry, idx *goIndex, defBase, defHead string) {
+	reg.Register(goSymbolTool(idx, "go_definition",
+		"Go: show where a symbol is declared — signature, doc comment and source. Accepts Name, pkg.Name, Type.Method or (*Type).Method. Type-checked, so it resolves exactly where grep would guess.",
+		(*goIndex).definition))
+	reg.Register(goSymbolTool(idx, "go_references",
+		"Go: list every reference to a symbol (type-checked, not text matches) — for impact analysis of a change.",
+		(*goIndex).references))
+	reg.Register(goSymbolTool(idx, "go_callers",
+		"Go: list the call sites of a function or method, with the calling function; for a method, calls made through interfaces it implements are included.",
+		(*goIndex).callers))
+	reg.Register(goSymbolTool(idx, "go_callees",
+		"Go: list the functions and methods a function calls, with their locations.",
+		(*goIndex).callees))
+	reg.Register(goSymbolTool(idx, "go_implementations",
+		"Go: for an interface, list the repository types implementing it; for a concrete type, list the repository interfaces it implements.",
+		(*goIndex).implementations))
+	reg.Register(goOutlineTool(idx.root, defBase, defHead))
+}
+
+// goSymbolTool builds a tool that resolves its symbol argument against the
+// index and renders the matches with query.
+func goSymbolTool(idx *goIndex, name, desc string, query func(*goIndex, []types.Object) string) (ToolDef, Handler) {
+	def := ToolDef{
+		Name:        name,
+		Description: desc,
+		Schema:      objSchema(map[string]any{fSymbol: strProp("Symbol: Name, pkg.Name, Type.Method or (*Type).Method")}, fSymbol),
+	}
+	h := func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			Symbol string `json:"symbol"`
+		}
+		if err := json.Unmarshal(raw, &a); err != nil {
+			return "", fmt.Errorf("%s: bad arguments: %w", name, err)
+		}
+		if !goSymbolRe.MatchString(a.Symbol) {
+			return "", fmt.Errorf("%s: invalid symbol %q", name, a.Symbol)
+		}
+		x, err := idx.get(ctx)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		objs := x.lookup(a.Symbol)
+		if len(objs) == 0 {
+			return fmt.Sprintf("no Go symbol %q found in the repository", a.Symbol), nil
+		}
+		return clipN(query(x, objs), goSemClip), nil
+	}
+	return def, h
+}
+
+// qualifier prints package names rather than full import paths.
+func qualifier(p *types.Package) string { return p.Name() }
+
+// definition renders each match with its signature, doc comment and source.
+func (x *goIndex) definition(objs []types.Object) string {
+	var b strings.Builder
+	for _, o := range objs {
+		fmt.Fprintf(&b, "%s  %s\n", x.pos(o.Pos()), types.ObjectString(o, qualifier))
+		node, doc := x.declOf(o)
+		if node == nil {
+			continue
+		}
+		from := node.Pos()
+		if doc != nil && doc.Pos() < from {
+			from = doc.Pos()
+		}
+		fmt.Fprintf(&b, "```go\n%s\n```\n\n", clipLines(x.text(from, node.End()), goDefMaxLines))
+	}
+	return b.String()
+}
+
+// goHit is one result location with a note.
+type goHit struct {
+	pos  token.Pos
+	note string
+}
+
+// references lists definitions and uses of the matched objects.
+func (x *goIndex) references(objs []types.Object) string {
+	set := objectSet(objs)
+	var hits []goHit
+	for id, o := range x.info.Uses {
+		if set[origin(o)] {
+			hits = append(hits, goHit{id.Pos(), ""})
+		}
+	}
+	for id, o := range x.info.Defs {
+		if o != nil && set[o] {
+			hits = append(hits, goHit{id.Pos(), "[def] "})
+		}
+	}
+	return x.renderHits(hits, func(h goHit) string { return h.note + x.line(h.pos) })
+}
+
+// callers lists call sites of the matched functions — directly, and through the
+// interface methods a matched concrete method implements.
+func (x *goIndex) callers(objs []types.Object) string {
+	via := make(map[types.Object]string)
+	for _, o := range objs {
+		fn, ok := o.(*types.Func)
+		if !ok {
+			continue
+		}
+		via[fn] = ""
+		for _, im := range x.interfaceMethodsOf(fn) {
+			via[im] = fmt.Sprintf(" (via %s)", types.ObjectString(im, qualifier))
+		}
+	}
+	if len(via) == 0 {
+		return "not a function or method"
+	}
+	var hits []goHit
+	x.eachCall(func(call *ast.CallExpr, caller string) {
+		if id := calleeIdent(call.Fun); id != nil {
+			if note, ok := via[origin(x.info.Uses[id])]; ok {
+				hits = append(hits, goHit{call.Pos(), "in " + caller + note + ": "})
+			}
+		}
+	})
+	return x.renderHits(hits, func(h goHit) string { return h.note + x.line(h.pos) })
+}
+
+// callees lists what the matched functions call, once each, in call order.
+func (x *goIndex) callees(objs []types.Object) string {
+	var b strings.Builder
+	for _, o := range objs {
+		fd, ok := x.funcDecl(o)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "%s calls:\n", types.ObjectString(o, qualifier))
+		seen := make(map[string]bool)
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if line := x.callee(call); line != "" && !seen[line] {
+				seen[line] = true
+				b.WriteString("  " + line + "\n")
+			}
+			return true
+		})
+	}
+	if b.Len() == 0 {
+		return "not a function or method with a body in the repository"
+	}
+	return b.String()
+}
+
+// callee describes the target of one call; empty for builtins and conversions.
+func (x *goIndex) callee(call *ast.CallExpr) string {
+	id := calleeIdent(call.Fun)
+	if id == nil {
+		return types.ExprString(call.Fun) + " (dynamic)"
+	}
+	switch o := x.info.Uses[id].(type) {
+	case *types.Func:
+		if x.own(o) {
+			return types.ObjectString(o.Origin(), qualifier) + "  " + x.pos(o.Pos())
+		}
+		return types.ExprString(call.Fun) + " (external)"
+	case *types.Var:
+		return types.ExprString(call.Fun) + " (func value)"
+	case *types.Builtin, *types.TypeName:
+		return ""
+	default:
+		return types.ExprString(call.Fun) + " (external)"
+	}
+}
+
+// implementations pairs interfaces and implementing types in the repository.
+func (x *goIndex) implementations(objs []types.Object) string {
+	var b strings.Builder
+	for _, o := range objs {
+		tn, ok := o.(*types.TypeName)
+		if !ok {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if iface, ok := named.Underlying().(*types.Interface); ok {
+			fmt.Fprintf(&b, "Types implementing %s:\n", types.ObjectString(tn, qualifier))
+			x.writeImplementers(&b, iface)
+		} else {
+			fmt.Fprintf(&b, "Interfaces implemented by %s:\n", types.ObjectString(tn, qualifier))
+			x.writeImplemented(&b, named)
+		}
+	}
+	if b.Len() == 0 {
+		return "not a named type"
+	}
+	return b.String()
+}
+
+func (x *goIndex) writeImplementers(b *strings.Builder, iface *types.Interface) {
+	if iface.NumMethods() == 0 {
+		b.WriteString("  (empty interface — every type)\n")
+		return
+	}
+	n := 0
+	x.eachNamed(func(tn *types.TypeName, named *types.Named) {
+		if types.IsInterface(named) || named.TypeParams().Len() > 0 {
+			return
+		}
+		if ptr, ok := implementsVia(named, iface); ok {
+			fmt.Fprintf(b, "  %s%s  %s\n", ptr, types.TypeString(named, qualifier), x.pos(tn.Pos()))
+			n++
+		}
+	})
+	if n == 0 {
+		b.WriteString("  (none in the repository)\n")
+	}
+}
+
+func (x *goIndex) writeImplemented(b *strings.Builder, named *types.Named) {
+	n := 0
+	x.eachNamed(func(tn *types.TypeName, cand *types.Named) {
+		iface, ok := cand.Underlying().(*types.Interface)
+		if !ok || iface.NumMethods() == 0 || cand.TypeParams().Len() > 0 || cand == named {
+			return
+		}
+		if ptr, ok := implementsVia(named, iface); ok {
+			fmt.Fprintf(b, "  %s%s  %s\n", types.TypeString(cand, qualifier), pointerNote(ptr), x.pos(tn.Pos()))
+			n++
+		}
+	})
+	if n == 0 {
+		b.WriteString("  (none in the repository)\n")
+	}
+}
+
+// implementsVia reports whether t satisfies iface, and ptr "*" when only *t does
+// (pointer receivers).
+func implementsVia(t types.Type, iface *types.Interface) (ptr string, ok bool) {
+	switch {
+	case types.Implements(t, iface):
+		return "", true
+	case types.Implements(types.NewPointer(t), iface):
+		return "*", true
+	default:
+		return "", false
+	}
+}
+
+func pointerNote(ptr string) string {
+	if ptr == "" {
+		return ""
+	}
+	return " (pointer receiver)"
+}
+
+// interfaceMethodsOf returns the repository interface methods that a concrete
+// method fn implements, so calls through those interfaces count as its callers.
+func (x *goIndex) interfaceMethodsOf(fn *types.Func) []types.Object {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil || types.IsInterface(sig.Recv().Type()) {
+		return nil
+	}
+	recv := sig.Recv().Type()
+	if p, ok := recv.(*types.Pointer); ok {
+		recv = p.Elem()
+	}
+	var out []types.Object
+	x.eachNamed(func(_ *types.TypeName, cand *types.Named) {
+		iface, ok := cand.Underlying().(*types.Interface)
+		if !ok || iface.NumMethods() == 0 {
+			return
+		}
+		for m := range iface.Methods() {
+			if _, ok := implementsVia(recv, iface); ok && m.Name() == fn.Name() {
+				out = append(out, m)
+			}
+		}
+	})
+	return out
+}
+
+// eachCall visits every call expression in the repository with the name of the
+// enclosing function.
+func (x *goIndex) eachCall(fn func(call *ast.CallExpr, caller string)) {
+	for _, p := range x.pkgs {
+		for _, f := range p.files {
+			spans := funcSpans(f)
+			ast.Inspect(f, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					fn(call, enclosingFunc(spans, call.Pos()))
+				}
+				return true
+			})
+		}
+	}
+}
+
+func (x *goIndex) funcDecl(o types.Object) (*ast.FuncDecl, bool) {
+	node, _ := x.declOf(o)
+	fd, ok := node.(*ast.FuncDecl)
+	return fd, ok && fd.Body != nil
+}
+
+// renderHits sorts hits by position and prints path:line plus detail, capped.
+func (x *goIndex) renderHits(hits []goHit, detail func(goHit) string) string {
+	if len(hits) == 0 {
+		return "(no results)"
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := x.fset.Position(hits[i].pos), x.fset.Position(hits[j].pos)
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	var b strings.Builder
+	for i, h := range hits {
+		if i == goSemMaxResults {
+			fmt.Fprintf(&b, "... [%d more]\n", len(hits)-i)
+			break
+		}
+		fmt.Fprintf(&b, "%s  %s\n", x.pos(h.pos), detail(h))
+	}
+	return b.String()
+}
+
+func objectSet(objs []types.Object) map[types.Object]bool {
+	set := make(map[types.Object]bool, len(objs))
+	for _, o := range objs {
+		set[o] = true
+	}
+	return set
+}
+
+// clipLines keeps the first n lines of s.
+func clipLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[:n], "\n") + fmt.Sprintf("\n// ... [%d more lines]", len(lines)-n)
+}
+
+func goOutlineTool(root, defBase, defHead string) (ToolDef, Handler) {
+	def := ToolDef{
+		Name:        "go_outline",
+		Description: "Go: outline a file — its types, functions and methods with signatures and line numbers. Without file, outlines every changed .go file.",
+		Schema:      objSchema(map[string]any{fFile: strProp("Repository-relative .go file (optional; default: all changed .go files)")}),
+	}
+	h := func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			File string `json:"file"`
+		}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &a); err != nil {
+				return "", fmt.Errorf("go_outline: bad arguments: %w", err)
+			}
+		}
+		files := []string{a.File}
+		if a.File == "" {
+			changed, err := changedFiles(ctx, root, defBase, defHead)
+			if err != nil {
+				return "", fmt.Errorf("go_outline: changed files: %w", err)
+			}
+			files = files[:0]
+			for _, f := range changed {
+				if strings.HasSuffix(f, ".go") {
+					files = append(files, f)
+				}
+			}
+			if len(files) == 0 {
+				return "no changed .go files", nil
+			}
+		}
+		var b strings.Builder
+		for _, f := range files {
+			out, err := goOutline(root, f)
+			if err != nil {
+				if a.File != "" {
+					return "", fmt.Errorf("go_outline: %w", err)
+				}
+				continue // deleted in the change
+			}
+			fmt.Fprintf(&b, "===== %s =====\n%s\n", f, out)
+		}
+		return clipN(b.String(), goSemClip), nil
+	}
+	return def, h
+}
+
+// goOutline lists a file's top-level declarations. Syntax only, so it works on
+// any file, including one the type checker could not make sense of.
+func goOutline(root, rel string) (string, error) {
+	abs, err := resolveInRoot(root, rel)
+	if err != nil {
+		return "", err
+	}
+	src, err := os.ReadFile(abs)
+	if err != nil {
+		return "", err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, rel, src, parser.SkipObjectResolution)
+	if f == nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n", f.Name.Name)
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			fmt.Fprintf(&b, "L%d %s\n", fset.Position(d.Pos()).Line, funcSignature(fset, d))
+		case *ast.GenDecl:
+			outlineGenDecl(&b, fset, d)
+		}
+	}
+	return b.String(), nil
+}
+
+// funcSignature prints a FuncDecl without its body and doc.
+func funcSignature(fset *token.FileSet, fd *ast.FuncDecl) string {
+	sig := *fd
+	sig.Body, sig.Doc = nil, nil
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, &sig); err != nil {
+		return "func " + funcDeclName(fd)
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+func outlineGenDecl(b *strings.Builder, fset *token.FileSet, d *ast.GenDecl) {
+	for _, spec := range d.Specs {
+		line := fset.Position(spec.Pos()).Line
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			fmt.Fprintf(b, "L%d type %s %s\n", line, s.Name.Name, typeKind(s.Type))
+		case *ast.ValueSpec:
+			names := make([]string, len(s.Names))
+			for i, n := range s.Names {
+				names[i] = n.Name
+			}
+			fmt.Fprintf(b, "L%d %s %s\n", line, d.Tok, strings.Join(names, ", "))
+		}
+	}
+}
+
+// typeKind summarises a type expression for the outline.
+func typeKind(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.StructType:
+		return fmt.Sprintf("struct (%d fields)", t.Fields.NumFields())
+	case *ast.InterfaceType:
+		names := make([]string, 0, len(t.Methods.List))
+		for _, m := range t.Methods.List {
+			for _, n := range m.Names {
+				names = append(names, n.Name)
+			}
+		}
+		return "interface {" + strings.Join(names, "; ") + "}"
+	default:
+		return types.ExprString(e)
+	}
+}
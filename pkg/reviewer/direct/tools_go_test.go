@@ -0,0 +1,159 @@
+package direct
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// goFixture writes a two-package module: an interface with a value- and a
+// pointer-receiver implementation, and a main package using both.
+func goFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	write(t, dir, "go.mod", "module example.com/m\n\ngo 1.22\n")
+	write(t, dir, "shape/shape.go", `package shape
+
+// Shape has an area.
+type Shape interface {
+	Area() float64
+}
+
+// Square is a value-receiver Shape.
+type Square struct{ S float64 }
+
+func (s Square) Area() float64 { return s.S * s.S }
+
+// Circle is a pointer-receiver Shape.
+type Circle struct{ R float64 }
+
+func (c *Circle) Area() float64 { return 3 * c.R * c.R }
+
+// Total sums the areas.
+func Total(ss []Shape) float64 {
+	t := 0.0
+	for _, s := range ss {
+		t += s.Area()
+	}
+	return t
+}
+`)
+	write(t, dir, "app/main.go", `package main
+
+import (
+	"fmt"
+
+	"example.com/m/shape"
+)
+
+func main() {
+	fmt.Println(shape.Total([]shape.Shape{shape.Square{S: 2}}))
+	_ = describe()
+}
+
+func describe() string {
+	return fmt.Sprint(shape.Square{}.Area())
+}
+`)
+	return dir
+}
+
+func goTool(t *testing.T, dir, name string) Handler {
+	t.Helper()
+	reg := NewRegistry()
+	registerGoTools(reg, newGoIndex(dir), "", "")
+	h, ok := reg.handlers[name]
+	require.True(t, ok, "tool %s not registered", name)
+	return h
+}
+
+func TestGoDefinition(t *testing.T) {
+	dir := goFixture(t)
+	out, err := call(t, goTool(t, dir, "go_definition"), `{"symbol":"shape.Total"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "shape/shape.go:19")
+	require.Contains(t, out, "func shape.Total(ss []shape.Shape) float64")
+	require.Contains(t, out, "// Total sums the areas.")
+
+	out, err = call(t, goTool(t, dir, "go_definition"), `{"symbol":"(*Circle).Area"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "func (c *Circle) Area() float64")
+
+	out, err = call(t, goTool(t, dir, "go_definition"), `{"symbol":"Nope"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "no Go symbol")
+
+	_, err = call(t, goTool(t, dir, "go_definition"), `{"symbol":"-x"}`)
+	require.Error(t, err)
+}
+
+func TestGoReferencesAndCallers(t *testing.T) {
+	dir := goFixture(t)
+	out, err := call(t, goTool(t, dir, "go_references"), `{"symbol":"Square"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "shape/shape.go:9  [def] type Square")
+	require.Contains(t, out, "app/main.go:10")
+	require.Contains(t, out, "app/main.go:15")
+
+	// Square.Area is called directly by describe and through Shape.Area by Total.
+	out, err = call(t, goTool(t, dir, "go_callers"), `{"symbol":"Square.Area"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "app/main.go:15  in describe:")
+	require.Contains(t, out, "shape/shape.go:22  in Total (via func (shape.Shape).Area() float64)")
+
+	out, err = call(t, goTool(t, dir, "go_callees"), `{"symbol":"main"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "func shape.Total(ss []shape.Shape) float64  shape/shape.go:19")
+	require.Contains(t, out, "func main.describe() string  app/main.go:14")
+	require.Contains(t, out, "fmt.Println (external)")
+}
+
+func TestGoImplementations(t *testing.T) {
+	dir := goFixture(t)
+	h := goTool(t, dir, "go_implementations")
+	out, err := call(t, h, `{"symbol":"Shape"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "  shape.Square  shape/shape.go:9")
+	require.Contains(t, out, "  *shape.Circle  shape/shape.go:14")
+
+	out, err = call(t, h, `{"symbol":"Circle"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "shape.Shape (pointer receiver)")
+}
+
+func TestGoOutline(t *testing.T) {
+	dir := goFixture(t)
+	out, err := call(t, goTool(t, dir, "go_outline"), `{"file":"shape/shape.go"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "L4 type Shape interface {Area}")
+	require.Contains(t, out, "L9 type Square struct (1 fields)")
+	require.Contains(t, out, "L16 func (c *Circle) Area() float64")
+
+	_, err = call(t, goTool(t, dir, "go_outline"), `{"file":"../etc/passwd"}`)
+	require.Error(t, err, "outline is sandboxed to the repository")
+}
+
+func TestGoToolsRegisteredForModules(t *testing.T) {
+	names := func(dir string) map[string]bool {
+		out := make(map[string]bool)
+		for _, d := range NewReviewRegistry(ReviewToolsConfig{Dir: dir}).Defs() {
+			out[d.Name] = true
+		}
+		return out
+	}
+	require.True(t, names(goFixture(t))["go_definition"])
+	require.False(t, names(t.TempDir())["go_definition"], "not a Go module")
+}
+
+func TestGoIndexCachedPerRun(t *testing.T) {
+	dir := goFixture(t)
+	idx := newGoIndex(dir)
+	a, err := idx.get(context.Background())
+	require.NoError(t, err)
+	write(t, dir, "shape/extra.go", "package shape\n\nfunc Extra() {}\n")
+	b, err := idx.get(context.Background())
+	require.NoError(t, err)
+	require.Same(t, a, b)
+	require.Empty(t, b.lookup("Extra"), "index is built once per run")
+}
@@ -0,0 +1,345 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	linterTimeout = 3 * time.Minute
+	linterClip    = 40_000
+	// linterPreRunTokens bounds the pre-run findings block when no budget is given.
+	linterPreRunTokens = 10_000
+
+	// Placeholders in a linter command, expanded to the changed targets:
+	// {pkgs} → changed Go package dirs (./pkg/x), {files} → changed files,
+	// {files:.ts,.vue} → changed files with those extensions.
+	linterPkgs  = "{pkgs}"
+	linterFiles = "{files"
+)
+
+var linterNameRe = regexp.MustCompile(`^[A-Za-z][\w-]*$`)
+
+// Linter is a project-configured analyzer command (go vet, staticcheck,
+// golangci-lint, eslint, ...). The model only picks a linter by name; the
+// command itself comes from configuration, never from the model.
+type Linter struct {
+	Name string
+	// Args is the argv (no shell). Without a placeholder the changed Go
+	// packages are appended.
+	Args []string
+}
+
+// ParseLinters parses a linter spec: "name=command args" entries separated by
+// ";", e.g. "vet=go vet {pkgs}; eslint=npx eslint {files:.ts,.vue}". Arguments
+// are split on whitespace; quoting is not supported.
+func ParseLinters(spec string) ([]Linter, error) {
+	var out []Linter
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, cmd, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		args := strings.Fields(cmd)
+		switch {
+		case !ok || len(args) == 0:
+			return nil, fmt.Errorf("linter %q: want name=command", strings.TrimSpace(entry))
+		case !linterNameRe.MatchString(name):
+			return nil, fmt.Errorf("linter %q: invalid name", name)
+		case slices.ContainsFunc(out, func(l Linter) bool { return l.Name == name }):
+			return nil, fmt.Errorf("linter %q: duplicate name", name)
+		}
+		out = append(out, Linter{Name: name, Args: args})
+	}
+	return out, nil
+}
+
+// lintTargets are the changed packages and files a linter is restricted to.
+type lintTargets struct {
+	pkgs  []string
+	files []string
+}
+
+// changedLintTargets lists the changed files still present in the tree and the
+// Go package directories they belong to. Names that could read as flags are
+// dropped — they are passed to the linter as plain arguments.
+func changedLintTargets(ctx context.Context, root, base, head string) (lintTargets, error) {
+	names, err := changedFiles(ctx, root, base, head)
+	if err != nil {
+		return lintTargets{}, err
+	}
+	var t lintTargets
+	for _, f := range names {
+		if !validPath(f) {
+			continue
+		}
+		if fi, err := os.Stat(filepath.Join(root, filepath.FromSlash(f))); err != nil || !fi.Mode().IsRegular() {
+			continue // deleted in the change
+		}
+		t.files = append(t.files, f)
+		if strings.HasSuffix(f, ".go") && !strings.Contains("/"+f, "/testdata/") {
+			t.pkgs = append(t.pkgs, "./"+path.Dir(f))
+		}
+	}
+	for i, p := range t.pkgs {
+		if p == "./." {
+			t.pkgs[i] = "."
+		}
+	}
+	t.pkgs = dedupeStrings(t.pkgs)
+	return t, nil
+}
+
+// expand substitutes the placeholders in l.Args. ok is false when a placeholder
+// expands to nothing — there is nothing of that kind to lint.
+func (l Linter) expand(t lintTargets) (argv []string, ok bool) {
+	placeholder := false
+	for _, a := range l.Args {
+		var items []string
+		switch {
+		case a == linterPkgs:
+			items = t.pkgs
+		case strings.HasPrefix(a, linterFiles) && strings.HasSuffix(a, "}"):
+			items = filesWithExt(t.files, strings.TrimSuffix(strings.TrimPrefix(a, linterFiles), "}"))
+		default:
+			argv = append(argv, a)
+			continue
+		}
+		if len(items) == 0 {
+			return nil, false
+		}
+		placeholder = true
+		argv = append(argv, items...)
+	}
+	if !placeholder {
+		if len(t.pkgs) == 0 {
+			return nil, false
+		}
+		argv = append(argv, t.pkgs...)
+	}
+	return argv, true
+}
+
+// filesWithExt filters files by the ":.ts,.vue" suffix of a {files:...}
+// placeholder; an empty suffix keeps every file.
+func filesWithExt(files []string, suffix string) []string {
+	exts := strings.TrimPrefix(suffix, ":")
+	if exts == "" {
+		return files
+	}
+	var out []string
+	for _, f := range files {
+		for _, ext := range strings.Split(exts, ",") {
+			if ext = strings.TrimSpace(ext); ext != "" && strings.HasSuffix(f, ext) {
+				out = append(out, f)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// runLinter runs l on the changed targets under linterTimeout and renders the
+// command line and its clipped output. A non-zero exit is the normal "findings
+// reported" outcome, not an error; a command that cannot start is.
+func runLinter(ctx context.Context, root string, l Linter, t lintTargets) (string, error) {
+	argv, ok := l.expand(t)
+	if !ok {
+		return fmt.Sprintf("%s: nothing to lint — no changed files match its targets", l.Name), nil
+	}
+	c, cancel := context.WithTimeout(ctx, linterTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(c, argv[0], argv[1:]...)
+	cmd.Dir = root
+	cmd.WaitDelay = 5 * time.Second
+	out, err := cmd.CombinedOutput()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ %s\n", strings.Join(argv, " "))
+	var ee *exec.ExitError
+	switch {
+	case c.Err() != nil && ctx.Err() == nil:
+		fmt.Fprintf(&b, "[timed out after %s; partial output]\n", linterTimeout)
+	case errors.As(err, &ee):
+		fmt.Fprintf(&b, "[exit %d]\n", ee.ExitCode())
+	case err != nil:
+		return "", fmt.Errorf("%s: %w", l.Name, err)
+	}
+	if res := strings.TrimSpace(string(out)); res != "" {
+		b.WriteString(clipN(res, linterClip))
+	} else {
+		b.WriteString("(no findings)")
+	}
+	return b.String(), nil
+}
+
+// linterSet runs the configured linters at most once per review: results
+// (including those of a pre-run, via seed) are cached by linter name.
+type linterSet struct {
+	root, base, head string
+	linters          []Linter
+
+	mu       sync.Mutex // guards the fields below, never held across a run
+	targets  *lintTargets
+	results  map[string]string
+	inflight map[string]*linterRun
+}
+
+// linterRun is a linter execution other callers of the same linter wait on.
+type linterRun struct {
+	done chan struct{}
+	out  string
+	err  error
+}
+
+func newLinterSet(root, base, head string, linters []Linter, seed map[string]string) *linterSet {
+	results := make(map[string]string, len(seed))
+	for k, v := range seed {
+		results[k] = v
+	}
+	return &linterSet{root: root, base: base, head: head, linters: linters, results: results, inflight: map[string]*linterRun{}}
+}
+
+func (s *linterSet) names() []string {
+	out := make([]string, 0, len(s.linters))
+	for _, l := range s.linters {
+		out = append(out, l.Name)
+	}
+	return out
+}
+
+// run returns the cached or fresh output of the named linter. Parallel calls
+// for the same linter share one execution; different linters run concurrently.
+// A failed run is not cached, so a later call retries it.
+func (s *linterSet) run(ctx context.Context, name string) (string, error) {
+	i := slices.IndexFunc(s.linters, func(l Linter) bool { return l.Name == name })
+	if i < 0 {
+		return "", fmt.Errorf("unknown linter %q (configured: %s)", name, strings.Join(s.names(), ", "))
+	}
+	s.mu.Lock()
+	if out, ok := s.results[name]; ok {
+		s.mu.Unlock()
+		return out, nil
+	}
+	if r, ok := s.inflight[name]; ok {
+		s.mu.Unlock()
+		select {
+		case <-r.done:
+			return r.out, r.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	r := &linterRun{done: make(chan struct{})}
+	s.inflight[name] = r
+	s.mu.Unlock()
+
+	r.out, r.err = s.exec(ctx, s.linters[i])
+
+	s.mu.Lock()
+	delete(s.inflight, name)
+	if r.err == nil {
+		s.results[name] = r.out
+	}
+	s.mu.Unlock()
+	close(r.done)
+	return r.out, r.err
+}
+
+// exec resolves the changed targets (once per review) and runs l on them.
+func (s *linterSet) exec(ctx context.Context, l Linter) (string, error) {
+	s.mu.Lock()
+	t := s.targets
+	s.mu.Unlock()
+	if t == nil {
+		ct, err := changedLintTargets(ctx, s.root, s.base, s.head)
+		if err != nil {
+			return "", fmt.Errorf("changed files: %w", err)
+		}
+		s.mu.Lock()
+		s.targets, t = &ct, &ct
+		s.mu.Unlock()
+	}
+	return runLinter(ctx, s.root, l, *t)
+}
+
+// runLinterTool exposes the configured linters to the model. It is registered
+// only when at least one linter is configured.
+func runLinterTool(s *linterSet) (ToolDef, Handler) {
+	def := ToolDef{
+		Name: "run_linter",
+		Description: "Run a project-configured linter/analyzer on the CHANGED packages/files only and return its findings. " +
+			"Use it to confirm a suspected issue instead of speculating, and cite its output. Results are cached per run.",
+		Schema: objSchema(map[string]any{
+			"name": enumProp("Linter to run", s.names()...),
+		}, "name"),
+	}
+	h := func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var a struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &a); err != nil {
+			return "", fmt.Errorf("run_linter: bad arguments: %w", err)
+		}
+		out, err := s.run(ctx, a.Name)
+		if err != nil {
+			return "", fmt.Errorf("run_linter: %w", err)
+		}
+		return out, nil
+	}
+	return def, h
+}
+
+// PreRunLinters runs every configured linter on the changed targets before the
+// loop and returns a kickoff block with their findings (next to PreloadContext),
+// plus the outputs by name to seed run_linter's cache so the model isn't
+// re-served them. maxTokens bounds the block (0 = default), split evenly between
+// linters. Best-effort: a linter that fails to start is reported in the block.
+func PreRunLinters(ctx context.Context, root, base, head string, linters []Linter, maxTokens int) (string, map[string]string) {
+	if len(linters) == 0 {
+		return "", nil
+	}
+	if maxTokens <= 0 {
+		maxTokens = linterPreRunTokens
+	}
+	t, err := changedLintTargets(ctx, root, base, head)
+	if err != nil || len(t.files) == 0 {
+		return "", nil
+	}
+
+	outs := make([]string, len(linters))
+	errs := make([]error, len(linters))
+	var wg sync.WaitGroup
+	for i, l := range linters {
+		wg.Go(func() { outs[i], errs[i] = runLinter(ctx, root, l, t) })
+	}
+	wg.Wait()
+
+	var b strings.Builder
+	b.WriteString("## Результаты линтеров по изменённым пакетам\n")
+	b.WriteString("Ниже — вывод настроенных в проекте линтеров. Ссылайся на них в находках и не дублируй ")
+	b.WriteString("их как собственные догадки. run_linter для них не вызывай — разве что вывод здесь обрезан ")
+	b.WriteString("(он вернёт полный вывод без повторного запуска).\n\n")
+	results := make(map[string]string, len(linters))
+	for i, l := range linters {
+		if errs[i] != nil {
+			fmt.Fprintf(&b, "### %s\n[не запустился: %v]\n\n", l.Name, errs[i])
+			continue
+		}
+		results[l.Name] = outs[i]
+		fmt.Fprintf(&b, "### %s\n```\n%s\n```\n\n", l.Name, clipTokens(outs[i], maxTokens/len(linters)))
+	}
+	return b.String(), results
+}
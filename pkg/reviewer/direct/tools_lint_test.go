@@ -0,0 +1,156 @@
+package direct
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLinters(t *testing.T) {
+	ls, err := ParseLinters(" vet=go vet {pkgs};  eslint = npx eslint {files:.ts,.vue} ;")
+	require.NoError(t, err)
+	require.Equal(t, []Linter{
+		{Name: "vet", Args: []string{"go", "vet", "{pkgs}"}},
+		{Name: "eslint", Args: []string{"npx", "eslint", "{files:.ts,.vue}"}},
+	}, ls)
+
+	ls, err = ParseLinters("")
+	require.NoError(t, err)
+	require.Empty(t, ls)
+
+	for _, bad := range []string{"vet", "vet=", "-x=go vet", "vet=go vet; vet=staticcheck"} {
+		_, err = ParseLinters(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestLinterExpand(t *testing.T) {
+	tg := lintTargets{pkgs: []string{".", "./pkg/a"}, files: []string{"main.go", "web/App.vue", "web/x.ts"}}
+
+	argv, ok := Linter{Args: []string{"go", "vet"}}.expand(tg)
+	require.True(t, ok)
+	require.Equal(t, []string{"go", "vet", ".", "./pkg/a"}, argv, "packages appended without a placeholder")
+
+	argv, ok = Linter{Args: []string{"eslint", "{files:.ts, .vue}"}}.expand(tg)
+	require.True(t, ok)
+	require.Equal(t, []string{"eslint", "web/App.vue", "web/x.ts"}, argv)
+
+	_, ok = Linter{Args: []string{"eslint", "{files:.js}"}}.expand(tg)
+	require.False(t, ok, "nothing of that kind changed")
+}
+
+// lintRepo commits a clean tree and leaves a.go modified and b.txt untracked.
+func lintRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitExec(t, dir, "init", "-q")
+	gitExec(t, dir, "config", "user.email", "t@t")
+	gitExec(t, dir, "config", "user.name", "t")
+	write(t, dir, "a.go", "package a\n")
+	write(t, dir, "sub/c.go", "package sub\n")
+	gitExec(t, dir, "add", ".")
+	gitExec(t, dir, "commit", "-q", "-m", "init")
+	write(t, dir, "a.go", "package a\n\n// TODO: handle errors\n")
+	write(t, dir, "b.txt", "TODO too\n")
+	return dir
+}
+
+func TestRunLinterTool(t *testing.T) {
+	dir := lintRepo(t)
+	linters, err := ParseLinters("todo=grep -n TODO {files}; gotodo=grep -n TODO {files:.go}; clean=grep -n FIXME {files}; missing=no-such-linter-bin {pkgs}")
+	require.NoError(t, err)
+	s := newLinterSet(dir, "", "", linters, map[string]string{"seeded": "from pre-run"})
+	_, h := runLinterTool(s)
+
+	out, err := call(t, h, `{"name":"todo"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "$ grep -n TODO a.go b.txt")
+	require.Contains(t, out, "a.go:3:// TODO: handle errors")
+	require.NotContains(t, out, "sub/c.go", "unchanged files are not linted")
+
+	out, err = call(t, h, `{"name":"gotodo"}`)
+	require.NoError(t, err)
+	require.NotContains(t, out, "b.txt")
+
+	out, err = call(t, h, `{"name":"clean"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "[exit 1]", "a non-zero exit is an outcome, not an error")
+	require.Contains(t, out, "(no findings)")
+
+	_, err = call(t, h, `{"name":"missing"}`)
+	require.Error(t, err)
+	_, err = call(t, h, `{"name":"rm"}`)
+	require.ErrorContains(t, err, "unknown linter")
+
+	s.linters = append(s.linters, Linter{Name: "seeded", Args: []string{"false"}})
+	out, err = call(t, h, `{"name":"seeded"}`)
+	require.NoError(t, err)
+	require.Equal(t, "from pre-run", out, "pre-run results are served from the cache")
+}
+
+func TestLinterSetRunsLintersConcurrently(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := lintRepo(t)
+	// Each linter waits for the other's marker, so they only finish when run
+	// side by side.
+	waitFor := func(own, other string) []string {
+		return []string{"sh", "-c", "touch " + own + "; while [ ! -f " + other + " ]; do sleep 0.05; done; echo " + own}
+	}
+	s := newLinterSet(dir, "", "", []Linter{{Name: "a", Args: waitFor("a.ok", "b.ok")}, {Name: "b", Args: waitFor("b.ok", "a.ok")}}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	outs := make([]string, 3)
+	errs := make([]error, 3)
+	var wg sync.WaitGroup
+	for i, name := range []string{"a", "b", "a"} {
+		wg.Go(func() { outs[i], errs[i] = s.run(ctx, name) })
+	}
+	wg.Wait()
+	for i := range outs {
+		require.NoError(t, errs[i])
+		require.NotContains(t, outs[i], "timed out")
+	}
+	require.Contains(t, outs[0], "a.ok")
+	require.Contains(t, outs[1], "b.ok")
+	require.Equal(t, outs[0], outs[2], "the same linter shares one run")
+}
+
+func TestPreRunLinters(t *testing.T) {
+	dir := lintRepo(t)
+	linters, err := ParseLinters("todo=grep -n TODO {files}; missing=no-such-linter-bin")
+	require.NoError(t, err)
+
+	block, results := PreRunLinters(context.Background(), dir, "", "", linters, 0)
+	require.Contains(t, block, "### todo")
+	require.Contains(t, block, "a.go:3:// TODO: handle errors")
+	require.Contains(t, block, "### missing\n[не запустился:")
+	require.Contains(t, results, "todo")
+	require.NotContains(t, results, "missing", "a failed linter stays runnable via run_linter")
+
+	block, results = PreRunLinters(context.Background(), dir, "", "", nil, 0)
+	require.Empty(t, block)
+	require.Nil(t, results)
+}
+
+func TestRunLinterRegisteredWhenConfigured(t *testing.T) {
+	has := func(cfg ReviewToolsConfig) bool {
+		for _, d := range NewReviewRegistry(cfg).Defs() {
+			if d.Name == "run_linter" {
+				return true
+			}
+		}
+		return false
+	}
+	require.False(t, has(ReviewToolsConfig{Dir: t.TempDir()}))
+	require.True(t, has(ReviewToolsConfig{Dir: t.TempDir(), Linters: []Linter{{Name: "vet", Args: []string{"go", "vet"}}}}))
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 
@@ -118,15 +119,86 @@ func (b *reviewBuilder) snapshot() (map[string]groupData, []rest.ReviewDraftIssu
 	return g, append([]rest.ReviewDraftIssue(nil), b.issues...)
 }
 
+// localIDHeaderRe captures the localId of a finding header for renumbering.
+var localIDHeaderRe = regexp.MustCompile(`(?m)^(#{2,4}\s+)([A-Za-z]\d+)\.`)
+
+// mergeFrom folds a sub-agent's output into b. A group several agents covered
+// (a per-package split) is concatenated: summaries joined, isAccepted only if
+// every agent accepted. LocalIDs already taken in b are renumbered in both the
+// markdown headers and the issues, so findings stay matched 1:1.
+func (b *reviewBuilder) mergeFrom(o *reviewBuilder) {
+	groups, issues := o.snapshot()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	used := make(map[string]bool)
+	for _, iss := range b.issues {
+		used[iss.LocalID] = true
+	}
+	for _, g := range b.groups {
+		for _, m := range localIDHeaderRe.FindAllStringSubmatch(g.markdown, -1) {
+			used[m[2]] = true
+		}
+	}
+	renamed := make(map[string]string)
+	claim := func(id string) {
+		if _, done := renamed[id]; done || id == "" {
+			return
+		}
+		nid := id
+		if used[id] {
+			prefix := strings.TrimRight(id, "0123456789")
+			for n := 1; used[nid]; n++ {
+				nid = fmt.Sprintf("%s%d", prefix, n)
+			}
+		}
+		used[nid] = true
+		renamed[id] = nid
+	}
+	for _, rt := range reviewTypes {
+		for _, m := range localIDHeaderRe.FindAllStringSubmatch(groups[rt].markdown, -1) {
+			claim(m[2])
+		}
+	}
+	for _, iss := range issues {
+		claim(iss.LocalID)
+	}
+
+	for rt, g := range groups {
+		g.markdown = localIDHeaderRe.ReplaceAllStringFunc(g.markdown, func(h string) string {
+			m := localIDHeaderRe.FindStringSubmatch(h)
+			return m[1] + renamed[m[2]] + "."
+		})
+		if prev, ok := b.groups[rt]; ok {
+			g = groupData{
+				summary:    prev.summary + "; " + g.summary,
+				isAccepted: prev.isAccepted && g.isAccepted,
+				markdown:   prev.markdown + "\n\n" + g.markdown,
+			}
+		}
+		b.groups[rt] = g
+	}
+	for _, iss := range issues {
+		if nid, ok := renamed[iss.LocalID]; ok {
+			iss.LocalID = nid
+		}
+		b.issues = append(b.issues, iss)
+	}
+}
+
 // setGroupTool sets one review group (summary + isAccepted + markdown body) — a
-// small payload, called once per group.
-func setGroupTool(b *reviewBuilder) (ToolDef, Handler) {
+// small payload, called once per group. types narrows the accepted groups (a
+// sub-agent's share); empty means all five.
+func setGroupTool(b *reviewBuilder, types ...string) (ToolDef, Handler) {
+	if len(types) == 0 {
+		types = reviewTypes
+	}
 	def := ToolDef{
 		Name: "set_group",
 		Description: "Set one review group: its one-line summary, isAccepted, and full markdown body. " +
 			"Call once per group (architecture, code, security, tests, operability). Small payload — preferred over packing all groups into submit_review.",
 		Schema: objSchema(map[string]any{
-			fReviewType: reviewTypeProp(),
+			fReviewType: enumProp("", types...),
 			fSummary:    strProp("One-line summary for this group"),
 			fIsAccepted: boolProp(),
 			fMarkdown:   strProp("Full markdown body; head each finding with ### C1. Title"),
@@ -142,8 +214,8 @@ func setGroupTool(b *reviewBuilder) (ToolDef, Handler) {
 		if err := json.Unmarshal(raw, &a); err != nil {
 			return "", fmt.Errorf("set_group: bad arguments: %w", err)
 		}
-		if !reviewer.IsValidReviewType(a.ReviewType) {
-			return "", fmt.Errorf("set_group: invalid reviewType %q", a.ReviewType)
+		if !reviewer.IsValidReviewType(a.ReviewType) || !slices.Contains(types, a.ReviewType) {
+			return "", fmt.Errorf("set_group: invalid reviewType %q (allowed: %s)", a.ReviewType, strings.Join(types, ", "))
 		}
 		if strings.TrimSpace(a.Summary) == "" || strings.TrimSpace(a.Markdown) == "" {
 			return "", fmt.Errorf("set_group: summary and markdown are required for %s", a.ReviewType)
@@ -155,13 +227,17 @@ func setGroupTool(b *reviewBuilder) (ToolDef, Handler) {
 }
 
 // addIssuesTool appends a batch of issues — called one or more times, small.
-func addIssuesTool(b *reviewBuilder) (ToolDef, Handler) {
+// types narrows the accepted fileType values like setGroupTool.
+func addIssuesTool(b *reviewBuilder, types ...string) (ToolDef, Handler) {
+	if len(types) == 0 {
+		types = reviewTypes
+	}
 	def := ToolDef{
 		Name: "add_issues",
 		Description: "Append a batch of issues to the review. Call one or more times; keep batches small to avoid output truncation. " +
 			"Every ### finding in a group's markdown must have a matching issue.",
 		Schema: objSchema(map[string]any{
-			fIssues: arrayOf(issueItemSchema()),
+			fIssues: arrayOf(issueItemSchema(types...)),
 		}, fIssues),
 	}
 	h := func(_ context.Context, raw json.RawMessage) (string, error) {
@@ -175,7 +251,7 @@ func addIssuesTool(b *reviewBuilder) (ToolDef, Handler) {
 			if !reviewer.IsValidSeverity(iss.Severity) {
 				return "", fmt.Errorf("add_issues: invalid severity at [%d] (localId=%s): %q", i, iss.LocalID, iss.Severity)
 			}
-			if !reviewer.IsValidReviewType(iss.FileType) {
+			if !reviewer.IsValidReviewType(iss.FileType) || !slices.Contains(types, iss.FileType) {
 				return "", fmt.Errorf("add_issues: invalid fileType at [%d] (localId=%s): %q", i, iss.LocalID, iss.FileType)
 			}
 		}
@@ -196,12 +272,7 @@ func submitReviewTool(dir string, b *reviewBuilder, reg *Registry) (ToolDef, Han
 			"Preferred: call set_group for all five groups and add_issues first, then submit_review with only the review metadata. " +
 			"You MAY also pass files/issues/markdown here for a one-shot submit if your output fits.",
 		Schema: objSchema(map[string]any{
-			"review": objectProp(map[string]any{
-				fDescription:    strProp("Overall verdict"),
-				"effortMinutes": intProp("Estimated minutes to address the findings"),
-				"aiSlopScore":   numberProp(),
-				fTitle:          strProp("Optional title override"),
-			}),
+			"review":  reviewMetaProp(),
 			"files":   arrayOf(fileItemSchema()),
 			fIssues:   arrayOf(issueItemSchema()),
 			fMarkdown: freeObject(),
@@ -299,9 +370,15 @@ func submitReviewTool(dir string, b *reviewBuilder, reg *Registry) (ToolDef, Han
 // checkGroupsComplete enforces that every group has a non-empty summary and
 // markdown body, and that every markdown finding ("### C1.") has an issue.
 func checkGroupsComplete(groups map[string]groupData, issues []rest.ReviewDraftIssue) error {
+	return checkGroups(toolSubmitReview, reviewTypes, groups, issues)
+}
+
+// checkGroups is checkGroupsComplete for the given groups; tool names the call
+// the model should retry.
+func checkGroups(tool string, types []string, groups map[string]groupData, issues []rest.ReviewDraftIssue) error {
 	var noMarkdown, noSummary []string
 	findings := 0
-	for _, rt := range reviewTypes {
+	for _, rt := range types {
 		g := groups[rt]
 		if strings.TrimSpace(g.markdown) == "" {
 			noMarkdown = append(noMarkdown, rt)
@@ -312,13 +389,13 @@ func checkGroupsComplete(groups map[string]groupData, issues []rest.ReviewDraftI
 		findings += len(findingHeaderRe.FindAllString(g.markdown, -1))
 	}
 	if len(noMarkdown) > 0 {
-		return fmt.Errorf("submit_review: missing group(s): %s — call set_group for each (summary + markdown), then submit_review again", strings.Join(noMarkdown, ", "))
+		return fmt.Errorf("%[1]s: missing group(s): %[2]s — call set_group for each (summary + markdown), then %[1]s again", tool, strings.Join(noMarkdown, ", "))
 	}
 	if len(noSummary) > 0 {
-		return fmt.Errorf("submit_review: missing summary for: %s — set it via set_group, then submit_review again", strings.Join(noSummary, ", "))
+		return fmt.Errorf("%[1]s: missing summary for: %[2]s — set it via set_group, then %[1]s again", tool, strings.Join(noSummary, ", "))
 	}
 	if findings > len(issues) {
-		return fmt.Errorf("submit_review: markdown has %d findings (### headers) but only %d issues — add_issues for the rest, then submit_review again", findings, len(issues))
+		return fmt.Errorf("%[1]s: markdown has %[2]d findings (### headers) but only %[3]d issues — add_issues for the rest, then %[1]s again", tool, findings, len(issues))
 	}
 	return nil
 }
@@ -339,7 +416,11 @@ func reviewTypeProp() map[string]any {
 	return enumProp("", reviewTypes...)
 }
 
-func issueItemSchema() map[string]any {
+// issueItemSchema describes one issue; types narrows fileType (empty = all five).
+func issueItemSchema(types ...string) map[string]any {
+	if len(types) == 0 {
+		types = reviewTypes
+	}
 	return objSchema(map[string]any{
 		fLocalID:       strProp("Stable id, matches a ### header"),
 		fSeverity:      enumProp("", "critical", "high", "medium", "low"),
@@ -349,11 +430,21 @@ func issueItemSchema() map[string]any {
 		fFile:          strProp("Path relative to repository root"),
 		"lines":        strProp("Line range, e.g. 10-20"),
 		"issueType":    strProp("Free-form category"),
-		fFileType:      reviewTypeProp(),
+		fFileType:      enumProp("", types...),
 		"suggestedFix": strProp("Optional suggested fix"),
 	}, fLocalID, fSeverity, fTitle, fDescription, fFile, fFileType)
 }
 
+// reviewMetaProp describes submit_review's overall review metadata.
+func reviewMetaProp() map[string]any {
+	return objectProp(map[string]any{
+		fDescription:    strProp("Overall verdict"),
+		"effortMinutes": intProp("Estimated minutes to address the findings"),
+		"aiSlopScore":   numberProp(),
+		fTitle:          strProp("Optional title override"),
+	})
+}
+
 func fileItemSchema() map[string]any {
 	return objSchema(map[string]any{
 		fReviewType: reviewTypeProp(),
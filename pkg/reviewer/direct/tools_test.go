@@ -203,6 +203,92 @@ func TestGitDiffHeadRequiresBase(t *testing.T) {
 	require.Contains(t, err.Error(), "requires a base")
 }
 
+// historyRepo makes a repo with two commits on a.go: "init" and "explain why".
+func historyRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitExec(t, dir, "init", "-q")
+	gitExec(t, dir, "config", "user.email", "t@t")
+	gitExec(t, dir, "config", "user.name", "alice")
+	write(t, dir, "a.go", "package a\n")
+	write(t, dir, "b.go", "package a\n")
+	gitExec(t, dir, "add", ".")
+	gitExec(t, dir, "commit", "-q", "-m", "init")
+	write(t, dir, "a.go", "package a\n\n// retry twice: the upstream API drops the first call\n")
+	gitExec(t, dir, "commit", "-q", "-am", "explain why\n\nUpstream drops the first call after idle.")
+	return dir
+}
+
+func TestGitLogTool(t *testing.T) {
+	dir := historyRepo(t)
+	_, h := gitLogTool(dir)
+
+	out, err := call(t, h, `{"path":"a.go"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "alice")
+	require.Contains(t, out, "    Upstream drops the first call after idle.", "full message body")
+	require.Contains(t, out, "    init")
+
+	out, err = call(t, h, `{"path":"b.go"}`)
+	require.NoError(t, err)
+	require.NotContains(t, out, "explain why", "scoped to the path")
+
+	out, err = call(t, h, `{"range":"HEAD~1..HEAD","maxCount":5}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "explain why")
+	require.NotContains(t, out, "    init")
+
+	_, err = call(t, h, `{"range":"--output=/tmp/x"}`)
+	require.ErrorContains(t, err, "invalid range")
+	_, err = call(t, h, `{"path":"../etc"}`)
+	require.ErrorContains(t, err, "invalid path")
+}
+
+func TestGitBlameTool(t *testing.T) {
+	dir := historyRepo(t)
+	write(t, dir, "a.go", "package a\n\n// retry twice: the upstream API drops the first call\nvar x = 1\n")
+	_, h := gitBlameTool(dir)
+
+	out, err := call(t, h, `{"path":"a.go","startLine":3,"endLine":4}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "alice")
+	require.Contains(t, out, "retry twice")
+	require.Contains(t, out, "Not Committed Yet", "working tree blame shows uncommitted lines")
+
+	out, err = call(t, h, `{"path":"a.go","startLine":1,"endLine":1,"ref":"HEAD~1"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "package a")
+
+	_, err = call(t, h, `{"path":"a.go","startLine":3,"endLine":2}`)
+	require.ErrorContains(t, err, "invalid line range")
+	_, err = call(t, h, `{"path":"/etc/passwd","startLine":1,"endLine":1}`)
+	require.ErrorContains(t, err, "invalid path")
+}
+
+func TestGitShowTool(t *testing.T) {
+	dir := historyRepo(t)
+	_, h := gitShowTool(dir)
+
+	out, err := call(t, h, `{"ref":"HEAD"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "explain why")
+	require.Contains(t, out, "a.go | 2 ++")
+	require.Contains(t, out, "+// retry twice")
+
+	out, err = call(t, h, `{"ref":"HEAD~1","path":"b.go"}`)
+	require.NoError(t, err)
+	require.Contains(t, out, "b/b.go")
+	require.NotContains(t, out, "b/a.go", "patch limited to the path")
+
+	_, err = call(t, h, `{"ref":""}`)
+	require.ErrorContains(t, err, "invalid ref")
+	_, err = call(t, h, `{"ref":"-p"}`)
+	require.ErrorContains(t, err, "invalid ref")
+}
+
 func write(t *testing.T, dir, rel, content string) {
 	t.Helper()
 	p := filepath.Join(dir, filepath.FromSlash(rel))
@@ -8,7 +8,10 @@
 // its own SDK. See docs/llm/DirectAPIRunner.Spec.md for the design.
 package direct
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Role identifies who produced a Message.
 type Role string
@@ -22,34 +25,36 @@ const (
 
 // Message is one turn of the conversation in neutral form. A provider translates
 // it to its SDK shape on every request. An assistant message may carry ToolCalls;
-// a tool message carries the ToolResults produced for the previous assistant turn.
+// a tool message carries the ToolResults produced for the previous assistant turn
+// and, optionally, Text — a harness note delivered right after the results.
 type Message struct {
-	Role        Role
-	Text        string
-	ToolCalls   []ToolCall
-	ToolResults []ToolResult
+	Role        Role         `json:"role"`
+	Text        string       `json:"text,omitempty"`
+	ToolCalls   []ToolCall   `json:"toolCalls,omitempty"`
+	ToolResults []ToolResult `json:"toolResults,omitempty"`
 
 	// Raw is an opaque, provider-native snapshot of an assistant turn used to
 	// replay it verbatim on later requests. The Anthropic provider stores the
 	// SDK MessageParam here so signed thinking blocks survive the round-trip
 	// (rebuilding from Text+ToolCalls alone would drop them). Nil for messages
 	// the loop creates itself; providers that don't set it just reconstruct.
-	Raw any
+	// Serialised separately (see encodeRaw), never as plain JSON.
+	Raw any `json:"-"`
 }
 
 // ToolCall is a tool invocation requested by the model.
 type ToolCall struct {
-	ID   string
-	Name string
-	Args json.RawMessage
+	ID   string          `json:"id"`
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args"`
 }
 
 // ToolResult is the outcome of executing a ToolCall, fed back to the model.
 type ToolResult struct {
-	CallID  string
-	Name    string
-	Content string
-	IsError bool
+	CallID  string `json:"callId"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	IsError bool   `json:"isError,omitempty"`
 }
 
 // Usage holds the token counters for a single round, with neutral names that
@@ -60,6 +65,17 @@ type Usage struct {
 	OutputTokens     int `json:"outputTokens"`
 	CacheReadTokens  int `json:"cacheReadTokens"`
 	CacheWriteTokens int `json:"cacheWriteTokens"`
+	// CacheWrite1hTokens is the part of CacheWriteTokens written with the 1h
+	// TTL (Anthropic), billed at Pricing.CacheWrite1hPerMTok.
+	CacheWrite1hTokens int `json:"cacheWrite1hTokens,omitempty"`
+}
+
+// CacheHitRatio is the share of prompt tokens read from the cache.
+func (u Usage) CacheHitRatio() float64 {
+	if n := promptTokens(u); n > 0 {
+		return float64(u.CacheReadTokens) / float64(n)
+	}
+	return 0
 }
 
 // Request is one provider call: stable system prompt + accumulated history + the
@@ -70,6 +86,16 @@ type Request struct {
 	Messages []Message
 	Tools    []ToolDef
 	Effort   string
+	// ResponseSchema, if set, constrains the reply to JSON matching this
+	// schema (structured output) on providers that support it.
+	ResponseSchema map[string]any
+
+	// OnDelta, if set, receives partial output while the response streams in.
+	// Called synchronously from Complete, so it runs on the caller's goroutine.
+	OnDelta func(StreamDelta)
+	// IdleTimeout aborts a streamed response that delivers no chunk for this
+	// long. Zero disables the check (only ctx bounds the call).
+	IdleTimeout time.Duration
 }
 
 // Response is the model's reply for one round.
@@ -91,5 +117,6 @@ func sumUsage(a, b Usage) Usage {
 	a.OutputTokens += b.OutputTokens
 	a.CacheReadTokens += b.CacheReadTokens
 	a.CacheWriteTokens += b.CacheWriteTokens
+	a.CacheWrite1hTokens += b.CacheWrite1hTokens
 	return a
 }
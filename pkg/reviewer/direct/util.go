@@ -1,6 +1,9 @@
 package direct
 
-import "fmt"
+import (
+	"fmt"
+	"unicode/utf8"
+)
 
 const defaultClip = 100_000
 
@@ -30,7 +33,23 @@ func clipN(s string, n int) string {
 	if len(s) <= n {
 		return s
 	}
-	return s[:n] + fmt.Sprintf("\n... [truncated %d bytes]", len(s)-n)
+	cut := truncateUTF8(s, n)
+	return cut + fmt.Sprintf("\n... [truncated %d bytes]", len(s)-len(cut))
+}
+
+// truncateUTF8 returns the longest prefix of s of at most n bytes that does
+// not split a rune.
+func truncateUTF8(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
 }
 
 // objSchema builds a JSON Schema object with the given properties and required
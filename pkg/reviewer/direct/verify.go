@@ -0,0 +1,222 @@
+package direct
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"reviewsrv/pkg/rest"
+	"reviewsrv/pkg/reviewer"
+)
+
+// Verifier verdicts.
+const (
+	VerdictConfirm   = "confirm"
+	VerdictDowngrade = "downgrade"
+	VerdictReject    = "reject"
+)
+
+const (
+	toolVerdict = "verdict"
+	// verifyParallel / verifyContextLines are the Verifier defaults.
+	verifyParallel     = 4
+	verifyContextLines = 30
+	// verifyExcerptClip caps the code excerpt sent with one issue.
+	verifyExcerptClip = 30_000
+)
+
+var lineNumRe = regexp.MustCompile(`\d+`)
+
+// Verifier re-checks each issue of a finished review in isolation: the issue,
+// the lines it cites and the code around them go to Provider — possibly a
+// cheaper model than the reviewer's — which confirms, downgrades or rejects it.
+// One round per issue, no tools besides the verdict.
+type Verifier struct {
+	Provider LLMProvider
+	Root     string
+	Effort   string
+	// Parallel bounds concurrent provider calls (0 = 4).
+	Parallel int
+	// ContextLines is how much code is shown around the cited lines (0 = 30).
+	ContextLines int
+}
+
+// Verdict is the verifier's answer for one issue.
+type Verdict struct {
+	LocalID string
+	Verdict string // confirm | downgrade | reject
+	// Severity is the new, lower severity of a downgrade.
+	Severity string
+	Reason   string
+	// Err is set when the issue could not be verified; Verdict is then
+	// confirm — an unverifiable finding is kept as the reviewer wrote it.
+	Err error
+}
+
+// VerifyResult is a verifier pass: one verdict per issue in input order, and
+// the pass's summed usage.
+type VerifyResult struct {
+	Verdicts      []Verdict
+	Model         string
+	Usage         Usage
+	CostUsd       float64
+	Rounds        int
+	DurationAPIMs int
+}
+
+// Verify asks Provider for a verdict on each issue, at most Parallel at once.
+// It never fails as a whole: per-issue errors land in Verdict.Err.
+func (v *Verifier) Verify(ctx context.Context, issues []rest.ReviewDraftIssue) *VerifyResult {
+	res := &VerifyResult{Verdicts: make([]Verdict, len(issues)), Model: v.Provider.Model()}
+	usages := make([]Usage, len(issues))
+	apiMs := make([]int, len(issues))
+	sem := make(chan struct{}, positiveOr(v.Parallel, verifyParallel))
+	var wg sync.WaitGroup
+	for i, iss := range issues {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				res.Verdicts[i] = Verdict{LocalID: iss.LocalID, Verdict: VerdictConfirm, Err: ctx.Err()}
+				return
+			}
+			start := time.Now()
+			res.Verdicts[i], usages[i] = v.verifyOne(ctx, iss)
+			apiMs[i] = max(1, int(time.Since(start).Milliseconds()))
+		})
+	}
+	wg.Wait()
+
+	for i := range issues {
+		res.Usage = sumUsage(res.Usage, usages[i])
+		res.DurationAPIMs += apiMs[i]
+		if apiMs[i] > 0 { // the provider was called
+			res.Rounds++
+		}
+	}
+	res.CostUsd = computeCost(res.Usage, v.Provider.Pricing())
+	return res
+}
+
+// verifyOne runs the single verifier round for iss.
+func (v *Verifier) verifyOne(ctx context.Context, iss rest.ReviewDraftIssue) (Verdict, Usage) {
+	out := Verdict{LocalID: iss.LocalID, Verdict: VerdictConfirm}
+	resp, err := v.Provider.Complete(ctx, Request{
+		System:   VerifierPrompt,
+		Messages: []Message{{Role: RoleUser, Text: v.issueBrief(iss)}},
+		Tools:    []ToolDef{verdictToolDef()},
+		Effort:   v.Effort,
+	})
+	if err != nil {
+		out.Err = err
+		return out, resp.Usage
+	}
+	i := slices.IndexFunc(resp.ToolCalls, func(c ToolCall) bool { return c.Name == toolVerdict })
+	if i < 0 {
+		out.Err = fmt.Errorf("no %s call in the response", toolVerdict)
+		return out, resp.Usage
+	}
+	var a struct {
+		Verdict  string `json:"verdict"`
+		Severity string `json:"severity"`
+		Reason   string `json:"reason"`
+	}
+	if err := json.Unmarshal(resp.ToolCalls[i].Args, &a); err != nil {
+		out.Err = fmt.Errorf("%s: bad arguments: %w", toolVerdict, err)
+		return out, resp.Usage
+	}
+	out.Reason = strings.TrimSpace(a.Reason)
+	switch a.Verdict {
+	case VerdictReject:
+		out.Verdict = VerdictReject
+	case VerdictDowngrade:
+		// Only a real step down counts; "downgrade" to the same or a higher
+		// severity is a confirmation.
+		if severityRank(a.Severity) > severityRank(iss.Severity) && severityRank(iss.Severity) >= 0 {
+			out.Verdict, out.Severity = VerdictDowngrade, a.Severity
+		}
+	case VerdictConfirm:
+	default:
+		out.Err = fmt.Errorf("%s: unknown verdict %q", toolVerdict, a.Verdict)
+	}
+	return out, resp.Usage
+}
+
+// severityRank orders severities from critical (0) to low; -1 if unknown.
+func severityRank(s string) int {
+	return slices.Index(reviewer.Severities, s)
+}
+
+func verdictToolDef() ToolDef {
+	return ToolDef{
+		Name:        toolVerdict,
+		Description: "Record your verdict on the finding. Call exactly once.",
+		Schema: objSchema(map[string]any{
+			"verdict": enumProp("confirm | downgrade | reject", VerdictConfirm, VerdictDowngrade, VerdictReject),
+			fSeverity: enumProp("For downgrade: the new, lower severity", reviewer.Severities...),
+			"reason":  strProp("One or two sentences pointing at the code"),
+		}, "verdict", "reason"),
+	}
+}
+
+// issueBrief renders the finding and the code it cites.
+func (v *Verifier) issueBrief(iss rest.ReviewDraftIssue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Находка %s [%s, %s, %s]\n%s\n\n", iss.LocalID, iss.Severity, iss.FileType, iss.IssueType, iss.Title)
+	if iss.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", iss.Description)
+	}
+	if iss.Content != "" {
+		fmt.Fprintf(&b, "%s\n\n", iss.Content)
+	}
+	if iss.SuggestedFix != "" {
+		fmt.Fprintf(&b, "Предложенное исправление:\n%s\n\n", iss.SuggestedFix)
+	}
+	fmt.Fprintf(&b, "## Код: %s, строки %s\n", iss.File, iss.Lines)
+	b.WriteString(v.excerpt(iss.File, iss.Lines))
+	return b.String()
+}
+
+// excerpt returns the numbered lines of file around the cited range (the
+// first and last number in lines), or a note when the file can't be shown.
+func (v *Verifier) excerpt(file, lines string) string {
+	if file == "" {
+		return "[файл не указан]\n"
+	}
+	abs, err := resolveInRoot(v.Root, file)
+	if err != nil {
+		return fmt.Sprintf("[файл недоступен: %v]\n", err)
+	}
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return fmt.Sprintf("[файл недоступен: %v]\n", err)
+	}
+	src := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+
+	pad := positiveOr(v.ContextLines, verifyContextLines)
+	from, to := 1, min(len(src), 2*pad)
+	if nums := lineNumRe.FindAllString(lines, -1); len(nums) > 0 {
+		first, _ := strconv.Atoi(nums[0])
+		last, _ := strconv.Atoi(nums[len(nums)-1])
+		first, last = min(first, last), max(first, last)
+		from, to = max(1, first-pad), min(len(src), last+pad)
+	}
+	if from > to {
+		return fmt.Sprintf("[строки %s вне файла: в нём %d строк]\n", lines, len(src))
+	}
+
+	var b strings.Builder
+	b.WriteString("```\n")
+	for n := from; n <= to; n++ {
+		fmt.Fprintf(&b, "%6d\t%s\n", n, src[n-1])
+	}
+	return clipN(b.String(), verifyExcerptClip) + "```\n"
+}
@@ -0,0 +1,94 @@
+package direct
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"reviewsrv/pkg/rest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// verdictProvider answers each verifier round with the verdict args keyed by
+// the issue's title; an unknown title gets a plain-text reply.
+type verdictProvider struct {
+	args map[string]string
+
+	mu    sync.Mutex
+	seen  []string
+	fails string
+}
+
+func (p *verdictProvider) Model() string    { return "verifier-model" }
+func (p *verdictProvider) Pricing() Pricing { return Pricing{InputPerMTok: 1, OutputPerMTok: 1} }
+
+func (p *verdictProvider) Complete(_ context.Context, req Request) (Response, error) {
+	brief := req.Messages[0].Text
+	p.mu.Lock()
+	p.seen = append(p.seen, brief)
+	p.mu.Unlock()
+	u := Usage{InputTokens: 100, OutputTokens: 10}
+	if p.fails != "" && strings.Contains(brief, p.fails) {
+		return Response{}, errors.New("boom")
+	}
+	for title, args := range p.args {
+		if strings.Contains(brief, title) {
+			return Response{ToolCalls: []ToolCall{{ID: "v", Name: toolVerdict, Args: []byte(args)}}, Usage: u}, nil
+		}
+	}
+	return Response{Text: "looks fine", Usage: u}, nil
+}
+
+func TestVerifierVerdicts(t *testing.T) {
+	dir := t.TempDir()
+	var src strings.Builder
+	for i := 1; i <= 100; i++ {
+		src.WriteString("line\n")
+	}
+	write(t, dir, "main.go", src.String())
+
+	prov := &verdictProvider{fails: "broken", args: map[string]string{
+		"real bug":    `{"verdict":"confirm","reason":"yes"}`,
+		"overstated":  `{"verdict":"downgrade","severity":"low","reason":"minor"}`,
+		"upgrade":     `{"verdict":"downgrade","severity":"critical","reason":"worse"}`,
+		"false alarm": `{"verdict":"reject","reason":"handled at line 3"}`,
+		"nonsense":    `{"verdict":"maybe","reason":"?"}`,
+	}}
+	issues := []rest.ReviewDraftIssue{
+		{LocalID: "C1", Severity: "high", Title: "real bug", File: "main.go", Lines: "50-52"},
+		{LocalID: "C2", Severity: "high", Title: "overstated", File: "main.go", Lines: "10"},
+		{LocalID: "C3", Severity: "medium", Title: "upgrade", File: "main.go"},
+		{LocalID: "C4", Severity: "high", Title: "false alarm", File: "../etc/passwd", Lines: "1"},
+		{LocalID: "C5", Severity: "low", Title: "nonsense"},
+		{LocalID: "C6", Severity: "low", Title: "silent"},
+		{LocalID: "C7", Severity: "low", Title: "broken"},
+	}
+	v := &Verifier{Provider: prov, Root: dir, Parallel: 2, ContextLines: 5}
+	res := v.Verify(context.Background(), issues)
+
+	require.Len(t, res.Verdicts, len(issues))
+	require.Equal(t, Verdict{LocalID: "C1", Verdict: VerdictConfirm, Reason: "yes"}, res.Verdicts[0])
+	require.Equal(t, Verdict{LocalID: "C2", Verdict: VerdictDowngrade, Severity: "low", Reason: "minor"}, res.Verdicts[1])
+	require.Equal(t, VerdictConfirm, res.Verdicts[2].Verdict, "a raise is not a downgrade")
+	require.Equal(t, VerdictReject, res.Verdicts[3].Verdict)
+	for _, i := range []int{4, 5, 6} {
+		require.Error(t, res.Verdicts[i].Err, issues[i].Title)
+		require.Equal(t, VerdictConfirm, res.Verdicts[i].Verdict, "unverifiable issues are kept")
+	}
+
+	require.Equal(t, "verifier-model", res.Model)
+	require.Equal(t, 7, res.Rounds)
+	require.Equal(t, Usage{InputTokens: 600, OutputTokens: 60}, res.Usage, "the failed call reports no usage")
+	require.InDelta(t, 660e-6, res.CostUsd, 1e-12)
+
+	briefs := strings.Join(prov.seen, "\n")
+	require.Contains(t, briefs, "    45\tline\n", "context before the cited lines")
+	require.Contains(t, briefs, "    57\tline\n", "context after the cited lines")
+	require.NotContains(t, briefs, "    44\tline\n")
+	require.Contains(t, briefs, "     1\tline\n", "no lines: the head of the file")
+	require.Contains(t, briefs, "[файл недоступен: path escapes repository root")
+	require.Contains(t, briefs, "[файл не указан]")
+}
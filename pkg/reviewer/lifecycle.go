@@ -0,0 +1,107 @@
+package reviewer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"reviewsrv/pkg/db"
+)
+
+// Issue lifecycle across review versions of the same MR, i.e. reviews sharing (projectId, externalId).
+const (
+	IssueLifecycleNew        = "new"        // first seen in its review
+	IssueLifecyclePersisting = "persisting" // carried over from the previous version
+	IssueLifecycleFixed      = "fixed"      // gone from the next version
+)
+
+type VersionStats db.ReviewVersionStats
+
+// IssueFingerprint identifies an issue across versions of one MR. It hashes the
+// file, the issue type and the normalized title, leaving out line numbers,
+// severity and wording of the description, which drift between runs.
+func IssueFingerprint(iss *db.Issue) string {
+	h := sha256.New()
+	for _, part := range []string{iss.File, strings.ToLower(iss.IssueType), normalizeTitle(iss.Title)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// normalizeTitle lowercases s and keeps only its words: digits (line numbers,
+// counts) and punctuation are dropped, whitespace is collapsed.
+func normalizeTitle(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// fingerprint returns the stored fingerprint, computing it for issues saved
+// before fingerprints were introduced.
+func (i *Issue) fingerprint() string {
+	if i.Fingerprint != nil && *i.Fingerprint != "" {
+		return *i.Fingerprint
+	}
+	return IssueFingerprint(&i.Issue)
+}
+
+// Lifecycle returns the issue state: fixed when the next version no longer has
+// it, persisting when it continues an issue of the previous version, new otherwise.
+func (i *Issue) Lifecycle() string {
+	switch {
+	case i.FixedInReviewID != nil:
+		return IssueLifecycleFixed
+	case i.PreviousIssueID != nil:
+		return IssueLifecyclePersisting
+	default:
+		return IssueLifecycleNew
+	}
+}
+
+// linkIssueVersions pairs the issues of a new review version with the issues
+// of the previous one by fingerprint, each predecessor used at most once.
+// A matched issue is linked to its predecessor and inherits its feedback
+// (status, comment, reviewer, archiving); predecessors left unmatched are returned as fixed.
+func linkIssueVersions(prev Issues, rv *Review) (fixed []int, stats VersionStats) {
+	byFP := make(map[string][]*Issue, len(prev))
+	for i := range prev {
+		fp := prev[i].fingerprint()
+		byFP[fp] = append(byFP[fp], &prev[i])
+	}
+	linked := make(map[int]struct{}, len(prev))
+
+	for i := range rv.ReviewFiles {
+		for j := range rv.ReviewFiles[i].Issues {
+			iss := &rv.ReviewFiles[i].Issues[j]
+			candidates := byFP[iss.fingerprint()]
+			if len(candidates) == 0 {
+				stats.New++
+				continue
+			}
+			p := candidates[0]
+			byFP[iss.fingerprint()] = candidates[1:]
+			linked[p.ID] = struct{}{}
+
+			iss.PreviousIssueID = &p.ID
+			iss.StatusID = p.StatusID
+			iss.ProcessedAt = p.ProcessedAt
+			iss.UserID = p.UserID
+			iss.ArchivedAt = p.ArchivedAt
+			if iss.Comment == nil {
+				iss.Comment = p.Comment
+			}
+			stats.Persisting++
+		}
+	}
+
+	for _, p := range prev {
+		if _, ok := linked[p.ID]; !ok {
+			fixed = append(fixed, p.ID)
+		}
+	}
+	stats.Fixed = len(fixed)
+	return fixed, stats
+}
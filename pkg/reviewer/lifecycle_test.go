@@ -0,0 +1,90 @@
+package reviewer
+
+import (
+	"testing"
+
+	"reviewsrv/pkg/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueFingerprint(t *testing.T) {
+	base := db.Issue{File: "main.go", IssueType: "error-handling", Title: "Missing error check", Lines: "10-15", Severity: SeverityHigh}
+	fp := IssueFingerprint(&base)
+	assert.Len(t, fp, 64)
+
+	t.Run("stable across lines, severity and wording noise", func(t *testing.T) {
+		moved := base
+		moved.Lines = "42-47"
+		moved.Severity = SeverityMedium
+		moved.Description = "another description"
+		moved.Title = "  missing ERROR-check (42): "
+		moved.IssueType = "Error-Handling"
+		assert.Equal(t, fp, IssueFingerprint(&moved))
+	})
+
+	t.Run("differs by file, type and title", func(t *testing.T) {
+		for _, change := range []func(*db.Issue){
+			func(i *db.Issue) { i.File = "other.go" },
+			func(i *db.Issue) { i.IssueType = "naming" },
+			func(i *db.Issue) { i.Title = "Unchecked error" },
+		} {
+			other := base
+			change(&other)
+			assert.NotEqual(t, fp, IssueFingerprint(&other))
+		}
+	})
+}
+
+func TestIssue_Lifecycle(t *testing.T) {
+	id := 1
+	assert.Equal(t, IssueLifecycleNew, (&Issue{}).Lifecycle())
+	assert.Equal(t, IssueLifecyclePersisting, (&Issue{db.Issue{PreviousIssueID: &id}}).Lifecycle())
+	assert.Equal(t, IssueLifecycleFixed, (&Issue{db.Issue{PreviousIssueID: &id, FixedInReviewID: &id}}).Lifecycle())
+}
+
+func TestLinkIssueVersions(t *testing.T) {
+	comment := "known, tracked in JIRA-1"
+	prev := Issues{
+		{db.Issue{ID: 1, File: "main.go", IssueType: "error-handling", Title: "Missing error check", StatusID: db.StatusIgnored, Comment: &comment}},
+		{db.Issue{ID: 2, File: "db.go", IssueType: "security", Title: "SQL injection", StatusID: db.StatusValid}},
+		{db.Issue{ID: 3, File: "main.go", IssueType: "naming", Title: "Unused var", StatusID: db.StatusEnabled}},
+		// Legacy issue without a stored fingerprint: computed on the fly.
+		{db.Issue{ID: 4, File: "api.go", IssueType: "performance", Title: "N+1 query"}},
+	}
+	prev[0].Fingerprint = Ptr(IssueFingerprint(&prev[0].Issue))
+
+	rv := &Review{ReviewFiles: ReviewFiles{
+		{Issues: Issues{
+			{db.Issue{File: "main.go", IssueType: "error-handling", Title: "Missing error check!", StatusID: db.StatusEnabled}},
+			{db.Issue{File: "main.go", IssueType: "error-handling", Title: "Missing error check", StatusID: db.StatusEnabled}},
+			{db.Issue{File: "api.go", IssueType: "performance", Title: "N+1 query", StatusID: db.StatusEnabled}},
+		}},
+		{Issues: Issues{
+			{db.Issue{File: "handler.go", IssueType: "security", Title: "Missing auth check", StatusID: db.StatusEnabled}},
+		}},
+	}}
+
+	fixed, stats := linkIssueVersions(prev, rv)
+
+	assert.Equal(t, VersionStats{New: 2, Persisting: 2, Fixed: 2}, stats)
+	assert.Equal(t, []int{2, 3}, fixed)
+
+	first := rv.ReviewFiles[0].Issues[0]
+	require.NotNil(t, first.PreviousIssueID)
+	assert.Equal(t, 1, *first.PreviousIssueID)
+	assert.Equal(t, db.StatusIgnored, first.StatusID, "feedback carried over")
+	assert.Equal(t, &comment, first.Comment)
+	assert.Equal(t, IssueLifecyclePersisting, first.Lifecycle())
+
+	dup := rv.ReviewFiles[0].Issues[1]
+	assert.Nil(t, dup.PreviousIssueID, "a predecessor continues one issue only")
+	assert.Equal(t, db.StatusEnabled, dup.StatusID)
+
+	legacy := rv.ReviewFiles[0].Issues[2]
+	require.NotNil(t, legacy.PreviousIssueID)
+	assert.Equal(t, 4, *legacy.PreviousIssueID)
+
+	assert.Equal(t, IssueLifecycleNew, rv.ReviewFiles[1].Issues[0].Lifecycle())
+}
@@ -13,22 +13,25 @@ import (
 
 type ReviewManager struct {
 	db.TxManager
-	repo db.ReviewRepo
+	repo        db.ReviewRepo
+	projectRepo db.ProjectRepo
 }
 
 // NewReviewManager creates a new ReviewManager.
 func NewReviewManager(dbc db.DB) *ReviewManager {
 	return &ReviewManager{
-		TxManager: db.NewTxManager(&dbc),
-		repo:      db.NewReviewRepo(dbc).WithEnabledAndIssueFilters(),
+		TxManager:   db.NewTxManager(&dbc),
+		repo:        db.NewReviewRepo(dbc).WithEnabledAndIssueFilters(),
+		projectRepo: db.NewProjectRepo(dbc).WithEnabledOnly(),
 	}
 }
 
 func (rm *ReviewManager) runInLock(ctx context.Context, lockName string, fn func(rm *ReviewManager) error) error {
 	return rm.DB().RunInLock(ctx, lockName, func(tx *pg.Tx) error {
 		txRM := &ReviewManager{
-			TxManager: db.NewTxManager(rm.DB()),
-			repo:      rm.repo.WithTransaction(tx),
+			TxManager:   db.NewTxManager(rm.DB()),
+			repo:        rm.repo.WithTransaction(tx),
+			projectRepo: rm.projectRepo.WithTransaction(tx),
 		}
 		txRM.SetTx(tx)
 
@@ -68,7 +71,6 @@ func prepareReview(pr *Project, rv *Review) error {
 	rv.StatusID = db.StatusEnabled
 
 	seen := make(map[string]struct{}, len(rv.ReviewFiles))
-	var totalStats IssueStats
 	for i := range rv.ReviewFiles {
 		rt := rv.ReviewFiles[i].ReviewType
 		if _, ok := seen[rt]; ok {
@@ -78,18 +80,27 @@ func prepareReview(pr *Project, rv *Review) error {
 
 		rv.ReviewFiles[i].StatusID = db.StatusEnabled
 
+		for j := range rv.ReviewFiles[i].Issues {
+			iss := &rv.ReviewFiles[i].Issues[j]
+			iss.StatusID = db.StatusEnabled
+			iss.Fingerprint = Ptr(IssueFingerprint(&iss.Issue))
+		}
+	}
+	calcReviewStats(rv)
+
+	return nil
+}
+
+// calcReviewStats fills issue stats and traffic lights of rv and its files.
+func calcReviewStats(rv *Review) {
+	var totalStats IssueStats
+	for i := range rv.ReviewFiles {
 		stats := calcIssueStats(rv.ReviewFiles[i].Issues)
 		rv.ReviewFiles[i].IssueStats = db.ReviewFileIssueStats(stats)
 		rv.ReviewFiles[i].TrafficLight = calcTrafficLight(stats)
 		totalStats.Add(stats)
-
-		for j := range rv.ReviewFiles[i].Issues {
-			rv.ReviewFiles[i].Issues[j].StatusID = db.StatusEnabled
-		}
 	}
 	rv.TrafficLight = calcTrafficLight(totalStats)
-
-	return nil
 }
 
 // CreateReview prepares and saves a review with all files and issues in a transaction.
@@ -99,6 +110,23 @@ func (rm *ReviewManager) CreateReview(ctx context.Context, pr *Project, rv *Revi
 	}
 
 	err := rm.runInLock(ctx, pr.ProjectKey, func(txRM *ReviewManager) error {
+		fixed, err := txRM.linkPreviousVersion(ctx, rv)
+		if err != nil {
+			return err
+		}
+		if err := txRM.applySuppressionRules(ctx, rv); err != nil {
+			return err
+		}
+		if err := txRM.suppressAcceptedRisks(ctx, rv); err != nil {
+			return err
+		}
+		if err := txRM.resolvePromptExperiment(ctx, rv); err != nil {
+			return err
+		}
+		if err := txRM.resolvePromptRevision(ctx, rv); err != nil {
+			return err
+		}
+
 		if _, err := txRM.repo.AddReview(ctx, &rv.Review); err != nil {
 			return fmt.Errorf("add review: %w", err)
 		}
@@ -120,12 +148,98 @@ func (rm *ReviewManager) CreateReview(ctx context.Context, pr *Project, rv *Revi
 			}
 		}
 
-		return nil
+		return txRM.markIssuesFixed(ctx, fixed, rv.ID)
 	})
 
 	return rv, err
 }
 
+// linkPreviousVersion links rv to the latest review of the same MR, if any:
+// its issues continue the matching issues of that version (see linkIssueVersions)
+// and the version stats are filled. Returns the IDs of the previous issues fixed by rv.
+func (rm *ReviewManager) linkPreviousVersion(ctx context.Context, rv *Review) ([]int, error) {
+	if rv.ExternalID == "" {
+		return nil, nil
+	}
+
+	prev, err := rm.repo.ReviewsByFilters(ctx, &db.ReviewSearch{ProjectID: &rv.ProjectID, ExternalID: &rv.ExternalID}, db.PagerOne,
+		db.WithSort(db.SortField{Column: db.Columns.Review.ID, Direction: db.SortDesc}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("previous version: %w", err)
+	}
+	if len(prev) == 0 {
+		return nil, nil
+	}
+
+	prevIssues, err := rm.repo.IssuesByFilters(ctx, &db.IssueSearch{ReviewID: &prev[0].ID}, db.PagerNoLimit,
+		db.WithSort(db.SortField{Column: db.Columns.Issue.ID, Direction: db.SortAsc}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("previous version issues: %w", err)
+	}
+
+	fixed, stats := linkIssueVersions(NewIssues(prevIssues), rv)
+	rv.PreviousReviewID = &prev[0].ID
+	rv.VersionStats = (*db.ReviewVersionStats)(&stats)
+
+	return fixed, nil
+}
+
+// applySuppressionRules suppresses the issues of rv matching the project's
+// active suppression rules (SuppressionRule.Matches) and recomputes the review
+// stats without them.
+func (rm *ReviewManager) applySuppressionRules(ctx context.Context, rv *Review) error {
+	rules, err := rm.projectRepo.SuppressionRulesByFilters(ctx, &db.SuppressionRuleSearch{ProjectID: &rv.ProjectID}, db.PagerNoLimit,
+		db.WithSort(db.SortField{Column: db.Columns.SuppressionRule.ID, Direction: db.SortAsc}),
+	)
+	if err != nil {
+		return fmt.Errorf("suppression rules: %w", err)
+	}
+
+	if applySuppressionRules(activeRules(NewSuppressionRules(rules), time.Now()), rv) > 0 {
+		calcReviewStats(rv)
+	}
+	return nil
+}
+
+// suppressAcceptedRisks matches the issues of rv against the project's
+// non-archived false-positive and ignored issues (matching rules in
+// suppression.go) and recomputes the review stats without the suppressed ones.
+func (rm *ReviewManager) suppressAcceptedRisks(ctx context.Context, rv *Review) error {
+	search := &IssueSearch{
+		ProjectID:       &rv.ProjectID,
+		StatusIDs:       []int{db.StatusFalsePositive, db.StatusIgnored},
+		ExcludeArchived: true,
+		LatestOnly:      true,
+	}
+	risks, err := rm.repo.IssuesByFilters(ctx, search.ToDB(), db.NewPager(0, acceptedRiskLimit),
+		rm.repo.FullIssue(),
+		db.WithSort(db.SortField{Column: db.Columns.Issue.ID, Direction: db.SortDesc}),
+	)
+	if err != nil {
+		return fmt.Errorf("accepted risks: %w", err)
+	}
+
+	if suppressAcceptedRisks(NewIssues(risks), rv) > 0 {
+		calcReviewStats(rv)
+	}
+	return nil
+}
+
+// markIssuesFixed records that the issues are gone in review reviewID.
+func (rm *ReviewManager) markIssuesFixed(ctx context.Context, issueIDs []int, reviewID int) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+
+	_, err := rm.Conn().ExecContext(ctx, `UPDATE issues SET "fixedInReviewId" = ? WHERE "issueId" IN (?)`, reviewID, pg.In(issueIDs))
+	if err != nil {
+		return fmt.Errorf("mark fixed issues: %w", err)
+	}
+	return nil
+}
+
 type lastVersionResult struct {
 	ReviewID            int `pg:"reviewId"`
 	LastVersionReviewID int `pg:"lastVersionReviewId"`
@@ -336,13 +450,15 @@ func (rm *ReviewManager) ListIssuesByProject(ctx context.Context, search *IssueS
 const ProjectInstructionsIssueLimit = 500
 
 // ListIgnoredIssuesByProject returns up to ProjectInstructionsIssueLimit
-// non-archived ignored issues for a project, sorted by issueId ASC. The bool
+// non-archived ignored issues for a project, sorted by issueId ASC; an issue
+// persisting across review versions is listed once, by its latest copy. The bool
 // is true when the limit was hit (more issues exist beyond the returned page).
 func (rm *ReviewManager) ListIgnoredIssuesByProject(ctx context.Context, projectID int) (Issues, bool, error) {
 	search := &IssueSearch{
 		ProjectID:       &projectID,
 		StatusIDs:       []int{db.StatusIgnored},
 		ExcludeArchived: true,
+		LatestOnly:      true,
 	}
 	// Fetch limit+1 to detect overflow without a separate COUNT roundtrip.
 	dbIssues, err := rm.repo.IssuesByFilters(ctx, search.ToDB(), db.NewPager(0, ProjectInstructionsIssueLimit+1),
@@ -122,6 +122,147 @@ func TestDBReviewManager_CreateReview(t *testing.T) {
 	})
 }
 
+func TestDBReviewManager_CreateReviewVersions(t *testing.T) {
+	rm, dbc := newTestReviewManager(t)
+	ensureIssueStatuses(t, dbc)
+	pr, prCl := createTestProject(t, dbc)
+	t.Cleanup(prCl)
+	ctx := t.Context()
+
+	v1 := createTestReview(t, rm, pr)
+	cleanupReview(t, dbc, v1)
+	assert.Nil(t, v1.PreviousReviewID)
+	assert.Nil(t, v1.VersionStats)
+
+	errCheck := v1.ReviewFiles[0].Issues[0]
+	_, err := rm.SetFeedback(ctx, errCheck.ID, db.StatusIgnored)
+	require.NoError(t, err)
+	_, err = rm.SetComment(ctx, errCheck.ID, Ptr("by design"))
+	require.NoError(t, err)
+
+	v2 := &Review{
+		Review: db.Review{Title: "Test Review", ExternalID: v1.ExternalID, CommitHash: "def456", SourceBranch: "feature/test", TargetBranch: "main", Author: "tester"},
+		ReviewFiles: ReviewFiles{
+			{
+				ReviewFile: db.ReviewFile{ReviewType: ReviewTypeCode, Content: "code review content", Summary: "code summary"},
+				Issues: Issues{
+					{db.Issue{Title: "Missing error check", Severity: SeverityHigh, IssueType: "error-handling", Description: "desc", Content: "content", File: "main.go", Lines: "12-17"}},
+					{db.Issue{Title: "Leaked goroutine", Severity: SeverityMedium, IssueType: "concurrency", Description: "desc4", Content: "content4", File: "worker.go", Lines: "5"}},
+				},
+			},
+		},
+	}
+	v2, err = rm.CreateReview(ctx, pr, v2)
+	require.NoError(t, err)
+	cleanupReview(t, dbc, v2)
+
+	require.NotNil(t, v2.PreviousReviewID)
+	assert.Equal(t, v1.ID, *v2.PreviousReviewID)
+
+	t.Run("version stats", func(t *testing.T) {
+		got, err := rm.GetReview(ctx, v2.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.VersionStats)
+		assert.Equal(t, db.ReviewVersionStats{New: 1, Persisting: 1, Fixed: 2}, *got.VersionStats)
+	})
+
+	t.Run("persisting issue inherits feedback", func(t *testing.T) {
+		got, err := rm.IssueByID(ctx, v2.ReviewFiles[0].Issues[0].ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.PreviousIssueID)
+		assert.Equal(t, errCheck.ID, *got.PreviousIssueID)
+		assert.Equal(t, IssueLifecyclePersisting, got.Lifecycle())
+		assert.Equal(t, db.StatusIgnored, got.StatusID)
+		require.NotNil(t, got.Comment)
+		assert.Equal(t, "by design", *got.Comment)
+	})
+
+	t.Run("gone issues are fixed", func(t *testing.T) {
+		for _, iss := range []Issue{v1.ReviewFiles[0].Issues[1], v1.ReviewFiles[1].Issues[0]} {
+			got, err := rm.IssueByID(ctx, iss.ID)
+			require.NoError(t, err)
+			require.NotNil(t, got.FixedInReviewID)
+			assert.Equal(t, v2.ID, *got.FixedInReviewID)
+			assert.Equal(t, IssueLifecycleFixed, got.Lifecycle())
+		}
+	})
+
+	t.Run("ignored issue listed once", func(t *testing.T) {
+		got, _, err := rm.ListIgnoredIssuesByProject(ctx, pr.ID)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, v2.ReviewFiles[0].Issues[0].ID, got[0].ID)
+	})
+}
+
+func TestDBReviewManager_CreateReviewSuppressesAcceptedRisks(t *testing.T) {
+	rm, dbc := newTestReviewManager(t)
+	ensureIssueStatuses(t, dbc)
+	pr, prCl := createTestProject(t, dbc)
+	t.Cleanup(prCl)
+	ctx := t.Context()
+
+	first := createTestReview(t, rm, pr)
+	cleanupReview(t, dbc, first)
+	risk := first.ReviewFiles[1].Issues[0] // SQL injection in db.go
+	_, err := rm.SetFeedback(ctx, risk.ID, db.StatusFalsePositive)
+	require.NoError(t, err)
+
+	other := &Review{
+		Review: db.Review{Title: "Other MR", ExternalID: "MR-456", CommitHash: "fff000", SourceBranch: "feature/other", TargetBranch: "main", Author: "tester"},
+		ReviewFiles: ReviewFiles{
+			{
+				ReviewFile: db.ReviewFile{ReviewType: ReviewTypeSecurity, Content: "security review content", Summary: "security summary"},
+				Issues: Issues{
+					{db.Issue{LocalID: Ptr("S1"), Title: "SQL injection!", Severity: SeverityCritical, IssueType: "security", Description: "desc", Content: "content", File: "db.go", Lines: "70"}},
+					{db.Issue{LocalID: Ptr("S2"), Title: "Weak hash", Severity: SeverityMedium, IssueType: "security", Description: "desc", Content: "content", File: "auth.go", Lines: "9"}},
+				},
+			},
+		},
+	}
+	other, err = rm.CreateReview(ctx, pr, other)
+	require.NoError(t, err)
+	cleanupReview(t, dbc, other)
+
+	assert.Nil(t, other.PreviousReviewID, "another MR is not a version")
+	assert.Equal(t, map[string]int{"S1": risk.ID}, other.SuppressedLocalIDs())
+	assert.Equal(t, db.ReviewFileIssueStats{Medium: 1, Total: 1}, other.ReviewFiles[0].IssueStats)
+	assert.Equal(t, "green", other.TrafficLight, "suppressed critical issue does not count")
+
+	got, err := rm.IssueByID(ctx, other.ReviewFiles[0].Issues[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.SuppressedByIssueID)
+	assert.Equal(t, risk.ID, *got.SuppressedByIssueID)
+}
+
+func TestDBReviewManager_CreateReviewAppliesSuppressionRules(t *testing.T) {
+	rm, dbc := newTestReviewManager(t)
+	ensureIssueStatuses(t, dbc)
+	pr, prCl := createTestProject(t, dbc)
+	t.Cleanup(prCl)
+	ctx := t.Context()
+
+	rule, ruleCl := test.SuppressionRule(t, dbc, &db.SuppressionRule{ProjectID: pr.ID, PathGlob: Ptr("db.go"), MaxSeverity: Ptr(SeverityCritical)}, test.WithFakeSuppressionRule)
+	t.Cleanup(ruleCl)
+	_, expiredCl := test.SuppressionRule(t, dbc, &db.SuppressionRule{ProjectID: pr.ID, PathGlob: Ptr("*.go"), ExpiresAt: time.Now().Add(-time.Hour)}, test.WithFakeSuppressionRule)
+	t.Cleanup(expiredCl)
+
+	rv := createTestReview(t, rm, pr)
+	cleanupReview(t, dbc, rv)
+
+	sec := rv.ReviewFiles[1]
+	require.NotNil(t, sec.Issues[0].SuppressedByRuleID, "SQL injection in db.go")
+	assert.Equal(t, rule.ID, *sec.Issues[0].SuppressedByRuleID)
+	assert.Nil(t, rv.ReviewFiles[0].Issues[0].SuppressedByRuleID, "expired rule does not apply")
+	assert.Zero(t, sec.IssueStats.Total)
+	assert.Equal(t, "yellow", rv.TrafficLight, "only the high issue in main.go counts")
+
+	got, err := rm.IssueByID(ctx, sec.Issues[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.SuppressedByRuleID)
+	assert.Equal(t, rule.ID, *got.SuppressedByRuleID)
+}
+
 func TestDBReviewManager_GetReview(t *testing.T) {
 	rm, dbc := newTestReviewManager(t)
 	pr, prCl := createTestProject(t, dbc)
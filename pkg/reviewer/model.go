@@ -154,9 +154,13 @@ func (s *IssueStats) Add(other IssueStats) {
 	s.Total += other.Total
 }
 
+// calcIssueStats counts issues by severity, skipping suppressed ones.
 func calcIssueStats(issues Issues) IssueStats {
 	var s IssueStats
 	for _, iss := range issues {
+		if iss.IsSuppressed() {
+			continue
+		}
 		switch iss.Severity {
 		case "critical":
 			s.Critical++
@@ -204,6 +208,7 @@ type ReviewSearch struct {
 	Author       *string
 	TrafficLight *string
 	ExternalID   *string
+	SourceBranch *string
 	FromReviewID *int
 }
 
@@ -219,6 +224,7 @@ func (s *ReviewSearch) ToDB() *db.ReviewSearch {
 		AuthorILike:  s.Author,
 		TrafficLight: s.TrafficLight,
 		ExternalID:   s.ExternalID,
+		SourceBranch: s.SourceBranch,
 		IDLt:         s.FromReviewID,
 	}
 	return search
@@ -234,6 +240,9 @@ type IssueSearch struct {
 	IssueType       *string
 	ReviewType      *string
 	ExcludeArchived bool
+	// LatestOnly skips issues continued by a later review version, so an
+	// issue persisting across versions of an MR is listed once.
+	LatestOnly bool
 }
 
 // ToDB converts domain search params to the database layer representation.
@@ -259,6 +268,9 @@ func (s *IssueSearch) ToDB() *db.IssueSearch {
 		t := true
 		search.ArchivedAtIsNull = &t
 	}
+	if s.LatestOnly {
+		search.With(`NOT EXISTS (SELECT 1 FROM issues n WHERE n."previousIssueId" = ?.?)`, pg.Ident("t"), pg.Ident(db.Columns.Issue.ID))
+	}
 	return search
 }
 
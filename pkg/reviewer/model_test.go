@@ -206,10 +206,12 @@ func TestPrepareReview(t *testing.T) {
 		// total: 1 critical + 1 high + 1 low = red
 		assert.Equal(t, "red", rv.TrafficLight)
 
-		// issues get statusID
+		// issues get statusID and fingerprint
 		for _, rf := range rv.ReviewFiles {
 			for _, iss := range rf.Issues {
 				assert.Equal(t, db.StatusEnabled, iss.StatusID)
+				require.NotNil(t, iss.Fingerprint)
+				assert.Equal(t, IssueFingerprint(&iss.Issue), *iss.Fingerprint)
 			}
 		}
 	})
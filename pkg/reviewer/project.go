@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"reviewsrv/pkg/db"
 )
@@ -45,28 +46,68 @@ func (pm *ProjectManager) List(ctx context.Context) (Projects, error) {
 	return NewProjects(projects), nil
 }
 
+// ReviewPrompt is an assembled prompt with the prompt revision it was built from
+// and the experiment variant the merge request is assigned to.
+type ReviewPrompt struct {
+	Text         string
+	RevisionID   *int
+	ExperimentID *int
+	Variant      *string
+}
+
 // Prompt returns an assembled prompt for the project.
 func (pm *ProjectManager) Prompt(ctx context.Context, projectKey string) (string, error) {
+	rp, err := pm.ReviewPrompt(ctx, projectKey, "")
+	return rp.Text, err
+}
+
+// ReviewPrompt returns an assembled prompt for the project built from the latest prompt revision.
+// If the project runs a prompt experiment, the merge request with externalID is assigned to a variant
+// and variant B gets the experiment prompt. Requests without externalID always get the project prompt.
+func (pm *ProjectManager) ReviewPrompt(ctx context.Context, projectKey, externalID string) (ReviewPrompt, error) {
 	p, err := pm.repo.OneProject(ctx, &db.ProjectSearch{ProjectKey: &projectKey}, pm.repo.FullProject())
 	if err != nil {
-		return "", err
+		return ReviewPrompt{}, err
 	}
 
 	pr := NewProject(p)
-	if pr == nil {
-		return "", nil
+	if pr == nil || pr.Prompt == nil {
+		return ReviewPrompt{}, nil
+	}
+
+	var rp ReviewPrompt
+	if externalID != "" {
+		exp, err := pm.runningExperiment(ctx, pr.ID)
+		if err != nil {
+			return rp, fmt.Errorf("prompt experiment: %w", err)
+		} else if exp != nil && exp.VariantPrompt != nil {
+			rp.ExperimentID, rp.Variant = &exp.ID, Ptr(exp.Variant(externalID))
+			if *rp.Variant == PromptVariantB {
+				pr.PromptID, pr.Prompt = exp.VariantPromptID, exp.VariantPrompt
+			}
+		}
+	}
+
+	rev, err := latestPromptRevision(ctx, pm.repo, pr.PromptID)
+	if err != nil {
+		return rp, fmt.Errorf("prompt revision: %w", err)
+	} else if rev != nil {
+		rev.ApplyTo(pr.Prompt)
+		rp.RevisionID = &rev.ID
 	}
 
-	return pm.createPrompt(ctx, pr)
+	rp.Text, err = pm.createPrompt(ctx, pr)
+	return rp, err
 }
 
 // promptData is the data structure for the prompt template.
 type promptData struct {
-	Common        string
-	Instructions  string
-	Types         []promptType
-	FetchPrompt   string
-	AcceptedRisks Issues
+	Common           string
+	Instructions     string
+	Types            []promptType
+	FetchPrompt      string
+	AcceptedRisks    Issues
+	SuppressionRules SuppressionRules
 }
 
 type promptType struct {
@@ -104,11 +145,21 @@ func (pm *ProjectManager) createPrompt(ctx context.Context, pr *Project) (string
 		data.FetchPrompt = fp
 	}
 
+	// Both lists are kept: accepted risks are single issues a reviewer marked
+	// false positive or ignored in the issue list, with no owner or expiry, and
+	// they also drive upload-time suppression (suppressAcceptedRisks). Rules are
+	// the scoped, expiring replacement; a risk a rule covers is left out.
+	rules, err := pm.SuppressionRules(ctx, pr.ID)
+	if err != nil {
+		return "", fmt.Errorf("suppression rules: %w", err)
+	}
+	data.SuppressionRules = rules
+
 	risks, err := pm.acceptedRisks(ctx, pr.ID)
 	if err != nil {
 		return "", fmt.Errorf("accepted risks: %w", err)
 	}
-	data.AcceptedRisks = risks
+	data.AcceptedRisks = uncoveredRisks(risks, rules)
 
 	var b strings.Builder
 	if err := promptTemplate.Execute(&b, data); err != nil {
@@ -128,9 +179,32 @@ func (pm *ProjectManager) acceptedRisks(ctx context.Context, projectID int) (Iss
 	}
 
 	issues, err := pm.reviewRepo.IssuesByFilters(ctx, search, db.PagerNoLimit,
-		db.WithColumns(db.TableColumns, db.Columns.Issue.Review),
+		db.WithColumns(db.TableColumns, db.Columns.Issue.Review, db.Columns.Issue.ReviewFile),
 		db.WithSort(db.SortField{Column: db.Columns.Issue.ID, Direction: db.SortAsc}),
 	)
 
 	return NewIssues(issues), err
 }
+
+// SuppressionRules returns the project's suppression rules that have not expired yet, oldest first.
+func (pm *ProjectManager) SuppressionRules(ctx context.Context, projectID int) (SuppressionRules, error) {
+	rules, err := pm.repo.SuppressionRulesByFilters(ctx, &db.SuppressionRuleSearch{ProjectID: &projectID}, db.PagerNoLimit,
+		db.WithSort(db.SortField{Column: db.Columns.SuppressionRule.ID, Direction: db.SortAsc}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return activeRules(NewSuppressionRules(rules), time.Now()), nil
+}
+
+// ExpireSuppressionRules disables the suppression rules expired by now and
+// returns them with their projects and Slack channels for notification.
+func (pm *ProjectManager) ExpireSuppressionRules(ctx context.Context, now time.Time) (SuppressionRules, error) {
+	rules, err := pm.repo.DisableExpiredSuppressionRules(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSuppressionRules(rules), nil
+}
@@ -48,6 +48,17 @@ const promptTmpl = `# Шаг 1 — сделай ревью в виде MD фай
 В начале КАЖДОГО MD-файла напиши строку: "Задачи: TASK-1, TASK-2, ..." с ID всех проанализированных задач через запятую.
 {{- end}}
 
+{{- if .SuppressionRules}}
+
+## Правила подавления
+
+Команда приняла риски, описанные правилами ниже. НЕ создавай замечания, подпадающие под правило (path — glob файла, type — issueType, review — reviewType, title~ — регулярное выражение по заголовку, severity<= — максимальная severity):
+
+{{- range .SuppressionRules}}
+- {{.Scope}} — {{.ShortReason}}
+{{- end}}
+{{- end}}
+
 {{- if .AcceptedRisks}}
 
 ## Принятые риски
@@ -0,0 +1,334 @@
+package reviewer
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+
+	"reviewsrv/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// Prompt variants of an experiment.
+const (
+	PromptVariantA = "A" // control, the project prompt
+	PromptVariantB = "B" // the experiment prompt
+)
+
+// Experiment metrics compared between variants.
+const (
+	MetricFalsePositiveRate    = "falsePositiveRate"
+	MetricValidIssuesPerReview = "validIssuesPerReview"
+	MetricCostPerReview        = "costPerReview"
+	MetricUnfilledRate         = "unfilledRate"
+)
+
+// Significance indicators of a metric comparison.
+const (
+	SignificanceSignificant    = "significant"
+	SignificanceNotSignificant = "notSignificant"
+	SignificanceInsufficient   = "insufficientData"
+)
+
+const (
+	// significanceLevel is the p-value below which a difference is reported as significant.
+	significanceLevel = 0.05
+
+	// minExperimentSample is the smallest per-variant sample a metric is tested on.
+	minExperimentSample = 10
+)
+
+type PromptExperiment struct {
+	db.PromptExperiment
+}
+
+// NewPromptExperiment converts a db.PromptExperiment to the domain model, returning nil for nil input.
+func NewPromptExperiment(in *db.PromptExperiment) *PromptExperiment {
+	if in == nil {
+		return nil
+	}
+
+	return &PromptExperiment{
+		PromptExperiment: *in,
+	}
+}
+
+// Variant assigns a merge request to a variant by hashing the experiment and MR IDs,
+// so every version of the MR gets the same prompt and experiments split independently.
+func (e *PromptExperiment) Variant(externalID string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strconv.Itoa(e.ID) + ":" + externalID))
+	if int(h.Sum32()%100) < e.TrafficPercent {
+		return PromptVariantB
+	}
+
+	return PromptVariantA
+}
+
+// runningExperiment returns the newest enabled experiment of the project or nil.
+func (pm *ProjectManager) runningExperiment(ctx context.Context, projectID int) (*PromptExperiment, error) {
+	list, err := pm.repo.PromptExperimentsByFilters(ctx, &db.PromptExperimentSearch{ProjectID: &projectID, StatusID: Ptr(db.StatusEnabled)}, db.PagerOne,
+		pm.repo.FullPromptExperiment(), pm.repo.DefaultPromptExperimentSort())
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return NewPromptExperiment(&list[0]), nil
+}
+
+// resolvePromptExperiment keeps the uploaded experiment assignment only if the experiment is running
+// in the review project and the variant is the one the server assigns to the MR; otherwise the review
+// is left out of the experiment. A variant B review is pointed to the experiment prompt.
+func (rm *ReviewManager) resolvePromptExperiment(ctx context.Context, rv *Review) error {
+	if rv.PromptExperimentID == nil || rv.PromptVariant == nil || rv.ExternalID == "" {
+		rv.PromptExperimentID, rv.PromptVariant = nil, nil
+		return nil
+	}
+
+	exp, err := rm.projectRepo.OnePromptExperiment(ctx, &db.PromptExperimentSearch{ID: rv.PromptExperimentID, ProjectID: &rv.ProjectID})
+	if err != nil {
+		return fmt.Errorf("prompt experiment: %w", err)
+	}
+
+	switch {
+	case exp == nil, *rv.PromptVariant != NewPromptExperiment(exp).Variant(rv.ExternalID):
+		rv.PromptExperimentID, rv.PromptVariant = nil, nil
+	case *rv.PromptVariant == PromptVariantB:
+		rv.PromptID = exp.VariantPromptID
+	}
+
+	return nil
+}
+
+// ExperimentVariantStats contains stats of the enabled reviews of one experiment variant.
+// A merge request is one sample however many versions it has: ReviewCount counts MRs,
+// CostUsd sums all their versions, and issues and feedback count each issue once, by its
+// latest copy. Issues and feedback exclude suppressed issues, as in review stats.
+type ExperimentVariantStats struct {
+	Variant     string
+	ReviewCount int
+	IssueCount  int
+	Feedback    FeedbackStats
+	Unfilled    int
+	CostUsd     float64
+
+	// per-MR values for the variance of the means
+	valid []float64
+	costs []float64
+}
+
+// FalsePositiveRate returns the share of false positives among processed issues.
+func (s ExperimentVariantStats) FalsePositiveRate() float64 {
+	return s.Feedback.Ratio(s.Feedback.FalsePositive)
+}
+
+// ValidIssuesPerReview returns the average number of valid issues per review.
+func (s ExperimentVariantStats) ValidIssuesPerReview() float64 {
+	return s.perReview(float64(s.Feedback.Valid))
+}
+
+// CostPerReview returns the average review cost.
+func (s ExperimentVariantStats) CostPerReview() float64 {
+	return s.perReview(s.CostUsd)
+}
+
+// UnfilledRate returns the share of reviews uploaded with an unfilled review.json.
+func (s ExperimentVariantStats) UnfilledRate() float64 {
+	return s.perReview(float64(s.Unfilled))
+}
+
+func (s ExperimentVariantStats) perReview(v float64) float64 {
+	if s.ReviewCount == 0 {
+		return 0
+	}
+	return v / float64(s.ReviewCount)
+}
+
+// ExperimentComparison compares a metric of variant B against variant A.
+// PValue is two-sided and nil when the samples are too small to test.
+type ExperimentComparison struct {
+	Metric       string
+	A, B         float64
+	PValue       *float64
+	Significance string
+}
+
+// ExperimentReport compares the variants of a prompt experiment.
+type ExperimentReport struct {
+	Experiment  *PromptExperiment
+	A, B        ExperimentVariantStats
+	Comparisons []ExperimentComparison
+}
+
+// experimentReviewRow is one merge request of the experiment.
+type experimentReviewRow struct {
+	Variant       string  `pg:"variant"`
+	CostUsd       float64 `pg:"costUsd"`
+	IssueCount    int     `pg:"issueCount"`
+	Valid         int     `pg:"valid"`
+	FalsePositive int     `pg:"falsePositive"`
+	Ignored       int     `pg:"ignored"`
+	Unfilled      bool    `pg:"unfilled"`
+}
+
+// ExperimentReport returns per-variant stats of the experiment reviews with significance tests.
+// Every merge request is one sample (see ExperimentVariantStats) taking the variant of its latest
+// version. It counts as unfilled when that version has no issues and no non-blank file summary.
+func (rm *ReviewManager) ExperimentReport(ctx context.Context, exp *PromptExperiment) (*ExperimentReport, error) {
+	var rows []experimentReviewRow
+	_, err := rm.Conn().QueryContext(ctx, &rows, `
+		WITH rv AS (
+			SELECT r."reviewId", r."projectId", r."externalId", r."promptVariant",
+				coalesce((r."modelInfo"->>'costUsd')::numeric, 0) AS "costUsd",
+				row_number() OVER (PARTITION BY r."projectId", r."externalId" ORDER BY r."createdAt" DESC, r."reviewId" DESC) AS "rn"
+			FROM reviews r
+			WHERE r."promptExperimentId" = ? AND r."statusId" = ?
+		), mr AS (
+			SELECT "projectId", "externalId", sum("costUsd") AS "costUsd"
+			FROM rv
+			GROUP BY 1, 2
+		), iss AS (
+			SELECT rv."projectId", rv."externalId",
+				count(*) AS "issueCount",
+				count(*) FILTER (WHERE i."statusId" = ?) AS "valid",
+				count(*) FILTER (WHERE i."statusId" = ?) AS "falsePositive",
+				count(*) FILTER (WHERE i."statusId" = ?) AS "ignored"
+			FROM rv
+			JOIN issues i ON i."reviewId" = rv."reviewId"
+			WHERE i."statusId" IN (?)
+			AND i."suppressedByIssueId" IS NULL AND i."suppressedByRuleId" IS NULL
+			AND NOT EXISTS (SELECT 1 FROM issues n JOIN rv nv ON nv."reviewId" = n."reviewId" WHERE n."previousIssueId" = i."issueId")
+			GROUP BY 1, 2
+		)
+		SELECT l."promptVariant" AS "variant",
+			mr."costUsd",
+			coalesce(iss."issueCount", 0) AS "issueCount",
+			coalesce(iss."valid", 0) AS "valid",
+			coalesce(iss."falsePositive", 0) AS "falsePositive",
+			coalesce(iss."ignored", 0) AS "ignored",
+			NOT EXISTS (SELECT 1 FROM issues ai WHERE ai."reviewId" = l."reviewId")
+			AND NOT EXISTS (SELECT 1 FROM "reviewFiles" rf WHERE rf."reviewId" = l."reviewId" AND btrim(rf."summary") <> '') AS "unfilled"
+		FROM rv l
+		JOIN mr ON mr."projectId" = l."projectId" AND mr."externalId" = l."externalId"
+		LEFT JOIN iss ON iss."projectId" = l."projectId" AND iss."externalId" = l."externalId"
+		WHERE l."rn" = 1
+	`, exp.ID, db.StatusEnabled, db.StatusValid, db.StatusFalsePositive, db.StatusIgnored, pg.In(db.IssueStatusFilter.Value))
+	if err != nil {
+		return nil, err
+	}
+
+	return newExperimentReport(exp, rows), nil
+}
+
+// newExperimentReport aggregates per-MR rows by variant and compares the variants.
+func newExperimentReport(exp *PromptExperiment, rows []experimentReviewRow) *ExperimentReport {
+	r := &ExperimentReport{
+		Experiment: exp,
+		A:          ExperimentVariantStats{Variant: PromptVariantA},
+		B:          ExperimentVariantStats{Variant: PromptVariantB},
+	}
+
+	for _, row := range rows {
+		s := &r.A
+		if row.Variant == PromptVariantB {
+			s = &r.B
+		}
+
+		s.ReviewCount++
+		s.IssueCount += row.IssueCount
+		s.Feedback.Valid += row.Valid
+		s.Feedback.FalsePositive += row.FalsePositive
+		s.Feedback.Ignored += row.Ignored
+		s.CostUsd += row.CostUsd
+		if row.Unfilled {
+			s.Unfilled++
+		}
+		s.valid = append(s.valid, float64(row.Valid))
+		s.costs = append(s.costs, row.CostUsd)
+	}
+
+	a, b := r.A, r.B
+	r.Comparisons = []ExperimentComparison{
+		compareProportions(MetricFalsePositiveRate, a.Feedback.FalsePositive, a.Feedback.Processed(), b.Feedback.FalsePositive, b.Feedback.Processed()),
+		compareMeans(MetricValidIssuesPerReview, a.valid, b.valid),
+		compareMeans(MetricCostPerReview, a.costs, b.costs),
+		compareProportions(MetricUnfilledRate, a.Unfilled, a.ReviewCount, b.Unfilled, b.ReviewCount),
+	}
+
+	return r
+}
+
+// compareProportions compares shares xA/nA and xB/nB with a two-proportion z-test.
+func compareProportions(metric string, xA, nA, xB, nB int) ExperimentComparison {
+	c := ExperimentComparison{Metric: metric, A: ratio(xA, nA), B: ratio(xB, nB)}
+	if nA < minExperimentSample || nB < minExperimentSample {
+		return c.withPValue(nil)
+	}
+
+	p := float64(xA+xB) / float64(nA+nB)
+	se := math.Sqrt(p * (1 - p) * (1/float64(nA) + 1/float64(nB)))
+	return c.withPValue(twoSidedPValue(c.B-c.A, se))
+}
+
+// compareMeans compares sample means with Welch's test in the normal approximation.
+func compareMeans(metric string, a, b []float64) ExperimentComparison {
+	meanA, varA := meanVariance(a)
+	meanB, varB := meanVariance(b)
+	c := ExperimentComparison{Metric: metric, A: meanA, B: meanB}
+	if len(a) < minExperimentSample || len(b) < minExperimentSample {
+		return c.withPValue(nil)
+	}
+
+	se := math.Sqrt(varA/float64(len(a)) + varB/float64(len(b)))
+	return c.withPValue(twoSidedPValue(meanB-meanA, se))
+}
+
+func (c ExperimentComparison) withPValue(p *float64) ExperimentComparison {
+	c.PValue = p
+	switch {
+	case p == nil:
+		c.Significance = SignificanceInsufficient
+	case *p < significanceLevel:
+		c.Significance = SignificanceSignificant
+	default:
+		c.Significance = SignificanceNotSignificant
+	}
+	return c
+}
+
+// twoSidedPValue returns the p-value of the difference for a normal statistic, nil for a zero standard error.
+func twoSidedPValue(diff, se float64) *float64 {
+	if se == 0 {
+		return nil
+	}
+	p := math.Erfc(math.Abs(diff/se) / math.Sqrt2)
+	return &p
+}
+
+// meanVariance returns the mean and the sample variance of xs.
+func meanVariance(xs []float64) (mean, variance float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	if len(xs) < 2 {
+		return mean, 0
+	}
+	for _, x := range xs {
+		variance += (x - mean) * (x - mean)
+	}
+	return mean, variance / float64(len(xs)-1)
+}
+
+func ratio(x, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return float64(x) / float64(n)
+}
@@ -0,0 +1,213 @@
+package reviewer
+
+import (
+	"strconv"
+	"testing"
+
+	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/db/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptExperiment_Variant(t *testing.T) {
+	exp := &PromptExperiment{db.PromptExperiment{ID: 7, TrafficPercent: 30}}
+
+	b := 0
+	for i := range 1000 {
+		v := exp.Variant(strconv.Itoa(i))
+		assert.Equal(t, v, exp.Variant(strconv.Itoa(i)), "assignment must be stable")
+		if v == PromptVariantB {
+			b++
+		}
+	}
+	assert.InDelta(t, 300, b, 60)
+
+	exp.TrafficPercent = 0
+	assert.Equal(t, PromptVariantA, exp.Variant("42"))
+	exp.TrafficPercent = 100
+	assert.Equal(t, PromptVariantB, exp.Variant("42"))
+}
+
+func TestCompareProportions(t *testing.T) {
+	c := compareProportions(MetricFalsePositiveRate, 30, 100, 10, 100)
+	assert.InDelta(t, 0.3, c.A, 1e-9)
+	assert.InDelta(t, 0.1, c.B, 1e-9)
+	require.NotNil(t, c.PValue)
+	assert.Less(t, *c.PValue, 0.001)
+	assert.Equal(t, SignificanceSignificant, c.Significance)
+
+	c = compareProportions(MetricFalsePositiveRate, 30, 100, 28, 100)
+	require.NotNil(t, c.PValue)
+	assert.Greater(t, *c.PValue, 0.5)
+	assert.Equal(t, SignificanceNotSignificant, c.Significance)
+
+	c = compareProportions(MetricUnfilledRate, 1, 5, 0, 100)
+	assert.Nil(t, c.PValue)
+	assert.Equal(t, SignificanceInsufficient, c.Significance)
+
+	// no variance: both variants have no unfilled reviews
+	c = compareProportions(MetricUnfilledRate, 0, 20, 0, 20)
+	assert.Nil(t, c.PValue)
+	assert.Equal(t, SignificanceInsufficient, c.Significance)
+}
+
+func TestCompareMeans(t *testing.T) {
+	repeat := func(vs ...float64) []float64 {
+		var out []float64
+		for range 5 {
+			out = append(out, vs...)
+		}
+		return out
+	}
+
+	c := compareMeans(MetricCostPerReview, repeat(1, 2, 3), repeat(3, 4, 5))
+	assert.InDelta(t, 2, c.A, 1e-9)
+	assert.InDelta(t, 4, c.B, 1e-9)
+	require.NotNil(t, c.PValue)
+	assert.Equal(t, SignificanceSignificant, c.Significance)
+
+	c = compareMeans(MetricCostPerReview, repeat(1, 2, 3), repeat(1, 2, 3))
+	require.NotNil(t, c.PValue)
+	assert.InDelta(t, 1, *c.PValue, 1e-9)
+	assert.Equal(t, SignificanceNotSignificant, c.Significance)
+
+	c = compareMeans(MetricValidIssuesPerReview, []float64{1, 2}, repeat(1, 2, 3))
+	assert.InDelta(t, 1.5, c.A, 1e-9)
+	assert.Nil(t, c.PValue)
+	assert.Equal(t, SignificanceInsufficient, c.Significance)
+}
+
+func TestNewExperimentReport(t *testing.T) {
+	r := newExperimentReport(&PromptExperiment{}, []experimentReviewRow{
+		{Variant: PromptVariantA, CostUsd: 1, IssueCount: 3, Valid: 1, FalsePositive: 2},
+		{Variant: PromptVariantA, CostUsd: 3, Unfilled: true},
+		{Variant: PromptVariantB, CostUsd: 2, IssueCount: 2, Valid: 2, Ignored: 1},
+	})
+
+	assert.Equal(t, 2, r.A.ReviewCount)
+	assert.Equal(t, 3, r.A.IssueCount)
+	assert.Equal(t, FeedbackStats{Valid: 1, FalsePositive: 2}, r.A.Feedback)
+	assert.Equal(t, 1, r.A.Unfilled)
+	assert.InDelta(t, 2.0/3, r.A.FalsePositiveRate(), 1e-9)
+	assert.InDelta(t, 0.5, r.A.ValidIssuesPerReview(), 1e-9)
+	assert.InDelta(t, 2, r.A.CostPerReview(), 1e-9)
+	assert.InDelta(t, 0.5, r.A.UnfilledRate(), 1e-9)
+
+	assert.Equal(t, 1, r.B.ReviewCount)
+	assert.Equal(t, FeedbackStats{Valid: 2, Ignored: 1}, r.B.Feedback)
+	assert.Zero(t, r.B.FalsePositiveRate())
+
+	require.Len(t, r.Comparisons, 4)
+	for _, c := range r.Comparisons {
+		assert.Equal(t, SignificanceInsufficient, c.Significance, c.Metric)
+	}
+	assert.Equal(t, MetricValidIssuesPerReview, r.Comparisons[1].Metric)
+	assert.InDelta(t, 2, r.Comparisons[1].B, 1e-9)
+}
+
+func TestDBPromptExperiment(t *testing.T) {
+	rm, dbc := newTestReviewManager(t)
+	ensureIssueStatuses(t, dbc)
+	pr, prCl := createTestProject(t, dbc)
+	t.Cleanup(prCl)
+	ctx := t.Context()
+
+	exp, expCl := test.PromptExperiment(t, dbc, &db.PromptExperiment{ProjectID: pr.ID, TrafficPercent: 100}, test.WithPromptExperimentRelations, test.WithFakePromptExperiment)
+	t.Cleanup(expCl)
+
+	t.Run("review prompt assigns variant", func(t *testing.T) {
+		pm := NewProjectManager(dbc)
+
+		rp, err := pm.ReviewPrompt(ctx, pr.ProjectKey, "MR-123")
+		require.NoError(t, err)
+		require.NotNil(t, rp.ExperimentID)
+		assert.Equal(t, exp.ID, *rp.ExperimentID)
+		assert.Equal(t, PromptVariantB, *rp.Variant)
+		assert.Contains(t, rp.Text, exp.VariantPrompt.Common)
+
+		rp, err = pm.ReviewPrompt(ctx, pr.ProjectKey, "")
+		require.NoError(t, err)
+		assert.Nil(t, rp.ExperimentID)
+		assert.NotContains(t, rp.Text, exp.VariantPrompt.Common)
+	})
+
+	t.Run("upload resolves assignment", func(t *testing.T) {
+		rv := &Review{}
+		rv.ProjectID, rv.PromptID, rv.ExternalID = pr.ID, pr.PromptID, "MR-7"
+		rv.PromptExperimentID, rv.PromptVariant = &exp.ID, Ptr(PromptVariantB)
+		require.NoError(t, rm.resolvePromptExperiment(ctx, rv))
+		assert.Equal(t, exp.VariantPromptID, rv.PromptID)
+
+		rv.PromptVariant = Ptr("C")
+		require.NoError(t, rm.resolvePromptExperiment(ctx, rv))
+		assert.Nil(t, rv.PromptExperimentID)
+		assert.Nil(t, rv.PromptVariant)
+
+		rv.ProjectID = pr.ID + 1
+		rv.PromptExperimentID, rv.PromptVariant = &exp.ID, Ptr(PromptVariantB)
+		require.NoError(t, rm.resolvePromptExperiment(ctx, rv))
+		assert.Nil(t, rv.PromptExperimentID, "foreign experiment")
+	})
+
+	t.Run("upload rejects a variant the server did not assign", func(t *testing.T) {
+		rv := &Review{}
+		rv.ProjectID, rv.PromptID, rv.ExternalID = pr.ID, pr.PromptID, "MR-7"
+		// The experiment sends all traffic to B, so a claimed A is a mismatch.
+		rv.PromptExperimentID, rv.PromptVariant = &exp.ID, Ptr(PromptVariantA)
+		require.NoError(t, rm.resolvePromptExperiment(ctx, rv))
+		assert.Nil(t, rv.PromptExperimentID)
+		assert.Nil(t, rv.PromptVariant)
+		assert.Equal(t, pr.PromptID, rv.PromptID)
+
+		rv.ExternalID = ""
+		rv.PromptExperimentID, rv.PromptVariant = &exp.ID, Ptr(PromptVariantB)
+		require.NoError(t, rm.resolvePromptExperiment(ctx, rv))
+		assert.Nil(t, rv.PromptExperimentID, "no MR to assign")
+	})
+
+	t.Run("report", func(t *testing.T) {
+		rv := &Review{}
+		rv.PromptExperimentID, rv.PromptVariant = &exp.ID, Ptr(PromptVariantB)
+		rv.Title, rv.ExternalID, rv.DurationMS = "Experiment review", "MR-1", 1000
+		rv.ReviewFiles = ReviewFiles{{
+			ReviewFile: db.ReviewFile{ReviewType: ReviewTypeCode, Content: "content", Summary: "summary"},
+			Issues:     Issues{{db.Issue{Title: "Bug", Severity: SeverityHigh, IssueType: "bug", Description: "d", Content: "c", File: "main.go", Lines: "1"}}},
+		}}
+		created, err := rm.CreateReview(ctx, pr, rv)
+		require.NoError(t, err)
+		cleanupReview(t, dbc, created)
+		_, err = rm.SetFeedback(ctx, created.ReviewFiles[0].Issues[0].ID, db.StatusFalsePositive)
+		require.NoError(t, err)
+
+		report, err := rm.ExperimentReport(ctx, NewPromptExperiment(exp))
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.B.ReviewCount)
+		assert.Equal(t, FeedbackStats{FalsePositive: 1}, report.B.Feedback)
+		assert.Zero(t, report.B.Unfilled)
+		assert.Zero(t, report.A.ReviewCount)
+
+		// A second version of the MR is the same sample: its persisting issue
+		// counts once and the cost of both versions adds up.
+		v2 := &Review{}
+		v2.PromptExperimentID, v2.PromptVariant = &exp.ID, Ptr(PromptVariantB)
+		v2.Title, v2.ExternalID, v2.CommitHash, v2.DurationMS = "Experiment review", "MR-1", "def456", 1000
+		v2.ReviewFiles = ReviewFiles{{
+			ReviewFile: db.ReviewFile{ReviewType: ReviewTypeCode, Content: "content", Summary: "summary"},
+			Issues:     Issues{{db.Issue{Title: "Bug", Severity: SeverityHigh, IssueType: "bug", Description: "d", Content: "c", File: "main.go", Lines: "1"}}},
+		}}
+		v2, err = rm.CreateReview(ctx, pr, v2)
+		require.NoError(t, err)
+		cleanupReview(t, dbc, v2)
+		_, err = dbc.ExecContext(ctx, `UPDATE reviews SET "modelInfo" = '{"costUsd": 1.5}' WHERE "reviewId" IN (?, ?)`, created.ID, v2.ID)
+		require.NoError(t, err)
+
+		report, err = rm.ExperimentReport(ctx, NewPromptExperiment(exp))
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.B.ReviewCount)
+		assert.Equal(t, 1, report.B.IssueCount)
+		assert.Equal(t, FeedbackStats{FalsePositive: 1}, report.B.Feedback)
+		assert.InDelta(t, 3, report.B.CostUsd, 1e-9)
+	})
+}
@@ -0,0 +1,351 @@
+package reviewer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"reviewsrv/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// Prompt sections stored in every revision, in prompt order.
+const (
+	PromptSectionCommon       = "common"
+	PromptSectionArchitecture = "architecture"
+	PromptSectionCode         = "code"
+	PromptSectionSecurity     = "security"
+	PromptSectionTests        = "tests"
+	PromptSectionOperability  = "operability"
+)
+
+// Diff operations of a DiffLine.
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// maxDiffCells limits the LCS table of DiffLines; larger inputs are diffed as full replacement.
+const maxDiffCells = 4_000_000
+
+var (
+	PromptSections            = []string{PromptSectionCommon, PromptSectionArchitecture, PromptSectionCode, PromptSectionSecurity, PromptSectionTests, PromptSectionOperability}
+	ErrPromptNotFound         = errors.New("prompt not found")
+	ErrPromptRevisionNotFound = errors.New("prompt revision not found")
+)
+
+type PromptRevision struct {
+	db.PromptRevision
+}
+
+// NewPromptRevision converts a db.PromptRevision to the domain model, returning nil for nil input.
+func NewPromptRevision(in *db.PromptRevision) *PromptRevision {
+	if in == nil {
+		return nil
+	}
+
+	return &PromptRevision{
+		PromptRevision: *in,
+	}
+}
+
+// newDBPromptRevision snapshots the prompt texts.
+func newDBPromptRevision(p *db.Prompt, userID *int) *db.PromptRevision {
+	return &db.PromptRevision{
+		PromptID:     p.ID,
+		Common:       p.Common,
+		Architecture: p.Architecture,
+		Code:         p.Code,
+		Security:     p.Security,
+		Tests:        p.Tests,
+		Operability:  p.Operability,
+		UserID:       userID,
+	}
+}
+
+// Section returns the text of the named prompt section.
+func (r *PromptRevision) Section(name string) string {
+	switch name {
+	case PromptSectionCommon:
+		return r.Common
+	case PromptSectionArchitecture:
+		return r.Architecture
+	case PromptSectionCode:
+		return r.Code
+	case PromptSectionSecurity:
+		return r.Security
+	case PromptSectionTests:
+		return r.Tests
+	case PromptSectionOperability:
+		return r.Operability
+	}
+	return ""
+}
+
+// SameTexts reports whether the revision holds exactly the prompt texts.
+func (r *PromptRevision) SameTexts(p *db.Prompt) bool {
+	return r.Common == p.Common && r.Architecture == p.Architecture && r.Code == p.Code &&
+		r.Security == p.Security && r.Tests == p.Tests && r.Operability == p.Operability
+}
+
+// ApplyTo copies the revision texts into the prompt.
+func (r *PromptRevision) ApplyTo(p *db.Prompt) {
+	p.Common, p.Architecture, p.Code = r.Common, r.Architecture, r.Code
+	p.Security, p.Tests, p.Operability = r.Security, r.Tests, r.Operability
+}
+
+// DiffLine is a single line of a line-based diff.
+type DiffLine struct {
+	Op   string
+	Text string
+}
+
+// PromptSectionDiff is a line diff of one prompt section between two revisions.
+type PromptSectionDiff struct {
+	Section string
+	Changed bool
+	Lines   []DiffLine
+}
+
+// DiffPromptRevisions returns per-section line diffs from one revision to another.
+func DiffPromptRevisions(from, to *PromptRevision) []PromptSectionDiff {
+	res := make([]PromptSectionDiff, 0, len(PromptSections))
+	for _, s := range PromptSections {
+		a, b := from.Section(s), to.Section(s)
+		res = append(res, PromptSectionDiff{Section: s, Changed: a != b, Lines: DiffLines(a, b)})
+	}
+	return res
+}
+
+// DiffLines returns a line diff from a to b based on the longest common subsequence.
+func DiffLines(a, b string) []DiffLine {
+	al, bl := splitLines(a), splitLines(b)
+	n, m := len(al), len(bl)
+
+	if n*m > maxDiffCells {
+		res := make([]DiffLine, 0, n+m)
+		for _, l := range al {
+			res = append(res, DiffLine{Op: DiffDelete, Text: l})
+		}
+		for _, l := range bl {
+			res = append(res, DiffLine{Op: DiffInsert, Text: l})
+		}
+		return res
+	}
+
+	// lcs[i][j] is the LCS length of al[i:] and bl[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	res := make([]DiffLine, 0, max(n, m))
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case al[i] == bl[j]:
+			res = append(res, DiffLine{Op: DiffEqual, Text: al[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			res = append(res, DiffLine{Op: DiffDelete, Text: al[i]})
+			i++
+		default:
+			res = append(res, DiffLine{Op: DiffInsert, Text: bl[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		res = append(res, DiffLine{Op: DiffDelete, Text: al[i]})
+	}
+	for ; j < m; j++ {
+		res = append(res, DiffLine{Op: DiffInsert, Text: bl[j]})
+	}
+
+	return res
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// PromptManager saves prompts and keeps their immutable revision history.
+type PromptManager struct {
+	db.TxManager
+	repo db.ProjectRepo
+}
+
+// NewPromptManager creates a new PromptManager.
+func NewPromptManager(dbc db.DB) *PromptManager {
+	return &PromptManager{
+		TxManager: db.NewTxManager(&dbc),
+		repo:      db.NewProjectRepo(dbc),
+	}
+}
+
+func (pm *PromptManager) runInTx(ctx context.Context, fn func(pm *PromptManager) error) error {
+	return pm.DB().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		txPM := &PromptManager{
+			TxManager: db.NewTxManager(pm.DB()),
+			repo:      pm.repo.WithTransaction(tx),
+		}
+		txPM.SetTx(tx)
+
+		return fn(txPM)
+	})
+}
+
+// Add creates the prompt together with its first revision.
+func (pm *PromptManager) Add(ctx context.Context, prompt *db.Prompt, userID *int) (*db.Prompt, error) {
+	err := pm.runInTx(ctx, func(pm *PromptManager) (err error) {
+		if prompt, err = pm.repo.AddPrompt(ctx, prompt); err != nil {
+			return err
+		}
+		_, err = pm.repo.AddPromptRevision(ctx, newDBPromptRevision(prompt, userID))
+		return err
+	})
+
+	return prompt, err
+}
+
+// Update saves the prompt and records a new revision if its texts differ from the latest one.
+func (pm *PromptManager) Update(ctx context.Context, prompt *db.Prompt, userID *int) (ok bool, err error) {
+	err = pm.runInTx(ctx, func(pm *PromptManager) error {
+		// the row lock taken by UPDATE serializes concurrent saves of the same prompt
+		if ok, err = pm.repo.UpdatePrompt(ctx, prompt); err != nil || !ok {
+			return err
+		}
+		_, err = pm.snapshot(ctx, prompt, userID)
+		return err
+	})
+
+	return ok, err
+}
+
+// Rollback restores the prompt texts from the given revision. The restored texts are recorded
+// as a new revision, so the history stays append-only.
+func (pm *PromptManager) Rollback(ctx context.Context, promptID, revisionID int, userID *int) (rev *PromptRevision, err error) {
+	err = pm.runInTx(ctx, func(pm *PromptManager) error {
+		target, err := pm.Revision(ctx, promptID, revisionID)
+		if err != nil {
+			return err
+		}
+
+		prompt, err := pm.repo.PromptByID(ctx, promptID)
+		if err != nil {
+			return err
+		} else if prompt == nil {
+			return ErrPromptNotFound
+		}
+
+		target.ApplyTo(prompt)
+		_, err = pm.repo.UpdatePrompt(ctx, prompt, db.WithColumns(
+			db.Columns.Prompt.Common, db.Columns.Prompt.Architecture, db.Columns.Prompt.Code,
+			db.Columns.Prompt.Security, db.Columns.Prompt.Tests, db.Columns.Prompt.Operability,
+		))
+		if err != nil {
+			return err
+		}
+
+		rev, err = pm.snapshot(ctx, prompt, userID)
+		return err
+	})
+
+	return rev, err
+}
+
+// snapshot adds a revision with the prompt texts unless the latest revision already holds them.
+func (pm *PromptManager) snapshot(ctx context.Context, prompt *db.Prompt, userID *int) (*PromptRevision, error) {
+	latest, err := pm.LatestRevision(ctx, prompt.ID)
+	if err != nil || (latest != nil && latest.SameTexts(prompt)) {
+		return latest, err
+	}
+
+	rev, err := pm.repo.AddPromptRevision(ctx, newDBPromptRevision(prompt, userID))
+	return NewPromptRevision(rev), err
+}
+
+// Revision returns the revision of the prompt. It returns ErrPromptRevisionNotFound
+// if the revision does not exist or belongs to another prompt.
+func (pm *PromptManager) Revision(ctx context.Context, promptID, revisionID int) (*PromptRevision, error) {
+	rev, err := pm.repo.OnePromptRevision(ctx, &db.PromptRevisionSearch{ID: &revisionID, PromptID: &promptID}, pm.repo.FullPromptRevision())
+	if err != nil {
+		return nil, err
+	} else if rev == nil {
+		return nil, ErrPromptRevisionNotFound
+	}
+
+	return NewPromptRevision(rev), nil
+}
+
+// LatestRevision returns the newest revision of the prompt or nil.
+func (pm *PromptManager) LatestRevision(ctx context.Context, promptID int) (*PromptRevision, error) {
+	return latestPromptRevision(ctx, pm.repo, promptID)
+}
+
+// Revisions returns all revisions of the prompt, newest first.
+func (pm *PromptManager) Revisions(ctx context.Context, promptID int) (PromptRevisions, error) {
+	list, err := pm.repo.PromptRevisionsByFilters(ctx, &db.PromptRevisionSearch{PromptID: &promptID}, db.PagerNoLimit,
+		pm.repo.FullPromptRevision(), pm.repo.DefaultPromptRevisionSort())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPromptRevisions(list), nil
+}
+
+// Diff returns per-section line diffs between two revisions of the prompt.
+func (pm *PromptManager) Diff(ctx context.Context, promptID, fromID, toID int) ([]PromptSectionDiff, error) {
+	from, err := pm.Revision(ctx, promptID, fromID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := pm.Revision(ctx, promptID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffPromptRevisions(from, to), nil
+}
+
+// resolvePromptRevision keeps the uploaded prompt revision only if it belongs to the review prompt.
+// Clients may send stale or foreign IDs, and a wrong link is worse than none.
+func (rm *ReviewManager) resolvePromptRevision(ctx context.Context, rv *Review) error {
+	if rv.PromptRevisionID == nil {
+		return nil
+	}
+
+	rev, err := rm.projectRepo.OnePromptRevision(ctx, &db.PromptRevisionSearch{ID: rv.PromptRevisionID, PromptID: &rv.PromptID})
+	if err != nil {
+		return fmt.Errorf("prompt revision: %w", err)
+	} else if rev == nil {
+		rv.PromptRevisionID = nil
+	}
+
+	return nil
+}
+
+func latestPromptRevision(ctx context.Context, repo db.ProjectRepo, promptID int) (*PromptRevision, error) {
+	list, err := repo.PromptRevisionsByFilters(ctx, &db.PromptRevisionSearch{PromptID: &promptID}, db.PagerOne, repo.DefaultPromptRevisionSort())
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return NewPromptRevision(&list[0]), nil
+}
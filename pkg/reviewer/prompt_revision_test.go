@@ -0,0 +1,100 @@
+package reviewer
+
+import (
+	"testing"
+
+	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/db/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLines(t *testing.T) {
+	got := DiffLines("a\nb\nc\n", "a\nc\nd")
+	assert.Equal(t, []DiffLine{
+		{Op: DiffEqual, Text: "a"},
+		{Op: DiffDelete, Text: "b"},
+		{Op: DiffEqual, Text: "c"},
+		{Op: DiffInsert, Text: "d"},
+	}, got)
+
+	assert.Empty(t, DiffLines("", ""))
+	assert.Equal(t, []DiffLine{{Op: DiffInsert, Text: "x"}}, DiffLines("", "x"))
+	assert.Equal(t, []DiffLine{{Op: DiffDelete, Text: "x"}}, DiffLines("x", ""))
+}
+
+func TestDiffPromptRevisions(t *testing.T) {
+	from := &PromptRevision{db.PromptRevision{Common: "c", Code: "old"}}
+	to := &PromptRevision{db.PromptRevision{Common: "c", Code: "new"}}
+
+	diff := DiffPromptRevisions(from, to)
+	require.Len(t, diff, len(PromptSections))
+	for _, d := range diff {
+		assert.Equal(t, d.Section == PromptSectionCode, d.Changed, d.Section)
+	}
+	assert.Equal(t, []DiffLine{{Op: DiffDelete, Text: "old"}, {Op: DiffInsert, Text: "new"}}, diff[2].Lines)
+}
+
+func cleanupPromptRevisions(t *testing.T, dbc db.DB, promptID int) {
+	t.Cleanup(func() {
+		_, _ = dbc.ModelContext(t.Context(), (*db.PromptRevision)(nil)).Where(`?TableAlias.? = ?`, db.Columns.PromptRevision.PromptID, promptID).Delete()
+	})
+}
+
+func TestDBPromptManager(t *testing.T) {
+	dbc, _ := test.Setup(t)
+	pm := NewPromptManager(dbc)
+	ctx := t.Context()
+
+	prompt, err := pm.Add(ctx, &db.Prompt{Title: "Revisioned", Common: "common", Code: "code v1", StatusID: db.StatusEnabled}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = dbc.ModelContext(t.Context(), &db.Prompt{ID: prompt.ID}).WherePK().Delete() })
+	cleanupPromptRevisions(t, dbc, prompt.ID)
+
+	first, err := pm.LatestRevision(ctx, prompt.ID)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	assert.Equal(t, "code v1", first.Code)
+
+	// title-only saves do not create revisions
+	prompt.Title = "Revisioned prompt"
+	ok, err := pm.Update(ctx, prompt, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	prompt.Code = "code v2"
+	_, err = pm.Update(ctx, prompt, nil)
+	require.NoError(t, err)
+
+	revs, err := pm.Revisions(ctx, prompt.ID)
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+	assert.Equal(t, "code v2", revs[0].Code)
+	assert.Equal(t, first.ID, revs[1].ID)
+
+	diff, err := pm.Diff(ctx, prompt.ID, first.ID, revs[0].ID)
+	require.NoError(t, err)
+	assert.True(t, diff[2].Changed)
+	assert.False(t, diff[0].Changed)
+
+	_, err = pm.Diff(ctx, prompt.ID+1, first.ID, revs[0].ID)
+	require.ErrorIs(t, err, ErrPromptRevisionNotFound)
+
+	rolled, err := pm.Rollback(ctx, prompt.ID, first.ID, nil)
+	require.NoError(t, err)
+	assert.Greater(t, rolled.ID, revs[0].ID)
+	assert.Equal(t, "code v1", rolled.Code)
+
+	t.Run("review prompt uses latest revision", func(t *testing.T) {
+		pr, clPr := test.Project(t, dbc, &db.Project{PromptID: prompt.ID, StatusID: db.StatusEnabled}, test.WithProjectRelations, test.WithFakeProject)
+		t.Cleanup(clPr)
+
+		rp, err := NewProjectManager(dbc).ReviewPrompt(t.Context(), pr.ProjectKey, "")
+		require.NoError(t, err)
+		require.NotNil(t, rp.RevisionID)
+		assert.Equal(t, rolled.ID, *rp.RevisionID)
+		assert.Contains(t, rp.Text, "code v1")
+		assert.NotContains(t, rp.Text, "code v2")
+	})
+}
@@ -40,6 +40,8 @@ type ClaudeResult struct {
 	PermissionDenials []any                     `json:"permission_denials"`
 	Usage             ClaudeUsage               `json:"usage"`
 	ModelUsage        map[string]ClaudeModelUse `json:"modelUsage"`
+	// ToolUsage is filled by the direct runner only; the CLIs do not report it.
+	ToolUsage map[string]db.ToolUseStats `json:"toolUsage,omitempty"`
 }
 
 // ClaudeUsage captures aggregated token usage across all models.
@@ -182,6 +184,7 @@ func (cr *ClaudeResult) ToModelInfo(model string) db.ReviewModelInfo {
 		StopReason:               cr.StopReason,
 		TerminalReason:           cr.TerminalReason,
 		IsError:                  cr.IsError,
+		Tools:                    cr.ToolUsage,
 	}
 
 	if len(cr.ModelUsage) > 0 {
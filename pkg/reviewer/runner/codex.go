@@ -11,6 +11,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"reviewsrv/pkg/reviewer/direct"
 )
 
 // Compile-time assertion that ExecCodexRunner satisfies ReviewRunner.
@@ -30,8 +32,9 @@ var _ ReviewRunner = (*ExecCodexRunner)(nil)
 type ExecCodexRunner struct {
 	Model           string
 	Dir             string
-	SessionID       string // if set, resumes the thread via `exec resume <id>`
-	ContinueSession bool   // codex has no auto-continue; kept for interface symmetry
+	SessionID       string          // if set, resumes the thread via `exec resume <id>`
+	ContinueSession bool            // codex has no auto-continue; kept for interface symmetry
+	Catalog         *direct.Catalog // prices the run; nil = direct.DefaultCatalog
 	Log             *slog.Logger
 }
 
@@ -94,7 +97,7 @@ func (r *ExecCodexRunner) Run(ctx context.Context, prompt string) (*ClaudeResult
 		return nil, errors.New("codex produced empty output")
 	}
 
-	cr := ParseCodexResult(out.stdout.Bytes(), r.Model)
+	cr := ParseCodexResult(out.stdout.Bytes(), r.Model, r.Catalog)
 	// codex can report a structured failure with a zero exit code; conversely a
 	// non-zero exit without a structured error is still a failure.
 	if out.err != nil {
@@ -246,14 +249,14 @@ func (a *codexAggregate) applyLine(line []byte) {
 	}
 }
 
-func (a *codexAggregate) toClaudeResult(fallbackModel string) *ClaudeResult {
+func (a *codexAggregate) toClaudeResult(fallbackModel string, cat *direct.Catalog) *ClaudeResult {
 	// codex input_tokens include cached; split so InputTokens is the fresh count.
 	cached := a.cached
 	if cached > a.inputTotal {
 		cached = a.inputTotal
 	}
 	freshInput := a.inputTotal - cached
-	cost := codexEstimateCostUSD(fallbackModel, freshInput, a.output, cached)
+	cost := codexEstimateCostUSD(cat, fallbackModel, freshInput, a.output, cached)
 
 	stop, subtype := "end_turn", directSubtypeSuccess
 	if a.isError {
@@ -292,8 +295,9 @@ func (a *codexAggregate) toClaudeResult(fallbackModel string) *ClaudeResult {
 }
 
 // ParseCodexResult aggregates the codex `--json` event stream into a ClaudeResult.
-// The fallback model names the run for cost estimation (codex reports no cost).
-func ParseCodexResult(data []byte, fallbackModel string) *ClaudeResult {
+// The fallback model names the run for cost estimation at the prices of cat
+// (codex reports no cost; nil = direct.DefaultCatalog).
+func ParseCodexResult(data []byte, fallbackModel string, cat *direct.Catalog) *ClaudeResult {
 	var agg codexAggregate
 	sc := bufio.NewScanner(bytes.NewReader(data))
 	// Events can be large (long text chunks). Bump the buffer to 4 MiB to be safe.
@@ -305,40 +309,14 @@ func ParseCodexResult(data []byte, fallbackModel string) *ClaudeResult {
 		}
 		agg.applyLine(line)
 	}
-	return agg.toClaudeResult(fallbackModel)
-}
-
-// codexTokenPrice is the USD-per-MTok rate for a codex/OpenAI model. cached input
-// is a subset of input (the caller passes fresh and cached separately).
-type codexTokenPrice struct {
-	input, cachedInput, output float64
+	return agg.toClaudeResult(fallbackModel, cat)
 }
 
-// codexModelPrices mirrors published OpenAI/codex token rates
-// (USD per 1M tokens). Unknown models estimate to 0 (cost reported as 0).
-var codexModelPrices = map[string]codexTokenPrice{
-	"codex-default":     {1.75, 0.175, 14.00},
-	"gpt-5.3-codex":     {1.75, 0.175, 14.00},
-	"gpt-5.2-codex":     {1.75, 0.175, 14.00},
-	"gpt-5.1-codex-max": {1.25, 0.125, 10.00},
-	"gpt-5.1-codex":     {1.25, 0.125, 10.00},
-	"gpt-5-codex":       {1.25, 0.125, 10.00},
-	"gpt-5.5":           {5.00, 0.50, 30.00},
-	"gpt-5.5-pro":       {30.00, 30.00, 180.00},
-	"gpt-5.4":           {2.50, 0.25, 15.00},
-	"gpt-5.4-mini":      {0.75, 0.075, 4.50},
-	"gpt-5.4-nano":      {0.20, 0.02, 1.25},
-	"gpt-5.4-pro":       {30.00, 30.00, 180.00},
-}
-
-// codexEstimateCostUSD estimates a codex run's cost from tokens. freshInput must
-// exclude cached tokens (cached is billed at the lower cachedInput rate).
-func codexEstimateCostUSD(model string, freshInput, output, cached int) float64 {
-	p, ok := codexModelPrices[strings.TrimSpace(model)]
-	if !ok {
-		return 0
-	}
-	return (float64(freshInput)*p.input +
-		float64(cached)*p.cachedInput +
-		float64(output)*p.output) / 1_000_000
+// codexEstimateCostUSD estimates a codex run's cost from tokens at the
+// catalogue prices of model (nil = direct.DefaultCatalog); unknown models
+// estimate to 0. freshInput must exclude cached tokens (cached is billed at the
+// lower cache-read rate).
+func codexEstimateCostUSD(cat *direct.Catalog, model string, freshInput, output, cached int) float64 {
+	spec, _ := cat.Lookup(model)
+	return spec.Cost(direct.Usage{InputTokens: freshInput, OutputTokens: output, CacheReadTokens: cached})
 }
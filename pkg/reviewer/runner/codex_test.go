@@ -3,6 +3,8 @@ package runner
 import (
 	"testing"
 
+	"reviewsrv/pkg/reviewer/direct"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,7 +14,7 @@ func TestParseCodexResult(t *testing.T) {
 {"type":"item.completed","item":{"type":"agent_message","text":"done"}}
 {"type":"turn.completed","usage":{"input_tokens":1000,"cached_input_tokens":200,"output_tokens":50}}
 `
-	cr := ParseCodexResult([]byte(stream), "gpt-5-codex")
+	cr := ParseCodexResult([]byte(stream), "gpt-5-codex", nil)
 	require.Equal(t, "th_abc", cr.SessionID)
 	require.Equal(t, "done", cr.Result)
 	require.Equal(t, 1, cr.NumTurns)
@@ -29,7 +31,7 @@ func TestParseCodexResultError(t *testing.T) {
 	stream := `{"type":"thread.started","thread_id":"th_x"}
 {"type":"turn.failed","error":{"message":"sandbox denied"}}
 `
-	cr := ParseCodexResult([]byte(stream), "")
+	cr := ParseCodexResult([]byte(stream), "", nil)
 	require.True(t, cr.IsError)
 	require.Equal(t, "error", cr.Subtype)
 	require.Equal(t, "sandbox denied", cr.Result)
@@ -60,8 +62,12 @@ func TestExecCodexRunnerBuildArgs(t *testing.T) {
 }
 
 func TestCodexEstimateCostUSD(t *testing.T) {
-	require.InDelta(t, 0.001525, codexEstimateCostUSD("gpt-5-codex", 800, 50, 200), 1e-9)
-	require.Zero(t, codexEstimateCostUSD("unknown-model", 1000, 100, 0))
+	require.InDelta(t, 0.001525, codexEstimateCostUSD(nil, "gpt-5-codex", 800, 50, 200), 1e-9)
+	require.Zero(t, codexEstimateCostUSD(nil, "unknown-model", 1000, 100, 0))
+
+	// A catalogue override prices models the defaults don't know.
+	cat := direct.DefaultCatalog().Merge(&direct.Catalog{Models: []direct.ModelSpec{{Match: "gpt-6", InputPerMTok: 10, OutputPerMTok: 40}}})
+	require.InDelta(t, 0.014, codexEstimateCostUSD(cat, "gpt-6-codex", 1000, 100, 0), 1e-9)
 }
 
 func TestExecCodexRunnerName(t *testing.T) {
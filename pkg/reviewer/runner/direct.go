@@ -3,18 +3,26 @@ package runner
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
+	"reviewsrv/pkg/db"
 	"reviewsrv/pkg/reviewer/direct"
 )
 
 // directSessionLog is the transcript file written next to the run for analysis.
 const directSessionLog = "direct-output.jsonl"
 
+// batchRunnerTimeout caps a run whose rounds go through a batch endpoint: a
+// batch job may wait up to a day before it is processed.
+const batchRunnerTimeout = 25 * time.Hour
+
 // ClaudeResult.Subtype values for a direct run.
 const (
 	directSubtypeSuccess = "success"
@@ -35,57 +43,84 @@ type DirectRunner struct {
 	DiffBase string // git_diff default base (target branch)
 	DiffHead string // git_diff default head (source branch)
 	Effort   string
-	Log      *slog.Logger
+	// Catalog sizes the context when the provider reports no window (e.g. a
+	// replayed cassette); nil = direct.DefaultCatalog.
+	Catalog *direct.Catalog
+	// StreamIdleTimeout aborts a round whose response stream stalls; zero keeps
+	// the loop default. Independent of runnerTimeout, which caps the whole run.
+	StreamIdleTimeout time.Duration
+	// Linters are the project-configured analyzers offered via run_linter;
+	// LintPreRun also runs them up front and puts their findings in the kickoff.
+	Linters    []direct.Linter
+	LintPreRun bool
+	// MCPServers are external MCP tool servers mounted into every review loop.
+	MCPServers []direct.MCPServer
+	// ToolLimits caps individual tools per run (direct.ParseToolLimits).
+	ToolLimits map[string]direct.ToolLimit
+	// SubAgents reviews with a planner and parallel sub-agents (direct.RunAgents)
+	// instead of a single loop.
+	SubAgents bool
+	// SingleShot reviews without tools in one structured-output answer
+	// (direct.RunSingleShot) — for tool-less prompts and batch runs.
+	SingleShot bool
+	// Structured reviews without tools in one structured-output answer per
+	// group (direct.RunStructured) — for models with weak function calling.
+	Structured bool
+	// SessionID resumes that saved session; ContinueSession the latest one.
+	// Sessions live in SessionDir (default direct.DefaultSessionDir).
+	SessionID       string
+	ContinueSession bool
+	SessionDir      string
+	Log             *slog.Logger
 }
 
 // Name implements ReviewRunner.
 func (r *DirectRunner) Name() string { return RunnerDirect }
 
-// SetSession implements ReviewRunner. The direct runner has no CLI session, so
-// this is a no-op — the Step-2 retry path is unnecessary because submit_review
-// always produces a filled review.json.
-func (r *DirectRunner) SetSession(string) {}
+// SetSession implements ReviewRunner: the next Run resumes the saved session
+// with this ID (the Step-2 retry path) instead of starting a new review.
+func (r *DirectRunner) SetSession(sessionID string) {
+	r.SessionID = sessionID
+	r.ContinueSession = false
+}
 
 // Run executes the agent loop and maps the result onto ClaudeResult.
 func (r *DirectRunner) Run(ctx context.Context, prompt string) (*ClaudeResult, error) {
 	// Cap the whole run like the CLI runners do (runnerTimeout), so a hung API
 	// or runaway loop can't stall a CI job when the caller passed no deadline.
-	ctx, cancel := context.WithTimeout(ctx, runnerTimeout)
+	// Batch jobs may legitimately queue for hours.
+	timeout := runnerTimeout
+	if _, batch := r.Provider.(*direct.BatchProvider); batch {
+		timeout = batchRunnerTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Pre-load the diff and the full content of changed files into the kickoff so
-	// the model reviews from them instead of fanning out one read_file per turn.
-	// The pre-loaded paths seed read-dedup so the model isn't re-served them.
-	preloadBlock, preloadedPaths := direct.PreloadContext(ctx, r.Dir, r.DiffBase, r.DiffHead)
-
-	reg := direct.NewReviewRegistry(direct.ReviewToolsConfig{
-		Dir:            r.Dir,
-		DiffBase:       r.DiffBase,
-		DiffHead:       r.DiffHead,
-		PreloadedPaths: preloadedPaths,
-	})
+	prov, closeCassette := r.recordCassette(ctx)
+	defer closeCassette()
+	limits := direct.LimitsOf(prov, r.Catalog)
 
-	// Rebuild the AST index so ast_* tools see the current working tree. No-op if
-	// ast-index isn't installed. Best-effort — a failure must not fail the review.
-	if ran, err := direct.EnsureAstIndex(ctx, r.Dir); err != nil && r.Log != nil {
-		r.Log.WarnContext(ctx, "ast-index rebuild failed; ast_* tools may be stale", "err", err)
-	} else if ran && r.Log != nil {
-		r.Log.InfoContext(ctx, "ast-index rebuilt")
+	sess, err := r.loadSession(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	opts := direct.DefaultOptions()
+	opts := direct.DefaultOptions().WithLimits(limits)
 	opts.Effort = r.Effort
+	if r.StreamIdleTimeout > 0 {
+		opts.StreamIdleTimeout = r.StreamIdleTimeout
+	}
 
 	// Stream the session transcript to <dir>/direct-output.jsonl for later
-	// analysis (mirrors claude-output.json / opencode-output.jsonl). Best-effort:
-	// a log open failure must not fail the review.
-	if closeLog := r.attachSessionLog(ctx, &opts); closeLog != nil {
+	// analysis (mirrors claude-output.json / opencode-output.jsonl); a resumed
+	// session appends to it. Best-effort: a log open failure must not fail the
+	// review.
+	if closeLog := r.attachSessionLog(ctx, &opts, sess != nil); closeLog != nil {
 		defer closeLog()
 	}
-
-	userPrompt := prompt
-	if preloadBlock != "" {
-		userPrompt = prompt + "\n\n" + preloadBlock
+	mcp := r.connectMCP(ctx, opts.OnEvent)
+	if mcp != nil {
+		defer func() { _ = mcp.Close() }()
 	}
 
 	// The fetched reviewsrv prompt is the authoritative review task (project,
@@ -93,7 +128,24 @@ func (r *DirectRunner) Run(ctx context.Context, prompt string) (*ClaudeResult, e
 	// like the claude/opencode runners. SystemPrompt is only the generic
 	// execution contract (tools + submit_review), not project/language specifics.
 	start := time.Now()
-	res, err := direct.Run(ctx, r.Provider, reg, direct.SystemPrompt, userPrompt, opts)
+	var res *direct.Result
+	switch {
+	case r.SingleShot:
+		_, userPrompt := r.kickoff(ctx, prompt, limits, nil)
+		res, err = direct.RunSingleShot(ctx, prov, r.Dir, userPrompt, opts)
+	case r.Structured:
+		_, userPrompt := r.kickoff(ctx, prompt, limits, nil)
+		res, err = direct.RunStructured(ctx, prov, r.Dir, userPrompt, opts)
+	case r.SubAgents:
+		tools, userPrompt := r.kickoff(ctx, prompt, limits, mcp)
+		res, err = direct.RunAgents(ctx, prov, tools, userPrompt, opts, direct.DefaultAgentOptions())
+	case sess != nil:
+		res, err = direct.RunSession(ctx, prov, r.resumeTools(sess, mcp), r.sessions(), sess, prompt, opts)
+	default:
+		tools, userPrompt := r.kickoff(ctx, prompt, limits, mcp)
+		sess = direct.NewSession(prompt, tools.PreloadedPaths)
+		res, err = direct.RunSession(ctx, prov, tools, r.sessions(), sess, userPrompt, opts)
+	}
 	elapsedMs := int(time.Since(start).Milliseconds())
 	if res == nil {
 		return nil, err
@@ -103,28 +155,190 @@ func (r *DirectRunner) Run(ctx context.Context, prompt string) (*ClaudeResult, e
 	cr := directToClaudeResult(res)
 	cr.DurationMs = elapsedMs
 	cr.DurationAPIMs = res.DurationAPIMs // provider time only, not local tool time
+	if sess != nil {
+		cr.SessionID = sess.ID
+	}
 
 	if err != nil {
+		if r.Log != nil && sess != nil {
+			r.Log.ErrorContext(ctx, "direct: run failed; resume it with --session", "sessionId", sess.ID, "stopReason", res.StopReason, "err", err)
+		}
 		return cr, err
 	}
 	if !res.Submitted {
+		if sess == nil { // sub-agent or tool-less run: nothing to resume
+			if r.Log != nil {
+				r.Log.ErrorContext(ctx, "direct: review not submitted", "stopReason", res.StopReason, "rounds", res.Rounds)
+			}
+			return cr, fmt.Errorf("direct: review not submitted (stop=%s)", res.StopReason)
+		}
+		// Like a CLI runner that ends without filling review.json: the skeleton
+		// stays on disk and the controller's Step-2 retry resumes the session.
 		if r.Log != nil {
-			r.Log.ErrorContext(ctx, "direct: review not submitted", "stopReason", res.StopReason, "rounds", res.Rounds)
+			r.Log.WarnContext(ctx, "direct: review not submitted", "stopReason", res.StopReason, "rounds", res.Rounds, "sessionId", sess.ID)
 		}
-		return cr, fmt.Errorf("direct: review not submitted (stop=%s)", res.StopReason)
 	}
 	return cr, nil
 }
 
-// attachSessionLog opens the transcript file and wires opts.OnEvent to it.
+// sessions is the store for resumable single-loop runs.
+func (r *DirectRunner) sessions() direct.SessionStore {
+	if r.SessionDir != "" {
+		return direct.SessionStore{Dir: r.SessionDir}
+	}
+	return direct.SessionStore{Dir: direct.DefaultSessionDir()}
+}
+
+// loadSession returns the session to resume: the one named by SessionID, or
+// the latest one for ContinueSession; nil starts a new review. Sub-agent,
+// single-shot and structured runs are not resumable.
+func (r *DirectRunner) loadSession(ctx context.Context) (*direct.Session, error) {
+	if r.SessionID == "" && !r.ContinueSession {
+		return nil, nil
+	}
+	if r.SubAgents || r.SingleShot || r.Structured {
+		if r.Log != nil {
+			r.Log.WarnContext(ctx, "direct: sub-agent and tool-less runs are not resumable; starting a new review")
+		}
+		return nil, nil
+	}
+	var sess *direct.Session
+	var err error
+	if r.SessionID != "" {
+		sess, err = r.sessions().Load(r.SessionID)
+	} else {
+		sess, err = r.sessions().Latest()
+	}
+	switch {
+	case errors.Is(err, direct.ErrNoSession):
+		if r.Log != nil {
+			r.Log.WarnContext(ctx, "direct: no session to continue; starting a new review")
+		}
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("direct: resume session: %w", err)
+	}
+	if r.Log != nil {
+		r.Log.InfoContext(ctx, "direct: resuming session", "sessionId", sess.ID, "model", sess.Model,
+			"stopReason", sess.StopReason, "submitted", sess.Submitted)
+	}
+	return sess, nil
+}
+
+// kickoff builds the tool config and the first user message of a new review.
+// The diff and the full content of changed files are pre-loaded so the model
+// reviews from them instead of fanning out one read_file per turn; the
+// pre-loaded paths seed read-dedup so the model isn't re-served them. The
+// preload is sized to the model's context window, like the compaction threshold.
+func (r *DirectRunner) kickoff(ctx context.Context, prompt string, limits direct.ModelLimits, mcp *direct.MCPTools) (direct.ReviewToolsConfig, string) {
+	preloadBlock, preloadedPaths := direct.PreloadContext(ctx, r.Dir, r.DiffBase, r.DiffHead, limits.PreloadTokens())
+	lintBlock, lintResults := r.preRunLinters(ctx, limits)
+	r.ensureAstIndex(ctx)
+
+	userPrompt := prompt
+	for _, block := range []string{preloadBlock, lintBlock} {
+		if block != "" {
+			userPrompt += "\n\n" + block
+		}
+	}
+	tools := direct.ReviewToolsConfig{
+		Dir:            r.Dir,
+		DiffBase:       r.DiffBase,
+		DiffHead:       r.DiffHead,
+		PreloadedPaths: preloadedPaths,
+		Linters:        r.Linters,
+		LinterResults:  lintResults,
+		MCP:            mcp,
+		ToolLimits:     r.ToolLimits,
+	}
+	return tools, userPrompt
+}
+
+// resumeTools is the tool config of a resumed session: the kickoff is already
+// in its history, so nothing is pre-loaded again.
+func (r *DirectRunner) resumeTools(sess *direct.Session, mcp *direct.MCPTools) direct.ReviewToolsConfig {
+	return direct.ReviewToolsConfig{
+		Dir:            r.Dir,
+		DiffBase:       r.DiffBase,
+		DiffHead:       r.DiffHead,
+		PreloadedPaths: sess.Preloaded,
+		Linters:        r.Linters,
+		MCP:            mcp,
+		ToolLimits:     r.ToolLimits,
+	}
+}
+
+// connectMCP connects the configured MCP servers for the run; nil when none
+// are configured. A server that fails is reported in the transcript and the
+// log, and the review goes on without it.
+func (r *DirectRunner) connectMCP(ctx context.Context, sink direct.Sink) *direct.MCPTools {
+	if len(r.MCPServers) == 0 {
+		return nil
+	}
+	return direct.ConnectMCP(ctx, r.Dir, r.MCPServers, sink)
+}
+
+// ensureAstIndex rebuilds the AST index so ast_* tools see the current working
+// tree. No-op if ast-index isn't installed. Best-effort — a failure must not
+// fail the review.
+func (r *DirectRunner) ensureAstIndex(ctx context.Context) {
+	if ran, err := direct.EnsureAstIndex(ctx, r.Dir); err != nil && r.Log != nil {
+		r.Log.WarnContext(ctx, "ast-index rebuild failed; ast_* tools may be stale", "err", err)
+	} else if ran && r.Log != nil {
+		r.Log.InfoContext(ctx, "ast-index rebuilt")
+	}
+}
+
+// preRunLinters runs the configured linters up front when LintPreRun is set.
+// Their findings go next to the preload so the model can cite them instead of
+// re-deriving them; the outputs seed run_linter's cache. The block gets a
+// quarter of the preload budget.
+func (r *DirectRunner) preRunLinters(ctx context.Context, limits direct.ModelLimits) (string, map[string]string) {
+	if !r.LintPreRun || len(r.Linters) == 0 {
+		return "", nil
+	}
+	block, results := direct.PreRunLinters(ctx, r.Dir, r.DiffBase, r.DiffHead, r.Linters, limits.PreloadTokens()/4)
+	if r.Log != nil {
+		r.Log.InfoContext(ctx, "direct: linters pre-run", "linters", len(r.Linters), "ok", len(results))
+	}
+	return block, results
+}
+
+// recordCassette wraps the provider in a recorder writing <dir>/direct-cassette.jsonl,
+// so any run — a customer-reported bad review included — can be replayed offline
+// with --replay. Not when already replaying; best-effort like the transcript.
+func (r *DirectRunner) recordCassette(ctx context.Context) (direct.LLMProvider, func()) {
+	inner := r.Provider
+	if bp, ok := inner.(*direct.BatchProvider); ok {
+		inner = bp.Unwrap()
+	}
+	if _, replaying := inner.(*direct.CassettePlayer); replaying {
+		return r.Provider, func() {}
+	}
+	rec, err := direct.NewCassetteRecorder(r.Provider, filepath.Join(r.Dir, direct.CassetteFile))
+	if err != nil {
+		if r.Log != nil {
+			r.Log.WarnContext(ctx, "direct: cassette not recorded", "err", err)
+		}
+		return r.Provider, func() {}
+	}
+	return rec, func() { _ = rec.Close() }
+}
+
+// attachSessionLog opens the transcript file (appending for a resumed session)
+// and wires opts.OnEvent to it.
 // Returns a close func (nil if the log could not be opened). Each event is one
 // JSON line. The loop emits events from a single goroutine, so no locking is
-// needed around the encoder.
-func (r *DirectRunner) attachSessionLog(ctx context.Context, opts *direct.Options) func() {
+// needed around the encoder or the progress tracker.
+func (r *DirectRunner) attachSessionLog(ctx context.Context, opts *direct.Options, appendTo bool) func() {
 	var enc *json.Encoder
 	closeFn := func() {}
 	path := filepath.Join(r.Dir, directSessionLog)
-	if f, err := os.Create(path); err != nil {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendTo {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	if f, err := os.OpenFile(path, flags, 0o644); err != nil {
 		if r.Log != nil {
 			r.Log.WarnContext(ctx, "direct: cannot open session log", "path", path, "err", err)
 		}
@@ -133,8 +347,18 @@ func (r *DirectRunner) attachSessionLog(ctx context.Context, opts *direct.Option
 		closeFn = func() { _ = f.Close() }
 	}
 	// Write the full transcript to the file (when open) AND surface significant
-	// events to the runner log, so a CI run shows live progress.
+	// events to the runner log, so a CI run shows live progress. Streamed deltas
+	// only feed the progress lines: the round's assistant/tool_call events
+	// already carry the assembled output, so the file stays readable.
+	prog := &streamProgress{}
 	opts.OnEvent = func(ev direct.Event) {
+		if prog.observe(ev, time.Now()) {
+			r.logProgress(ctx, prog)
+			return
+		}
+		if isDeltaEvent(ev.Kind) {
+			return
+		}
 		if enc != nil {
 			_ = enc.Encode(ev)
 		}
@@ -150,18 +374,101 @@ func (r *DirectRunner) logEvent(ctx context.Context, ev direct.Event) {
 	if r.Log == nil {
 		return
 	}
+	log := r.Log
+	if ev.Agent != "" {
+		log = log.With("agent", ev.Agent) // sub-agent run: loops interleave
+	}
 	switch ev.Kind {
 	case "tool_call":
-		r.Log.InfoContext(ctx, "direct tool", "round", ev.Round, "tool", ev.Tool, "args", truncate(string(ev.Args), 200))
+		log.InfoContext(ctx, "direct tool", "round", ev.Round, "tool", ev.Tool, "args", truncate(string(ev.Args), 200))
 	case "round":
 		if ev.Usage != nil {
-			r.Log.DebugContext(ctx, "direct round", "round", ev.Round,
+			log.DebugContext(ctx, "direct round", "round", ev.Round,
 				"inputTokens", ev.Usage.InputTokens, "outputTokens", ev.Usage.OutputTokens,
 				"cacheRead", ev.Usage.CacheReadTokens, "cacheWrite", ev.Usage.CacheWriteTokens)
 		}
+	case "compaction":
+		if ev.IsError {
+			log.WarnContext(ctx, "direct compaction without summary", "round", ev.Round, "dropped", ev.Dropped, "err", ev.Content)
+			return
+		}
+		log.InfoContext(ctx, "direct compaction", "round", ev.Round, "dropped", ev.Dropped, "summaryBytes", len(ev.Text))
+	case "plan":
+		if ev.IsError {
+			log.WarnContext(ctx, "direct plan fallback", "err", ev.Content, "tasks", ev.Text)
+			return
+		}
+		log.InfoContext(ctx, "direct plan", "tasks", ev.Text)
+	case "mcp":
+		if ev.IsError {
+			log.WarnContext(ctx, "direct mcp server unavailable", "server", ev.Text, "err", ev.Content)
+			return
+		}
+		log.InfoContext(ctx, "direct mcp server mounted", "server", ev.Text, "tools", ev.Content)
 	}
 }
 
+// logProgress prints one periodic line for a round that is still streaming.
+func (r *DirectRunner) logProgress(ctx context.Context, p *streamProgress) {
+	if r.Log == nil {
+		return
+	}
+	r.Log.InfoContext(ctx, "direct streaming", "round", p.round,
+		"elapsed", p.last.Sub(p.started).Round(time.Second),
+		"thinkingChars", p.thinking, "textChars", p.text, "toolArgChars", p.toolArgs, "tool", p.tool)
+}
+
+// progressInterval is how often a streaming round reports progress to the log.
+const progressInterval = 15 * time.Second
+
+// streamProgress folds streamed deltas of the current round into counters and
+// throttles them to one log line per progressInterval, so a long xhigh round
+// shows it is alive without flooding CI logs.
+type streamProgress struct {
+	round    int
+	started  time.Time
+	last     time.Time
+	thinking int
+	text     int
+	toolArgs int
+	tool     string
+}
+
+func isDeltaEvent(kind string) bool {
+	return kind == "text_delta" || kind == "thinking_delta" || kind == "tool_delta"
+}
+
+// observe updates the counters from ev and reports whether a progress line is
+// due. A "round" event closes the current round and resets the counters.
+func (p *streamProgress) observe(ev direct.Event, now time.Time) bool {
+	if ev.Kind == "round" {
+		*p = streamProgress{}
+		return false
+	}
+	if !isDeltaEvent(ev.Kind) {
+		return false
+	}
+	if p.started.IsZero() || ev.Round != p.round {
+		*p = streamProgress{round: ev.Round, started: now, last: now}
+	}
+	switch ev.Kind {
+	case "thinking_delta":
+		p.thinking += len(ev.Text)
+	case "text_delta":
+		p.text += len(ev.Text)
+	case "tool_delta":
+		p.toolArgs += len(ev.Text)
+		if ev.Tool != "" {
+			p.tool = ev.Tool
+		}
+	}
+	if now.Sub(p.last) < progressInterval {
+		return false
+	}
+	p.last = now
+	return true
+}
+
 func (r *DirectRunner) logResult(ctx context.Context, res *direct.Result) {
 	if r.Log == nil {
 		return
@@ -175,8 +482,19 @@ func (r *DirectRunner) logResult(ctx context.Context, res *direct.Result) {
 		"outputTokens", res.Usage.OutputTokens,
 		"cacheRead", res.Usage.CacheReadTokens,
 		"cacheWrite", res.Usage.CacheWriteTokens,
+		"cacheHit", fmt.Sprintf("%.1f%%", res.Usage.CacheHitRatio()*100),
 		"cost", res.CostUsd,
 	)
+	for _, a := range res.Agents {
+		r.Log.InfoContext(ctx, "direct agent", "agent", a.Name, "rounds", a.Rounds, "stopReason", a.StopReason,
+			"inputTokens", a.Usage.InputTokens, "outputTokens", a.Usage.OutputTokens, "cacheRead", a.Usage.CacheReadTokens,
+			"cacheHit", fmt.Sprintf("%.1f%%", a.Usage.CacheHitRatio()*100), "cost", a.CostUsd)
+	}
+	for _, name := range slices.Sorted(maps.Keys(res.Tools)) {
+		s := res.Tools[name]
+		r.Log.InfoContext(ctx, "direct tool stats", "tool", name, "calls", s.Calls, "errors", s.Errors, "capped", s.Capped,
+			"bytes", s.Bytes, "durationMs", s.DurationMs)
+	}
 }
 
 // directToClaudeResult maps a direct.Result onto the canonical ClaudeResult shape
@@ -198,6 +516,10 @@ func directToClaudeResult(res *direct.Result) *ClaudeResult {
 			OutputTokens:             res.Usage.OutputTokens,
 			CacheReadInputTokens:     res.Usage.CacheReadTokens,
 			CacheCreationInputTokens: res.Usage.CacheWriteTokens,
+			CacheCreation: ClaudeCacheCreation{
+				Ephemeral1hInputTokens: res.Usage.CacheWrite1hTokens,
+				Ephemeral5mInputTokens: res.Usage.CacheWriteTokens - res.Usage.CacheWrite1hTokens,
+			},
 		},
 		ModelUsage: map[string]ClaudeModelUse{
 			res.Model: {
@@ -208,5 +530,18 @@ func directToClaudeResult(res *direct.Result) *ClaudeResult {
 				CostUSD:                  res.CostUsd,
 			},
 		},
+		ToolUsage: directToolUsage(res.Tools),
+	}
+}
+
+// directToolUsage converts the loop's per-tool stats to their stored shape.
+func directToolUsage(tools map[string]direct.ToolStats) map[string]db.ToolUseStats {
+	if len(tools) == 0 {
+		return nil
+	}
+	out := make(map[string]db.ToolUseStats, len(tools))
+	for name, s := range tools {
+		out[name] = db.ToolUseStats(s)
 	}
+	return out
 }
@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/reviewer/direct"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamProgressThrottles(t *testing.T) {
+	p := &streamProgress{}
+	t0 := time.Now()
+
+	require.False(t, p.observe(direct.Event{Kind: "thinking_delta", Text: "abc"}, t0), "first delta only starts the round")
+	require.False(t, p.observe(direct.Event{Kind: "text_delta", Text: "hi"}, t0.Add(time.Second)))
+	require.True(t, p.observe(direct.Event{Kind: "tool_delta", Tool: "grep", Text: "{}"}, t0.Add(progressInterval)))
+	require.Equal(t, 3, p.thinking)
+	require.Equal(t, 2, p.text)
+	require.Equal(t, 2, p.toolArgs)
+	require.Equal(t, "grep", p.tool)
+
+	// Not due again until another interval passes.
+	require.False(t, p.observe(direct.Event{Kind: "text_delta", Text: "x"}, t0.Add(progressInterval+time.Second)))
+
+	// Non-delta events never trigger a line; a round event resets the counters.
+	require.False(t, p.observe(direct.Event{Kind: "tool_call"}, t0.Add(time.Hour)))
+	require.False(t, p.observe(direct.Event{Kind: "round"}, t0.Add(time.Hour)))
+	require.Zero(t, p.text)
+}
+
+// turnProvider answers every round with the same response.
+type turnProvider struct {
+	resp direct.Response
+	seen []direct.Request
+}
+
+func (p *turnProvider) Model() string           { return "fake-model" }
+func (p *turnProvider) Pricing() direct.Pricing { return direct.Pricing{} }
+func (p *turnProvider) Complete(_ context.Context, req direct.Request) (direct.Response, error) {
+	p.seen = append(p.seen, req)
+	return p.resp, nil
+}
+
+func TestDirectRunnerResumesSession(t *testing.T) {
+	dir := t.TempDir()
+	r := &DirectRunner{Provider: &turnProvider{resp: direct.Response{Text: "thinking"}}, Dir: dir, SessionDir: t.TempDir()}
+
+	// The model stops without submitting: no error, so the controller's Step-2
+	// retry can resume the saved session.
+	cr, err := r.Run(context.Background(), "review task")
+	require.NoError(t, err)
+	require.True(t, cr.IsError)
+	require.Equal(t, "end_turn", cr.StopReason)
+	require.NotEmpty(t, cr.SessionID)
+
+	next := &turnProvider{resp: direct.Response{Text: "still thinking"}}
+	r.Provider = next
+	r.SetSession(cr.SessionID)
+	cr2, err := r.Run(context.Background(), "review task")
+	require.NoError(t, err)
+	require.Equal(t, cr.SessionID, cr2.SessionID)
+	msgs := next.seen[0].Messages
+	require.Greater(t, len(msgs), 1, "history of the first run is replayed")
+	require.Contains(t, msgs[0].Text, "review task")
+	require.Contains(t, msgs[len(msgs)-1].Text, "Continue the review")
+
+	r.SetSession("missing")
+	_, err = r.Run(context.Background(), "review task")
+	require.ErrorContains(t, err, "resume session")
+}
+
+func TestDirectToClaudeResultToolUsage(t *testing.T) {
+	res := &direct.Result{Model: "m", Submitted: true, Tools: map[string]direct.ToolStats{
+		"read_file": {Calls: 4, Capped: 1, Bytes: 2048, DurationMs: 12},
+	}}
+	mi := directToClaudeResult(res).ToModelInfo("m")
+	require.Equal(t, map[string]db.ToolUseStats{"read_file": {Calls: 4, Capped: 1, Bytes: 2048, DurationMs: 12}}, mi.Tools)
+
+	// The Step 2 retry adds its own run's counts.
+	mi.Add(db.ReviewModelInfo{Tools: map[string]db.ToolUseStats{"read_file": {Calls: 1, Bytes: 10}, "grep": {Calls: 2, Errors: 1}}})
+	require.Equal(t, db.ToolUseStats{Calls: 5, Capped: 1, Bytes: 2058, DurationMs: 12}, mi.Tools["read_file"])
+	require.Equal(t, db.ToolUseStats{Calls: 2, Errors: 1}, mi.Tools["grep"])
+
+	require.Nil(t, directToClaudeResult(&direct.Result{}).ToolUsage)
+}
+
+func TestDirectToClaudeResultCacheTTLSplit(t *testing.T) {
+	res := &direct.Result{Model: "m", Usage: direct.Usage{CacheWriteTokens: 300, CacheWrite1hTokens: 200}}
+	mi := directToClaudeResult(res).ToModelInfo("m")
+	require.Equal(t, 300, mi.CacheCreationInputTokens)
+	require.Equal(t, 200, mi.CacheCreate1hInputTokens)
+	require.Equal(t, 100, mi.CacheCreate5mInputTokens)
+}
@@ -13,6 +13,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"reviewsrv/pkg/reviewer/direct"
 )
 
 // exportTimeout caps `opencode export` — it should return in seconds,
@@ -34,7 +36,10 @@ type ExecOpenCodeRunner struct {
 	// disables interactive permission prompts. Required for unattended CI runs
 	// but should stay off when the reviewer config trusts the working tree less.
 	AllowDangerousPermissions bool
-	Log                       *slog.Logger
+	// Catalog estimates the cost when opencode reports none (e.g. a self-hosted
+	// provider without prices); nil = direct.DefaultCatalog.
+	Catalog *direct.Catalog
+	Log     *slog.Logger
 }
 
 // Name implements ReviewRunner.
@@ -85,7 +90,7 @@ func (r *ExecOpenCodeRunner) Run(ctx context.Context, prompt string) (*ClaudeRes
 		)
 		// Try to parse whatever arrived before the error — matches Claude runner behaviour.
 		if out.stdout.Len() > 0 {
-			if cr, parseErr := ParseOpenCodeResult(out.stdout.Bytes(), r.Model); parseErr == nil {
+			if cr, parseErr := ParseOpenCodeResult(out.stdout.Bytes(), r.Model, r.Catalog); parseErr == nil {
 				return cr, fmt.Errorf("opencode exited with error: %w", out.err)
 			}
 		}
@@ -97,7 +102,7 @@ func (r *ExecOpenCodeRunner) Run(ctx context.Context, prompt string) (*ClaudeRes
 		return nil, errors.New("opencode produced empty output")
 	}
 
-	cr, parseErr := ParseOpenCodeResult(out.stdout.Bytes(), r.Model)
+	cr, parseErr := ParseOpenCodeResult(out.stdout.Bytes(), r.Model, r.Catalog)
 	if parseErr != nil {
 		r.Log.WarnContext(ctx, "failed to parse opencode output",
 			"err", parseErr,
@@ -347,7 +352,7 @@ func (a *opencodeAggregate) applyStepFinish(p *opencodeStepFinishPart) {
 	}
 }
 
-func (a *opencodeAggregate) toClaudeResult(fallbackModel string) *ClaudeResult {
+func (a *opencodeAggregate) toClaudeResult(fallbackModel string, cat *direct.Catalog) *ClaudeResult {
 	isError := a.stopReason != "" && a.stopReason != "stop" && a.stopReason != "end_turn"
 	subtype := directSubtypeSuccess
 	if isError {
@@ -358,6 +363,13 @@ func (a *opencodeAggregate) toClaudeResult(fallbackModel string) *ClaudeResult {
 	if modelName == "" {
 		modelName = fallbackModel
 	}
+	if a.totalCost == 0 {
+		spec, _ := cat.Lookup(modelName)
+		a.totalCost = spec.Cost(direct.Usage{
+			InputTokens: a.inputTokens, OutputTokens: a.outputTokens,
+			CacheReadTokens: a.cacheRead, CacheWriteTokens: a.cacheWrite,
+		})
+	}
 
 	cr := &ClaudeResult{
 		Type:         claudeResultType,
@@ -400,7 +412,8 @@ func (a *opencodeAggregate) toClaudeResult(fallbackModel string) *ClaudeResult {
 
 // ParseOpenCodeResult aggregates the NDJSON event stream from `opencode run --format json`
 // into a ClaudeResult. The fallback model name is used when the stream does not expose one.
-func ParseOpenCodeResult(data []byte, fallbackModel string) (*ClaudeResult, error) {
+// A run opencode reports no cost for is priced from cat (nil = direct.DefaultCatalog).
+func ParseOpenCodeResult(data []byte, fallbackModel string, cat *direct.Catalog) (*ClaudeResult, error) {
 	if len(data) == 0 {
 		return nil, errors.New("empty opencode output")
 	}
@@ -434,5 +447,5 @@ func ParseOpenCodeResult(data []byte, fallbackModel string) (*ClaudeResult, erro
 		return nil, errors.New("no text or step_finish events in opencode output")
 	}
 
-	return agg.toClaudeResult(fallbackModel), nil
+	return agg.toClaudeResult(fallbackModel, cat), nil
 }
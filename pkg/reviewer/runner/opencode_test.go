@@ -15,7 +15,7 @@ func TestParseOpenCodeResult(t *testing.T) {
 {"type":"step_finish","timestamp":1776937712736,"sessionID":"ses_246425057ffeZVW0DxiwJpiyjY","part":{"id":"prt_d","reason":"stop","modelID":"claude-sonnet-4-5","providerID":"anthropic","type":"step-finish","tokens":{"total":12575,"input":10711,"output":24,"reasoning":0,"cache":{"write":500,"read":1840}},"cost":0.0123}}
 `
 
-	cr, err := ParseOpenCodeResult([]byte(stream), "fallback")
+	cr, err := ParseOpenCodeResult([]byte(stream), "fallback", nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, "result", cr.Type)
@@ -45,16 +45,27 @@ func TestParseOpenCodeResult_FallbackModel(t *testing.T) {
 	stream := `{"type":"text","timestamp":1,"sessionID":"ses_x","part":{"type":"text","text":"hi"}}
 {"type":"step_finish","timestamp":2,"sessionID":"ses_x","part":{"reason":"stop","tokens":{"input":5,"output":2,"cache":{"read":0,"write":0}},"cost":0}}
 `
-	cr, err := ParseOpenCodeResult([]byte(stream), "opencode/gpt-5-nano")
+	cr, err := ParseOpenCodeResult([]byte(stream), "opencode/gpt-5-nano", nil)
 	require.NoError(t, err)
 	require.Contains(t, cr.ModelUsage, "opencode/gpt-5-nano")
 }
 
+func TestParseOpenCodeResult_EstimatesMissingCost(t *testing.T) {
+	// A provider opencode has no prices for reports cost 0 → priced from the catalogue.
+	stream := `{"type":"text","timestamp":1,"sessionID":"ses_x","part":{"type":"text","text":"hi"}}
+{"type":"step_finish","timestamp":2,"sessionID":"ses_x","part":{"reason":"stop","modelID":"claude-sonnet-4-5","providerID":"corp-proxy","tokens":{"input":1000000,"output":100000,"cache":{"read":0,"write":0}},"cost":0}}
+`
+	cr, err := ParseOpenCodeResult([]byte(stream), "", nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.5, cr.TotalCostUSD, 1e-9) // 1M × $3 + 0.1M × $15
+	assert.InDelta(t, 4.5, cr.ModelUsage["corp-proxy/claude-sonnet-4-5"].CostUSD, 1e-9)
+}
+
 func TestParseOpenCodeResult_ErrorReason(t *testing.T) {
 	stream := `{"type":"text","timestamp":1,"sessionID":"ses_x","part":{"type":"text","text":"partial"}}
 {"type":"step_finish","timestamp":2,"sessionID":"ses_x","part":{"reason":"max_tokens","tokens":{"input":5,"output":2,"cache":{"read":0,"write":0}},"cost":0}}
 `
-	cr, err := ParseOpenCodeResult([]byte(stream), "m")
+	cr, err := ParseOpenCodeResult([]byte(stream), "m", nil)
 	require.NoError(t, err)
 	assert.True(t, cr.IsError)
 	assert.Equal(t, "error", cr.Subtype)
@@ -62,10 +73,10 @@ func TestParseOpenCodeResult_ErrorReason(t *testing.T) {
 }
 
 func TestParseOpenCodeResult_Empty(t *testing.T) {
-	_, err := ParseOpenCodeResult(nil, "")
+	_, err := ParseOpenCodeResult(nil, "", nil)
 	require.Error(t, err)
 
-	_, err = ParseOpenCodeResult([]byte("\n\n"), "")
+	_, err = ParseOpenCodeResult([]byte("\n\n"), "", nil)
 	require.Error(t, err)
 }
 
@@ -74,7 +85,7 @@ func TestParseOpenCodeResult_SkipsMalformedLines(t *testing.T) {
 		`{"type":"text","timestamp":1,"sessionID":"ses_x","part":{"type":"text","text":"ok"}}` + "\n" +
 		"{broken json\n" +
 		`{"type":"step_finish","timestamp":2,"sessionID":"ses_x","part":{"reason":"stop","tokens":{"input":1,"output":1,"cache":{"read":0,"write":0}},"cost":0}}` + "\n"
-	cr, err := ParseOpenCodeResult([]byte(stream), "m")
+	cr, err := ParseOpenCodeResult([]byte(stream), "m", nil)
 	require.NoError(t, err)
 	assert.Equal(t, "ok", cr.Result)
 	assert.Equal(t, 1, cr.NumTurns)
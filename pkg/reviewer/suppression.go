@@ -0,0 +1,142 @@
+package reviewer
+
+import (
+	"path"
+	"strings"
+)
+
+const (
+	// acceptedRiskLimit caps how many of the project's latest accepted risks
+	// incoming issues are matched against.
+	acceptedRiskLimit = 5000
+
+	// suppressMinScore is the similarity an issue needs to be suppressed by an
+	// accepted risk. With the weights below, the same file needs a title
+	// similarity of about 0.65, a file with the same name in another directory
+	// about 0.85; a different file never matches.
+	suppressMinScore    = 0.75
+	suppressTitleWeight = 0.7
+	suppressFileWeight  = 0.3
+)
+
+// acceptedRisk is an accepted risk with its match keys computed once.
+type acceptedRisk struct {
+	issue       *Issue
+	fingerprint string
+	words       map[string]struct{}
+}
+
+// score rates how likely iss re-reports the risk: 1 for an equal fingerprint,
+// otherwise a weighted title and file similarity.
+func (r acceptedRisk) score(iss *Issue, fingerprint string, words map[string]struct{}) float64 {
+	if fingerprint == r.fingerprint {
+		return 1
+	}
+	return suppressTitleWeight*jaccard(words, r.words) + suppressFileWeight*fileSimilarity(iss.File, r.issue.File)
+}
+
+// jaccard is the Jaccard index of two word sets.
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	var common int
+	for w := range a {
+		if _, ok := b[w]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+func wordSet(title string) map[string]struct{} {
+	words := strings.Fields(normalizeTitle(title))
+	set := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		set[w] = struct{}{}
+	}
+	return set
+}
+
+// fileSimilarity is 1 for the same path, 0.5 for the same file name in
+// another directory (a moved file) and 0 otherwise.
+func fileSimilarity(a, b string) float64 {
+	switch {
+	case a == b:
+		return 1
+	case a != "" && b != "" && path.Base(a) == path.Base(b):
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// suppressAcceptedRisks links each issue of rv that re-reports one of the
+// project's accepted risks (false-positive or ignored issues) to the best
+// matching risk. Suppressed issues stay in the review but do not count
+// towards its stats and traffic light. Returns the number of suppressed issues.
+func suppressAcceptedRisks(risks Issues, rv *Review) int {
+	if len(risks) == 0 {
+		return 0
+	}
+
+	keyed := make([]acceptedRisk, len(risks))
+	for i := range risks {
+		keyed[i] = acceptedRisk{issue: &risks[i], fingerprint: risks[i].fingerprint(), words: wordSet(risks[i].Title)}
+	}
+
+	var n int
+	for i := range rv.ReviewFiles {
+		for j := range rv.ReviewFiles[i].Issues {
+			iss := &rv.ReviewFiles[i].Issues[j]
+			if iss.IsSuppressed() {
+				continue
+			}
+			fp, words := iss.fingerprint(), wordSet(iss.Title)
+			var (
+				best      *Issue
+				bestScore float64
+			)
+			for _, r := range keyed {
+				if s := r.score(iss, fp, words); s >= suppressMinScore && s > bestScore {
+					best, bestScore = r.issue, s
+				}
+			}
+			if best != nil {
+				iss.SuppressedByIssueID = &best.ID
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// IsSuppressed reports whether the issue re-reports an accepted risk or falls
+// under a suppression rule.
+func (i *Issue) IsSuppressed() bool {
+	return i.SuppressedByIssueID != nil || i.SuppressedByRuleID != nil
+}
+
+// SuppressedLocalIDs maps the localId of each issue suppressed as a re-reported
+// accepted risk to the ID of that risk.
+func (rv *Review) SuppressedLocalIDs() map[string]int {
+	return rv.suppressedLocalIDs(func(iss *Issue) *int { return iss.SuppressedByIssueID })
+}
+
+// SuppressedByRuleLocalIDs maps the localId of each issue suppressed by a
+// suppression rule to the ID of that rule.
+func (rv *Review) SuppressedByRuleLocalIDs() map[string]int {
+	return rv.suppressedLocalIDs(func(iss *Issue) *int { return iss.SuppressedByRuleID })
+}
+
+func (rv *Review) suppressedLocalIDs(by func(*Issue) *int) map[string]int {
+	out := map[string]int{}
+	for _, rf := range rv.ReviewFiles {
+		for _, iss := range rf.Issues {
+			if id := by(&iss); id != nil && iss.LocalID != nil {
+				out[*iss.LocalID] = *id
+			}
+		}
+	}
+	return out
+}
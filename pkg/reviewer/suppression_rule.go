@@ -0,0 +1,204 @@
+package reviewer
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"reviewsrv/pkg/db"
+)
+
+// suppressionReasonLimit keeps rule reasons short in the prompt.
+const suppressionReasonLimit = 160
+
+// SuppressionRule is a project-wide accepted risk: issues matching all of its
+// set criteria are suppressed at upload until the rule expires.
+type SuppressionRule struct {
+	db.SuppressionRule
+	title *regexp.Regexp
+}
+
+// NewSuppressionRule converts a db.SuppressionRule to the domain model, returning nil for nil input.
+// The title pattern is compiled case-insensitively; a rule with an invalid pattern never matches.
+func NewSuppressionRule(in *db.SuppressionRule) *SuppressionRule {
+	if in == nil {
+		return nil
+	}
+
+	out := &SuppressionRule{SuppressionRule: *in}
+	if p, ok := criterion(in.TitlePattern); ok {
+		out.title, _ = regexp.Compile("(?i)" + p)
+	}
+	return out
+}
+
+// criterion returns the value of an optional rule field; empty means unset.
+func criterion(p *string) (string, bool) {
+	if p == nil || *p == "" {
+		return "", false
+	}
+	return *p, true
+}
+
+// IsScoped reports whether the rule narrows by path or title. Rules without
+// either would suppress whole issue or review types and are rejected.
+func (r *SuppressionRule) IsScoped() bool {
+	_, byPath := criterion(r.PathGlob)
+	_, byTitle := criterion(r.TitlePattern)
+	return byPath || byTitle
+}
+
+// IsExpired reports whether the rule no longer applies at now.
+func (r *SuppressionRule) IsExpired(now time.Time) bool {
+	return !r.ExpiresAt.After(now)
+}
+
+// Matches reports whether iss from a review file of reviewType falls under the rule.
+func (r *SuppressionRule) Matches(iss *Issue, reviewType string) bool {
+	if !r.IsScoped() {
+		return false
+	}
+	if g, ok := criterion(r.PathGlob); ok && !MatchGlob(g, iss.File) {
+		return false
+	}
+	if t, ok := criterion(r.IssueType); ok && !strings.EqualFold(t, iss.IssueType) {
+		return false
+	}
+	if rt, ok := criterion(r.ReviewType); ok && rt != reviewType {
+		return false
+	}
+	if _, ok := criterion(r.TitlePattern); ok && (r.title == nil || !r.title.MatchString(iss.Title)) {
+		return false
+	}
+	if s, ok := criterion(r.MaxSeverity); ok && !withinSeverity(iss.Severity, s) {
+		return false
+	}
+	return true
+}
+
+// Scope renders the set criteria compactly, e.g. `path=internal/legacy/** type=naming severity<=low`.
+func (r *SuppressionRule) Scope() string {
+	var parts []string
+	add := func(key string, p *string) {
+		if v, ok := criterion(p); ok {
+			parts = append(parts, key+v)
+		}
+	}
+	add("path=", r.PathGlob)
+	add("type=", r.IssueType)
+	add("review=", r.ReviewType)
+	add("title~", r.TitlePattern)
+	add("severity<=", r.MaxSeverity)
+	return strings.Join(parts, " ")
+}
+
+// ShortReason returns the reason on one line, cut to suppressionReasonLimit runes for the prompt.
+func (r *SuppressionRule) ShortReason() string {
+	reason := []rune(strings.Join(strings.Fields(r.Reason), " "))
+	if len(reason) <= suppressionReasonLimit {
+		return string(reason)
+	}
+	return string(reason[:suppressionReasonLimit-1]) + "…"
+}
+
+// withinSeverity reports whether severity is at most maxSeverity on the Severities scale.
+func withinSeverity(severity, maxSeverity string) bool {
+	i, j := slices.Index(Severities, severity), slices.Index(Severities, maxSeverity)
+	return i >= 0 && j >= 0 && i >= j
+}
+
+// MatchGlob reports whether file matches the glob pattern. Segments are
+// matched with path.Match and "**" matches any number of directories.
+// As in .gitignore, a pattern without a slash matches the file name in any
+// directory and a trailing slash matches everything below a directory.
+func MatchGlob(pattern, file string) bool {
+	file = strings.TrimPrefix(file, "./")
+	pattern = strings.TrimPrefix(pattern, "./")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(file))
+		return ok
+	}
+	return matchSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(file, "/"))
+}
+
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := len(parts); i >= 0; i-- {
+				if matchSegments(pattern[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
+
+// IsValidGlob reports whether every segment of pattern is a valid path.Match pattern.
+func IsValidGlob(pattern string) bool {
+	for seg := range strings.SplitSeq(pattern, "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// activeRules returns the rules not expired at now.
+func activeRules(rules SuppressionRules, now time.Time) SuppressionRules {
+	return slices.DeleteFunc(rules, func(r SuppressionRule) bool { return r.IsExpired(now) })
+}
+
+// uncoveredRisks returns the accepted risks no rule covers. The prompt lists
+// only those: a risk a rule already describes would be rendered twice, and the
+// per-issue list shrinks as the team folds its risks into rules.
+func uncoveredRisks(risks Issues, rules SuppressionRules) Issues {
+	if len(rules) == 0 {
+		return risks
+	}
+	return slices.DeleteFunc(risks, func(iss Issue) bool {
+		var reviewType string
+		if iss.ReviewFile != nil {
+			reviewType = iss.ReviewFile.ReviewType
+		}
+		return slices.ContainsFunc(rules, func(r SuppressionRule) bool { return r.Matches(&iss, reviewType) })
+	})
+}
+
+// applySuppressionRules links each issue of rv to the first rule matching it.
+// Like accepted risks, suppressed issues stay in the review but do not count
+// towards its stats and traffic light. Returns the number of suppressed issues.
+func applySuppressionRules(rules SuppressionRules, rv *Review) int {
+	if len(rules) == 0 {
+		return 0
+	}
+
+	var n int
+	for i := range rv.ReviewFiles {
+		rf := &rv.ReviewFiles[i]
+		for j := range rf.Issues {
+			iss := &rf.Issues[j]
+			for k := range rules {
+				if rules[k].Matches(iss, rf.ReviewType) {
+					iss.SuppressedByRuleID = &rules[k].ID
+					n++
+					break
+				}
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,133 @@
+package reviewer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"reviewsrv/pkg/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"internal/legacy/**", "internal/legacy/a.go", true},
+		{"internal/legacy/**", "internal/legacy/x/y/a.go", true},
+		{"internal/legacy/**", "internal/legacyx/a.go", false},
+		{"internal/legacy/", "internal/legacy/x/a.go", true},
+		{"**/mocks/*.go", "mocks/a.go", true},
+		{"**/mocks/*.go", "pkg/x/mocks/a.go", true},
+		{"**/mocks/*.go", "pkg/x/mocks/y/a.go", false},
+		{"pkg/*/gen.go", "pkg/api/gen.go", true},
+		{"pkg/*/gen.go", "pkg/api/v1/gen.go", false},
+		{"*_gen.go", "pkg/api/model_gen.go", true},
+		{"*_gen.go", "pkg/api/model.go", false},
+		{"./pkg/**", "pkg/a.go", true},
+		{"/pkg/**/a.go", "./pkg/a.go", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchGlob(tt.pattern, tt.file), "%s ~ %s", tt.pattern, tt.file)
+	}
+
+	assert.True(t, IsValidGlob("internal/**/*.go"))
+	assert.False(t, IsValidGlob("internal/[a-/*.go"))
+}
+
+func TestSuppressionRule_Matches(t *testing.T) {
+	iss := &Issue{db.Issue{File: "internal/legacy/user.go", IssueType: "Naming", Title: "Exported func lacks doc comment", Severity: SeverityMedium}}
+
+	tests := []struct {
+		name string
+		rule db.SuppressionRule
+		want bool
+	}{
+		{"path", db.SuppressionRule{PathGlob: Ptr("internal/legacy/**")}, true},
+		{"other path", db.SuppressionRule{PathGlob: Ptr("internal/api/**")}, false},
+		{"path and type", db.SuppressionRule{PathGlob: Ptr("internal/**"), IssueType: Ptr("naming")}, true},
+		{"other type", db.SuppressionRule{PathGlob: Ptr("internal/**"), IssueType: Ptr("security")}, false},
+		{"review type", db.SuppressionRule{PathGlob: Ptr("internal/**"), ReviewType: Ptr(ReviewTypeCode)}, true},
+		{"other review type", db.SuppressionRule{PathGlob: Ptr("internal/**"), ReviewType: Ptr(ReviewTypeSecurity)}, false},
+		{"title", db.SuppressionRule{TitlePattern: Ptr("doc comment$")}, true},
+		{"other title", db.SuppressionRule{TitlePattern: Ptr("^missing")}, false},
+		{"invalid title", db.SuppressionRule{TitlePattern: Ptr("doc(")}, false},
+		{"severity cap", db.SuppressionRule{PathGlob: Ptr("internal/**"), MaxSeverity: Ptr(SeverityMedium)}, true},
+		{"below severity", db.SuppressionRule{PathGlob: Ptr("internal/**"), MaxSeverity: Ptr(SeverityLow)}, false},
+		{"empty criteria unset", db.SuppressionRule{PathGlob: Ptr("internal/**"), IssueType: Ptr("")}, true},
+		{"unscoped", db.SuppressionRule{IssueType: Ptr("naming")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewSuppressionRule(&tt.rule).Matches(iss, ReviewTypeCode))
+		})
+	}
+}
+
+func TestUncoveredRisks(t *testing.T) {
+	risks := Issues{
+		{db.Issue{ID: 1, File: "internal/legacy/user.go", Title: "Exported func lacks doc comment", ReviewFile: &db.ReviewFile{ReviewType: ReviewTypeCode}}},
+		{db.Issue{ID: 2, File: "internal/api/user.go", Title: "Exported func lacks doc comment", ReviewFile: &db.ReviewFile{ReviewType: ReviewTypeCode}}},
+		{db.Issue{ID: 3, File: "internal/legacy/auth.go", Title: "Weak hash", ReviewFile: &db.ReviewFile{ReviewType: ReviewTypeSecurity}}},
+	}
+	rules := SuppressionRules{*NewSuppressionRule(&db.SuppressionRule{PathGlob: Ptr("internal/legacy/**"), ReviewType: Ptr(ReviewTypeCode)})}
+
+	got := uncoveredRisks(risks, rules)
+	require.Len(t, got, 2)
+	assert.Equal(t, 2, got[0].ID)
+	assert.Equal(t, 3, got[1].ID, "the rule is limited to code reviews")
+
+	assert.Len(t, uncoveredRisks(got, nil), 2)
+}
+
+func TestSuppressionRule_ScopeAndReason(t *testing.T) {
+	r := NewSuppressionRule(&db.SuppressionRule{
+		PathGlob:    Ptr("internal/legacy/**"),
+		IssueType:   Ptr("naming"),
+		MaxSeverity: Ptr(SeverityLow),
+		Reason:      "Legacy code,\n  rewritten in Q3.",
+	})
+	assert.Equal(t, "path=internal/legacy/** type=naming severity<=low", r.Scope())
+	assert.Equal(t, "Legacy code, rewritten in Q3.", r.ShortReason())
+
+	r.Reason = strings.Repeat("a", 200)
+	assert.Equal(t, strings.Repeat("a", suppressionReasonLimit-1)+"…", r.ShortReason())
+}
+
+func TestApplySuppressionRules(t *testing.T) {
+	now := time.Now()
+	rules := NewSuppressionRules([]db.SuppressionRule{
+		{ID: 1, PathGlob: Ptr("vendor/**"), ExpiresAt: now.Add(-time.Hour)},
+		{ID: 2, PathGlob: Ptr("internal/legacy/**"), MaxSeverity: Ptr(SeverityMedium), ExpiresAt: now.Add(time.Hour)},
+		{ID: 3, TitlePattern: Ptr("magic number"), ExpiresAt: now.Add(time.Hour)},
+	})
+	rules = activeRules(rules, now)
+	require.Equal(t, []int{2, 3}, rules.IDs())
+
+	rv := &Review{ReviewFiles: ReviewFiles{
+		{
+			ReviewFile: db.ReviewFile{ReviewType: ReviewTypeCode},
+			Issues: Issues{
+				{db.Issue{LocalID: Ptr("C1"), File: "internal/legacy/a.go", Title: "Long function", Severity: SeverityLow}},
+				{db.Issue{LocalID: Ptr("C2"), File: "internal/legacy/a.go", Title: "Nil dereference", Severity: SeverityHigh}},
+				{db.Issue{LocalID: Ptr("C3"), File: "internal/api/b.go", Title: "Magic number in retry", Severity: SeverityLow}},
+				{db.Issue{LocalID: Ptr("C4"), File: "vendor/x/c.go", Title: "Unused var", Severity: SeverityLow}},
+			},
+		},
+	}}
+	pr := &Project{db.Project{ID: 1, PromptID: 1}}
+	require.NoError(t, prepareReview(pr, rv))
+
+	assert.Equal(t, 2, applySuppressionRules(rules, rv))
+	calcReviewStats(rv)
+
+	assert.Equal(t, map[string]int{"C1": 2, "C3": 3}, rv.SuppressedByRuleLocalIDs())
+	assert.Empty(t, rv.SuppressedLocalIDs())
+	assert.True(t, rv.ReviewFiles[0].Issues[0].IsSuppressed())
+	assert.Equal(t, db.ReviewFileIssueStats{High: 1, Low: 1, Total: 2}, rv.ReviewFiles[0].IssueStats)
+
+	assert.Zero(t, applySuppressionRules(nil, rv))
+}
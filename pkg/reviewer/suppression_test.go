@@ -0,0 +1,69 @@
+package reviewer
+
+import (
+	"testing"
+
+	"reviewsrv/pkg/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTitleAndFileSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, jaccard(wordSet("Missing error check"), wordSet("missing ERROR-check (42)")), 1e-9)
+	assert.InDelta(t, 0.75, jaccard(wordSet("Missing error check"), wordSet("Missing error check here")), 1e-9)
+	assert.Zero(t, jaccard(wordSet(""), wordSet("Missing error check")))
+
+	assert.InDelta(t, 1.0, fileSimilarity("pkg/a/main.go", "pkg/a/main.go"), 1e-9)
+	assert.InDelta(t, 0.5, fileSimilarity("pkg/a/main.go", "pkg/b/main.go"), 1e-9)
+	assert.Zero(t, fileSimilarity("pkg/a/main.go", "pkg/a/db.go"))
+	assert.Zero(t, fileSimilarity("", "main.go"))
+}
+
+func TestSuppressAcceptedRisks(t *testing.T) {
+	risks := Issues{
+		{db.Issue{ID: 10, File: "pkg/api/handler.go", IssueType: "security", Title: "Missing CSRF protection on form handler", StatusID: db.StatusIgnored}},
+		{db.Issue{ID: 11, File: "pkg/db/store.go", IssueType: "error-handling", Title: "Error from Close is ignored", StatusID: db.StatusFalsePositive}},
+	}
+
+	rv := &Review{ReviewFiles: ReviewFiles{
+		{
+			ReviewFile: db.ReviewFile{ReviewType: ReviewTypeSecurity},
+			Issues: Issues{
+				// Same fingerprint: another wording of punctuation and case.
+				{db.Issue{LocalID: Ptr("S1"), File: "pkg/api/handler.go", IssueType: "security", Title: "Missing CSRF protection on form handler!", Severity: SeverityCritical}},
+				// Fuzzy: same file, close title.
+				{db.Issue{LocalID: Ptr("S2"), File: "pkg/api/handler.go", IssueType: "auth", Title: "Missing CSRF protection on the form handler", Severity: SeverityHigh}},
+				// Different file, same title: a new finding.
+				{db.Issue{LocalID: Ptr("S3"), File: "pkg/api/admin.go", IssueType: "security", Title: "Missing CSRF protection on form handler", Severity: SeverityHigh}},
+			},
+		},
+		{
+			ReviewFile: db.ReviewFile{ReviewType: ReviewTypeCode},
+			Issues: Issues{
+				// Moved file: the same name needs a near-identical title.
+				{db.Issue{LocalID: Ptr("C1"), File: "internal/db/store.go", IssueType: "error-handling", Title: "Error from Close is ignored", Severity: SeverityMedium}},
+				{db.Issue{LocalID: Ptr("C2"), File: "internal/db/store.go", IssueType: "error-handling", Title: "Error from Rollback is ignored on retry", Severity: SeverityMedium}},
+			},
+		},
+	}}
+	pr := &Project{db.Project{ID: 1, PromptID: 1}}
+	require.NoError(t, prepareReview(pr, rv))
+	assert.Equal(t, "red", rv.TrafficLight)
+
+	n := suppressAcceptedRisks(risks, rv)
+	calcReviewStats(rv)
+
+	assert.Equal(t, 3, n)
+	assert.Equal(t, map[string]int{"S1": 10, "S2": 10, "C1": 11}, rv.SuppressedLocalIDs())
+	assert.False(t, rv.ReviewFiles[0].Issues[2].IsSuppressed())
+	assert.False(t, rv.ReviewFiles[1].Issues[1].IsSuppressed())
+
+	// Suppressed issues stay in the review but leave the stats.
+	assert.Len(t, rv.ReviewFiles[0].Issues, 3)
+	assert.Equal(t, db.ReviewFileIssueStats{High: 1, Total: 1}, rv.ReviewFiles[0].IssueStats)
+	assert.Equal(t, db.ReviewFileIssueStats{Medium: 1, Total: 1}, rv.ReviewFiles[1].IssueStats)
+	assert.Equal(t, "yellow", rv.TrafficLight)
+
+	assert.Zero(t, suppressAcceptedRisks(nil, rv))
+}
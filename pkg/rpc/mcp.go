@@ -0,0 +1,391 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/reviewer"
+
+	"github.com/vmkteam/embedlog"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	// mcpProtocolVersion is the latest MCP revision the server speaks; older
+	// clients get their own version echoed back.
+	mcpProtocolVersion = "2025-06-18"
+	// mcpMaxBody caps one JSON-RPC request.
+	mcpMaxBody = 1 << 20
+	// mcpReviewsDefault / mcpReviewsMax bound list_reviews.
+	mcpReviewsDefault = 10
+	mcpReviewsMax     = 50
+)
+
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC 2.0 error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// mcpStatuses maps the feedback statuses an agent may set to statusIds.
+var mcpStatuses = map[string]int{
+	"unprocessed":   db.StatusEnabled,
+	"valid":         db.StatusValid,
+	"falsePositive": db.StatusFalsePositive,
+	"ignored":       db.StatusIgnored,
+}
+
+// MCPServer exposes review data to IDE agents as MCP tools over the streamable
+// HTTP transport: list a branch's or MR's reviews, read a review with its
+// issues, fetch the fix markdown and the project's accepted risks, and mark
+// issue feedback. It is stateless (one JSON response per POST, no sessions)
+// and goes through ReviewService, so tools see the same data, checks and
+// errors as the JSON-RPC API.
+type MCPServer struct {
+	s       ReviewService
+	logger  embedlog.Logger
+	version string
+	tools   []mcpTool
+}
+
+// mcpTool is a tool as listed to the client, with its handler.
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+
+	call func(ctx context.Context, args json.RawMessage) (any, error) `json:"-"`
+}
+
+// NewMCPServer returns the MCP endpoint handler.
+func NewMCPServer(dbo db.DB, logger embedlog.Logger, version string) *MCPServer {
+	m := &MCPServer{s: *NewReviewService(dbo), logger: logger, version: version}
+	m.tools = m.toolset()
+	return m
+}
+
+// mcpMessage is a JSON-RPC 2.0 request, notification or response.
+type mcpMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *mcpError       `json:"error,omitempty"`
+}
+
+type mcpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ServeHTTP implements the POST side of the streamable HTTP transport. GET
+// (a server-initiated event stream) and DELETE (session end) are not offered.
+func (m *MCPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, mcpMaxBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req mcpMessage
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeMCP(w, &mcpMessage{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &mcpError{Code: rpcParseError, Message: "parse error"}})
+		return
+	}
+	if len(req.ID) == 0 { // notification or a response to us: nothing to answer
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeMCP(w, m.handle(r.Context(), &req))
+}
+
+func writeMCP(w http.ResponseWriter, resp *mcpMessage) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handle answers one request.
+func (m *MCPServer) handle(ctx context.Context, req *mcpMessage) *mcpMessage {
+	resp := &mcpMessage{JSONRPC: "2.0", ID: req.ID}
+	fail := func(code int, msg string) *mcpMessage {
+		resp.Error = &mcpError{Code: code, Message: msg}
+		return resp
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return fail(rpcInvalidRequest, "invalid request")
+	}
+
+	switch req.Method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &p)
+		version := mcpProtocolVersion
+		if slices.Contains(mcpProtocolVersions, p.ProtocolVersion) {
+			version = p.ProtocolVersion
+		}
+		resp.Result = map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "reviewsrv", "version": m.version},
+			"instructions": "Code reviews produced by reviewsrv. Find the project with list_projects, the review of your branch or MR " +
+				"with list_reviews, then read it with get_review or take the fix task from get_fix_markdown. " +
+				"After fixing or dismissing an issue, record it with set_issue_feedback.",
+		}
+	case "ping":
+		resp.Result = map[string]any{}
+	case "tools/list":
+		resp.Result = map[string]any{"tools": m.tools}
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return fail(rpcInvalidParams, "invalid params")
+		}
+		i := slices.IndexFunc(m.tools, func(t mcpTool) bool { return t.Name == p.Name })
+		if i < 0 {
+			return fail(rpcInvalidParams, fmt.Sprintf("unknown tool %q", p.Name))
+		}
+		resp.Result = m.callTool(ctx, m.tools[i], p.Arguments)
+	default:
+		return fail(rpcMethodNotFound, "method not found: "+req.Method)
+	}
+	return resp
+}
+
+// callTool runs a tool and wraps its output as a tools/call result. A string
+// is returned as text (markdown), anything else as JSON text plus
+// structuredContent. Errors come back as isError results the agent can read;
+// internal errors are logged and not exposed.
+func (m *MCPServer) callTool(ctx context.Context, t mcpTool, args json.RawMessage) map[string]any {
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage(`{}`)
+	}
+	out, err := t.call(ctx, args)
+	if err != nil {
+		msg := ErrInternal.Message
+		var zerr *zenrpc.Error
+		if errors.As(err, &zerr) && zerr.Code < http.StatusInternalServerError {
+			msg = zerr.Message
+		} else {
+			m.logger.Error(ctx, "mcp tool failed", "tool", t.Name, "err", err)
+		}
+		return map[string]any{"content": []map[string]any{{"type": "text", "text": msg}}, "isError": true}
+	}
+
+	if text, ok := out.(string); ok {
+		return map[string]any{"content": []map[string]any{{"type": "text", "text": text}}}
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return map[string]any{"content": []map[string]any{{"type": "text", "text": ErrInternal.Message}}, "isError": true}
+	}
+	// structuredContent must be an object; lists are wrapped.
+	structured := out
+	if data[0] == '[' {
+		structured = map[string]any{"items": out}
+	}
+	return map[string]any{
+		"content":           []map[string]any{{"type": "text", "text": string(data)}},
+		"structuredContent": structured,
+	}
+}
+
+// decodeArgs unmarshals tool arguments, reporting bad input as a 400.
+func decodeArgs(args json.RawMessage, v any) error {
+	if err := json.Unmarshal(args, v); err != nil {
+		return zenrpc.NewStringError(http.StatusBadRequest, "invalid arguments: "+err.Error())
+	}
+	return nil
+}
+
+// ReviewWithIssues is a review with its issues, for get_review.
+type ReviewWithIssues struct {
+	Review *Review `json:"review"`
+	Issues []Issue `json:"issues"`
+}
+
+// toolset declares the MCP tools.
+func (m *MCPServer) toolset() []mcpTool {
+	id := func(desc string) map[string]any {
+		return map[string]any{"type": "integer", "minimum": 1, "description": desc}
+	}
+	str := func(desc string) map[string]any { return map[string]any{"type": "string", "description": desc} }
+	obj := func(props map[string]any, required ...string) map[string]any {
+		s := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	}
+
+	return []mcpTool{
+		{
+			Name:        "list_projects",
+			Description: "List projects with their review counts. Use it to find the projectId of your repository (match title or vcsURL).",
+			InputSchema: obj(map[string]any{}),
+			call:        func(ctx context.Context, _ json.RawMessage) (any, error) { return m.s.Projects(ctx) },
+		},
+		{
+			Name:        "list_reviews",
+			Description: "List the latest reviews of a project, newest first, optionally for one source branch or merge request.",
+			InputSchema: obj(map[string]any{
+				"projectId":    id("Project ID"),
+				"sourceBranch": str("Source branch of the merge request"),
+				"externalId":   str("Merge request IID"),
+				"limit":        map[string]any{"type": "integer", "minimum": 1, "maximum": mcpReviewsMax, "description": "Max reviews (default 10)"},
+			}, "projectId"),
+			call: m.listReviews,
+		},
+		{
+			Name:        "get_review",
+			Description: "Get a review with all its issues (severity, file, lines, description, suggested fix, feedback status).",
+			InputSchema: obj(map[string]any{"reviewId": id("Review ID")}, "reviewId"),
+			call:        m.getReview,
+		},
+		{
+			Name: "get_fix_markdown",
+			Description: "Get the fix task of a review as markdown: the issues marked valid, with files, lines and suggested fixes. " +
+				"Same as /v1/rpc/review-fix-<reviewId>.md.",
+			InputSchema: obj(map[string]any{"reviewId": id("Review ID")}, "reviewId"),
+			call:        m.getFixMarkdown,
+		},
+		{
+			Name:        "set_issue_feedback",
+			Description: "Record feedback on an issue: valid (to fix), falsePositive, ignored (accepted risk) or unprocessed; optionally with a comment.",
+			InputSchema: obj(map[string]any{
+				"issueId": id("Issue ID"),
+				"status":  map[string]any{"type": "string", "enum": []string{"valid", "falsePositive", "ignored", "unprocessed"}},
+				"comment": map[string]any{"type": "string", "maxLength": 255, "description": "Short reason (max 255 chars)"},
+			}, "issueId", "status"),
+			call: m.setIssueFeedback,
+		},
+		{
+			Name:        "get_accepted_risks",
+			Description: "Get the project's accepted risks (ignored issues) as markdown, to avoid re-raising or re-fixing them.",
+			InputSchema: obj(map[string]any{"projectId": id("Project ID")}, "projectId"),
+			call:        m.getAcceptedRisks,
+		},
+	}
+}
+
+func (m *MCPServer) listReviews(ctx context.Context, args json.RawMessage) (any, error) {
+	var a struct {
+		ProjectID    int     `json:"projectId"`
+		SourceBranch *string `json:"sourceBranch"`
+		ExternalID   *string `json:"externalId"`
+		Limit        int     `json:"limit"`
+	}
+	if err := decodeArgs(args, &a); err != nil {
+		return nil, err
+	}
+	reviews, err := m.s.Get(ctx, a.ProjectID, &ReviewFilters{SourceBranch: a.SourceBranch, ExternalID: a.ExternalID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	limit := a.Limit
+	if limit <= 0 {
+		limit = mcpReviewsDefault
+	}
+	return reviews[:min(len(reviews), limit, mcpReviewsMax)], nil
+}
+
+func (m *MCPServer) getReview(ctx context.Context, args json.RawMessage) (any, error) {
+	var a struct {
+		ReviewID int `json:"reviewId"`
+	}
+	if err := decodeArgs(args, &a); err != nil {
+		return nil, err
+	}
+	rv, err := m.s.GetByID(ctx, a.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := m.s.Issues(ctx, a.ReviewID, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The per-group markdown repeats the issues; keep the agent's context lean.
+	for i := range rv.ReviewFiles {
+		rv.ReviewFiles[i].Content = ""
+	}
+	return ReviewWithIssues{Review: rv, Issues: issues}, nil
+}
+
+func (m *MCPServer) getFixMarkdown(ctx context.Context, args json.RawMessage) (any, error) {
+	var a struct {
+		ReviewID int `json:"reviewId"`
+	}
+	if err := decodeArgs(args, &a); err != nil {
+		return nil, err
+	}
+	md, err := m.s.rm.RenderFixMarkdown(ctx, m.s.pm, a.ReviewID)
+	switch {
+	case errors.Is(err, reviewer.ErrReviewNotFound):
+		return nil, ErrNotFound
+	case err != nil:
+		return nil, newInternalError(err)
+	}
+	return md, nil
+}
+
+func (m *MCPServer) setIssueFeedback(ctx context.Context, args json.RawMessage) (any, error) {
+	var a struct {
+		IssueID int     `json:"issueId"`
+		Status  string  `json:"status"`
+		Comment *string `json:"comment"`
+	}
+	if err := decodeArgs(args, &a); err != nil {
+		return nil, err
+	}
+	statusID, ok := mcpStatuses[a.Status]
+	if !ok {
+		return nil, zenrpc.NewStringError(http.StatusBadRequest, fmt.Sprintf("invalid status %q", a.Status))
+	}
+	if a.Comment != nil {
+		// SetComment validates the length and the issue before anything changes.
+		if _, err := m.s.SetComment(ctx, a.IssueID, a.Comment); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := m.s.Feedback(ctx, a.IssueID, statusID); err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("issue %d marked %s", a.IssueID, a.Status), nil
+}
+
+func (m *MCPServer) getAcceptedRisks(ctx context.Context, args json.RawMessage) (any, error) {
+	var a struct {
+		ProjectID int `json:"projectId"`
+	}
+	if err := decodeArgs(args, &a); err != nil {
+		return nil, err
+	}
+	md, err := m.s.rm.RenderProjectInstructionsMarkdown(ctx, m.s.pm, a.ProjectID)
+	switch {
+	case errors.Is(err, reviewer.ErrProjectNotFound):
+		return nil, ErrNotFound
+	case err != nil:
+		return nil, newInternalError(err)
+	}
+	return md, nil
+}
@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMCPServer returns an MCPServer without a database: enough for the
+// protocol and for tool calls rejected before any query.
+func newTestMCPServer() *MCPServer {
+	m := &MCPServer{version: "test"}
+	m.tools = m.toolset()
+	return m
+}
+
+func postMCP(t *testing.T, m *MCPServer, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/mcp/", strings.NewReader(body)))
+	return rec
+}
+
+func decodeMCP(t *testing.T, rec *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	return out
+}
+
+func TestMCPServerProtocol(t *testing.T) {
+	m := newTestMCPServer()
+
+	resp := decodeMCP(t, postMCP(t, m, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{}}}`))
+	result := resp["result"].(map[string]any)
+	assert.Equal(t, "2025-03-26", result["protocolVersion"], "a supported client version is echoed")
+	assert.Equal(t, "reviewsrv", result["serverInfo"].(map[string]any)["name"])
+
+	resp = decodeMCP(t, postMCP(t, m, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`))
+	assert.Equal(t, mcpProtocolVersion, resp["result"].(map[string]any)["protocolVersion"])
+
+	rec := postMCP(t, m, `{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	resp = decodeMCP(t, postMCP(t, m, `{"jsonrpc":"2.0","id":"a","method":"tools/list"}`))
+	assert.Equal(t, "a", resp["id"])
+	var names []string
+	for _, tool := range resp["result"].(map[string]any)["tools"].([]any) {
+		names = append(names, tool.(map[string]any)["name"].(string))
+	}
+	assert.Equal(t, []string{"list_projects", "list_reviews", "get_review", "get_fix_markdown", "set_issue_feedback", "get_accepted_risks"}, names)
+
+	resp = decodeMCP(t, postMCP(t, m, `{"jsonrpc":"2.0","id":2,"method":"resources/list"}`))
+	assert.InDelta(t, rpcMethodNotFound, resp["error"].(map[string]any)["code"], 0)
+
+	resp = decodeMCP(t, postMCP(t, m, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"drop_db"}}`))
+	assert.InDelta(t, rpcInvalidParams, resp["error"].(map[string]any)["code"], 0)
+
+	resp = decodeMCP(t, postMCP(t, m, `{not json`))
+	assert.InDelta(t, rpcParseError, resp["error"].(map[string]any)["code"], 0)
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/mcp/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestMCPServerToolErrors(t *testing.T) {
+	m := newTestMCPServer()
+	for args, want := range map[string]string{
+		`{"issueId":1,"status":"wontfix"}`:                                            `invalid status "wontfix"`,
+		`{"issueId":"one","status":"valid"}`:                                          "invalid arguments",
+		`{"issueId":1,"status":"valid","comment":"` + strings.Repeat("x", 256) + `"}`: "comment > 255",
+	} {
+		body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"set_issue_feedback","arguments":` + args + `}}`
+		result := decodeMCP(t, postMCP(t, m, body))["result"].(map[string]any)
+		assert.Equal(t, true, result["isError"], args)
+		text := result["content"].([]any)[0].(map[string]any)["text"].(string)
+		assert.Contains(t, text, want, args)
+	}
+}
@@ -17,6 +17,24 @@ type IssueStats struct {
 	Total    int `json:"total"`
 }
 
+// VersionStats — судьба замечаний относительно предыдущей версии того же MR.
+type VersionStats struct {
+	New        int `json:"new"`
+	Persisting int `json:"persisting"`
+	Fixed      int `json:"fixed"`
+}
+
+func newVersionStats(in *db.ReviewVersionStats) *VersionStats {
+	if in == nil {
+		return nil
+	}
+	return &VersionStats{
+		New:        in.New,
+		Persisting: in.Persisting,
+		Fixed:      in.Fixed,
+	}
+}
+
 // ModelInfo — информация о модели, выполнившей ревью.
 type ModelInfo struct {
 	Model        string  `json:"model"`
@@ -155,6 +173,8 @@ type ReviewSummary struct {
 	EffortMinutes       *int                `json:"effortMinutes,omitempty"`
 	AiSlopScore         *float32            `json:"aiSlopScore,omitempty"`
 	LastVersionReviewID *int                `json:"lastVersionReviewId,omitempty"`
+	PreviousReviewID    *int                `json:"previousReviewId,omitempty"`
+	VersionStats        *VersionStats       `json:"versionStats,omitempty"`
 }
 
 // ReviewFileSummary — мини-кружок A/C/S/T в таблице ревью.
@@ -192,29 +212,36 @@ func newReviewSummary(in *reviewer.Review) *ReviewSummary {
 	rs.EffortMinutes = in.EffortMinutes
 	rs.AiSlopScore = in.AiSlopScore
 	rs.LastVersionReviewID = in.LastVersionReviewID
+	rs.PreviousReviewID = in.PreviousReviewID
+	rs.VersionStats = newVersionStats(in.VersionStats)
 
 	return rs
 }
 
 // Review — полные данные ревью для /reviews/<reviewId>/.
 type Review struct {
-	ID                  int          `json:"reviewId"`
-	ProjectID           int          `json:"projectId"`
-	Title               string       `json:"title"`
-	Description         string       `json:"description"`
-	ExternalID          string       `json:"externalId"`
-	TrafficLight        string       `json:"trafficLight"`
-	CommitHash          string       `json:"commitHash"`
-	SourceBranch        string       `json:"sourceBranch"`
-	TargetBranch        string       `json:"targetBranch"`
-	Author              string       `json:"author"`
-	CreatedAt           time.Time    `json:"createdAt"`
-	DurationMS          int          `json:"durationMs"`
-	ModelInfo           ModelInfo    `json:"modelInfo"`
-	ReviewFiles         []ReviewFile `json:"reviewFiles"`
-	EffortMinutes       *int         `json:"effortMinutes,omitempty"`
-	AiSlopScore         *float32     `json:"aiSlopScore,omitempty"`
-	LastVersionReviewID *int         `json:"lastVersionReviewId,omitempty"`
+	ID                  int           `json:"reviewId"`
+	ProjectID           int           `json:"projectId"`
+	Title               string        `json:"title"`
+	Description         string        `json:"description"`
+	ExternalID          string        `json:"externalId"`
+	TrafficLight        string        `json:"trafficLight"`
+	CommitHash          string        `json:"commitHash"`
+	SourceBranch        string        `json:"sourceBranch"`
+	TargetBranch        string        `json:"targetBranch"`
+	Author              string        `json:"author"`
+	CreatedAt           time.Time     `json:"createdAt"`
+	DurationMS          int           `json:"durationMs"`
+	ModelInfo           ModelInfo     `json:"modelInfo"`
+	ReviewFiles         []ReviewFile  `json:"reviewFiles"`
+	EffortMinutes       *int          `json:"effortMinutes,omitempty"`
+	AiSlopScore         *float32      `json:"aiSlopScore,omitempty"`
+	LastVersionReviewID *int          `json:"lastVersionReviewId,omitempty"`
+	PreviousReviewID    *int          `json:"previousReviewId,omitempty"`
+	VersionStats        *VersionStats `json:"versionStats,omitempty"`
+	PromptRevisionID    *int          `json:"promptRevisionId,omitempty"`   // ревизия промпта, по которой сделано ревью
+	PromptExperimentID  *int          `json:"promptExperimentId,omitempty"` // A/B-эксперимент промпта, в который попало ревью
+	PromptVariant       *string       `json:"promptVariant,omitempty"`      // вариант промпта в эксперименте: A или B
 }
 
 func newReview(in *reviewer.Review) *Review {
@@ -240,6 +267,11 @@ func newReview(in *reviewer.Review) *Review {
 		EffortMinutes:       in.EffortMinutes,
 		AiSlopScore:         in.AiSlopScore,
 		LastVersionReviewID: in.LastVersionReviewID,
+		PreviousReviewID:    in.PreviousReviewID,
+		VersionStats:        newVersionStats(in.VersionStats),
+		PromptRevisionID:    in.PromptRevisionID,
+		PromptExperimentID:  in.PromptExperimentID,
+		PromptVariant:       in.PromptVariant,
 	}
 
 	return r
@@ -285,6 +317,19 @@ type Issue struct {
 	SuggestedFix *string `json:"suggestedFix,omitempty"`
 	StatusID     int     `json:"statusId"`
 	Comment      *string `json:"comment"`
+	// Lifecycle — new, persisting (есть в предыдущей версии MR) или fixed (нет в следующей).
+	Lifecycle       string `json:"lifecycle"`
+	PreviousIssueID *int   `json:"previousIssueId,omitempty"`
+	FixedInReviewID *int   `json:"fixedInReviewId,omitempty"`
+	// SuppressedByIssueID — принятый риск (false positive / ignored), который повторяет замечание;
+	// такое замечание не влияет на светофор и не публикуется в MR.
+	SuppressedByIssueID *int `json:"suppressedByIssueId,omitempty"`
+	// SuppressedByRuleID — правило подавления проекта, под которое попадает замечание;
+	// такое замечание не влияет на светофор и не публикуется в MR.
+	SuppressedByRuleID *int `json:"suppressedByRuleId,omitempty"`
+	// VerifierVerdict — вердикт проверки верификатором: confirm, downgrade или reject;
+	// пусто, если замечание не проверялось.
+	VerifierVerdict *string `json:"verifierVerdict,omitempty"`
 }
 
 func newIssue(in *reviewer.Issue) *Issue {
@@ -307,6 +352,15 @@ func newIssue(in *reviewer.Issue) *Issue {
 		SuggestedFix: in.SuggestedFix,
 		StatusID:     in.StatusID,
 		Comment:      in.Comment,
+		Lifecycle:    in.Lifecycle(),
+
+		PreviousIssueID: in.PreviousIssueID,
+		FixedInReviewID: in.FixedInReviewID,
+
+		SuppressedByIssueID: in.SuppressedByIssueID,
+		SuppressedByRuleID:  in.SuppressedByRuleID,
+
+		VerifierVerdict: in.VerifierVerdict,
 	}
 
 	if in.Review != nil {
@@ -322,6 +376,7 @@ type ReviewFilters struct {
 	Author       *string `json:"author"`
 	TrafficLight *string `json:"trafficLight"`
 	ExternalID   *string `json:"externalId"`
+	SourceBranch *string `json:"sourceBranch"`
 }
 
 // ToDomain converts RPC filters to a domain ReviewSearch with pagination cursor.
@@ -335,6 +390,7 @@ func (f *ReviewFilters) ToDomain(projectID int, fromReviewID *int) *reviewer.Rev
 		s.Author = f.Author
 		s.TrafficLight = f.TrafficLight
 		s.ExternalID = f.ExternalID
+		s.SourceBranch = f.SourceBranch
 	}
 	return s
 }
@@ -377,3 +433,156 @@ func (f *IssueFilters) ToDomainByProject(projectID int) *reviewer.IssueSearch {
 	}
 	return s
 }
+
+// TrendFilters — период и группировка для аналитики проектов.
+type TrendFilters struct {
+	ProjectIDs []int      `json:"projectIds"` // пусто — все проекты
+	From       *time.Time `json:"from"`       // по умолчанию год назад от to
+	To         *time.Time `json:"to"`         // по умолчанию сейчас
+	Bucket     *string    `json:"bucket"`     // day, week, month (по умолчанию)
+}
+
+// ToDomain converts RPC filters to a domain TrendSearch.
+func (f *TrendFilters) ToDomain() reviewer.TrendSearch {
+	var s reviewer.TrendSearch
+	if f == nil {
+		return s
+	}
+	s.ProjectIDs = f.ProjectIDs
+	if f.From != nil {
+		s.From = *f.From
+	}
+	if f.To != nil {
+		s.To = *f.To
+	}
+	if f.Bucket != nil {
+		s.Bucket = *f.Bucket
+	}
+	return s
+}
+
+// FeedbackStats — разметка обработанных замечаний: количество и доли от обработанных.
+type FeedbackStats struct {
+	Valid              int     `json:"valid"`
+	FalsePositive      int     `json:"falsePositive"`
+	Ignored            int     `json:"ignored"`
+	ValidRatio         float64 `json:"validRatio"`
+	FalsePositiveRatio float64 `json:"falsePositiveRatio"`
+	IgnoredRatio       float64 `json:"ignoredRatio"`
+}
+
+func newFeedbackStats(in reviewer.FeedbackStats) FeedbackStats {
+	return FeedbackStats{
+		Valid:              in.Valid,
+		FalsePositive:      in.FalsePositive,
+		Ignored:            in.Ignored,
+		ValidRatio:         in.Ratio(in.Valid),
+		FalsePositiveRatio: in.Ratio(in.FalsePositive),
+		IgnoredRatio:       in.Ratio(in.Ignored),
+	}
+}
+
+// ProjectTrend — статистика проекта за один интервал (bucketStart — начало интервала в UTC).
+type ProjectTrend struct {
+	ProjectID          int            `json:"projectId"`
+	BucketStart        time.Time      `json:"bucketStart"`
+	ReviewCount        int            `json:"reviewCount"`
+	Issues             IssueStats     `json:"issues"`
+	IssuesByReviewType map[string]int `json:"issuesByReviewType"`
+	Feedback           FeedbackStats  `json:"feedback"`
+	MedianDurationMS   int            `json:"medianDurationMs"`
+	CostUsd            float64        `json:"costUsd"`
+	CostPerReview      float64        `json:"costPerReview"`
+}
+
+func newProjectTrend(in reviewer.ProjectTrend) ProjectTrend {
+	return ProjectTrend{
+		ProjectID:          in.ProjectID,
+		BucketStart:        in.BucketStart,
+		ReviewCount:        in.ReviewCount,
+		Issues:             newIssueStats(db.ReviewFileIssueStats(in.Issues)),
+		IssuesByReviewType: in.IssuesByReviewType,
+		Feedback:           newFeedbackStats(in.Feedback),
+		MedianDurationMS:   in.MedianDurationMS,
+		CostUsd:            in.CostUsd,
+		CostPerReview:      in.CostPerReview(),
+	}
+}
+
+func newProjectTrends(in []reviewer.ProjectTrend) []ProjectTrend {
+	out := make([]ProjectTrend, len(in))
+	for i := range in {
+		out[i] = newProjectTrend(in[i])
+	}
+	return out
+}
+
+// LeaderboardFilters — фильтры рейтинга моделей.
+type LeaderboardFilters struct {
+	ProjectID *int       `json:"projectId"`
+	Language  *string    `json:"language"` // язык проекта, без учёта регистра
+	From      *time.Time `json:"from"`     // по умолчанию год назад от to
+	To        *time.Time `json:"to"`       // по умолчанию сейчас
+	RankBy    *string    `json:"rankBy"`   // precision (по умолчанию), issuesPerReview, costPerValidIssue, latency
+}
+
+// ToDomain converts RPC filters to a domain LeaderboardSearch.
+func (f *LeaderboardFilters) ToDomain() reviewer.LeaderboardSearch {
+	var s reviewer.LeaderboardSearch
+	if f == nil {
+		return s
+	}
+	s.ProjectID = f.ProjectID
+	s.Language = f.Language
+	if f.From != nil {
+		s.From = *f.From
+	}
+	if f.To != nil {
+		s.To = *f.To
+	}
+	if f.RankBy != nil {
+		s.RankBy = *f.RankBy
+	}
+	return s
+}
+
+// LeaderboardEntry — строка рейтинга: связка раннер + модель + промпт и её метрики.
+type LeaderboardEntry struct {
+	Runner            string        `json:"runner"`
+	Model             string        `json:"model"`
+	PromptID          int           `json:"promptId"`
+	PromptTitle       string        `json:"promptTitle"`
+	ReviewCount       int           `json:"reviewCount"`
+	IssueCount        int           `json:"issueCount"`
+	Feedback          FeedbackStats `json:"feedback"`
+	Precision         float64       `json:"precision"` // valid / обработанные
+	IssuesPerReview   float64       `json:"issuesPerReview"`
+	CostUsd           float64       `json:"costUsd"`
+	CostPerValidIssue *float64      `json:"costPerValidIssue"` // null, если нет valid
+	MedianDurationMS  int           `json:"medianDurationMs"`  // латентность
+}
+
+func newLeaderboardEntry(in reviewer.LeaderboardEntry) LeaderboardEntry {
+	return LeaderboardEntry{
+		Runner:            in.Runner,
+		Model:             in.Model,
+		PromptID:          in.PromptID,
+		PromptTitle:       in.PromptTitle,
+		ReviewCount:       in.ReviewCount,
+		IssueCount:        in.IssueCount,
+		Feedback:          newFeedbackStats(in.Feedback()),
+		Precision:         in.Precision(),
+		IssuesPerReview:   in.IssuesPerReview(),
+		CostUsd:           in.CostUsd,
+		CostPerValidIssue: in.CostPerValidIssue(),
+		MedianDurationMS:  in.Latency(),
+	}
+}
+
+func newLeaderboard(in []reviewer.LeaderboardEntry) []LeaderboardEntry {
+	out := make([]LeaderboardEntry, len(in))
+	for i := range in {
+		out[i] = newLeaderboardEntry(in[i])
+	}
+	return out
+}
@@ -3,6 +3,7 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"unicode/utf8"
 
@@ -95,6 +96,45 @@ func (s ReviewService) ProjectByID(ctx context.Context, projectId int) (*Project
 	return newProject(project), nil
 }
 
+// Trends returns review stats per project and time bucket for charts:
+// review count, issues by severity and review type, feedback ratios, median duration and cost.
+// Buckets without reviews are omitted.
+//
+//zenrpc:filters Period, bucket (day, week, month) and projects; defaults to the last year by months for all projects
+//zenrpc:return []ProjectTrend
+//zenrpc:400 Bad Request
+//zenrpc:500 Internal Error
+func (s ReviewService) Trends(ctx context.Context, filters *TrendFilters) ([]ProjectTrend, error) {
+	trends, err := s.rm.ProjectTrends(ctx, filters.ToDomain())
+	switch {
+	case errors.Is(err, reviewer.ErrInvalidBucket), errors.Is(err, reviewer.ErrInvalidPeriod), errors.Is(err, reviewer.ErrTooManyBuckets):
+		return nil, zenrpc.NewStringError(http.StatusBadRequest, err.Error())
+	case err != nil:
+		return nil, newInternalError(err)
+	}
+
+	return newProjectTrends(trends), nil
+}
+
+// Leaderboard ranks (runner, model, prompt) combinations by human feedback:
+// precision (valid / processed), issues per review, cost per valid issue and latency.
+//
+//zenrpc:filters Project, project language, period (defaults to the last year) and ranking metric
+//zenrpc:return []LeaderboardEntry
+//zenrpc:400 Bad Request
+//zenrpc:500 Internal Error
+func (s ReviewService) Leaderboard(ctx context.Context, filters *LeaderboardFilters) ([]LeaderboardEntry, error) {
+	entries, err := s.rm.Leaderboard(ctx, filters.ToDomain())
+	switch {
+	case errors.Is(err, reviewer.ErrInvalidRank), errors.Is(err, reviewer.ErrInvalidPeriod):
+		return nil, zenrpc.NewStringError(http.StatusBadRequest, err.Error())
+	case err != nil:
+		return nil, newInternalError(err)
+	}
+
+	return newLeaderboard(entries), nil
+}
+
 // Get returns list of reviews for a project.
 //
 //zenrpc:projectId Project ID
@@ -12,14 +12,16 @@ import (
 
 var RPC = struct {
 	AppService    struct{ Version string }
-	ReviewService struct{ Projects, ProjectByID, Get, Count, GetByID, Issues, CountIssues, IssuesByProject, CountIssuesByProject, ArchiveAcceptedRisks, Feedback, SetComment string }
+	ReviewService struct{ Projects, ProjectByID, Trends, Leaderboard, Get, Count, GetByID, Issues, CountIssues, IssuesByProject, CountIssuesByProject, ArchiveAcceptedRisks, Feedback, SetComment string }
 }{
 	AppService: struct{ Version string }{
 		Version: "version",
 	},
-	ReviewService: struct{ Projects, ProjectByID, Get, Count, GetByID, Issues, CountIssues, IssuesByProject, CountIssuesByProject, ArchiveAcceptedRisks, Feedback, SetComment string }{
+	ReviewService: struct{ Projects, ProjectByID, Trends, Leaderboard, Get, Count, GetByID, Issues, CountIssues, IssuesByProject, CountIssuesByProject, ArchiveAcceptedRisks, Feedback, SetComment string }{
 		Projects:             "projects",
 		ProjectByID:          "projectbyid",
+		Trends:               "trends",
+		Leaderboard:          "leaderboard",
 		Get:                  "get",
 		Count:                "count",
 		GetByID:              "getbyid",
@@ -215,6 +217,304 @@ func (ReviewService) SMD() smd.ServiceInfo {
 					404: "Not Found",
 				},
 			},
+			"Trends": {
+				Description: `Trends returns review stats per project and time bucket for charts:
+review count, issues by severity and review type, feedback ratios, median duration and cost.
+Buckets without reviews are omitted.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "filters",
+						Optional:    true,
+						Description: `Period, bucket (day, week, month) and projects; defaults to the last year by months for all projects`,
+						Type:        smd.Object,
+						TypeName:    "TrendFilters",
+						Properties: smd.PropertyList{
+							{
+								Name:        "projectIds",
+								Description: `пусто — все проекты`,
+								Type:        smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "from",
+								Optional:    true,
+								Description: `по умолчанию год назад от to`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "to",
+								Optional:    true,
+								Description: `по умолчанию сейчас`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "bucket",
+								Optional:    true,
+								Description: `day, week, month (по умолчанию)`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]ProjectTrend`,
+					Type:        smd.Array,
+					TypeName:    "[]ProjectTrend",
+					Items: map[string]string{
+						"$ref": "#/definitions/ProjectTrend",
+					},
+					Definitions: map[string]smd.Definition{
+						"ProjectTrend": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "projectId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "bucketStart",
+									Type: smd.String,
+								},
+								{
+									Name: "reviewCount",
+									Type: smd.Integer,
+								},
+								{
+									Name: "issues",
+									Ref:  "#/definitions/IssueStats",
+									Type: smd.Object,
+								},
+								{
+									Name: "issuesByReviewType",
+									Type: smd.Object,
+								},
+								{
+									Name: "feedback",
+									Ref:  "#/definitions/FeedbackStats",
+									Type: smd.Object,
+								},
+								{
+									Name: "medianDurationMs",
+									Type: smd.Integer,
+								},
+								{
+									Name: "costUsd",
+									Type: smd.Float,
+								},
+								{
+									Name: "costPerReview",
+									Type: smd.Float,
+								},
+							},
+						},
+						"IssueStats": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "critical",
+									Type: smd.Integer,
+								},
+								{
+									Name: "high",
+									Type: smd.Integer,
+								},
+								{
+									Name: "medium",
+									Type: smd.Integer,
+								},
+								{
+									Name: "low",
+									Type: smd.Integer,
+								},
+								{
+									Name: "total",
+									Type: smd.Integer,
+								},
+							},
+						},
+						"FeedbackStats": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "valid",
+									Type: smd.Integer,
+								},
+								{
+									Name: "falsePositive",
+									Type: smd.Integer,
+								},
+								{
+									Name: "ignored",
+									Type: smd.Integer,
+								},
+								{
+									Name: "validRatio",
+									Type: smd.Float,
+								},
+								{
+									Name: "falsePositiveRatio",
+									Type: smd.Float,
+								},
+								{
+									Name: "ignoredRatio",
+									Type: smd.Float,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Bad Request",
+					500: "Internal Error",
+				},
+			},
+			"Leaderboard": {
+				Description: `Leaderboard ranks (runner, model, prompt) combinations by human feedback:
+precision (valid / processed), issues per review, cost per valid issue and latency.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "filters",
+						Optional:    true,
+						Description: `Project, project language, period (defaults to the last year) and ranking metric`,
+						Type:        smd.Object,
+						TypeName:    "LeaderboardFilters",
+						Properties: smd.PropertyList{
+							{
+								Name:     "projectId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:        "language",
+								Optional:    true,
+								Description: `язык проекта, без учёта регистра`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "from",
+								Optional:    true,
+								Description: `по умолчанию год назад от to`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "to",
+								Optional:    true,
+								Description: `по умолчанию сейчас`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "rankBy",
+								Optional:    true,
+								Description: `precision (по умолчанию), issuesPerReview, costPerValidIssue, latency`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]LeaderboardEntry`,
+					Type:        smd.Array,
+					TypeName:    "[]LeaderboardEntry",
+					Items: map[string]string{
+						"$ref": "#/definitions/LeaderboardEntry",
+					},
+					Definitions: map[string]smd.Definition{
+						"LeaderboardEntry": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "runner",
+									Type: smd.String,
+								},
+								{
+									Name: "model",
+									Type: smd.String,
+								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "promptTitle",
+									Type: smd.String,
+								},
+								{
+									Name: "reviewCount",
+									Type: smd.Integer,
+								},
+								{
+									Name: "issueCount",
+									Type: smd.Integer,
+								},
+								{
+									Name: "feedback",
+									Ref:  "#/definitions/FeedbackStats",
+									Type: smd.Object,
+								},
+								{
+									Name:        "precision",
+									Description: `valid / обработанные`,
+									Type:        smd.Float,
+								},
+								{
+									Name: "issuesPerReview",
+									Type: smd.Float,
+								},
+								{
+									Name: "costUsd",
+									Type: smd.Float,
+								},
+								{
+									Name:        "costPerValidIssue",
+									Optional:    true,
+									Description: `null, если нет valid`,
+									Type:        smd.Float,
+								},
+								{
+									Name:        "medianDurationMs",
+									Description: `латентность`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+						"FeedbackStats": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "valid",
+									Type: smd.Integer,
+								},
+								{
+									Name: "falsePositive",
+									Type: smd.Integer,
+								},
+								{
+									Name: "ignored",
+									Type: smd.Integer,
+								},
+								{
+									Name: "validRatio",
+									Type: smd.Float,
+								},
+								{
+									Name: "falsePositiveRatio",
+									Type: smd.Float,
+								},
+								{
+									Name: "ignoredRatio",
+									Type: smd.Float,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Bad Request",
+					500: "Internal Error",
+				},
+			},
 			"Get": {
 				Description: `Get returns list of reviews for a project.`,
 				Parameters: []smd.JSONSchema{
@@ -250,6 +550,11 @@ func (ReviewService) SMD() smd.ServiceInfo {
 								Optional: true,
 								Type:     smd.String,
 							},
+							{
+								Name:     "sourceBranch",
+								Optional: true,
+								Type:     smd.String,
+							},
 						},
 					},
 					{
@@ -323,6 +628,17 @@ func (ReviewService) SMD() smd.ServiceInfo {
 									Optional: true,
 									Type:     smd.Integer,
 								},
+								{
+									Name:     "previousReviewId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "versionStats",
+									Optional: true,
+									Ref:      "#/definitions/VersionStats",
+									Type:     smd.Object,
+								},
 							},
 						},
 						"ReviewFileSummary": {
@@ -368,6 +684,23 @@ func (ReviewService) SMD() smd.ServiceInfo {
 								},
 							},
 						},
+						"VersionStats": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "new",
+									Type: smd.Integer,
+								},
+								{
+									Name: "persisting",
+									Type: smd.Integer,
+								},
+								{
+									Name: "fixed",
+									Type: smd.Integer,
+								},
+							},
+						},
 					},
 				},
 				Errors: map[int]string{
@@ -410,6 +743,11 @@ func (ReviewService) SMD() smd.ServiceInfo {
 								Optional: true,
 								Type:     smd.String,
 							},
+							{
+								Name:     "sourceBranch",
+								Optional: true,
+								Type:     smd.String,
+							},
 						},
 					},
 				},
@@ -512,6 +850,35 @@ func (ReviewService) SMD() smd.ServiceInfo {
 							Optional: true,
 							Type:     smd.Integer,
 						},
+						{
+							Name:     "previousReviewId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name:     "versionStats",
+							Optional: true,
+							Ref:      "#/definitions/VersionStats",
+							Type:     smd.Object,
+						},
+						{
+							Name:        "promptRevisionId",
+							Optional:    true,
+							Description: `ревизия промпта, по которой сделано ревью`,
+							Type:        smd.Integer,
+						},
+						{
+							Name:        "promptExperimentId",
+							Optional:    true,
+							Description: `A/B-эксперимент промпта, в который попало ревью`,
+							Type:        smd.Integer,
+						},
+						{
+							Name:        "promptVariant",
+							Optional:    true,
+							Description: `вариант промпта в эксперименте: A или B`,
+							Type:        smd.String,
+						},
 					},
 					Definitions: map[string]smd.Definition{
 						"ModelInfo": {
@@ -676,6 +1043,23 @@ func (ReviewService) SMD() smd.ServiceInfo {
 								},
 							},
 						},
+						"VersionStats": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "new",
+									Type: smd.Integer,
+								},
+								{
+									Name: "persisting",
+									Type: smd.Integer,
+								},
+								{
+									Name: "fixed",
+									Type: smd.Integer,
+								},
+							},
+						},
 					},
 				},
 				Errors: map[int]string{
@@ -801,6 +1185,42 @@ func (ReviewService) SMD() smd.ServiceInfo {
 									Optional: true,
 									Type:     smd.String,
 								},
+								{
+									Name:        "lifecycle",
+									Description: `Lifecycle — new, persisting (есть в предыдущей версии MR) или fixed (нет в следующей).`,
+									Type:        smd.String,
+								},
+								{
+									Name:     "previousIssueId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "fixedInReviewId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "suppressedByIssueId",
+									Optional: true,
+									Description: `SuppressedByIssueID — принятый риск (false positive / ignored), который повторяет замечание;
+такое замечание не влияет на светофор и не публикуется в MR.`,
+									Type: smd.Integer,
+								},
+								{
+									Name:     "suppressedByRuleId",
+									Optional: true,
+									Description: `SuppressedByRuleID — правило подавления проекта, под которое попадает замечание;
+такое замечание не влияет на светофор и не публикуется в MR.`,
+									Type: smd.Integer,
+								},
+								{
+									Name:     "verifierVerdict",
+									Optional: true,
+									Description: `VerifierVerdict — вердикт проверки верификатором: confirm, downgrade или reject;
+пусто, если замечание не проверялось.`,
+									Type: smd.String,
+								},
 							},
 						},
 					},
@@ -985,6 +1405,42 @@ func (ReviewService) SMD() smd.ServiceInfo {
 									Optional: true,
 									Type:     smd.String,
 								},
+								{
+									Name:        "lifecycle",
+									Description: `Lifecycle — new, persisting (есть в предыдущей версии MR) или fixed (нет в следующей).`,
+									Type:        smd.String,
+								},
+								{
+									Name:     "previousIssueId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "fixedInReviewId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "suppressedByIssueId",
+									Optional: true,
+									Description: `SuppressedByIssueID — принятый риск (false positive / ignored), который повторяет замечание;
+такое замечание не влияет на светофор и не публикуется в MR.`,
+									Type: smd.Integer,
+								},
+								{
+									Name:     "suppressedByRuleId",
+									Optional: true,
+									Description: `SuppressedByRuleID — правило подавления проекта, под которое попадает замечание;
+такое замечание не влияет на светофор и не публикуется в MR.`,
+									Type: smd.Integer,
+								},
+								{
+									Name:     "verifierVerdict",
+									Optional: true,
+									Description: `VerifierVerdict — вердикт проверки верификатором: confirm, downgrade или reject;
+пусто, если замечание не проверялось.`,
+									Type: smd.String,
+								},
 							},
 						},
 					},
@@ -1146,6 +1602,44 @@ func (s ReviewService) Invoke(ctx context.Context, method string, params json.Ra
 
 		resp.Set(s.ProjectByID(ctx, args.ProjectId))
 
+	case RPC.ReviewService.Trends:
+		var args = struct {
+			Filters *TrendFilters `json:"filters"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"filters"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Trends(ctx, args.Filters))
+
+	case RPC.ReviewService.Leaderboard:
+		var args = struct {
+			Filters *LeaderboardFilters `json:"filters"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"filters"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Leaderboard(ctx, args.Filters))
+
 	case RPC.ReviewService.Get:
 		var args = struct {
 			ProjectId    int            `json:"projectId"`
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/vmkteam/embedlog"
@@ -83,13 +84,47 @@ type slackMessage struct {
 
 // Send sends a review notification to Slack. Logs errors without returning them.
 func (n *Notifier) Send(ctx context.Context, notif ReviewNotification) {
-	body, err := json.Marshal(slackMessage{Text: notif.text()})
+	n.post(ctx, notif.WebhookURL, notif.text())
+}
+
+// ExpiredRule describes a suppression rule that has expired.
+type ExpiredRule struct {
+	ID    int
+	Scope string
+	Owner string
+}
+
+// RulesExpiredNotification contains data for a Slack notification about
+// suppression rules of a project that expired and were disabled.
+type RulesExpiredNotification struct {
+	WebhookURL   string
+	ProjectTitle string
+	Rules        []ExpiredRule
+	RulesURL     string
+}
+
+func (n RulesExpiredNotification) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ":hourglass: [%s] %d suppression rule(s) expired and no longer hide issues — *<%s|review rules>*", n.ProjectTitle, len(n.Rules), n.RulesURL)
+	for _, r := range n.Rules {
+		fmt.Fprintf(&b, "\n• #%d `%s` (owner: %s)", r.ID, r.Scope, r.Owner)
+	}
+	return b.String()
+}
+
+// SendRulesExpired sends an expired suppression rules notification to Slack. Logs errors without returning them.
+func (n *Notifier) SendRulesExpired(ctx context.Context, notif RulesExpiredNotification) {
+	n.post(ctx, notif.WebhookURL, notif.text())
+}
+
+func (n *Notifier) post(ctx context.Context, webhookURL, text string) {
+	body, err := json.Marshal(slackMessage{Text: text})
 	if err != nil {
 		n.Error(ctx, "slack: marshal error", "err", err)
 		return
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notif.WebhookURL, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
 	if err != nil {
 		n.Error(ctx, "slack: request error", "err", err)
 		return
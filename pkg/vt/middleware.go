@@ -64,6 +64,14 @@ func UserFromContext(ctx context.Context) *db.User {
 	return nil
 }
 
+// currentUserID returns ID of the user from context or nil.
+func currentUserID(ctx context.Context) *int {
+	if user := UserFromContext(ctx); user != nil {
+		return &user.ID
+	}
+	return nil
+}
+
 // HTTPAuthMiddleware checks user from authKey header
 func HTTPAuthMiddleware(commonRepo db.CommonRepo, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
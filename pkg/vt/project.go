@@ -3,8 +3,12 @@ package vt
 import (
 	"context"
 	_ "embed"
+	"errors"
+	"regexp"
+	"time"
 
 	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/reviewer"
 
 	"github.com/vmkteam/embedlog"
 	"github.com/vmkteam/zenrpc/v2"
@@ -246,12 +250,14 @@ type PromptService struct {
 	zenrpc.Service
 	embedlog.Logger
 	projectRepo db.ProjectRepo
+	pm          *reviewer.PromptManager
 }
 
 func NewPromptService(dbo db.DB, logger embedlog.Logger) *PromptService {
 	return &PromptService{
 		Logger:      logger,
 		projectRepo: db.NewProjectRepo(dbo),
+		pm:          reviewer.NewPromptManager(dbo),
 	}
 }
 
@@ -337,7 +343,7 @@ func (s PromptService) Add(ctx context.Context, prompt Prompt) (*Prompt, error)
 		return nil, ve.Error()
 	}
 
-	db, err := s.projectRepo.AddPrompt(ctx, prompt.ToDB())
+	db, err := s.pm.Add(ctx, prompt.ToDB(), currentUserID(ctx))
 	if err != nil {
 		return nil, InternalError(err)
 	}
@@ -360,7 +366,7 @@ func (s PromptService) Update(ctx context.Context, prompt Prompt) (bool, error)
 		return false, ve.Error()
 	}
 
-	ok, err := s.projectRepo.UpdatePrompt(ctx, prompt.ToDB())
+	ok, err := s.pm.Update(ctx, prompt.ToDB(), currentUserID(ctx))
 	if err != nil {
 		return false, InternalError(err)
 	}
@@ -386,6 +392,69 @@ func (s PromptService) Delete(ctx context.Context, id int) (bool, error) {
 	return ok, err
 }
 
+// Revisions returns all revisions of the Prompt, newest first.
+//
+//zenrpc:promptId int
+//zenrpc:return []PromptRevision
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s PromptService) Revisions(ctx context.Context, promptId int) ([]PromptRevision, error) {
+	if _, err := s.byID(ctx, promptId); err != nil {
+		return nil, err
+	}
+
+	list, err := s.pm.Revisions(ctx, promptId)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	revisions := make([]PromptRevision, 0, len(list))
+	for i := range list {
+		revisions = append(revisions, *NewPromptRevision(&list[i].PromptRevision))
+	}
+	return revisions, nil
+}
+
+// Diff returns per-section line diffs between two revisions of the Prompt.
+//
+//zenrpc:promptId int
+//zenrpc:fromId revision to diff from
+//zenrpc:toId revision to diff to
+//zenrpc:return []PromptSectionDiff
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s PromptService) Diff(ctx context.Context, promptId, fromId, toId int) ([]PromptSectionDiff, error) {
+	list, err := s.pm.Diff(ctx, promptId, fromId, toId)
+	if errors.Is(err, reviewer.ErrPromptRevisionNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, InternalError(err)
+	}
+
+	diffs := make([]PromptSectionDiff, len(list))
+	for i := range list {
+		diffs[i] = NewPromptSectionDiff(list[i])
+	}
+	return diffs, nil
+}
+
+// Rollback restores the Prompt texts from the revision and records them as a new revision.
+//
+//zenrpc:promptId int
+//zenrpc:revisionId revision to restore
+//zenrpc:return PromptRevision
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s PromptService) Rollback(ctx context.Context, promptId, revisionId int) (*PromptRevision, error) {
+	rev, err := s.pm.Rollback(ctx, promptId, revisionId, currentUserID(ctx))
+	if errors.Is(err, reviewer.ErrPromptRevisionNotFound) || errors.Is(err, reviewer.ErrPromptNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, InternalError(err)
+	}
+	return NewPromptRevision(&rev.PromptRevision), nil
+}
+
 // Validate verifies that Prompt data is valid.
 //
 //zenrpc:prompt Prompt
@@ -421,6 +490,224 @@ func (s PromptService) isValid(ctx context.Context, prompt Prompt, isUpdate bool
 	return v
 }
 
+type PromptExperimentService struct {
+	zenrpc.Service
+	embedlog.Logger
+	projectRepo db.ProjectRepo
+}
+
+func NewPromptExperimentService(dbo db.DB, logger embedlog.Logger) *PromptExperimentService {
+	return &PromptExperimentService{
+		Logger:      logger,
+		projectRepo: db.NewProjectRepo(dbo),
+	}
+}
+
+func (s PromptExperimentService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.projectRepo.DefaultPromptExperimentSort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.PromptExperiment.ID, db.Columns.PromptExperiment.ProjectID, db.Columns.PromptExperiment.Title, db.Columns.PromptExperiment.VariantPromptID, db.Columns.PromptExperiment.TrafficPercent, db.Columns.PromptExperiment.CreatedAt, db.Columns.PromptExperiment.StatusID:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count returns count PromptExperiments according to conditions in search params.
+//
+//zenrpc:search PromptExperimentSearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s PromptExperimentService) Count(ctx context.Context, search *PromptExperimentSearch) (int, error) {
+	count, err := s.projectRepo.CountPromptExperiments(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get returns а list of PromptExperiments according to conditions in search params.
+//
+//zenrpc:search PromptExperimentSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []PromptExperimentSummary
+//zenrpc:500 Internal Error
+func (s PromptExperimentService) Get(ctx context.Context, search *PromptExperimentSearch, viewOps *ViewOps) ([]PromptExperimentSummary, error) {
+	list, err := s.projectRepo.PromptExperimentsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.projectRepo.FullPromptExperiment())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	promptExperiments := make([]PromptExperimentSummary, 0, len(list))
+	for i := range list {
+		if promptExperiment := NewPromptExperimentSummary(&list[i]); promptExperiment != nil {
+			promptExperiments = append(promptExperiments, *promptExperiment)
+		}
+	}
+	return promptExperiments, nil
+}
+
+// GetByID returns a PromptExperiment by its ID.
+//
+//zenrpc:id int
+//zenrpc:return PromptExperiment
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s PromptExperimentService) GetByID(ctx context.Context, id int) (*PromptExperiment, error) {
+	db, err := s.byID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewPromptExperiment(db), nil
+}
+
+func (s PromptExperimentService) byID(ctx context.Context, id int) (*db.PromptExperiment, error) {
+	db, err := s.projectRepo.PromptExperimentByID(ctx, id, s.projectRepo.FullPromptExperiment())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if db == nil {
+		return nil, ErrNotFound
+	}
+	return db, nil
+}
+
+// Add adds a PromptExperiment from the query.
+//
+//zenrpc:promptExperiment PromptExperiment
+//zenrpc:return PromptExperiment
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s PromptExperimentService) Add(ctx context.Context, promptExperiment PromptExperiment) (*PromptExperiment, error) {
+	if ve := s.isValid(ctx, promptExperiment, false); ve.HasErrors() {
+		return nil, ve.Error()
+	}
+
+	db, err := s.projectRepo.AddPromptExperiment(ctx, promptExperiment.ToDB())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return NewPromptExperiment(db), nil
+}
+
+// Update updates the PromptExperiment data identified by id from the query.
+//
+//zenrpc:promptExperiments PromptExperiment
+//zenrpc:return PromptExperiment
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s PromptExperimentService) Update(ctx context.Context, promptExperiment PromptExperiment) (bool, error) {
+	if _, err := s.byID(ctx, promptExperiment.ID); err != nil {
+		return false, err
+	}
+
+	if ve := s.isValid(ctx, promptExperiment, true); ve.HasErrors() {
+		return false, ve.Error()
+	}
+
+	ok, err := s.projectRepo.UpdatePromptExperiment(ctx, promptExperiment.ToDB())
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, nil
+}
+
+// Delete deletes the PromptExperiment by its ID.
+//
+//zenrpc:id int
+//zenrpc:return isDeleted
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s PromptExperimentService) Delete(ctx context.Context, id int) (bool, error) {
+	if _, err := s.byID(ctx, id); err != nil {
+		return false, err
+	}
+
+	ok, err := s.projectRepo.DeletePromptExperiment(ctx, id)
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, err
+}
+
+// Validate verifies that PromptExperiment data is valid.
+//
+//zenrpc:promptExperiment PromptExperiment
+//zenrpc:return []FieldError
+//zenrpc:500 Internal Error
+func (s PromptExperimentService) Validate(ctx context.Context, promptExperiment PromptExperiment) ([]FieldError, error) {
+	isUpdate := promptExperiment.ID != 0
+	if isUpdate {
+		_, err := s.byID(ctx, promptExperiment.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ve := s.isValid(ctx, promptExperiment, isUpdate)
+	if ve.HasInternalError() {
+		return nil, ve.Error()
+	}
+
+	return ve.Fields(), nil
+}
+
+func (s PromptExperimentService) isValid(ctx context.Context, promptExperiment PromptExperiment, isUpdate bool) Validator {
+	_ = isUpdate
+
+	var v Validator
+
+	if v.CheckBasic(ctx, promptExperiment); v.HasInternalError() {
+		return v
+	}
+
+	// check fks
+	var project *db.Project
+	if promptExperiment.ProjectID != 0 {
+		item, err := s.projectRepo.ProjectByID(ctx, promptExperiment.ProjectID)
+		if err != nil {
+			v.SetInternalError(err)
+		} else if item == nil {
+			v.Append("projectId", FieldErrorIncorrect)
+		}
+		project = item
+	}
+	if promptExperiment.VariantPromptID != 0 {
+		item, err := s.projectRepo.PromptByID(ctx, promptExperiment.VariantPromptID)
+		if err != nil {
+			v.SetInternalError(err)
+		} else if item == nil {
+			v.Append("variantPromptId", FieldErrorIncorrect)
+		}
+	}
+
+	// custom validation starts here
+	// variant B must differ from the control prompt, otherwise both variants are the same
+	if project != nil && project.PromptID == promptExperiment.VariantPromptID {
+		v.Append("variantPromptId", FieldErrorIncorrect)
+	}
+
+	// reviews are assigned by the newest enabled experiment only, so a second one would never run
+	if promptExperiment.StatusID == db.StatusEnabled && promptExperiment.ProjectID != 0 {
+		item, err := s.projectRepo.OnePromptExperiment(ctx, &db.PromptExperimentSearch{
+			ProjectID: &promptExperiment.ProjectID,
+			StatusID:  reviewer.Ptr(db.StatusEnabled),
+			NotID:     &promptExperiment.ID,
+		})
+		if err != nil {
+			v.SetInternalError(err)
+		} else if item != nil {
+			v.Append("statusId", FieldErrorUnique)
+		}
+	}
+
+	return v
+}
+
 type SlackChannelService struct {
 	zenrpc.Service
 	embedlog.Logger
@@ -599,6 +886,219 @@ func (s SlackChannelService) isValid(ctx context.Context, slackChannel SlackChan
 	return v
 }
 
+type SuppressionRuleService struct {
+	zenrpc.Service
+	embedlog.Logger
+	projectRepo db.ProjectRepo
+}
+
+func NewSuppressionRuleService(dbo db.DB, logger embedlog.Logger) *SuppressionRuleService {
+	return &SuppressionRuleService{
+		Logger:      logger,
+		projectRepo: db.NewProjectRepo(dbo),
+	}
+}
+
+func (s SuppressionRuleService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.projectRepo.DefaultSuppressionRuleSort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.SuppressionRule.ID, db.Columns.SuppressionRule.ProjectID, db.Columns.SuppressionRule.PathGlob, db.Columns.SuppressionRule.IssueType, db.Columns.SuppressionRule.ReviewType, db.Columns.SuppressionRule.Owner, db.Columns.SuppressionRule.ExpiresAt, db.Columns.SuppressionRule.CreatedAt, db.Columns.SuppressionRule.StatusID:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count returns count SuppressionRules according to conditions in search params.
+//
+//zenrpc:search SuppressionRuleSearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s SuppressionRuleService) Count(ctx context.Context, search *SuppressionRuleSearch) (int, error) {
+	count, err := s.projectRepo.CountSuppressionRules(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get returns а list of SuppressionRules according to conditions in search params.
+//
+//zenrpc:search SuppressionRuleSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []SuppressionRuleSummary
+//zenrpc:500 Internal Error
+func (s SuppressionRuleService) Get(ctx context.Context, search *SuppressionRuleSearch, viewOps *ViewOps) ([]SuppressionRuleSummary, error) {
+	list, err := s.projectRepo.SuppressionRulesByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.projectRepo.FullSuppressionRule())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	suppressionRules := make([]SuppressionRuleSummary, 0, len(list))
+	for i := range list {
+		if suppressionRule := NewSuppressionRuleSummary(&list[i]); suppressionRule != nil {
+			suppressionRules = append(suppressionRules, *suppressionRule)
+		}
+	}
+	return suppressionRules, nil
+}
+
+// GetByID returns a SuppressionRule by its ID.
+//
+//zenrpc:id int
+//zenrpc:return SuppressionRule
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s SuppressionRuleService) GetByID(ctx context.Context, id int) (*SuppressionRule, error) {
+	db, err := s.byID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewSuppressionRule(db), nil
+}
+
+func (s SuppressionRuleService) byID(ctx context.Context, id int) (*db.SuppressionRule, error) {
+	db, err := s.projectRepo.SuppressionRuleByID(ctx, id, s.projectRepo.FullSuppressionRule())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if db == nil {
+		return nil, ErrNotFound
+	}
+	return db, nil
+}
+
+// Add adds a SuppressionRule from the query.
+//
+//zenrpc:suppressionRule SuppressionRule
+//zenrpc:return SuppressionRule
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s SuppressionRuleService) Add(ctx context.Context, suppressionRule SuppressionRule) (*SuppressionRule, error) {
+	if ve := s.isValid(ctx, suppressionRule, false); ve.HasErrors() {
+		return nil, ve.Error()
+	}
+
+	db, err := s.projectRepo.AddSuppressionRule(ctx, suppressionRule.ToDB())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return NewSuppressionRule(db), nil
+}
+
+// Update updates the SuppressionRule data identified by id from the query.
+//
+//zenrpc:suppressionRules SuppressionRule
+//zenrpc:return SuppressionRule
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s SuppressionRuleService) Update(ctx context.Context, suppressionRule SuppressionRule) (bool, error) {
+	if _, err := s.byID(ctx, suppressionRule.ID); err != nil {
+		return false, err
+	}
+
+	if ve := s.isValid(ctx, suppressionRule, true); ve.HasErrors() {
+		return false, ve.Error()
+	}
+
+	ok, err := s.projectRepo.UpdateSuppressionRule(ctx, suppressionRule.ToDB())
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, nil
+}
+
+// Delete deletes the SuppressionRule by its ID.
+//
+//zenrpc:id int
+//zenrpc:return isDeleted
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s SuppressionRuleService) Delete(ctx context.Context, id int) (bool, error) {
+	if _, err := s.byID(ctx, id); err != nil {
+		return false, err
+	}
+
+	ok, err := s.projectRepo.DeleteSuppressionRule(ctx, id)
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, err
+}
+
+// Validate verifies that SuppressionRule data is valid.
+//
+//zenrpc:suppressionRule SuppressionRule
+//zenrpc:return []FieldError
+//zenrpc:500 Internal Error
+func (s SuppressionRuleService) Validate(ctx context.Context, suppressionRule SuppressionRule) ([]FieldError, error) {
+	isUpdate := suppressionRule.ID != 0
+	if isUpdate {
+		_, err := s.byID(ctx, suppressionRule.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ve := s.isValid(ctx, suppressionRule, isUpdate)
+	if ve.HasInternalError() {
+		return nil, ve.Error()
+	}
+
+	return ve.Fields(), nil
+}
+
+func (s SuppressionRuleService) isValid(ctx context.Context, suppressionRule SuppressionRule, isUpdate bool) Validator {
+	_ = isUpdate
+
+	var v Validator
+
+	if v.CheckBasic(ctx, suppressionRule); v.HasInternalError() {
+		return v
+	}
+
+	// check fks
+	if suppressionRule.ProjectID != 0 {
+		item, err := s.projectRepo.ProjectByID(ctx, suppressionRule.ProjectID)
+		if err != nil {
+			v.SetInternalError(err)
+		} else if item == nil {
+			v.Append("projectId", FieldErrorIncorrect)
+		}
+	}
+
+	// custom validation starts here
+	rule := reviewer.NewSuppressionRule(suppressionRule.ToDB())
+	if !rule.IsScoped() {
+		v.Append("pathGlob", FieldErrorRequired)
+		v.Append("titlePattern", FieldErrorRequired)
+	}
+	if rule.PathGlob != nil && !reviewer.IsValidGlob(*rule.PathGlob) {
+		v.Append("pathGlob", FieldErrorFormat)
+	}
+	if rule.TitlePattern != nil {
+		if _, err := regexp.Compile(*rule.TitlePattern); err != nil {
+			v.Append("titlePattern", FieldErrorFormat)
+		}
+	}
+	if rule.ReviewType != nil && !reviewer.IsValidReviewType(*rule.ReviewType) {
+		v.Append("reviewType", FieldErrorIncorrect)
+	}
+	if rule.MaxSeverity != nil && !reviewer.IsValidSeverity(*rule.MaxSeverity) {
+		v.Append("maxSeverity", FieldErrorIncorrect)
+	}
+	// an enabled rule past its expiry would be disabled again within the hour
+	if rule.StatusID == db.StatusEnabled && rule.IsExpired(time.Now()) {
+		v.Append("expiresAt", FieldErrorIncorrect)
+	}
+
+	return v
+}
+
 type TaskTrackerService struct {
 	zenrpc.Service
 	embedlog.Logger
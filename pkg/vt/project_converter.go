@@ -2,6 +2,7 @@ package vt
 
 import (
 	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/reviewer"
 )
 
 func NewProject(in *db.Project) *Project {
@@ -93,6 +94,81 @@ func NewPromptSummary(in *db.Prompt) *PromptSummary {
 	}
 }
 
+func NewPromptRevision(in *db.PromptRevision) *PromptRevision {
+	if in == nil {
+		return nil
+	}
+
+	return &PromptRevision{
+		ID:           in.ID,
+		PromptID:     in.PromptID,
+		Common:       in.Common,
+		Architecture: in.Architecture,
+		Code:         in.Code,
+		Security:     in.Security,
+		Tests:        in.Tests,
+		Operability:  in.Operability,
+		UserID:       in.UserID,
+		CreatedAt:    fmtDate(in.CreatedAt),
+
+		User: NewUserSummary(in.User),
+	}
+}
+
+func NewPromptSectionDiff(in reviewer.PromptSectionDiff) PromptSectionDiff {
+	lines := make([]PromptDiffLine, len(in.Lines))
+	for i, l := range in.Lines {
+		lines[i] = PromptDiffLine{Op: l.Op, Text: l.Text}
+	}
+
+	return PromptSectionDiff{
+		Section: in.Section,
+		Changed: in.Changed,
+		Lines:   lines,
+	}
+}
+
+func NewPromptExperiment(in *db.PromptExperiment) *PromptExperiment {
+	if in == nil {
+		return nil
+	}
+
+	promptExperiment := &PromptExperiment{
+		ID:              in.ID,
+		ProjectID:       in.ProjectID,
+		Title:           in.Title,
+		VariantPromptID: in.VariantPromptID,
+		TrafficPercent:  in.TrafficPercent,
+		CreatedAt:       fmtDate(in.CreatedAt),
+		StatusID:        in.StatusID,
+
+		Project:       NewProjectSummary(in.Project),
+		VariantPrompt: NewPromptSummary(in.VariantPrompt),
+		Status:        NewStatus(in.StatusID),
+	}
+
+	return promptExperiment
+}
+
+func NewPromptExperimentSummary(in *db.PromptExperiment) *PromptExperimentSummary {
+	if in == nil {
+		return nil
+	}
+
+	return &PromptExperimentSummary{
+		ID:              in.ID,
+		ProjectID:       in.ProjectID,
+		Title:           in.Title,
+		VariantPromptID: in.VariantPromptID,
+		TrafficPercent:  in.TrafficPercent,
+		CreatedAt:       fmtDate(in.CreatedAt),
+
+		Project:       NewProjectSummary(in.Project),
+		VariantPrompt: NewPromptSummary(in.VariantPrompt),
+		Status:        NewStatus(in.StatusID),
+	}
+}
+
 func NewSlackChannel(in *db.SlackChannel) *SlackChannel {
 	if in == nil {
 		return nil
@@ -126,6 +202,55 @@ func NewSlackChannelSummary(in *db.SlackChannel) *SlackChannelSummary {
 	}
 }
 
+func NewSuppressionRule(in *db.SuppressionRule) *SuppressionRule {
+	if in == nil {
+		return nil
+	}
+
+	suppressionRule := &SuppressionRule{
+		ID:           in.ID,
+		ProjectID:    in.ProjectID,
+		PathGlob:     in.PathGlob,
+		IssueType:    in.IssueType,
+		ReviewType:   in.ReviewType,
+		TitlePattern: in.TitlePattern,
+		MaxSeverity:  in.MaxSeverity,
+		Reason:       in.Reason,
+		Owner:        in.Owner,
+		ExpiresAt:    in.ExpiresAt,
+		CreatedAt:    fmtDate(in.CreatedAt),
+		StatusID:     in.StatusID,
+
+		Project: NewProjectSummary(in.Project),
+		Status:  NewStatus(in.StatusID),
+	}
+
+	return suppressionRule
+}
+
+func NewSuppressionRuleSummary(in *db.SuppressionRule) *SuppressionRuleSummary {
+	if in == nil {
+		return nil
+	}
+
+	return &SuppressionRuleSummary{
+		ID:           in.ID,
+		ProjectID:    in.ProjectID,
+		PathGlob:     in.PathGlob,
+		IssueType:    in.IssueType,
+		ReviewType:   in.ReviewType,
+		TitlePattern: in.TitlePattern,
+		MaxSeverity:  in.MaxSeverity,
+		Reason:       in.Reason,
+		Owner:        in.Owner,
+		ExpiresAt:    in.ExpiresAt,
+		CreatedAt:    fmtDate(in.CreatedAt),
+
+		Project: NewProjectSummary(in.Project),
+		Status:  NewStatus(in.StatusID),
+	}
+}
+
 func NewTaskTracker(in *db.TaskTracker) *TaskTracker {
 	if in == nil {
 		return nil
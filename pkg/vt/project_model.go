@@ -2,6 +2,9 @@
 package vt
 
 import (
+	"strings"
+	"time"
+
 	"reviewsrv/pkg/db"
 )
 
@@ -171,6 +174,100 @@ type PromptSummary struct {
 	Status *Status `json:"status"`
 }
 
+type PromptRevision struct {
+	ID           int    `json:"id"`
+	PromptID     int    `json:"promptId"`
+	Common       string `json:"common"`
+	Architecture string `json:"architecture"`
+	Code         string `json:"code"`
+	Security     string `json:"security"`
+	Tests        string `json:"tests"`
+	Operability  string `json:"operability"`
+	UserID       *int   `json:"userId"`
+	CreatedAt    string `json:"createdAt"`
+
+	User *UserSummary `json:"user"`
+}
+
+type PromptDiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type PromptSectionDiff struct {
+	Section string           `json:"section"`
+	Changed bool             `json:"changed"`
+	Lines   []PromptDiffLine `json:"lines"`
+}
+
+type PromptExperiment struct {
+	ID              int    `json:"id"`
+	ProjectID       int    `json:"projectId" validate:"required"`
+	Title           string `json:"title" validate:"required,max=255"`
+	VariantPromptID int    `json:"variantPromptId" validate:"required"`
+	TrafficPercent  int    `json:"trafficPercent" validate:"required,min=1,max=99"`
+	CreatedAt       string `json:"createdAt"`
+	StatusID        int    `json:"statusId" validate:"required,status"`
+
+	Project       *ProjectSummary `json:"project"`
+	VariantPrompt *PromptSummary  `json:"variantPrompt"`
+	Status        *Status         `json:"status"`
+}
+
+func (pe *PromptExperiment) ToDB() *db.PromptExperiment {
+	if pe == nil {
+		return nil
+	}
+
+	promptExperiment := &db.PromptExperiment{
+		ID:              pe.ID,
+		ProjectID:       pe.ProjectID,
+		Title:           pe.Title,
+		VariantPromptID: pe.VariantPromptID,
+		TrafficPercent:  pe.TrafficPercent,
+		StatusID:        pe.StatusID,
+	}
+
+	return promptExperiment
+}
+
+type PromptExperimentSearch struct {
+	ID              *int    `json:"id"`
+	ProjectID       *int    `json:"projectId"`
+	Title           *string `json:"title"`
+	VariantPromptID *int    `json:"variantPromptId"`
+	StatusID        *int    `json:"statusId"`
+	IDs             []int   `json:"ids"`
+}
+
+func (pes *PromptExperimentSearch) ToDB() *db.PromptExperimentSearch {
+	if pes == nil {
+		return nil
+	}
+
+	return &db.PromptExperimentSearch{
+		ID:              pes.ID,
+		ProjectID:       pes.ProjectID,
+		TitleILike:      pes.Title,
+		VariantPromptID: pes.VariantPromptID,
+		StatusID:        pes.StatusID,
+		IDs:             pes.IDs,
+	}
+}
+
+type PromptExperimentSummary struct {
+	ID              int    `json:"id"`
+	ProjectID       int    `json:"projectId"`
+	Title           string `json:"title"`
+	VariantPromptID int    `json:"variantPromptId"`
+	TrafficPercent  int    `json:"trafficPercent"`
+	CreatedAt       string `json:"createdAt"`
+
+	Project       *ProjectSummary `json:"project"`
+	VariantPrompt *PromptSummary  `json:"variantPrompt"`
+	Status        *Status         `json:"status"`
+}
+
 type SlackChannel struct {
 	ID         int    `json:"id"`
 	Title      string `json:"title" validate:"required,max=255"`
@@ -230,6 +327,107 @@ type SlackChannelSummary struct {
 	Status *Status `json:"status"`
 }
 
+type SuppressionRule struct {
+	ID           int       `json:"id"`
+	ProjectID    int       `json:"projectId" validate:"required"`
+	PathGlob     *string   `json:"pathGlob" validate:"omitempty,max=255"`
+	IssueType    *string   `json:"issueType" validate:"omitempty,max=32"`
+	ReviewType   *string   `json:"reviewType" validate:"omitempty,max=32"`
+	TitlePattern *string   `json:"titlePattern" validate:"omitempty,max=255"`
+	MaxSeverity  *string   `json:"maxSeverity" validate:"omitempty,max=16"`
+	Reason       string    `json:"reason" validate:"required,max=1024"`
+	Owner        string    `json:"owner" validate:"required,max=255"`
+	ExpiresAt    time.Time `json:"expiresAt" validate:"required"`
+	CreatedAt    string    `json:"createdAt"`
+	StatusID     int       `json:"statusId" validate:"required,status"`
+
+	Project *ProjectSummary `json:"project"`
+	Status  *Status         `json:"status"`
+}
+
+func (sr *SuppressionRule) ToDB() *db.SuppressionRule {
+	if sr == nil {
+		return nil
+	}
+
+	suppressionRule := &db.SuppressionRule{
+		ID:           sr.ID,
+		ProjectID:    sr.ProjectID,
+		PathGlob:     trimmedOrNil(sr.PathGlob),
+		IssueType:    trimmedOrNil(sr.IssueType),
+		ReviewType:   trimmedOrNil(sr.ReviewType),
+		TitlePattern: trimmedOrNil(sr.TitlePattern),
+		MaxSeverity:  trimmedOrNil(sr.MaxSeverity),
+		Reason:       sr.Reason,
+		Owner:        sr.Owner,
+		ExpiresAt:    sr.ExpiresAt,
+		StatusID:     sr.StatusID,
+	}
+
+	return suppressionRule
+}
+
+// trimmedOrNil treats blank optional rule criteria from the form as unset.
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+type SuppressionRuleSearch struct {
+	ID           *int    `json:"id"`
+	ProjectID    *int    `json:"projectId"`
+	PathGlob     *string `json:"pathGlob"`
+	IssueType    *string `json:"issueType"`
+	ReviewType   *string `json:"reviewType"`
+	TitlePattern *string `json:"titlePattern"`
+	Reason       *string `json:"reason"`
+	Owner        *string `json:"owner"`
+	StatusID     *int    `json:"statusId"`
+	IDs          []int   `json:"ids"`
+}
+
+func (srs *SuppressionRuleSearch) ToDB() *db.SuppressionRuleSearch {
+	if srs == nil {
+		return nil
+	}
+
+	return &db.SuppressionRuleSearch{
+		ID:                srs.ID,
+		ProjectID:         srs.ProjectID,
+		PathGlobILike:     srs.PathGlob,
+		IssueType:         srs.IssueType,
+		ReviewType:        srs.ReviewType,
+		TitlePatternILike: srs.TitlePattern,
+		ReasonILike:       srs.Reason,
+		OwnerILike:        srs.Owner,
+		StatusID:          srs.StatusID,
+		IDs:               srs.IDs,
+	}
+}
+
+type SuppressionRuleSummary struct {
+	ID           int       `json:"id"`
+	ProjectID    int       `json:"projectId"`
+	PathGlob     *string   `json:"pathGlob"`
+	IssueType    *string   `json:"issueType"`
+	ReviewType   *string   `json:"reviewType"`
+	TitlePattern *string   `json:"titlePattern"`
+	MaxSeverity  *string   `json:"maxSeverity"`
+	Reason       string    `json:"reason"`
+	Owner        string    `json:"owner"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	CreatedAt    string    `json:"createdAt"`
+
+	Project *ProjectSummary `json:"project"`
+	Status  *Status         `json:"status"`
+}
+
 type TaskTracker struct {
 	ID          int     `json:"id"`
 	Title       string  `json:"title" validate:"required,max=255"`
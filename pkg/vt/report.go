@@ -0,0 +1,209 @@
+package vt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"reviewsrv/pkg/db"
+	"reviewsrv/pkg/reviewer"
+
+	"github.com/vmkteam/embedlog"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+type ReportService struct {
+	zenrpc.Service
+	embedlog.Logger
+	rm          *reviewer.ReviewManager
+	projectRepo db.ProjectRepo
+}
+
+func NewReportService(dbo db.DB, logger embedlog.Logger) *ReportService {
+	return &ReportService{
+		Logger:      logger,
+		rm:          reviewer.NewReviewManager(dbo),
+		projectRepo: db.NewProjectRepo(dbo),
+	}
+}
+
+// LeaderboardSearch contains filters for the model leaderboard.
+type LeaderboardSearch struct {
+	ProjectID *int       `json:"projectId"`
+	Language  *string    `json:"language"`
+	From      *time.Time `json:"from"`
+	To        *time.Time `json:"to"`
+	RankBy    *string    `json:"rankBy"`
+}
+
+// ToDomain converts the search to a domain LeaderboardSearch.
+func (ls *LeaderboardSearch) ToDomain() reviewer.LeaderboardSearch {
+	var s reviewer.LeaderboardSearch
+	if ls == nil {
+		return s
+	}
+	s.ProjectID, s.Language = ls.ProjectID, ls.Language
+	if ls.From != nil {
+		s.From = *ls.From
+	}
+	if ls.To != nil {
+		s.To = *ls.To
+	}
+	if ls.RankBy != nil {
+		s.RankBy = *ls.RankBy
+	}
+	return s
+}
+
+// LeaderboardEntry is a leaderboard row for a (runner, model, prompt) combination.
+type LeaderboardEntry struct {
+	Runner            string   `json:"runner"`
+	Model             string   `json:"model"`
+	PromptID          int      `json:"promptId"`
+	PromptTitle       string   `json:"promptTitle"`
+	ReviewCount       int      `json:"reviewCount"`
+	IssueCount        int      `json:"issueCount"`
+	Processed         int      `json:"processed"`
+	Valid             int      `json:"valid"`
+	FalsePositive     int      `json:"falsePositive"`
+	Ignored           int      `json:"ignored"`
+	Precision         float64  `json:"precision"`
+	IssuesPerReview   float64  `json:"issuesPerReview"`
+	CostUsd           float64  `json:"costUsd"`
+	CostPerValidIssue *float64 `json:"costPerValidIssue"`
+	MedianDurationMS  int      `json:"medianDurationMs"`
+}
+
+func newLeaderboardEntry(in reviewer.LeaderboardEntry) LeaderboardEntry {
+	return LeaderboardEntry{
+		Runner:            in.Runner,
+		Model:             in.Model,
+		PromptID:          in.PromptID,
+		PromptTitle:       in.PromptTitle,
+		ReviewCount:       in.ReviewCount,
+		IssueCount:        in.IssueCount,
+		Processed:         in.Feedback().Processed(),
+		Valid:             in.Valid,
+		FalsePositive:     in.FalsePositive,
+		Ignored:           in.Ignored,
+		Precision:         in.Precision(),
+		IssuesPerReview:   in.IssuesPerReview(),
+		CostUsd:           in.CostUsd,
+		CostPerValidIssue: in.CostPerValidIssue(),
+		MedianDurationMS:  in.Latency(),
+	}
+}
+
+// Leaderboard ranks (runner, model, prompt) combinations by precision (valid / processed),
+// issues per review, cost per valid issue or latency.
+//
+//zenrpc:search Project, project language, period (defaults to the last year) and rankBy: precision, issuesPerReview, costPerValidIssue, latency
+//zenrpc:return []LeaderboardEntry
+//zenrpc:400 Bad Request
+//zenrpc:500 Internal Error
+func (s ReportService) Leaderboard(ctx context.Context, search *LeaderboardSearch) ([]LeaderboardEntry, error) {
+	entries, err := s.rm.Leaderboard(ctx, search.ToDomain())
+	switch {
+	case errors.Is(err, reviewer.ErrInvalidRank), errors.Is(err, reviewer.ErrInvalidPeriod):
+		return nil, zenrpc.NewStringError(http.StatusBadRequest, err.Error())
+	case err != nil:
+		return nil, InternalError(err)
+	}
+
+	out := make([]LeaderboardEntry, len(entries))
+	for i := range entries {
+		out[i] = newLeaderboardEntry(entries[i])
+	}
+	return out, nil
+}
+
+// ExperimentVariant contains stats of the reviews assigned to one prompt experiment variant.
+type ExperimentVariant struct {
+	Variant              string  `json:"variant"`
+	ReviewCount          int     `json:"reviewCount"`
+	IssueCount           int     `json:"issueCount"`
+	Processed            int     `json:"processed"`
+	Valid                int     `json:"valid"`
+	FalsePositive        int     `json:"falsePositive"`
+	Ignored              int     `json:"ignored"`
+	Unfilled             int     `json:"unfilled"`
+	CostUsd              float64 `json:"costUsd"`
+	FalsePositiveRate    float64 `json:"falsePositiveRate"`
+	ValidIssuesPerReview float64 `json:"validIssuesPerReview"`
+	CostPerReview        float64 `json:"costPerReview"`
+	UnfilledRate         float64 `json:"unfilledRate"`
+}
+
+func newExperimentVariant(in reviewer.ExperimentVariantStats) ExperimentVariant {
+	return ExperimentVariant{
+		Variant:              in.Variant,
+		ReviewCount:          in.ReviewCount,
+		IssueCount:           in.IssueCount,
+		Processed:            in.Feedback.Processed(),
+		Valid:                in.Feedback.Valid,
+		FalsePositive:        in.Feedback.FalsePositive,
+		Ignored:              in.Feedback.Ignored,
+		Unfilled:             in.Unfilled,
+		CostUsd:              in.CostUsd,
+		FalsePositiveRate:    in.FalsePositiveRate(),
+		ValidIssuesPerReview: in.ValidIssuesPerReview(),
+		CostPerReview:        in.CostPerReview(),
+		UnfilledRate:         in.UnfilledRate(),
+	}
+}
+
+// ExperimentComparison compares a metric of variant B against variant A.
+type ExperimentComparison struct {
+	Metric       string   `json:"metric"`
+	A            float64  `json:"a"`
+	B            float64  `json:"b"`
+	PValue       *float64 `json:"pValue"`
+	Significance string   `json:"significance"`
+}
+
+// ExperimentReport compares the variants of a prompt experiment.
+type ExperimentReport struct {
+	Experiment  *PromptExperimentSummary `json:"experiment"`
+	A           ExperimentVariant        `json:"a"`
+	B           ExperimentVariant        `json:"b"`
+	Comparisons []ExperimentComparison   `json:"comparisons"`
+}
+
+func newExperimentReport(in *reviewer.ExperimentReport) *ExperimentReport {
+	comparisons := make([]ExperimentComparison, len(in.Comparisons))
+	for i, c := range in.Comparisons {
+		comparisons[i] = ExperimentComparison{Metric: c.Metric, A: c.A, B: c.B, PValue: c.PValue, Significance: c.Significance}
+	}
+
+	return &ExperimentReport{
+		Experiment:  NewPromptExperimentSummary(&in.Experiment.PromptExperiment),
+		A:           newExperimentVariant(in.A),
+		B:           newExperimentVariant(in.B),
+		Comparisons: comparisons,
+	}
+}
+
+// Experiment compares the prompt experiment variants: false positive rate, valid issues per review,
+// cost per review and unfilled review.json rate. Each metric has a two-sided p-value and
+// significance: significant (p < 0.05), notSignificant or insufficientData (under 10 samples per variant).
+//
+//zenrpc:promptExperimentId Prompt experiment ID
+//zenrpc:return ExperimentReport
+//zenrpc:404 Not Found
+//zenrpc:500 Internal Error
+func (s ReportService) Experiment(ctx context.Context, promptExperimentId int) (*ExperimentReport, error) {
+	exp, err := s.projectRepo.PromptExperimentByID(ctx, promptExperimentId, s.projectRepo.FullPromptExperiment())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if exp == nil {
+		return nil, ErrNotFound
+	}
+
+	report, err := s.rm.ExperimentReport(ctx, reviewer.NewPromptExperiment(exp))
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return newExperimentReport(report), nil
+}
@@ -17,12 +17,15 @@ const (
 )
 
 const (
-	NSAuth         = "auth"
-	NSUser         = "user"
-	NSProject      = "project"
-	NSPrompt       = "prompt"
-	NSSlackChannel = "slackChannel"
-	NSTaskTracker  = "taskTracker"
+	NSAuth             = "auth"
+	NSUser             = "user"
+	NSProject          = "project"
+	NSPrompt           = "prompt"
+	NSPromptExperiment = "promptExperiment"
+	NSReport           = "report"
+	NSSlackChannel     = "slackChannel"
+	NSSuppressionRule  = "suppressionRule"
+	NSTaskTracker      = "taskTracker"
 )
 
 var (
@@ -68,12 +71,15 @@ func New(dbo db.DB, logger embedlog.Logger, isDevel bool, baseURL string) *zenrp
 
 	// services
 	rpc.RegisterAll(map[string]zenrpc.Invoker{
-		NSAuth:         NewAuthService(dbo, logger),
-		NSUser:         NewUserService(dbo, logger),
-		NSProject:      NewProjectService(dbo, logger, baseURL),
-		NSPrompt:       NewPromptService(dbo, logger),
-		NSSlackChannel: NewSlackChannelService(dbo, logger),
-		NSTaskTracker:  NewTaskTrackerService(dbo, logger),
+		NSAuth:             NewAuthService(dbo, logger),
+		NSUser:             NewUserService(dbo, logger),
+		NSProject:          NewProjectService(dbo, logger, baseURL),
+		NSPrompt:           NewPromptService(dbo, logger),
+		NSPromptExperiment: NewPromptExperimentService(dbo, logger),
+		NSReport:           NewReportService(dbo, logger),
+		NSSlackChannel:     NewSlackChannelService(dbo, logger),
+		NSSuppressionRule:  NewSuppressionRuleService(dbo, logger),
+		NSTaskTracker:      NewTaskTrackerService(dbo, logger),
 	})
 
 	return rpc
@@ -11,12 +11,15 @@ import (
 )
 
 var RPC = struct {
-	ProjectService      struct{ Count, Get, GetByID, Add, Update, Delete, GitlabCI, Validate string }
-	PromptService       struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	SlackChannelService struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	TaskTrackerService  struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	AuthService         struct{ Login, Logout, Profile, ChangePassword, VfsAuthToken string }
-	UserService         struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
+	ProjectService          struct{ Count, Get, GetByID, Add, Update, Delete, GitlabCI, Validate string }
+	PromptService           struct{ Count, Get, GetByID, Add, Update, Delete, Revisions, Diff, Rollback, Validate string }
+	PromptExperimentService struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
+	SlackChannelService     struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
+	SuppressionRuleService  struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
+	TaskTrackerService      struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
+	ReportService           struct{ Leaderboard, Experiment string }
+	AuthService             struct{ Login, Logout, Profile, ChangePassword, VfsAuthToken string }
+	UserService             struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
 }{
 	ProjectService: struct{ Count, Get, GetByID, Add, Update, Delete, GitlabCI, Validate string }{
 		Count:    "count",
@@ -28,7 +31,19 @@ var RPC = struct {
 		GitlabCI: "gitlabci",
 		Validate: "validate",
 	},
-	PromptService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
+	PromptService: struct{ Count, Get, GetByID, Add, Update, Delete, Revisions, Diff, Rollback, Validate string }{
+		Count:     "count",
+		Get:       "get",
+		GetByID:   "getbyid",
+		Add:       "add",
+		Update:    "update",
+		Delete:    "delete",
+		Revisions: "revisions",
+		Diff:      "diff",
+		Rollback:  "rollback",
+		Validate:  "validate",
+	},
+	PromptExperimentService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
 		Count:    "count",
 		Get:      "get",
 		GetByID:  "getbyid",
@@ -46,6 +61,15 @@ var RPC = struct {
 		Delete:   "delete",
 		Validate: "validate",
 	},
+	SuppressionRuleService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
+		Count:    "count",
+		Get:      "get",
+		GetByID:  "getbyid",
+		Add:      "add",
+		Update:   "update",
+		Delete:   "delete",
+		Validate: "validate",
+	},
 	TaskTrackerService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
 		Count:    "count",
 		Get:      "get",
@@ -55,6 +79,10 @@ var RPC = struct {
 		Delete:   "delete",
 		Validate: "validate",
 	},
+	ReportService: struct{ Leaderboard, Experiment string }{
+		Leaderboard: "leaderboard",
+		Experiment:  "experiment",
+	},
 	AuthService: struct{ Login, Logout, Profile, ChangePassword, VfsAuthToken string }{
 		Login:          "login",
 		Logout:         "logout",
@@ -2288,119 +2316,184 @@ func (PromptService) SMD() smd.ServiceInfo {
 					404: "Not Found",
 				},
 			},
-			"Validate": {
-				Description: `Validate verifies that Prompt data is valid.`,
+			"Revisions": {
+				Description: `Revisions returns all revisions of the Prompt, newest first.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "prompt",
-						Description: `Prompt`,
-						Type:        smd.Object,
-						TypeName:    "Prompt",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "common",
-								Type: smd.String,
-							},
-							{
-								Name: "architecture",
-								Type: smd.String,
-							},
-							{
-								Name: "code",
-								Type: smd.String,
-							},
-							{
-								Name: "security",
-								Type: smd.String,
-							},
-							{
-								Name: "tests",
-								Type: smd.String,
-							},
-							{
-								Name: "operability",
-								Type: smd.String,
-							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
+						Name:        "promptId",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]PromptRevision`,
+					Type:        smd.Array,
+					TypeName:    "[]PromptRevision",
+					Items: map[string]string{
+						"$ref": "#/definitions/PromptRevision",
+					},
+					Definitions: map[string]smd.Definition{
+						"PromptRevision": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "common",
+									Type: smd.String,
+								},
+								{
+									Name: "architecture",
+									Type: smd.String,
+								},
+								{
+									Name: "code",
+									Type: smd.String,
+								},
+								{
+									Name: "security",
+									Type: smd.String,
+								},
+								{
+									Name: "tests",
+									Type: smd.String,
+								},
+								{
+									Name: "operability",
+									Type: smd.String,
+								},
+								{
+									Name:     "userId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
 							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
 								},
 							},
 						},
 					},
 				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Diff": {
+				Description: `Diff returns per-section line diffs between two revisions of the Prompt.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "promptId",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "fromId",
+						Description: `revision to diff from`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "toId",
+						Description: `revision to diff to`,
+						Type:        smd.Integer,
+					},
+				},
 				Returns: smd.JSONSchema{
-					Description: `[]FieldError`,
+					Description: `[]PromptSectionDiff`,
 					Type:        smd.Array,
-					TypeName:    "[]FieldError",
+					TypeName:    "[]PromptSectionDiff",
 					Items: map[string]string{
-						"$ref": "#/definitions/FieldError",
+						"$ref": "#/definitions/PromptSectionDiff",
 					},
 					Definitions: map[string]smd.Definition{
-						"FieldError": {
+						"PromptSectionDiff": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name: "field",
+									Name: "section",
 									Type: smd.String,
 								},
 								{
-									Name: "error",
-									Type: smd.String,
+									Name: "changed",
+									Type: smd.Boolean,
 								},
 								{
-									Name:        "constraint",
-									Optional:    true,
-									Description: `Help with generating an error message.`,
-									Ref:         "#/definitions/FieldErrorConstraint",
-									Type:        smd.Object,
+									Name: "lines",
+									Type: smd.Array,
+									Items: map[string]string{
+										"$ref": "#/definitions/PromptDiffLine",
+									},
 								},
 							},
 						},
-						"FieldErrorConstraint": {
+						"PromptDiffLine": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name:        "max",
-									Description: `Max value for field.`,
-									Type:        smd.Integer,
+									Name: "op",
+									Type: smd.String,
 								},
 								{
-									Name:        "min",
-									Description: `Min value for field.`,
-									Type:        smd.Integer,
+									Name: "text",
+									Type: smd.String,
 								},
 							},
 						},
@@ -2408,64 +2501,269 @@ func (PromptService) SMD() smd.ServiceInfo {
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
+					404: "Not Found",
 				},
 			},
-		},
-	}
-}
-
-// Invoke is as generated code from zenrpc cmd
-func (s PromptService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
-	resp := zenrpc.Response{}
-	var err error
-
-	switch method {
-	case RPC.PromptService.Count:
-		var args = struct {
-			Search *PromptSearch `json:"search"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Count(ctx, args.Search))
-
-	case RPC.PromptService.Get:
-		var args = struct {
-			Search  *PromptSearch `json:"search"`
-			ViewOps *ViewOps      `json:"viewOps"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+			"Rollback": {
+				Description: `Rollback restores the Prompt texts from the revision and records them as a new revision.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "promptId",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "revisionId",
+						Description: `revision to restore`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `PromptRevision`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "PromptRevision",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "promptId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "common",
+							Type: smd.String,
+						},
+						{
+							Name: "architecture",
+							Type: smd.String,
+						},
+						{
+							Name: "code",
+							Type: smd.String,
+						},
+						{
+							Name: "security",
+							Type: smd.String,
+						},
+						{
+							Name: "tests",
+							Type: smd.String,
+						},
+						{
+							Name: "operability",
+							Type: smd.String,
+						},
+						{
+							Name:     "userId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name: "createdAt",
+							Type: smd.String,
+						},
+						{
+							Name:     "user",
+							Optional: true,
+							Ref:      "#/definitions/UserSummary",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that Prompt data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "prompt",
+						Description: `Prompt`,
+						Type:        smd.Object,
+						TypeName:    "Prompt",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "common",
+								Type: smd.String,
+							},
+							{
+								Name: "architecture",
+								Type: smd.String,
+							},
+							{
+								Name: "code",
+								Type: smd.String,
+							},
+							{
+								Name: "security",
+								Type: smd.String,
+							},
+							{
+								Name: "tests",
+								Type: smd.String,
+							},
+							{
+								Name: "operability",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
 
-	case RPC.PromptService.GetByID:
+// Invoke is as generated code from zenrpc cmd
+func (s PromptService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.PromptService.Count:
 		var args = struct {
-			Id int `json:"id"`
+			Search *PromptSearch `json:"search"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2476,11 +2774,50 @@ func (s PromptService) Invoke(ctx context.Context, method string, params json.Ra
 			}
 		}
 
-		resp.Set(s.GetByID(ctx, args.Id))
+		resp.Set(s.Count(ctx, args.Search))
 
-	case RPC.PromptService.Add:
+	case RPC.PromptService.Get:
 		var args = struct {
-			Prompt Prompt `json:"prompt"`
+			Search  *PromptSearch `json:"search"`
+			ViewOps *ViewOps      `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.PromptService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.PromptService.Add:
+		var args = struct {
+			Prompt Prompt `json:"prompt"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -2535,6 +2872,66 @@ func (s PromptService) Invoke(ctx context.Context, method string, params json.Ra
 
 		resp.Set(s.Delete(ctx, args.Id))
 
+	case RPC.PromptService.Revisions:
+		var args = struct {
+			PromptId int `json:"promptId"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"promptId"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Revisions(ctx, args.PromptId))
+
+	case RPC.PromptService.Diff:
+		var args = struct {
+			PromptId int `json:"promptId"`
+			FromId   int `json:"fromId"`
+			ToId     int `json:"toId"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"promptId", "fromId", "toId"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Diff(ctx, args.PromptId, args.FromId, args.ToId))
+
+	case RPC.PromptService.Rollback:
+		var args = struct {
+			PromptId   int `json:"promptId"`
+			RevisionId int `json:"revisionId"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"promptId", "revisionId"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Rollback(ctx, args.PromptId, args.RevisionId))
+
 	case RPC.PromptService.Validate:
 		var args = struct {
 			Prompt Prompt `json:"prompt"`
@@ -2561,18 +2958,18 @@ func (s PromptService) Invoke(ctx context.Context, method string, params json.Ra
 	return resp
 }
 
-func (SlackChannelService) SMD() smd.ServiceInfo {
+func (PromptExperimentService) SMD() smd.ServiceInfo {
 	return smd.ServiceInfo{
 		Methods: map[string]smd.Service{
 			"Count": {
-				Description: `Count returns count SlackChannels according to conditions in search params.`,
+				Description: `Count returns count PromptExperiments according to conditions in search params.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `SlackChannelSearch`,
+						Description: `PromptExperimentSearch`,
 						Type:        smd.Object,
-						TypeName:    "SlackChannelSearch",
+						TypeName:    "PromptExperimentSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -2580,19 +2977,19 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name:     "projectId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "channel",
+								Name:     "title",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "webhookURL",
+								Name:     "variantPromptId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
 								Name:     "statusId",
@@ -2618,14 +3015,14 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Get": {
-				Description: `Get returns а list of SlackChannels according to conditions in search params.`,
+				Description: `Get returns а list of PromptExperiments according to conditions in search params.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `SlackChannelSearch`,
+						Description: `PromptExperimentSearch`,
 						Type:        smd.Object,
-						TypeName:    "SlackChannelSearch",
+						TypeName:    "PromptExperimentSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -2633,19 +3030,19 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name:     "projectId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "channel",
+								Name:     "title",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "webhookURL",
+								Name:     "variantPromptId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
 								Name:     "statusId",
@@ -2692,32 +3089,52 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]SlackChannelSummary`,
+					Description: `[]PromptExperimentSummary`,
 					Type:        smd.Array,
-					TypeName:    "[]SlackChannelSummary",
+					TypeName:    "[]PromptExperimentSummary",
 					Items: map[string]string{
-						"$ref": "#/definitions/SlackChannelSummary",
+						"$ref": "#/definitions/PromptExperimentSummary",
 					},
 					Definitions: map[string]smd.Definition{
-						"SlackChannelSummary": {
+						"PromptExperimentSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
 									Name: "id",
 									Type: smd.Integer,
 								},
+								{
+									Name: "projectId",
+									Type: smd.Integer,
+								},
 								{
 									Name: "title",
 									Type: smd.String,
 								},
 								{
-									Name: "channel",
-									Type: smd.String,
+									Name: "variantPromptId",
+									Type: smd.Integer,
 								},
 								{
-									Name: "webhookURL",
+									Name: "trafficPercent",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
 									Type: smd.String,
 								},
+								{
+									Name:     "project",
+									Optional: true,
+									Ref:      "#/definitions/ProjectSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "variantPrompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
 								{
 									Name:     "status",
 									Optional: true,
@@ -2726,7 +3143,7 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 								},
 							},
 						},
-						"Status": {
+						"ProjectSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -2734,65 +3151,62 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "alias",
+									Name: "title",
 									Type: smd.String,
 								},
 								{
-									Name: "title",
+									Name: "vcsURL",
+									Type: smd.String,
+								},
+								{
+									Name: "language",
+									Type: smd.String,
+								},
+								{
+									Name: "projectKey",
 									Type: smd.String,
 								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "taskTrackerId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "slackChannelId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "prompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "taskTracker",
+									Optional: true,
+									Ref:      "#/definitions/TaskTrackerSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "slackChannel",
+									Optional: true,
+									Ref:      "#/definitions/SlackChannelSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
-					},
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-				},
-			},
-			"GetByID": {
-				Description: `GetByID returns a SlackChannel by its ID.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `SlackChannel`,
-					Optional:    true,
-					Type:        smd.Object,
-					TypeName:    "SlackChannel",
-					Properties: smd.PropertyList{
-						{
-							Name: "id",
-							Type: smd.Integer,
-						},
-						{
-							Name: "title",
-							Type: smd.String,
-						},
-						{
-							Name: "channel",
-							Type: smd.String,
-						},
-						{
-							Name: "webhookURL",
-							Type: smd.String,
-						},
-						{
-							Name: "statusId",
-							Type: smd.Integer,
-						},
-						{
-							Name:     "status",
-							Optional: true,
-							Ref:      "#/definitions/Status",
-							Type:     smd.Object,
-						},
-					},
-					Definitions: map[string]smd.Definition{
-						"Status": {
+						"PromptSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -2800,105 +3214,178 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "alias",
+									Name: "title",
 									Type: smd.String,
 								},
 								{
-									Name: "title",
+									Name: "common",
 									Type: smd.String,
 								},
+								{
+									Name: "architecture",
+									Type: smd.String,
+								},
+								{
+									Name: "code",
+									Type: smd.String,
+								},
+								{
+									Name: "security",
+									Type: smd.String,
+								},
+								{
+									Name: "tests",
+									Type: smd.String,
+								},
+								{
+									Name: "operability",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
-						},
-					},
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					404: "Not Found",
-				},
-			},
-			"Add": {
-				Description: `Add adds a SlackChannel from the query.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "slackChannel",
-						Description: `SlackChannel`,
-						Type:        smd.Object,
-						TypeName:    "SlackChannel",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "channel",
-								Type: smd.String,
-							},
-							{
-								Name: "webhookURL",
-								Type: smd.String,
-							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
 							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+						},
+						"TaskTrackerSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "url",
+									Type: smd.String,
+								},
+								{
+									Name:     "authToken",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "fetchPrompt",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
+						"SlackChannelSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "channel",
+									Type: smd.String,
+								},
+								{
+									Name: "webhookURL",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
 								},
 							},
 						},
 					},
 				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a PromptExperiment by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
 				Returns: smd.JSONSchema{
-					Description: `SlackChannel`,
+					Description: `PromptExperiment`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "SlackChannel",
+					TypeName:    "PromptExperiment",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
+						{
+							Name: "projectId",
+							Type: smd.Integer,
+						},
 						{
 							Name: "title",
 							Type: smd.String,
 						},
 						{
-							Name: "channel",
-							Type: smd.String,
+							Name: "variantPromptId",
+							Type: smd.Integer,
 						},
 						{
-							Name: "webhookURL",
+							Name: "trafficPercent",
+							Type: smd.Integer,
+						},
+						{
+							Name: "createdAt",
 							Type: smd.String,
 						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
+						{
+							Name:     "project",
+							Optional: true,
+							Ref:      "#/definitions/ProjectSummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "variantPrompt",
+							Optional: true,
+							Ref:      "#/definitions/PromptSummary",
+							Type:     smd.Object,
+						},
 						{
 							Name:     "status",
 							Optional: true,
@@ -2907,7 +3394,7 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 						},
 					},
 					Definitions: map[string]smd.Definition{
-						"Status": {
+						"ProjectSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -2915,137 +3402,236 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "alias",
+									Name: "title",
 									Type: smd.String,
 								},
 								{
-									Name: "title",
+									Name: "vcsURL",
+									Type: smd.String,
+								},
+								{
+									Name: "language",
+									Type: smd.String,
+								},
+								{
+									Name: "projectKey",
 									Type: smd.String,
 								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "taskTrackerId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "slackChannelId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "prompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "taskTracker",
+									Optional: true,
+									Ref:      "#/definitions/TaskTrackerSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "slackChannel",
+									Optional: true,
+									Ref:      "#/definitions/SlackChannelSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
-					},
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-				},
-			},
-			"Update": {
-				Description: `Update updates the SlackChannel data identified by id from the query.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:     "slackChannel",
-						Type:     smd.Object,
-						TypeName: "SlackChannel",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "channel",
-								Type: smd.String,
-							},
-							{
-								Name: "webhookURL",
-								Type: smd.String,
-							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
-							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+						"PromptSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "common",
+									Type: smd.String,
+								},
+								{
+									Name: "architecture",
+									Type: smd.String,
+								},
+								{
+									Name: "code",
+									Type: smd.String,
+								},
+								{
+									Name: "security",
+									Type: smd.String,
+								},
+								{
+									Name: "tests",
+									Type: smd.String,
+								},
+								{
+									Name: "operability",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+						"TaskTrackerSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "url",
+									Type: smd.String,
+								},
+								{
+									Name:     "authToken",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "fetchPrompt",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"SlackChannelSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "channel",
+									Type: smd.String,
+								},
+								{
+									Name: "webhookURL",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
 								},
 							},
 						},
 					},
 				},
-				Returns: smd.JSONSchema{
-					Description: `SlackChannel`,
-					Type:        smd.Boolean,
-					TypeName:    "SlackChannel",
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
-				},
-			},
-			"Delete": {
-				Description: `Delete deletes the SlackChannel by its ID.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `isDeleted`,
-					Type:        smd.Boolean,
-				},
 				Errors: map[int]string{
 					500: "Internal Error",
-					400: "Validation Error",
 					404: "Not Found",
 				},
 			},
-			"Validate": {
-				Description: `Validate verifies that SlackChannel data is valid.`,
+			"Add": {
+				Description: `Add adds a PromptExperiment from the query.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "slackChannel",
-						Description: `SlackChannel`,
+						Name:        "promptExperiment",
+						Description: `PromptExperiment`,
 						Type:        smd.Object,
-						TypeName:    "SlackChannel",
+						TypeName:    "PromptExperiment",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
+							{
+								Name: "projectId",
+								Type: smd.Integer,
+							},
 							{
 								Name: "title",
 								Type: smd.String,
 							},
 							{
-								Name: "channel",
-								Type: smd.String,
+								Name: "variantPromptId",
+								Type: smd.Integer,
 							},
 							{
-								Name: "webhookURL",
+								Name: "trafficPercent",
+								Type: smd.Integer,
+							},
+							{
+								Name: "createdAt",
 								Type: smd.String,
 							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:     "project",
+								Optional: true,
+								Ref:      "#/definitions/ProjectSummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "variantPrompt",
+								Optional: true,
+								Ref:      "#/definitions/PromptSummary",
+								Type:     smd.Object,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -3054,7 +3640,7 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 							},
 						},
 						Definitions: map[string]smd.Definition{
-							"Status": {
+							"ProjectSummary": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
@@ -3062,48 +3648,3659 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 										Type: smd.Integer,
 									},
 									{
-										Name: "alias",
+										Name: "title",
 										Type: smd.String,
 									},
 									{
-										Name: "title",
+										Name: "vcsURL",
 										Type: smd.String,
 									},
+									{
+										Name: "language",
+										Type: smd.String,
+									},
+									{
+										Name: "projectKey",
+										Type: smd.String,
+									},
+									{
+										Name: "promptId",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "taskTrackerId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "slackChannelId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "prompt",
+										Optional: true,
+										Ref:      "#/definitions/PromptSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "taskTracker",
+										Optional: true,
+										Ref:      "#/definitions/TaskTrackerSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "slackChannel",
+										Optional: true,
+										Ref:      "#/definitions/SlackChannelSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
 								},
 							},
-						},
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `[]FieldError`,
-					Type:        smd.Array,
-					TypeName:    "[]FieldError",
-					Items: map[string]string{
-						"$ref": "#/definitions/FieldError",
-					},
-					Definitions: map[string]smd.Definition{
-						"FieldError": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "field",
-									Type: smd.String,
-								},
-								{
-									Name: "error",
-									Type: smd.String,
-								},
-								{
-									Name:        "constraint",
-									Optional:    true,
-									Description: `Help with generating an error message.`,
-									Ref:         "#/definitions/FieldErrorConstraint",
-									Type:        smd.Object,
-								},
-							},
-						},
-						"FieldErrorConstraint": {
-							Type: "object",
+							"PromptSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "common",
+										Type: smd.String,
+									},
+									{
+										Name: "architecture",
+										Type: smd.String,
+									},
+									{
+										Name: "code",
+										Type: smd.String,
+									},
+									{
+										Name: "security",
+										Type: smd.String,
+									},
+									{
+										Name: "tests",
+										Type: smd.String,
+									},
+									{
+										Name: "operability",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+							"TaskTrackerSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "url",
+										Type: smd.String,
+									},
+									{
+										Name:     "authToken",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "fetchPrompt",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"SlackChannelSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "channel",
+										Type: smd.String,
+									},
+									{
+										Name: "webhookURL",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `PromptExperiment`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "PromptExperiment",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "projectId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "variantPromptId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "trafficPercent",
+							Type: smd.Integer,
+						},
+						{
+							Name: "createdAt",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "project",
+							Optional: true,
+							Ref:      "#/definitions/ProjectSummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "variantPrompt",
+							Optional: true,
+							Ref:      "#/definitions/PromptSummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"ProjectSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "vcsURL",
+									Type: smd.String,
+								},
+								{
+									Name: "language",
+									Type: smd.String,
+								},
+								{
+									Name: "projectKey",
+									Type: smd.String,
+								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "taskTrackerId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "slackChannelId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "prompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "taskTracker",
+									Optional: true,
+									Ref:      "#/definitions/TaskTrackerSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "slackChannel",
+									Optional: true,
+									Ref:      "#/definitions/SlackChannelSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"PromptSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "common",
+									Type: smd.String,
+								},
+								{
+									Name: "architecture",
+									Type: smd.String,
+								},
+								{
+									Name: "code",
+									Type: smd.String,
+								},
+								{
+									Name: "security",
+									Type: smd.String,
+								},
+								{
+									Name: "tests",
+									Type: smd.String,
+								},
+								{
+									Name: "operability",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+						"TaskTrackerSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "url",
+									Type: smd.String,
+								},
+								{
+									Name:     "authToken",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "fetchPrompt",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"SlackChannelSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "channel",
+									Type: smd.String,
+								},
+								{
+									Name: "webhookURL",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Update": {
+				Description: `Update updates the PromptExperiment data identified by id from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "promptExperiment",
+						Type:     smd.Object,
+						TypeName: "PromptExperiment",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "projectId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "variantPromptId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "trafficPercent",
+								Type: smd.Integer,
+							},
+							{
+								Name: "createdAt",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "project",
+								Optional: true,
+								Ref:      "#/definitions/ProjectSummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "variantPrompt",
+								Optional: true,
+								Ref:      "#/definitions/PromptSummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"ProjectSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "vcsURL",
+										Type: smd.String,
+									},
+									{
+										Name: "language",
+										Type: smd.String,
+									},
+									{
+										Name: "projectKey",
+										Type: smd.String,
+									},
+									{
+										Name: "promptId",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "taskTrackerId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "slackChannelId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "prompt",
+										Optional: true,
+										Ref:      "#/definitions/PromptSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "taskTracker",
+										Optional: true,
+										Ref:      "#/definitions/TaskTrackerSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "slackChannel",
+										Optional: true,
+										Ref:      "#/definitions/SlackChannelSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"PromptSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "common",
+										Type: smd.String,
+									},
+									{
+										Name: "architecture",
+										Type: smd.String,
+									},
+									{
+										Name: "code",
+										Type: smd.String,
+									},
+									{
+										Name: "security",
+										Type: smd.String,
+									},
+									{
+										Name: "tests",
+										Type: smd.String,
+									},
+									{
+										Name: "operability",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+							"TaskTrackerSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "url",
+										Type: smd.String,
+									},
+									{
+										Name:     "authToken",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "fetchPrompt",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"SlackChannelSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "channel",
+										Type: smd.String,
+									},
+									{
+										Name: "webhookURL",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `PromptExperiment`,
+					Type:        smd.Boolean,
+					TypeName:    "PromptExperiment",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the PromptExperiment by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that PromptExperiment data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "promptExperiment",
+						Description: `PromptExperiment`,
+						Type:        smd.Object,
+						TypeName:    "PromptExperiment",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "projectId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "variantPromptId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "trafficPercent",
+								Type: smd.Integer,
+							},
+							{
+								Name: "createdAt",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "project",
+								Optional: true,
+								Ref:      "#/definitions/ProjectSummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "variantPrompt",
+								Optional: true,
+								Ref:      "#/definitions/PromptSummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"ProjectSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "vcsURL",
+										Type: smd.String,
+									},
+									{
+										Name: "language",
+										Type: smd.String,
+									},
+									{
+										Name: "projectKey",
+										Type: smd.String,
+									},
+									{
+										Name: "promptId",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "taskTrackerId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "slackChannelId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "prompt",
+										Optional: true,
+										Ref:      "#/definitions/PromptSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "taskTracker",
+										Optional: true,
+										Ref:      "#/definitions/TaskTrackerSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "slackChannel",
+										Optional: true,
+										Ref:      "#/definitions/SlackChannelSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"PromptSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "common",
+										Type: smd.String,
+									},
+									{
+										Name: "architecture",
+										Type: smd.String,
+									},
+									{
+										Name: "code",
+										Type: smd.String,
+									},
+									{
+										Name: "security",
+										Type: smd.String,
+									},
+									{
+										Name: "tests",
+										Type: smd.String,
+									},
+									{
+										Name: "operability",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+							"TaskTrackerSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "url",
+										Type: smd.String,
+									},
+									{
+										Name:     "authToken",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "fetchPrompt",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"SlackChannelSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "channel",
+										Type: smd.String,
+									},
+									{
+										Name: "webhookURL",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s PromptExperimentService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.PromptExperimentService.Count:
+		var args = struct {
+			Search *PromptExperimentSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.PromptExperimentService.Get:
+		var args = struct {
+			Search  *PromptExperimentSearch `json:"search"`
+			ViewOps *ViewOps                `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.PromptExperimentService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.PromptExperimentService.Add:
+		var args = struct {
+			PromptExperiment PromptExperiment `json:"promptExperiment"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"promptExperiment"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.PromptExperiment))
+
+	case RPC.PromptExperimentService.Update:
+		var args = struct {
+			PromptExperiment PromptExperiment `json:"promptExperiment"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"promptExperiment"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.PromptExperiment))
+
+	case RPC.PromptExperimentService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.PromptExperimentService.Validate:
+		var args = struct {
+			PromptExperiment PromptExperiment `json:"promptExperiment"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"promptExperiment"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.PromptExperiment))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (SlackChannelService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count SlackChannels according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `SlackChannelSearch`,
+						Type:        smd.Object,
+						TypeName:    "SlackChannelSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "channel",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "webhookURL",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get returns а list of SlackChannels according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `SlackChannelSearch`,
+						Type:        smd.Object,
+						TypeName:    "SlackChannelSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "channel",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "webhookURL",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]SlackChannelSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]SlackChannelSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/SlackChannelSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"SlackChannelSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "channel",
+									Type: smd.String,
+								},
+								{
+									Name: "webhookURL",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a SlackChannel by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `SlackChannel`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "SlackChannel",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "channel",
+							Type: smd.String,
+						},
+						{
+							Name: "webhookURL",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Add": {
+				Description: `Add adds a SlackChannel from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "slackChannel",
+						Description: `SlackChannel`,
+						Type:        smd.Object,
+						TypeName:    "SlackChannel",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "channel",
+								Type: smd.String,
+							},
+							{
+								Name: "webhookURL",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `SlackChannel`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "SlackChannel",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "channel",
+							Type: smd.String,
+						},
+						{
+							Name: "webhookURL",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Update": {
+				Description: `Update updates the SlackChannel data identified by id from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "slackChannel",
+						Type:     smd.Object,
+						TypeName: "SlackChannel",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "channel",
+								Type: smd.String,
+							},
+							{
+								Name: "webhookURL",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `SlackChannel`,
+					Type:        smd.Boolean,
+					TypeName:    "SlackChannel",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the SlackChannel by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that SlackChannel data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "slackChannel",
+						Description: `SlackChannel`,
+						Type:        smd.Object,
+						TypeName:    "SlackChannel",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "channel",
+								Type: smd.String,
+							},
+							{
+								Name: "webhookURL",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s SlackChannelService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.SlackChannelService.Count:
+		var args = struct {
+			Search *SlackChannelSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.SlackChannelService.Get:
+		var args = struct {
+			Search  *SlackChannelSearch `json:"search"`
+			ViewOps *ViewOps            `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.SlackChannelService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.SlackChannelService.Add:
+		var args = struct {
+			SlackChannel SlackChannel `json:"slackChannel"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"slackChannel"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.SlackChannel))
+
+	case RPC.SlackChannelService.Update:
+		var args = struct {
+			SlackChannel SlackChannel `json:"slackChannel"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"slackChannel"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.SlackChannel))
+
+	case RPC.SlackChannelService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.SlackChannelService.Validate:
+		var args = struct {
+			SlackChannel SlackChannel `json:"slackChannel"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"slackChannel"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.SlackChannel))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (SuppressionRuleService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count SuppressionRules according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `SuppressionRuleSearch`,
+						Type:        smd.Object,
+						TypeName:    "SuppressionRuleSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "projectId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "pathGlob",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "issueType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "reviewType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "titlePattern",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "reason",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "owner",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get returns а list of SuppressionRules according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `SuppressionRuleSearch`,
+						Type:        smd.Object,
+						TypeName:    "SuppressionRuleSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "projectId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "pathGlob",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "issueType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "reviewType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "titlePattern",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "reason",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "owner",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]SuppressionRuleSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]SuppressionRuleSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/SuppressionRuleSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"SuppressionRuleSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "projectId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "pathGlob",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "issueType",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "reviewType",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "titlePattern",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "maxSeverity",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "reason",
+									Type: smd.String,
+								},
+								{
+									Name: "owner",
+									Type: smd.String,
+								},
+								{
+									Name: "expiresAt",
+									Type: smd.String,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "project",
+									Optional: true,
+									Ref:      "#/definitions/ProjectSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"ProjectSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "vcsURL",
+									Type: smd.String,
+								},
+								{
+									Name: "language",
+									Type: smd.String,
+								},
+								{
+									Name: "projectKey",
+									Type: smd.String,
+								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "taskTrackerId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "slackChannelId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "prompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "taskTracker",
+									Optional: true,
+									Ref:      "#/definitions/TaskTrackerSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "slackChannel",
+									Optional: true,
+									Ref:      "#/definitions/SlackChannelSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"PromptSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "common",
+									Type: smd.String,
+								},
+								{
+									Name: "architecture",
+									Type: smd.String,
+								},
+								{
+									Name: "code",
+									Type: smd.String,
+								},
+								{
+									Name: "security",
+									Type: smd.String,
+								},
+								{
+									Name: "tests",
+									Type: smd.String,
+								},
+								{
+									Name: "operability",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+						"TaskTrackerSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "url",
+									Type: smd.String,
+								},
+								{
+									Name:     "authToken",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "fetchPrompt",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"SlackChannelSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "channel",
+									Type: smd.String,
+								},
+								{
+									Name: "webhookURL",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a SuppressionRule by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `SuppressionRule`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "SuppressionRule",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "projectId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "pathGlob",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "issueType",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "reviewType",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "titlePattern",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "maxSeverity",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "reason",
+							Type: smd.String,
+						},
+						{
+							Name: "owner",
+							Type: smd.String,
+						},
+						{
+							Name: "expiresAt",
+							Type: smd.String,
+						},
+						{
+							Name: "createdAt",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "project",
+							Optional: true,
+							Ref:      "#/definitions/ProjectSummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"ProjectSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "vcsURL",
+									Type: smd.String,
+								},
+								{
+									Name: "language",
+									Type: smd.String,
+								},
+								{
+									Name: "projectKey",
+									Type: smd.String,
+								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "taskTrackerId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "slackChannelId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "prompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "taskTracker",
+									Optional: true,
+									Ref:      "#/definitions/TaskTrackerSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "slackChannel",
+									Optional: true,
+									Ref:      "#/definitions/SlackChannelSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"PromptSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "common",
+									Type: smd.String,
+								},
+								{
+									Name: "architecture",
+									Type: smd.String,
+								},
+								{
+									Name: "code",
+									Type: smd.String,
+								},
+								{
+									Name: "security",
+									Type: smd.String,
+								},
+								{
+									Name: "tests",
+									Type: smd.String,
+								},
+								{
+									Name: "operability",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+						"TaskTrackerSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "url",
+									Type: smd.String,
+								},
+								{
+									Name:     "authToken",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "fetchPrompt",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"SlackChannelSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "channel",
+									Type: smd.String,
+								},
+								{
+									Name: "webhookURL",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Add": {
+				Description: `Add adds a SuppressionRule from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "suppressionRule",
+						Description: `SuppressionRule`,
+						Type:        smd.Object,
+						TypeName:    "SuppressionRule",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "projectId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "pathGlob",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "issueType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "reviewType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "titlePattern",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "maxSeverity",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "reason",
+								Type: smd.String,
+							},
+							{
+								Name: "owner",
+								Type: smd.String,
+							},
+							{
+								Name: "expiresAt",
+								Type: smd.String,
+							},
+							{
+								Name: "createdAt",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "project",
+								Optional: true,
+								Ref:      "#/definitions/ProjectSummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"ProjectSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "vcsURL",
+										Type: smd.String,
+									},
+									{
+										Name: "language",
+										Type: smd.String,
+									},
+									{
+										Name: "projectKey",
+										Type: smd.String,
+									},
+									{
+										Name: "promptId",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "taskTrackerId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "slackChannelId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "prompt",
+										Optional: true,
+										Ref:      "#/definitions/PromptSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "taskTracker",
+										Optional: true,
+										Ref:      "#/definitions/TaskTrackerSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "slackChannel",
+										Optional: true,
+										Ref:      "#/definitions/SlackChannelSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"PromptSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "common",
+										Type: smd.String,
+									},
+									{
+										Name: "architecture",
+										Type: smd.String,
+									},
+									{
+										Name: "code",
+										Type: smd.String,
+									},
+									{
+										Name: "security",
+										Type: smd.String,
+									},
+									{
+										Name: "tests",
+										Type: smd.String,
+									},
+									{
+										Name: "operability",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+							"TaskTrackerSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "url",
+										Type: smd.String,
+									},
+									{
+										Name:     "authToken",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "fetchPrompt",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"SlackChannelSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "channel",
+										Type: smd.String,
+									},
+									{
+										Name: "webhookURL",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `SuppressionRule`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "SuppressionRule",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "projectId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "pathGlob",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "issueType",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "reviewType",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "titlePattern",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "maxSeverity",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "reason",
+							Type: smd.String,
+						},
+						{
+							Name: "owner",
+							Type: smd.String,
+						},
+						{
+							Name: "expiresAt",
+							Type: smd.String,
+						},
+						{
+							Name: "createdAt",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "project",
+							Optional: true,
+							Ref:      "#/definitions/ProjectSummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"ProjectSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "vcsURL",
+									Type: smd.String,
+								},
+								{
+									Name: "language",
+									Type: smd.String,
+								},
+								{
+									Name: "projectKey",
+									Type: smd.String,
+								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "taskTrackerId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "slackChannelId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "prompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "taskTracker",
+									Optional: true,
+									Ref:      "#/definitions/TaskTrackerSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "slackChannel",
+									Optional: true,
+									Ref:      "#/definitions/SlackChannelSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"PromptSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "common",
+									Type: smd.String,
+								},
+								{
+									Name: "architecture",
+									Type: smd.String,
+								},
+								{
+									Name: "code",
+									Type: smd.String,
+								},
+								{
+									Name: "security",
+									Type: smd.String,
+								},
+								{
+									Name: "tests",
+									Type: smd.String,
+								},
+								{
+									Name: "operability",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+						"TaskTrackerSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "url",
+									Type: smd.String,
+								},
+								{
+									Name:     "authToken",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "fetchPrompt",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"SlackChannelSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "channel",
+									Type: smd.String,
+								},
+								{
+									Name: "webhookURL",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Update": {
+				Description: `Update updates the SuppressionRule data identified by id from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "suppressionRule",
+						Type:     smd.Object,
+						TypeName: "SuppressionRule",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "projectId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "pathGlob",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "issueType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "reviewType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "titlePattern",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "maxSeverity",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "reason",
+								Type: smd.String,
+							},
+							{
+								Name: "owner",
+								Type: smd.String,
+							},
+							{
+								Name: "expiresAt",
+								Type: smd.String,
+							},
+							{
+								Name: "createdAt",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "project",
+								Optional: true,
+								Ref:      "#/definitions/ProjectSummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"ProjectSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "vcsURL",
+										Type: smd.String,
+									},
+									{
+										Name: "language",
+										Type: smd.String,
+									},
+									{
+										Name: "projectKey",
+										Type: smd.String,
+									},
+									{
+										Name: "promptId",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "taskTrackerId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "slackChannelId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "prompt",
+										Optional: true,
+										Ref:      "#/definitions/PromptSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "taskTracker",
+										Optional: true,
+										Ref:      "#/definitions/TaskTrackerSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "slackChannel",
+										Optional: true,
+										Ref:      "#/definitions/SlackChannelSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"PromptSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "common",
+										Type: smd.String,
+									},
+									{
+										Name: "architecture",
+										Type: smd.String,
+									},
+									{
+										Name: "code",
+										Type: smd.String,
+									},
+									{
+										Name: "security",
+										Type: smd.String,
+									},
+									{
+										Name: "tests",
+										Type: smd.String,
+									},
+									{
+										Name: "operability",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+							"TaskTrackerSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "url",
+										Type: smd.String,
+									},
+									{
+										Name:     "authToken",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "fetchPrompt",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"SlackChannelSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "channel",
+										Type: smd.String,
+									},
+									{
+										Name: "webhookURL",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `SuppressionRule`,
+					Type:        smd.Boolean,
+					TypeName:    "SuppressionRule",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the SuppressionRule by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that SuppressionRule data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "suppressionRule",
+						Description: `SuppressionRule`,
+						Type:        smd.Object,
+						TypeName:    "SuppressionRule",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "projectId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "pathGlob",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "issueType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "reviewType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "titlePattern",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "maxSeverity",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "reason",
+								Type: smd.String,
+							},
+							{
+								Name: "owner",
+								Type: smd.String,
+							},
+							{
+								Name: "expiresAt",
+								Type: smd.String,
+							},
+							{
+								Name: "createdAt",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "project",
+								Optional: true,
+								Ref:      "#/definitions/ProjectSummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"ProjectSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "vcsURL",
+										Type: smd.String,
+									},
+									{
+										Name: "language",
+										Type: smd.String,
+									},
+									{
+										Name: "projectKey",
+										Type: smd.String,
+									},
+									{
+										Name: "promptId",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "taskTrackerId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "slackChannelId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "prompt",
+										Optional: true,
+										Ref:      "#/definitions/PromptSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "taskTracker",
+										Optional: true,
+										Ref:      "#/definitions/TaskTrackerSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "slackChannel",
+										Optional: true,
+										Ref:      "#/definitions/SlackChannelSummary",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"PromptSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "common",
+										Type: smd.String,
+									},
+									{
+										Name: "architecture",
+										Type: smd.String,
+									},
+									{
+										Name: "code",
+										Type: smd.String,
+									},
+									{
+										Name: "security",
+										Type: smd.String,
+									},
+									{
+										Name: "tests",
+										Type: smd.String,
+									},
+									{
+										Name: "operability",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+							"TaskTrackerSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "url",
+										Type: smd.String,
+									},
+									{
+										Name:     "authToken",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "fetchPrompt",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"SlackChannelSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "channel",
+										Type: smd.String,
+									},
+									{
+										Name: "webhookURL",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
 							Properties: smd.PropertyList{
 								{
 									Name:        "max",
@@ -3128,14 +7325,14 @@ func (SlackChannelService) SMD() smd.ServiceInfo {
 }
 
 // Invoke is as generated code from zenrpc cmd
-func (s SlackChannelService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+func (s SuppressionRuleService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
 	resp := zenrpc.Response{}
 	var err error
 
 	switch method {
-	case RPC.SlackChannelService.Count:
+	case RPC.SuppressionRuleService.Count:
 		var args = struct {
-			Search *SlackChannelSearch `json:"search"`
+			Search *SuppressionRuleSearch `json:"search"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -3152,10 +7349,10 @@ func (s SlackChannelService) Invoke(ctx context.Context, method string, params j
 
 		resp.Set(s.Count(ctx, args.Search))
 
-	case RPC.SlackChannelService.Get:
+	case RPC.SuppressionRuleService.Get:
 		var args = struct {
-			Search  *SlackChannelSearch `json:"search"`
-			ViewOps *ViewOps            `json:"viewOps"`
+			Search  *SuppressionRuleSearch `json:"search"`
+			ViewOps *ViewOps               `json:"viewOps"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -3172,7 +7369,7 @@ func (s SlackChannelService) Invoke(ctx context.Context, method string, params j
 
 		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
 
-	case RPC.SlackChannelService.GetByID:
+	case RPC.SuppressionRuleService.GetByID:
 		var args = struct {
 			Id int `json:"id"`
 		}{}
@@ -3191,13 +7388,13 @@ func (s SlackChannelService) Invoke(ctx context.Context, method string, params j
 
 		resp.Set(s.GetByID(ctx, args.Id))
 
-	case RPC.SlackChannelService.Add:
+	case RPC.SuppressionRuleService.Add:
 		var args = struct {
-			SlackChannel SlackChannel `json:"slackChannel"`
+			SuppressionRule SuppressionRule `json:"suppressionRule"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"slackChannel"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"suppressionRule"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -3208,15 +7405,15 @@ func (s SlackChannelService) Invoke(ctx context.Context, method string, params j
 			}
 		}
 
-		resp.Set(s.Add(ctx, args.SlackChannel))
+		resp.Set(s.Add(ctx, args.SuppressionRule))
 
-	case RPC.SlackChannelService.Update:
+	case RPC.SuppressionRuleService.Update:
 		var args = struct {
-			SlackChannel SlackChannel `json:"slackChannel"`
+			SuppressionRule SuppressionRule `json:"suppressionRule"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"slackChannel"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"suppressionRule"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -3227,9 +7424,9 @@ func (s SlackChannelService) Invoke(ctx context.Context, method string, params j
 			}
 		}
 
-		resp.Set(s.Update(ctx, args.SlackChannel))
+		resp.Set(s.Update(ctx, args.SuppressionRule))
 
-	case RPC.SlackChannelService.Delete:
+	case RPC.SuppressionRuleService.Delete:
 		var args = struct {
 			Id int `json:"id"`
 		}{}
@@ -3248,13 +7445,13 @@ func (s SlackChannelService) Invoke(ctx context.Context, method string, params j
 
 		resp.Set(s.Delete(ctx, args.Id))
 
-	case RPC.SlackChannelService.Validate:
+	case RPC.SuppressionRuleService.Validate:
 		var args = struct {
-			SlackChannel SlackChannel `json:"slackChannel"`
+			SuppressionRule SuppressionRule `json:"suppressionRule"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"slackChannel"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"suppressionRule"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -3265,7 +7462,7 @@ func (s SlackChannelService) Invoke(ctx context.Context, method string, params j
 			}
 		}
 
-		resp.Set(s.Validate(ctx, args.SlackChannel))
+		resp.Set(s.Validate(ctx, args.SuppressionRule))
 
 	default:
 		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
@@ -3674,13 +7871,105 @@ func (TaskTrackerService) SMD() smd.ServiceInfo {
 					400: "Validation Error",
 				},
 			},
-			"Update": {
-				Description: `Update updates the TaskTracker data identified by id from the query.`,
+			"Update": {
+				Description: `Update updates the TaskTracker data identified by id from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "taskTracker",
+						Type:     smd.Object,
+						TypeName: "TaskTracker",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "url",
+								Type: smd.String,
+							},
+							{
+								Name:     "authToken",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "fetchPrompt",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `TaskTracker`,
+					Type:        smd.Boolean,
+					TypeName:    "TaskTracker",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the TaskTracker by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that TaskTracker data is valid.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:     "taskTracker",
-						Type:     smd.Object,
-						TypeName: "TaskTracker",
+						Name:        "taskTracker",
+						Description: `TaskTracker`,
+						Type:        smd.Object,
+						TypeName:    "TaskTracker",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
@@ -3735,144 +8024,687 @@ func (TaskTrackerService) SMD() smd.ServiceInfo {
 						},
 					},
 				},
-				Returns: smd.JSONSchema{
-					Description: `TaskTracker`,
-					Type:        smd.Boolean,
-					TypeName:    "TaskTracker",
-				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s TaskTrackerService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.TaskTrackerService.Count:
+		var args = struct {
+			Search *TaskTrackerSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.TaskTrackerService.Get:
+		var args = struct {
+			Search  *TaskTrackerSearch `json:"search"`
+			ViewOps *ViewOps           `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.TaskTrackerService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.TaskTrackerService.Add:
+		var args = struct {
+			TaskTracker TaskTracker `json:"taskTracker"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"taskTracker"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.TaskTracker))
+
+	case RPC.TaskTrackerService.Update:
+		var args = struct {
+			TaskTracker TaskTracker `json:"taskTracker"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"taskTracker"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.TaskTracker))
+
+	case RPC.TaskTrackerService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.TaskTrackerService.Validate:
+		var args = struct {
+			TaskTracker TaskTracker `json:"taskTracker"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"taskTracker"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.TaskTracker))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (ReportService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Leaderboard": {
+				Description: `Leaderboard ranks (runner, model, prompt) combinations by precision (valid / processed),
+issues per review, cost per valid issue or latency.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `Project, project language, period (defaults to the last year) and rankBy: precision, issuesPerReview, costPerValidIssue, latency`,
+						Type:        smd.Object,
+						TypeName:    "LeaderboardSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "projectId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "language",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "from",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "to",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "rankBy",
+								Optional: true,
+								Type:     smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]LeaderboardEntry`,
+					Type:        smd.Array,
+					TypeName:    "[]LeaderboardEntry",
+					Items: map[string]string{
+						"$ref": "#/definitions/LeaderboardEntry",
+					},
+					Definitions: map[string]smd.Definition{
+						"LeaderboardEntry": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "runner",
+									Type: smd.String,
+								},
+								{
+									Name: "model",
+									Type: smd.String,
+								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "promptTitle",
+									Type: smd.String,
+								},
+								{
+									Name: "reviewCount",
+									Type: smd.Integer,
+								},
+								{
+									Name: "issueCount",
+									Type: smd.Integer,
+								},
+								{
+									Name: "processed",
+									Type: smd.Integer,
+								},
+								{
+									Name: "valid",
+									Type: smd.Integer,
+								},
+								{
+									Name: "falsePositive",
+									Type: smd.Integer,
+								},
+								{
+									Name: "ignored",
+									Type: smd.Integer,
+								},
+								{
+									Name: "precision",
+									Type: smd.Float,
+								},
+								{
+									Name: "issuesPerReview",
+									Type: smd.Float,
+								},
+								{
+									Name: "costUsd",
+									Type: smd.Float,
+								},
+								{
+									Name:     "costPerValidIssue",
+									Optional: true,
+									Type:     smd.Float,
+								},
+								{
+									Name: "medianDurationMs",
+									Type: smd.Integer,
+								},
+							},
+						},
+					},
+				},
 				Errors: map[int]string{
+					400: "Bad Request",
 					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
 				},
 			},
-			"Delete": {
-				Description: `Delete deletes the TaskTracker by its ID.`,
+			"Experiment": {
+				Description: `Experiment compares the prompt experiment variants: false positive rate, valid issues per review,
+cost per review and unfilled review.json rate. Each metric has a two-sided p-value and
+significance: significant (p < 0.05), notSignificant or insufficientData (under 10 samples per variant).`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "id",
-						Description: `int`,
+						Name:        "promptExperimentId",
+						Description: `Prompt experiment ID`,
 						Type:        smd.Integer,
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `isDeleted`,
-					Type:        smd.Boolean,
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
-				},
-			},
-			"Validate": {
-				Description: `Validate verifies that TaskTracker data is valid.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "taskTracker",
-						Description: `TaskTracker`,
-						Type:        smd.Object,
-						TypeName:    "TaskTracker",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "url",
-								Type: smd.String,
-							},
-							{
-								Name:     "authToken",
-								Optional: true,
-								Type:     smd.String,
+					Description: `ExperimentReport`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "ExperimentReport",
+					Properties: smd.PropertyList{
+						{
+							Name:     "experiment",
+							Optional: true,
+							Ref:      "#/definitions/PromptExperimentSummary",
+							Type:     smd.Object,
+						},
+						{
+							Name: "a",
+							Ref:  "#/definitions/ExperimentVariant",
+							Type: smd.Object,
+						},
+						{
+							Name: "b",
+							Ref:  "#/definitions/ExperimentVariant",
+							Type: smd.Object,
+						},
+						{
+							Name: "comparisons",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/ExperimentComparison",
 							},
-							{
-								Name: "fetchPrompt",
-								Type: smd.String,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"PromptExperimentSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "projectId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "variantPromptId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "trafficPercent",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "project",
+									Optional: true,
+									Ref:      "#/definitions/ProjectSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "variantPrompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
+						},
+						"ProjectSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "vcsURL",
+									Type: smd.String,
+								},
+								{
+									Name: "language",
+									Type: smd.String,
+								},
+								{
+									Name: "projectKey",
+									Type: smd.String,
+								},
+								{
+									Name: "promptId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "taskTrackerId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "slackChannelId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "prompt",
+									Optional: true,
+									Ref:      "#/definitions/PromptSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "taskTracker",
+									Optional: true,
+									Ref:      "#/definitions/TaskTrackerSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "slackChannel",
+									Optional: true,
+									Ref:      "#/definitions/SlackChannelSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+						},
+						"PromptSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "common",
+									Type: smd.String,
+								},
+								{
+									Name: "architecture",
+									Type: smd.String,
+								},
+								{
+									Name: "code",
+									Type: smd.String,
+								},
+								{
+									Name: "security",
+									Type: smd.String,
+								},
+								{
+									Name: "tests",
+									Type: smd.String,
+								},
+								{
+									Name: "operability",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
 								},
 							},
 						},
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `[]FieldError`,
-					Type:        smd.Array,
-					TypeName:    "[]FieldError",
-					Items: map[string]string{
-						"$ref": "#/definitions/FieldError",
-					},
-					Definitions: map[string]smd.Definition{
-						"FieldError": {
+						"TaskTrackerSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name: "field",
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
 									Type: smd.String,
 								},
 								{
-									Name: "error",
+									Name: "url",
 									Type: smd.String,
 								},
 								{
-									Name:        "constraint",
-									Optional:    true,
-									Description: `Help with generating an error message.`,
-									Ref:         "#/definitions/FieldErrorConstraint",
-									Type:        smd.Object,
+									Name:     "authToken",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "fetchPrompt",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
 								},
 							},
 						},
-						"FieldErrorConstraint": {
+						"SlackChannelSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name:        "max",
-									Description: `Max value for field.`,
-									Type:        smd.Integer,
+									Name: "id",
+									Type: smd.Integer,
 								},
 								{
-									Name:        "min",
-									Description: `Min value for field.`,
-									Type:        smd.Integer,
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "channel",
+									Type: smd.String,
+								},
+								{
+									Name: "webhookURL",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"ExperimentVariant": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "variant",
+									Type: smd.String,
+								},
+								{
+									Name: "reviewCount",
+									Type: smd.Integer,
+								},
+								{
+									Name: "issueCount",
+									Type: smd.Integer,
+								},
+								{
+									Name: "processed",
+									Type: smd.Integer,
+								},
+								{
+									Name: "valid",
+									Type: smd.Integer,
+								},
+								{
+									Name: "falsePositive",
+									Type: smd.Integer,
+								},
+								{
+									Name: "ignored",
+									Type: smd.Integer,
+								},
+								{
+									Name: "unfilled",
+									Type: smd.Integer,
+								},
+								{
+									Name: "costUsd",
+									Type: smd.Float,
+								},
+								{
+									Name: "falsePositiveRate",
+									Type: smd.Float,
+								},
+								{
+									Name: "validIssuesPerReview",
+									Type: smd.Float,
+								},
+								{
+									Name: "costPerReview",
+									Type: smd.Float,
+								},
+								{
+									Name: "unfilledRate",
+									Type: smd.Float,
+								},
+							},
+						},
+						"ExperimentComparison": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "metric",
+									Type: smd.String,
+								},
+								{
+									Name: "a",
+									Type: smd.Float,
+								},
+								{
+									Name: "b",
+									Type: smd.Float,
+								},
+								{
+									Name:     "pValue",
+									Optional: true,
+									Type:     smd.Float,
+								},
+								{
+									Name: "significance",
+									Type: smd.String,
 								},
 							},
 						},
 					},
 				},
 				Errors: map[int]string{
+					404: "Not Found",
 					500: "Internal Error",
 				},
 			},
@@ -3881,14 +8713,14 @@ func (TaskTrackerService) SMD() smd.ServiceInfo {
 }
 
 // Invoke is as generated code from zenrpc cmd
-func (s TaskTrackerService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+func (s ReportService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
 	resp := zenrpc.Response{}
 	var err error
 
 	switch method {
-	case RPC.TaskTrackerService.Count:
+	case RPC.ReportService.Leaderboard:
 		var args = struct {
-			Search *TaskTrackerSearch `json:"search"`
+			Search *LeaderboardSearch `json:"search"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -3903,111 +8735,15 @@ func (s TaskTrackerService) Invoke(ctx context.Context, method string, params js
 			}
 		}
 
-		resp.Set(s.Count(ctx, args.Search))
-
-	case RPC.TaskTrackerService.Get:
-		var args = struct {
-			Search  *TaskTrackerSearch `json:"search"`
-			ViewOps *ViewOps           `json:"viewOps"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
-
-	case RPC.TaskTrackerService.GetByID:
-		var args = struct {
-			Id int `json:"id"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.GetByID(ctx, args.Id))
-
-	case RPC.TaskTrackerService.Add:
-		var args = struct {
-			TaskTracker TaskTracker `json:"taskTracker"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"taskTracker"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Add(ctx, args.TaskTracker))
-
-	case RPC.TaskTrackerService.Update:
-		var args = struct {
-			TaskTracker TaskTracker `json:"taskTracker"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"taskTracker"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Update(ctx, args.TaskTracker))
-
-	case RPC.TaskTrackerService.Delete:
-		var args = struct {
-			Id int `json:"id"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Delete(ctx, args.Id))
+		resp.Set(s.Leaderboard(ctx, args.Search))
 
-	case RPC.TaskTrackerService.Validate:
+	case RPC.ReportService.Experiment:
 		var args = struct {
-			TaskTracker TaskTracker `json:"taskTracker"`
+			PromptExperimentId int `json:"promptExperimentId"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"taskTracker"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"promptExperimentId"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -4018,7 +8754,7 @@ func (s TaskTrackerService) Invoke(ctx context.Context, method string, params js
 			}
 		}
 
-		resp.Set(s.Validate(ctx, args.TaskTracker))
+		resp.Set(s.Experiment(ctx, args.PromptExperimentId))
 
 	default:
 		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)